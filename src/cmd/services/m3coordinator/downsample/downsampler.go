@@ -65,6 +65,10 @@ func (d *downsampler) NewMetricsAppender() MetricsAppender {
 	return newMetricsAppender(metricsAppenderOptions{
 		agg: d.agg.aggregator,
 		defaultStagedMetadatas:  d.agg.defaultStagedMetadatas,
+		mappingRules:            d.agg.mappingRules,
+		rollupRules:             d.agg.rollupRules,
+		nameTag:                 d.agg.nameTag,
+		rollupIDProviderPool:    d.agg.pools.rollupIDProviderPool,
 		clockOpts:               d.agg.clockOpts,
 		tagEncoder:              d.agg.pools.tagEncoderPool.Get(),
 		matcher:                 d.agg.matcher,
@@ -111,6 +111,93 @@ func TestDownsamplerAggregationWithRulesStore(t *testing.T) {
 	testDownsamplerAggregation(t, testDownsampler)
 }
 
+func TestDownsamplerAggregationWithFilteredMappingRules(t *testing.T) {
+	testDownsampler := newTestDownsampler(t, testDownsamplerOptions{
+		mappingRules: []MappingRule{
+			{
+				Filter:       "app:test*",
+				Aggregations: []aggregation.Type{testAggregationType},
+				Policies:     testAggregationStoragePolicies,
+			},
+			{
+				// Does not match any of the test metrics
+				Filter:       "app:other",
+				Aggregations: []aggregation.Type{testAggregationType},
+				Policies:     testAggregationStoragePolicies,
+			},
+		},
+	})
+
+	// Test expected output
+	testDownsamplerAggregation(t, testDownsampler)
+}
+
+func TestDownsamplerAggregationWithRollupRules(t *testing.T) {
+	testDownsampler := newTestDownsampler(t, testDownsamplerOptions{
+		rollupRules: []RollupRule{
+			{
+				Filter:       "__name__:requests",
+				NewName:      "requests_by_app",
+				GroupBy:      []string{"app"},
+				Aggregations: []aggregation.Type{aggregation.Sum},
+				Policies:     testAggregationStoragePolicies,
+			},
+		},
+	})
+
+	appender := testDownsampler.downsampler.NewMetricsAppender()
+	defer appender.Finalize()
+
+	for _, tags := range []map[string]string{
+		{"__name__": "requests", "app": "web", "request_id": "1"},
+		{"__name__": "requests", "app": "web", "request_id": "2"},
+		// Not rolled up since it is missing the group by tag
+		{"__name__": "requests", "request_id": "3"},
+	} {
+		appender.Reset()
+		for name, value := range tags {
+			appender.AddTag(name, value)
+		}
+
+		samplesAppender, err := appender.SamplesAppender()
+		require.NoError(t, err)
+		require.NoError(t, samplesAppender.AppendCounterSample(2))
+	}
+
+	// Wait for the rolled up write
+	for len(testDownsampler.storage.Writes()) == 0 {
+		time.Sleep(100 * time.Millisecond)
+	}
+
+	writes := testDownsampler.storage.Writes()
+	require.Equal(t, 1, len(writes))
+	write := mustFindWrite(t, writes, "requests_by_app")
+	assert.Equal(t, map[string]string{
+		"__name__":  "requests_by_app",
+		"app":       "web",
+		"m3_rollup": "true",
+	}, write.Tags.StringMap())
+	require.Equal(t, 1, len(write.Datapoints))
+	assert.Equal(t, float64(4), write.Datapoints[0].Value)
+}
+
+func TestDownsamplerInvalidRules(t *testing.T) {
+	for _, opts := range []testDownsamplerOptions{
+		{mappingRules: []MappingRule{{Filter: "app:test*"}}},
+		{rollupRules: []RollupRule{{
+			NewName:  "rollup",
+			Policies: testAggregationStoragePolicies,
+		}}},
+		{rollupRules: []RollupRule{{
+			Filter:   "app:test*",
+			Policies: testAggregationStoragePolicies,
+		}}},
+	} {
+		_, err := newTestDownsamplerWithError(t, opts)
+		assert.Error(t, err)
+	}
+}
+
 func testDownsamplerAggregation(
 	t *testing.T,
 	testDownsampler testDownsampler,
@@ -215,11 +302,22 @@ type testDownsampler struct {
 
 type testDownsamplerOptions struct {
 	autoMappingRules []MappingRule
+	mappingRules     []MappingRule
+	rollupRules      []RollupRule
 	clockOpts        clock.Options
 	instrumentOpts   instrument.Options
 }
 
 func newTestDownsampler(t *testing.T, opts testDownsamplerOptions) testDownsampler {
+	instance, err := newTestDownsamplerWithError(t, opts)
+	require.NoError(t, err)
+	return instance
+}
+
+func newTestDownsamplerWithError(
+	t *testing.T,
+	opts testDownsamplerOptions,
+) (testDownsampler, error) {
 	storage := mock.NewMockStorage()
 	rulesKVStore := mem.NewStore()
 
@@ -263,6 +361,8 @@ func newTestDownsampler(t *testing.T, opts testDownsamplerOptions) testDownsampl
 		Storage:               storage,
 		RulesKVStore:          rulesKVStore,
 		AutoMappingRules:      opts.autoMappingRules,
+		MappingRules:          opts.mappingRules,
+		RollupRules:           opts.rollupRules,
 		ClockOptions:          clockOpts,
 		InstrumentOptions:     instrumentOpts,
 		TagEncoderOptions:     tagEncoderOptions,
@@ -270,7 +370,9 @@ func newTestDownsampler(t *testing.T, opts testDownsamplerOptions) testDownsampl
 		TagEncoderPoolOptions: tagEncoderPoolOptions,
 		TagDecoderPoolOptions: tagDecoderPoolOptions,
 	})
-	require.NoError(t, err)
+	if err != nil {
+		return testDownsampler{}, err
+	}
 
 	downcast, ok := instance.(*downsampler)
 	require.True(t, ok)
@@ -282,7 +384,7 @@ func newTestDownsampler(t *testing.T, opts testDownsamplerOptions) testDownsampl
 		storage:        storage,
 		rulesStore:     rulesStore,
 		instrumentOpts: instrumentOpts,
-	}
+	}, nil
 }
 
 func newTestID(t *testing.T, tags map[string]string) id.ID {
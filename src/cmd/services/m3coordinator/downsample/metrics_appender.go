@@ -29,6 +29,7 @@ import (
 	"github.com/m3db/m3aggregator/aggregator"
 	"github.com/m3db/m3metrics/matcher"
 	"github.com/m3db/m3metrics/metadata"
+	"github.com/m3db/m3metrics/metric/id"
 	"github.com/m3db/m3x/clock"
 )
 
@@ -37,11 +38,16 @@ type metricsAppender struct {
 
 	tags                 *tags
 	multiSamplesAppender *multiSamplesAppender
+	rollupTagPairs       []id.TagPair
 }
 
 type metricsAppenderOptions struct {
 	agg                     aggregator.Aggregator
 	defaultStagedMetadatas  []metadata.StagedMetadatas
+	mappingRules            []mappingRuleMatcher
+	rollupRules             []rollupRuleMatcher
+	nameTag                 []byte
+	rollupIDProviderPool    *rollupIDProviderPool
 	clockOpts               clock.Options
 	tagEncoder              serialize.TagEncoder
 	matcher                 matcher.Matcher
@@ -89,6 +95,38 @@ func (a *metricsAppender) SamplesAppender() (SamplesAppender, error) {
 		})
 	}
 
+	// Aggregate any static mapping rules whose filters match
+	for _, rule := range a.mappingRules {
+		if !rule.filter.Matches(unownedID) {
+			continue
+		}
+		a.multiSamplesAppender.addSamplesAppender(samplesAppender{
+			agg:             a.agg,
+			unownedID:       unownedID,
+			stagedMetadatas: rule.stagedMetadatas,
+		})
+	}
+
+	// Roll up into new metrics for any static rollup rules whose filters match
+	for _, rule := range a.rollupRules {
+		if !rule.filter.Matches(unownedID) {
+			continue
+		}
+		rollupID, ok, err := a.rollupID(rule)
+		if err != nil {
+			return nil, err
+		}
+		if !ok {
+			// Missing one or more of the group by tags
+			continue
+		}
+		a.multiSamplesAppender.addSamplesAppender(samplesAppender{
+			agg:             a.agg,
+			unownedID:       rollupID,
+			stagedMetadatas: rule.stagedMetadatas,
+		})
+	}
+
 	stagedMetadatas := matchResult.ForExistingIDAt(nowNanos)
 	if !stagedMetadatas.IsDefault() && len(stagedMetadatas) != 0 {
 		// Only sample if going to actually aggregate
@@ -112,6 +150,32 @@ func (a *metricsAppender) SamplesAppender() (SamplesAppender, error) {
 	return a.multiSamplesAppender, nil
 }
 
+// rollupID returns the ID of the rolled up metric for the rule, false if
+// the tags do not contain all of the group by tags. The tags must be sorted.
+func (a *metricsAppender) rollupID(rule rollupRuleMatcher) ([]byte, bool, error) {
+	a.rollupTagPairs = a.rollupTagPairs[:0]
+	for _, name := range rule.groupBy {
+		idx := sort.SearchStrings(a.tags.names, name)
+		if idx == len(a.tags.names) || a.tags.names[idx] != name {
+			return nil, false, nil
+		}
+		a.rollupTagPairs = append(a.rollupTagPairs, id.TagPair{
+			Name:  []byte(name),
+			Value: []byte(a.tags.values[idx]),
+		})
+	}
+
+	tagPairs := rollupTagPairs(a.nameTag, rule.newName, a.rollupTagPairs)
+	provider := a.rollupIDProviderPool.Get()
+	rollupID, err := provider.provide(tagPairs)
+	provider.finalize()
+	if err != nil {
+		return nil, false, err
+	}
+
+	return rollupID, true, nil
+}
+
 func (a *metricsAppender) Reset() {
 	a.tags.names = a.tags.names[:0]
 	a.tags.values = a.tags.values[:0]
@@ -79,6 +79,8 @@ type DownsamplerOptions struct {
 	StorageFlushConcurrency int
 	RulesKVStore            kv.Store
 	AutoMappingRules        []MappingRule
+	MappingRules            []MappingRule
+	RollupRules             []RollupRule
 	NameTag                 string
 	ClockOptions            clock.Options
 	InstrumentOptions       instrument.Options
@@ -91,8 +93,16 @@ type DownsamplerOptions struct {
 
 // MappingRule is a mapping rule to apply to metrics.
 type MappingRule struct {
-	Aggregations []aggregation.Type
-	Policies     policy.StoragePolicies
+	// Filter is the tag filter metrics must match, in the same format as
+	// the filters of rules in the rules KV store, e.g. "app:web* env:prod".
+	// If empty the rule applies to all metrics.
+	Filter string `yaml:"filter"`
+
+	// Aggregations are the aggregations applied to matching metrics.
+	Aggregations []aggregation.Type `yaml:"aggregations"`
+
+	// Policies are the storage policies of matching metrics.
+	Policies policy.StoragePolicies `yaml:"storagePolicies"`
 }
 
 // StagedMetadatas returns the corresponding staged metadatas for this mapping rule.
@@ -148,6 +158,9 @@ func (o DownsamplerOptions) validate() error {
 type agg struct {
 	aggregator             aggregator.Aggregator
 	defaultStagedMetadatas []metadata.StagedMetadatas
+	mappingRules           []mappingRuleMatcher
+	rollupRules            []rollupRuleMatcher
+	nameTag                []byte
 	clockOpts              clock.Options
 	matcher                matcher.Matcher
 	pools                  aggPools
@@ -165,7 +178,6 @@ func (o DownsamplerOptions) newAggregator() (agg, error) {
 		clockOpts               = o.ClockOptions
 		instrumentOpts          = o.InstrumentOptions
 		openTimeout             = defaultOpenTimeout
-		defaultStagedMetadatas  []metadata.StagedMetadatas
 	)
 	if o.StorageFlushConcurrency > 0 {
 		storageFlushConcurrency = o.StorageFlushConcurrency
@@ -173,16 +185,17 @@ func (o DownsamplerOptions) newAggregator() (agg, error) {
 	if o.OpenTimeout > 0 {
 		openTimeout = o.OpenTimeout
 	}
-	for _, rule := range o.AutoMappingRules {
-		metadatas, err := rule.StagedMetadatas()
-		if err != nil {
-			return agg{}, err
-		}
-		defaultStagedMetadatas = append(defaultStagedMetadatas, metadatas)
-	}
 
 	pools := o.newAggregatorPools()
-	ruleSetOpts := o.newAggregatorRulesOptions(pools)
+	tagsFilterOpts := o.newAggregatorTagsFilterOptions(pools)
+	ruleSetOpts := o.newAggregatorRulesOptions(pools, tagsFilterOpts)
+
+	// Validate the static rules before starting the aggregator
+	defaultStagedMetadatas, mappingRules, rollupRules, err :=
+		o.newRuleMatchers(tagsFilterOpts)
+	if err != nil {
+		return agg{}, err
+	}
 
 	// Use default aggregation types, in future we can provide more configurability
 	var defaultAggregationTypes aggregation.TypesConfiguration
@@ -268,6 +281,9 @@ func (o DownsamplerOptions) newAggregator() (agg, error) {
 	return agg{
 		aggregator:             aggregatorInstance,
 		defaultStagedMetadatas: defaultStagedMetadatas,
+		mappingRules:           mappingRules,
+		rollupRules:            rollupRules,
+		nameTag:                o.nameTag(),
 		matcher:                matcher,
 		pools:                  pools,
 	}, nil
@@ -277,6 +293,7 @@ type aggPools struct {
 	tagEncoderPool          serialize.TagEncoderPool
 	tagDecoderPool          serialize.TagDecoderPool
 	encodedTagsIteratorPool *encodedTagsIteratorPool
+	rollupIDProviderPool    *rollupIDProviderPool
 }
 
 func (o DownsamplerOptions) newAggregatorPools() aggPools {
@@ -292,18 +309,29 @@ func (o DownsamplerOptions) newAggregatorPools() aggPools {
 		o.TagDecoderPoolOptions)
 	encodedTagsIteratorPool.Init()
 
+	rollupIDProviderPool := newRollupIDProviderPool(tagEncoderPool,
+		o.TagEncoderPoolOptions)
+	rollupIDProviderPool.Init()
+
 	return aggPools{
 		tagEncoderPool:          tagEncoderPool,
 		tagDecoderPool:          tagDecoderPool,
 		encodedTagsIteratorPool: encodedTagsIteratorPool,
+		rollupIDProviderPool:    rollupIDProviderPool,
 	}
 }
 
-func (o DownsamplerOptions) newAggregatorRulesOptions(pools aggPools) rules.Options {
-	nameTag := defaultMetricNameTagName
+func (o DownsamplerOptions) nameTag() []byte {
 	if o.NameTag != "" {
-		nameTag = []byte(o.NameTag)
+		return []byte(o.NameTag)
 	}
+	return defaultMetricNameTagName
+}
+
+func (o DownsamplerOptions) newAggregatorTagsFilterOptions(
+	pools aggPools,
+) filters.TagsFilterOptions {
+	nameTag := o.nameTag()
 
 	sortedTagIteratorFn := func(tagPairs []byte) id.SortedTagIterator {
 		it := pools.encodedTagsIteratorPool.Get()
@@ -311,7 +339,7 @@ func (o DownsamplerOptions) newAggregatorRulesOptions(pools aggPools) rules.Opti
 		return it
 	}
 
-	tagsFilterOpts := filters.TagsFilterOptions{
+	return filters.TagsFilterOptions{
 		NameTagKey: nameTag,
 		NameAndTagsFn: func(id []byte) ([]byte, []byte, error) {
 			name, err := resolveEncodedTagsNameTag(id, pools.encodedTagsIteratorPool,
@@ -325,17 +353,22 @@ func (o DownsamplerOptions) newAggregatorRulesOptions(pools aggPools) rules.Opti
 		},
 		SortedTagIteratorFn: sortedTagIteratorFn,
 	}
+}
+
+func (o DownsamplerOptions) newAggregatorRulesOptions(
+	pools aggPools,
+	tagsFilterOpts filters.TagsFilterOptions,
+) rules.Options {
+	nameTag := o.nameTag()
 
 	isRollupIDFn := func(name []byte, tags []byte) bool {
 		return isRollupID(tags, pools.encodedTagsIteratorPool)
 	}
 
-	newRollupIDProviderPool := newRollupIDProviderPool(pools.tagEncoderPool,
-		o.TagEncoderPoolOptions)
-	newRollupIDProviderPool.Init()
-
 	newRollupIDFn := func(name []byte, tagPairs []id.TagPair) []byte {
-		rollupIDProvider := newRollupIDProviderPool.Get()
+		// Include the new metric name of the rollup in the rollup ID
+		tagPairs = rollupTagPairs(nameTag, name, tagPairs)
+		rollupIDProvider := pools.rollupIDProviderPool.Get()
 		id, err := rollupIDProvider.provide(tagPairs)
 		if err != nil {
 			panic(err) // Encoding should never fail
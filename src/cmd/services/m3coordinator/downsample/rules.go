@@ -0,0 +1,233 @@
+// Copyright (c) 2018 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package downsample
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"sort"
+
+	"github.com/m3db/m3metrics/aggregation"
+	"github.com/m3db/m3metrics/filters"
+	"github.com/m3db/m3metrics/metadata"
+	"github.com/m3db/m3metrics/metric/id"
+	"github.com/m3db/m3metrics/policy"
+)
+
+var (
+	errNoRuleStoragePolicies = errors.New("rule has no storage policies")
+	errNoRollupNewName       = errors.New("rollup rule has no new metric name")
+	errNoRollupFilter        = errors.New("rollup rule has no filter")
+	errRollupGroupByName     = errors.New("rollup rule groups by the name tag which would replace the new metric name")
+)
+
+// RulesConfiguration is the configuration of static downsampling rules
+// applied in addition to the rules in the rules KV store.
+type RulesConfiguration struct {
+	// MappingRules are the mapping rules.
+	MappingRules []MappingRule `yaml:"mappingRules"`
+
+	// RollupRules are the rollup rules.
+	RollupRules []RollupRule `yaml:"rollupRules"`
+}
+
+// RollupRule is a rollup rule to apply to metrics, matching metrics are
+// aggregated together into a new metric with the new name and only the
+// group by tags.
+type RollupRule struct {
+	// Filter is the tag filter metrics must match, in the same format as
+	// the filters of rules in the rules KV store, e.g. "app:web* env:prod".
+	Filter string `yaml:"filter"`
+
+	// NewName is the metric name of the rolled up metric.
+	NewName string `yaml:"newName"`
+
+	// GroupBy are the tags kept on the rolled up metric, metrics without
+	// all of the tags are not rolled up. Must not include the name tag as
+	// the rolled up metric is named after the new name.
+	GroupBy []string `yaml:"groupBy"`
+
+	// Aggregations are the aggregations applied to the rolled up metric.
+	Aggregations []aggregation.Type `yaml:"aggregations"`
+
+	// Policies are the storage policies of the rolled up metric.
+	Policies policy.StoragePolicies `yaml:"storagePolicies"`
+}
+
+// StagedMetadatas returns the corresponding staged metadatas for this rollup rule.
+func (r RollupRule) StagedMetadatas() (metadata.StagedMetadatas, error) {
+	return MappingRule{
+		Aggregations: r.Aggregations,
+		Policies:     r.Policies,
+	}.StagedMetadatas()
+}
+
+type mappingRuleMatcher struct {
+	filter          filters.TagsFilter
+	stagedMetadatas metadata.StagedMetadatas
+}
+
+type rollupRuleMatcher struct {
+	filter          filters.TagsFilter
+	newName         []byte
+	groupBy         []string
+	stagedMetadatas metadata.StagedMetadatas
+}
+
+func newTagsFilter(
+	filter string,
+	opts filters.TagsFilterOptions,
+) (filters.TagsFilter, error) {
+	filterValues, err := filters.ParseTagFilterValueMap(filter)
+	if err != nil {
+		return nil, err
+	}
+	return filters.NewTagsFilter(filterValues, filters.Conjunction, opts)
+}
+
+func newMappingRuleMatcher(
+	rule MappingRule,
+	opts filters.TagsFilterOptions,
+) (mappingRuleMatcher, error) {
+	filter, err := newTagsFilter(rule.Filter, opts)
+	if err != nil {
+		return mappingRuleMatcher{}, err
+	}
+
+	stagedMetadatas, err := rule.StagedMetadatas()
+	if err != nil {
+		return mappingRuleMatcher{}, err
+	}
+
+	return mappingRuleMatcher{
+		filter:          filter,
+		stagedMetadatas: stagedMetadatas,
+	}, nil
+}
+
+func newRollupRuleMatcher(
+	rule RollupRule,
+	nameTag []byte,
+	opts filters.TagsFilterOptions,
+) (rollupRuleMatcher, error) {
+	if rule.Filter == "" {
+		return rollupRuleMatcher{}, errNoRollupFilter
+	}
+	if rule.NewName == "" {
+		return rollupRuleMatcher{}, errNoRollupNewName
+	}
+	if len(rule.Policies) == 0 {
+		return rollupRuleMatcher{}, errNoRuleStoragePolicies
+	}
+	for _, tag := range rule.GroupBy {
+		if tag == string(nameTag) {
+			return rollupRuleMatcher{}, errRollupGroupByName
+		}
+	}
+
+	filter, err := newTagsFilter(rule.Filter, opts)
+	if err != nil {
+		return rollupRuleMatcher{}, err
+	}
+
+	stagedMetadatas, err := rule.StagedMetadatas()
+	if err != nil {
+		return rollupRuleMatcher{}, err
+	}
+
+	groupBy := append([]string(nil), rule.GroupBy...)
+	sort.Strings(groupBy)
+
+	return rollupRuleMatcher{
+		filter:          filter,
+		newName:         []byte(rule.NewName),
+		groupBy:         groupBy,
+		stagedMetadatas: stagedMetadatas,
+	}, nil
+}
+
+func (o DownsamplerOptions) newRuleMatchers(
+	tagsFilterOpts filters.TagsFilterOptions,
+) ([]metadata.StagedMetadatas, []mappingRuleMatcher, []rollupRuleMatcher, error) {
+	var (
+		defaultStagedMetadatas []metadata.StagedMetadatas
+		mappingRules           []mappingRuleMatcher
+		rollupRules            []rollupRuleMatcher
+	)
+	mapping := make([]MappingRule, 0, len(o.AutoMappingRules)+len(o.MappingRules))
+	mapping = append(mapping, o.AutoMappingRules...)
+	mapping = append(mapping, o.MappingRules...)
+	for i, rule := range mapping {
+		if len(rule.Policies) == 0 {
+			return nil, nil, nil, fmt.Errorf("invalid mapping rule %d: %v",
+				i, errNoRuleStoragePolicies)
+		}
+
+		if rule.Filter == "" {
+			// Unfiltered rules apply to every metric
+			metadatas, err := rule.StagedMetadatas()
+			if err != nil {
+				return nil, nil, nil, err
+			}
+			defaultStagedMetadatas = append(defaultStagedMetadatas, metadatas)
+			continue
+		}
+
+		matcher, err := newMappingRuleMatcher(rule, tagsFilterOpts)
+		if err != nil {
+			return nil, nil, nil, fmt.Errorf("invalid mapping rule %d: %v", i, err)
+		}
+		mappingRules = append(mappingRules, matcher)
+	}
+
+	nameTag := o.nameTag()
+	for i, rule := range o.RollupRules {
+		matcher, err := newRollupRuleMatcher(rule, nameTag, tagsFilterOpts)
+		if err != nil {
+			return nil, nil, nil, fmt.Errorf("invalid rollup rule %d: %v", i, err)
+		}
+		rollupRules = append(rollupRules, matcher)
+	}
+
+	return defaultStagedMetadatas, mappingRules, rollupRules, nil
+}
+
+// rollupTagPairs returns the tag pairs of a rolled up metric, the name tag
+// is inserted in sorted order if not already present.
+func rollupTagPairs(nameTag, name []byte, tagPairs []id.TagPair) []id.TagPair {
+	if len(name) == 0 {
+		return tagPairs
+	}
+
+	idx := sort.Search(len(tagPairs), func(i int) bool {
+		return bytes.Compare(tagPairs[i].Name, nameTag) >= 0
+	})
+	if idx < len(tagPairs) && bytes.Equal(tagPairs[idx].Name, nameTag) {
+		return tagPairs
+	}
+
+	result := make([]id.TagPair, 0, len(tagPairs)+1)
+	result = append(result, tagPairs[:idx]...)
+	result = append(result, id.TagPair{Name: nameTag, Value: name})
+	result = append(result, tagPairs[idx:]...)
+	return result
+}
@@ -0,0 +1,69 @@
+// Copyright (c) 2018 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package downsample
+
+import (
+	"testing"
+
+	"github.com/m3db/m3metrics/filters"
+	"github.com/m3db/m3metrics/metric/id"
+	"github.com/m3db/m3metrics/policy"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRollupTagPairs(t *testing.T) {
+	nameTag := []byte("__name__")
+	tagPairs := []id.TagPair{
+		{Name: []byte("app"), Value: []byte("web")},
+		{Name: []byte("env"), Value: []byte("prod")},
+	}
+
+	assert.Equal(t, []id.TagPair{
+		{Name: []byte("__name__"), Value: []byte("requests")},
+		{Name: []byte("app"), Value: []byte("web")},
+		{Name: []byte("env"), Value: []byte("prod")},
+	}, rollupTagPairs(nameTag, []byte("requests"), tagPairs))
+
+	// Name tag is inserted in sorted order
+	assert.Equal(t, []id.TagPair{
+		{Name: []byte("app"), Value: []byte("web")},
+		{Name: []byte("env"), Value: []byte("prod")},
+		{Name: []byte("name"), Value: []byte("requests")},
+	}, rollupTagPairs([]byte("name"), []byte("requests"), tagPairs))
+
+	// Existing name tag and empty names are left as is
+	withName := rollupTagPairs(nameTag, []byte("requests"), tagPairs)
+	assert.Equal(t, withName, rollupTagPairs(nameTag, []byte("other"), withName))
+	assert.Equal(t, tagPairs, rollupTagPairs(nameTag, nil, tagPairs))
+}
+
+func TestNewRollupRuleMatcherGroupByNameTag(t *testing.T) {
+	rule := RollupRule{
+		Filter:   "app:web",
+		NewName:  "requests_by_name",
+		GroupBy:  []string{"__name__", "app"},
+		Policies: policy.StoragePolicies{policy.MustParseStoragePolicy("1m:2d")},
+	}
+
+	_, err := newRollupRuleMatcher(rule, []byte("__name__"), filters.TagsFilterOptions{})
+	assert.Equal(t, errRollupGroupByName, err)
+}
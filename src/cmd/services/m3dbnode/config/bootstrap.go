@@ -68,6 +68,13 @@ func (bsc BootstrapConfiguration) fsNumProcessors() int {
 	return int(math.Ceil(float64(runtime.NumCPU()) * np))
 }
 
+func (bsc BootstrapConfiguration) fsQuarantineCorruptFileSets() bool {
+	if fsCfg := bsc.Filesystem; fsCfg != nil {
+		return fsCfg.QuarantineCorruptFileSets
+	}
+	return false
+}
+
 // TODO: Remove once v1 endpoint no longer required.
 func (bsc BootstrapConfiguration) peersFetchBlocksMetadataEndpointVersion() client.FetchBlocksMetadataEndpointVersion {
 	version := client.FetchBlocksMetadataEndpointDefault
@@ -81,6 +88,10 @@ func (bsc BootstrapConfiguration) peersFetchBlocksMetadataEndpointVersion() clie
 type BootstrapFilesystemConfiguration struct {
 	// NumProcessorsPerCPU is the number of processors per CPU.
 	NumProcessorsPerCPU float64 `yaml:"numProcessorsPerCPU" validate:"min=0.0"`
+
+	// QuarantineCorruptFileSets moves filesets that fail validation into the
+	// quarantine directory so that the peers bootstrapper can refill them.
+	QuarantineCorruptFileSets bool `yaml:"quarantineCorruptFileSets"`
 }
 
 // BootstrapPeersConfiguration specifies config for the peers bootstrapper.
@@ -129,7 +140,8 @@ func (bsc BootstrapConfiguration) New(
 				SetBoostrapDataNumProcessors(bsc.fsNumProcessors()).
 				SetDatabaseBlockRetrieverManager(opts.DatabaseBlockRetrieverManager()).
 				SetRuntimeOptionsManager(opts.RuntimeOptionsManager()).
-				SetIdentifierPool(opts.IdentifierPool())
+				SetIdentifierPool(opts.IdentifierPool()).
+				SetQuarantineCorruptFileSets(bsc.fsQuarantineCorruptFileSets())
 			bs, err = bfs.NewFileSystemBootstrapperProvider(fsbOpts, bs)
 			if err != nil {
 				return nil, err
@@ -47,6 +47,7 @@ type SeriesCacheConfiguration struct {
 // LRUSeriesCachePolicyConfiguration contains configuration for the LRU
 // series caching policy.
 type LRUSeriesCachePolicyConfiguration struct {
-	MaxBlocks         uint `yaml:"maxBlocks" validate:"nonzero"`
-	EventsChannelSize uint `yaml:"eventsChannelSize" validate:"nonzero"`
+	MaxBlocks         uint   `yaml:"maxBlocks"`
+	MaxBytes          uint64 `yaml:"maxBytes"`
+	EventsChannelSize uint   `yaml:"eventsChannelSize" validate:"nonzero"`
 }
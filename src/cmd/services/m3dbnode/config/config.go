@@ -32,6 +32,8 @@ import (
 	coordinatorcfg "github.com/m3db/m3/src/cmd/services/m3query/config"
 	"github.com/m3db/m3/src/dbnode/client"
 	"github.com/m3db/m3/src/dbnode/environment"
+	xtls "github.com/m3db/m3/src/dbnode/x/tls"
+	"github.com/m3db/m3/src/x/tracing"
 	"github.com/m3db/m3x/config/hostid"
 	"github.com/m3db/m3x/instrument"
 	xlog "github.com/m3db/m3x/log"
@@ -69,6 +71,9 @@ type DBConfiguration struct {
 	// Metrics configuration.
 	Metrics instrument.MetricsConfiguration `yaml:"metrics"`
 
+	// Tracing configuration.
+	Tracing tracing.Configuration `yaml:"tracing"`
+
 	// The host and port on which to listen for the node service.
 	ListenAddress string `yaml:"listenAddress" validate:"nonzero"`
 
@@ -84,6 +89,10 @@ type DBConfiguration struct {
 	// The host and port on which to listen for debug endpoints.
 	DebugListenAddress string `yaml:"debugListenAddress"`
 
+	// TLS configuration for the node and cluster tchannel and HTTP endpoints,
+	// if not set the endpoints are plaintext.
+	TLS *xtls.Configuration `yaml:"tls"`
+
 	// HostID is the local host ID configuration.
 	HostID hostid.Configuration `yaml:"hostID"`
 
@@ -111,6 +120,9 @@ type DBConfiguration struct {
 	// Cache configurations.
 	Cache CacheConfigurations `yaml:"cache"`
 
+	// The node memory budget, omit this to disable memory accounting.
+	MemoryBudget *MemoryBudgetConfiguration `yaml:"memoryBudget"`
+
 	// The filesystem configuration for the node.
 	Filesystem FilesystemConfiguration `yaml:"fs"`
 
@@ -140,6 +152,11 @@ type IndexConfiguration struct {
 	// important to prevent index queries from overloading the database entirely
 	// as they are very CPU-intensive (regex and FST matching.)
 	MaxQueryIDsConcurrency int `yaml:"maxQueryIDsConcurrency" validate:"min=0"`
+
+	// PostingsListCacheSize is the maximum number of postings lists resolved
+	// from immutable index segments to cache, zero disables the cache. The
+	// size can be changed at runtime with the postings list cache size KV key.
+	PostingsListCacheSize int `yaml:"postingsListCacheSize" validate:"min=0"`
 }
 
 // TickConfiguration is the tick configuration for background processing of
@@ -180,6 +197,10 @@ type CommitLogPolicy struct {
 
 	// The commit log block size.
 	BlockSize time.Duration `yaml:"blockSize" validate:"nonzero"`
+
+	// The number of stripes writes are spread across, each with its own queue
+	// and commit log file, defaults to a single stripe if not set.
+	NumStripes int `yaml:"numStripes" validate:"min=0"`
 }
 
 // CalculationType is a type of configuration parameter.
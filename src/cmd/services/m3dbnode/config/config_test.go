@@ -301,6 +301,7 @@ db:
 	expected := `db:
   index:
     maxQueryIDsConcurrency: 0
+    postingsListCacheSize: 0
   logging:
     file: /var/log/m3dbnode.log
     level: info
@@ -318,11 +319,14 @@ db:
     samplingRate: 1
     extended: 3
     sanitization: 2
+  tracing:
+    backend: ""
   listenAddress: 0.0.0.0:9000
   clusterListenAddress: 0.0.0.0:9001
   httpNodeListenAddress: 0.0.0.0:9002
   httpClusterListenAddress: 0.0.0.0:9003
   debugListenAddress: 0.0.0.0:9004
+  tls: null
   hostID:
     resolver: config
     value: host1
@@ -358,6 +362,10 @@ db:
     backgroundHealthCheckFailThrottleFactor: 0.5
     hashing:
       seed: 42
+    tls: null
+    hedgedReads: null
+    readRepair: null
+    hintedHandoff: null
   gcPercentage: 100
   writeNewSeriesLimitPerSecond: 1048576
   writeNewSeriesBackoffDuration: 2ms
@@ -369,11 +377,13 @@ db:
     - noop-all
     fs:
       numProcessorsPerCPU: 0.125
+      quarantineCorruptFileSets: false
     peers: null
     cacheSeriesMetadata: null
   blockRetrieve: null
   cache:
     series: null
+  memoryBudget: null
   fs:
     filePathPrefix: /var/lib/m3db
     writeBufferSize: 65536
@@ -392,6 +402,7 @@ db:
       calculationType: fixed
       size: 2097152
     blockSize: 10m0s
+    numStripes: 0
   repair:
     enabled: false
     interval: 2h0m0s
@@ -23,7 +23,13 @@ package config
 import (
 	"time"
 
+	"github.com/m3db/m3/src/cmd/services/m3coordinator/downsample"
+	"github.com/m3db/m3/src/query/executor"
+	"github.com/m3db/m3/src/query/ha"
+	"github.com/m3db/m3/src/query/relabel"
+	"github.com/m3db/m3/src/query/rules"
 	"github.com/m3db/m3/src/query/storage/local"
+	"github.com/m3db/m3/src/x/tracing"
 	etcdclient "github.com/m3db/m3cluster/client/etcd"
 	"github.com/m3db/m3x/config/listenaddress"
 	"github.com/m3db/m3x/instrument"
@@ -44,6 +50,9 @@ type Configuration struct {
 	// Metrics configuration.
 	Metrics instrument.MetricsConfiguration `yaml:"metrics"`
 
+	// Tracing configuration.
+	Tracing tracing.Configuration `yaml:"tracing"`
+
 	// Clusters is the DB cluster configurations for read, write and
 	// query endpoints.
 	Clusters local.ClustersStaticConfiguration `yaml:"clusters"`
@@ -71,6 +80,82 @@ type Configuration struct {
 	// DecompressWorkerPoolSize is the size of the worker pool given to each
 	// fetch request.
 	DecompressWorkerPoolSize int `yaml:"workerPoolSize"`
+
+	// Rules is the recording and alerting rule evaluation configuration
+	// (optional).
+	Rules *rules.Configuration `yaml:"rules"`
+
+	// ResultOptions are the options for query results.
+	ResultOptions ResultOptions `yaml:"resultOptions"`
+
+	// Query is the query engine configuration.
+	Query QueryConfiguration `yaml:"query"`
+
+	// Writes is the write pipeline configuration.
+	Writes WritesConfiguration `yaml:"writes"`
+
+	// HA is the configuration for deduplicating remote writes from highly
+	// available Prometheus pairs (optional).
+	HA *ha.Configuration `yaml:"ha"`
+
+	// Relabel is the configuration for relabeling and dropping series
+	// before they are written (optional).
+	Relabel *relabel.Configuration `yaml:"relabel"`
+
+	// Downsample is the configuration of the embedded downsampler used when
+	// writing to aggregated cluster namespaces.
+	Downsample DownsampleConfiguration `yaml:"downsample"`
+}
+
+// DownsampleConfiguration is the configuration of the embedded downsampler.
+type DownsampleConfiguration struct {
+	// Rules are static mapping and rollup rules applied in addition to the
+	// rules in the rules KV store.
+	Rules downsample.RulesConfiguration `yaml:"rules"`
+}
+
+// WritesConfiguration is the configuration for batched writes to the local
+// cluster namespaces.
+type WritesConfiguration struct {
+	// WorkerPoolSize is the number of workers writing batches of series
+	// concurrently, if zero a default is used.
+	WorkerPoolSize int `yaml:"workerPoolSize"`
+
+	// QueueSize is the number of batches that can be queued awaiting a
+	// worker, writes are rejected with a 429 once full, if zero a default
+	// is used.
+	QueueSize int `yaml:"queueSize"`
+
+	// WriteConcurrency is the number of datapoints written concurrently
+	// across all batches, if zero a default is used.
+	WriteConcurrency int `yaml:"writeConcurrency"`
+}
+
+// QueryConfiguration is the query engine configuration.
+type QueryConfiguration struct {
+	// MaxConcurrentQueries is the maximum number of queries that can execute
+	// at once, if zero the number of queries is not limited.
+	MaxConcurrentQueries int `yaml:"maxConcurrentQueries"`
+
+	// LogQueriesAfter logs queries along with their plan if they take longer
+	// than this to execute, if zero slow queries are not logged.
+	LogQueriesAfter time.Duration `yaml:"logQueriesAfter"`
+}
+
+// NewTracker returns a new query tracker for the configuration.
+func (c QueryConfiguration) NewTracker() *executor.Tracker {
+	tracker := executor.NewTracker()
+	tracker.MaxConcurrentQueries = c.MaxConcurrentQueries
+	tracker.LogQueriesAfter = c.LogQueriesAfter
+	return tracker
+}
+
+// ResultOptions are the options for query results.
+type ResultOptions struct {
+	// RequireExhaustive returns an error instead of partial results when a
+	// query hits a limit or a store fails, callers can override this per
+	// request with the M3-Require-Exhaustive header.
+	RequireExhaustive bool `yaml:"requireExhaustive"`
 }
 
 // LocalConfiguration is the local embedded configuration if running
@@ -0,0 +1,377 @@
+// Copyright (c) 2018 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package main
+
+import (
+	"math"
+	"sort"
+	"time"
+
+	"github.com/m3db/m3/src/dbnode/digest"
+	"github.com/m3db/m3/src/dbnode/encoding"
+	"github.com/m3db/m3/src/dbnode/encoding/m3tsz"
+	"github.com/m3db/m3/src/dbnode/persist"
+	"github.com/m3db/m3/src/dbnode/sharding"
+	"github.com/m3db/m3/src/dbnode/storage/index/convert"
+	"github.com/m3db/m3/src/dbnode/storage/namespace"
+	"github.com/m3db/m3/src/dbnode/ts"
+	"github.com/m3db/m3/src/m3ninx/index/segment"
+	"github.com/m3db/m3/src/m3ninx/index/segment/mem"
+	"github.com/m3db/m3/src/query/generated/proto/prompb"
+	"github.com/m3db/m3/src/query/models"
+	"github.com/m3db/m3/src/query/storage"
+	"github.com/m3db/m3x/ident"
+	xlog "github.com/m3db/m3x/log"
+	xtime "github.com/m3db/m3x/time"
+
+	"github.com/prometheus/tsdb/chunkenc"
+	"github.com/prometheus/tsdb/labels"
+)
+
+type importerOptions struct {
+	nsMetadata     namespace.Metadata
+	persistManager persist.Manager
+	hashFn         sharding.HashFn
+	shards         map[uint32]struct{}
+	encodingOpts   encoding.Options
+	logger         xlog.Logger
+}
+
+// importer converts Prometheus TSDB blocks into M3DB data and index filesets.
+// Data for a block is held in memory until no Prometheus block left to import
+// can contain samples for it, at which point it is flushed to disk.
+type importer struct {
+	opts           importerOptions
+	blockSize      time.Duration
+	indexBlockSize time.Duration
+
+	dataBlocks  map[xtime.UnixNano]*dataBlock
+	indexBlocks map[xtime.UnixNano]segment.MutableSegment
+
+	numSeries  int
+	numSamples int
+	numSkipped int
+}
+
+type dataBlock struct {
+	start  time.Time
+	shards map[uint32]map[string]*seriesEncoder
+}
+
+type seriesEncoder struct {
+	id      ident.ID
+	tags    ident.Tags
+	encoder encoding.Encoder
+	lastTs  int64
+}
+
+func newImporter(opts importerOptions) *importer {
+	nsOpts := opts.nsMetadata.Options()
+	return &importer{
+		opts:           opts,
+		blockSize:      nsOpts.RetentionOptions().BlockSize(),
+		indexBlockSize: nsOpts.IndexOptions().BlockSize(),
+		dataBlocks:     make(map[xtime.UnixNano]*dataBlock),
+		indexBlocks:    make(map[xtime.UnixNano]segment.MutableSegment),
+	}
+}
+
+// Import imports the given blocks, which must be ordered by min time.
+func (i *importer) Import(blocks []promBlock) error {
+	pool := chunkenc.NewPool()
+	for idx, block := range blocks {
+		i.opts.logger.Infof("importing block %s [%s, %s)", block.meta.ULID,
+			msToTime(block.meta.MinTime).String(), msToTime(block.meta.MaxTime).String())
+
+		if err := block.forEachSeries(pool, i.importSeries); err != nil {
+			return err
+		}
+
+		// Flush everything that subsequent blocks cannot contain samples for
+		watermark := time.Unix(0, math.MaxInt64)
+		if idx+1 < len(blocks) {
+			watermark = msToTime(blocks[idx+1].meta.MinTime)
+		}
+		if err := i.flushBefore(watermark); err != nil {
+			return err
+		}
+	}
+
+	i.opts.logger.Infof("imported %d series, %d samples, skipped %d out of order samples",
+		i.numSeries, i.numSamples, i.numSkipped)
+	return nil
+}
+
+func (i *importer) importSeries(lset labels.Labels, iter chunkenc.Iterator) error {
+	// Use the same ID and tags scheme as the remote write handler
+	promLabels := make([]*prompb.Label, 0, len(lset))
+	for _, l := range lset {
+		promLabels = append(promLabels, &prompb.Label{Name: l.Name, Value: l.Value})
+	}
+	tags := storage.PromLabelsToM3Tags(promLabels)
+	id := tags.ID()
+
+	shard := i.opts.hashFn(ident.StringID(id))
+	if _, ok := i.opts.shards[shard]; !ok {
+		return nil
+	}
+
+	var (
+		series     *seriesEncoder
+		blockStart time.Time
+	)
+	for iter.Next() {
+		t, v := iter.At()
+		timestamp := msToTime(t)
+		if series == nil || !timestamp.Before(blockStart.Add(i.blockSize)) {
+			blockStart = timestamp.Truncate(i.blockSize)
+			var err error
+			series, err = i.seriesEncoder(blockStart, shard, id, tags)
+			if err != nil {
+				return err
+			}
+		}
+
+		// Overlapping blocks may contain the same samples, keep the first
+		if t <= series.lastTs {
+			i.numSkipped++
+			continue
+		}
+
+		dp := ts.Datapoint{Timestamp: timestamp, Value: v}
+		if err := series.encoder.Encode(dp, xtime.Millisecond, nil); err != nil {
+			return err
+		}
+		series.lastTs = t
+		i.numSamples++
+	}
+
+	return iter.Err()
+}
+
+func (i *importer) seriesEncoder(
+	blockStart time.Time,
+	shard uint32,
+	id string,
+	tags models.Tags,
+) (*seriesEncoder, error) {
+	block, ok := i.dataBlocks[xtime.ToUnixNano(blockStart)]
+	if !ok {
+		block = &dataBlock{
+			start:  blockStart,
+			shards: make(map[uint32]map[string]*seriesEncoder),
+		}
+		i.dataBlocks[xtime.ToUnixNano(blockStart)] = block
+	}
+
+	shardSeries, ok := block.shards[shard]
+	if !ok {
+		shardSeries = make(map[string]*seriesEncoder)
+		block.shards[shard] = shardSeries
+	}
+
+	series, ok := shardSeries[id]
+	if ok {
+		return series, nil
+	}
+
+	identTags := make([]ident.Tag, 0, len(tags))
+	for _, tag := range tags {
+		identTags = append(identTags, ident.StringTag(tag.Name, tag.Value))
+	}
+	series = &seriesEncoder{
+		id:      ident.StringID(id),
+		tags:    ident.NewTags(identTags...),
+		encoder: m3tsz.NewEncoder(blockStart, nil, m3tsz.DefaultIntOptimizationEnabled, i.opts.encodingOpts),
+		lastTs:  math.MinInt64,
+	}
+	shardSeries[id] = series
+	i.numSeries++
+
+	if err := i.indexSeries(blockStart, series); err != nil {
+		return nil, err
+	}
+	return series, nil
+}
+
+func (i *importer) indexSeries(blockStart time.Time, series *seriesEncoder) error {
+	indexBlockStart := blockStart.Truncate(i.indexBlockSize)
+	seg, ok := i.indexBlocks[xtime.ToUnixNano(indexBlockStart)]
+	if !ok {
+		var err error
+		seg, err = mem.NewSegment(0, mem.NewOptions())
+		if err != nil {
+			return err
+		}
+		i.indexBlocks[xtime.ToUnixNano(indexBlockStart)] = seg
+	}
+
+	exists, err := seg.ContainsID(series.id.Bytes())
+	if err != nil || exists {
+		return err
+	}
+
+	d, err := convert.FromMetric(series.id, series.tags)
+	if err != nil {
+		return err
+	}
+	_, err = seg.Insert(d)
+	return err
+}
+
+func (i *importer) flushBefore(watermark time.Time) error {
+	if err := i.flushDataBefore(watermark); err != nil {
+		return err
+	}
+	return i.flushIndexBefore(watermark)
+}
+
+func (i *importer) flushDataBefore(watermark time.Time) error {
+	var starts []xtime.UnixNano
+	for start, block := range i.dataBlocks {
+		if !block.start.Add(i.blockSize).After(watermark) {
+			starts = append(starts, start)
+		}
+	}
+	if len(starts) == 0 {
+		return nil
+	}
+	sort.Slice(starts, func(a, b int) bool { return starts[a] < starts[b] })
+
+	flush, err := i.opts.persistManager.StartDataPersist()
+	if err != nil {
+		return err
+	}
+
+	for _, start := range starts {
+		block := i.dataBlocks[start]
+		if err := i.persistDataBlock(flush, block); err != nil {
+			flush.DoneData()
+			return err
+		}
+		delete(i.dataBlocks, start)
+	}
+
+	return flush.DoneData()
+}
+
+func (i *importer) persistDataBlock(flush persist.DataFlush, block *dataBlock) error {
+	for shard, shardSeries := range block.shards {
+		prepared, err := flush.PrepareData(persist.DataPrepareOptions{
+			NamespaceMetadata: i.opts.nsMetadata,
+			BlockStart:        block.start,
+			Shard:             shard,
+			FileSetType:       persist.FileSetFlushType,
+		})
+		if err != nil {
+			return err
+		}
+
+		for _, series := range shardSeries {
+			segment := series.encoder.Discard()
+			checksum := digest.SegmentChecksum(segment)
+			err := prepared.Persist(series.id, series.tags, segment, checksum)
+			segment.Finalize()
+			if err != nil {
+				prepared.Close()
+				return err
+			}
+		}
+
+		if err := prepared.Close(); err != nil {
+			return err
+		}
+
+		i.opts.logger.Infof("wrote data fileset for shard %d, block %s with %d series",
+			shard, block.start.String(), len(shardSeries))
+	}
+	return nil
+}
+
+func (i *importer) flushIndexBefore(watermark time.Time) error {
+	var starts []xtime.UnixNano
+	for start := range i.indexBlocks {
+		if !start.ToTime().Add(i.indexBlockSize).After(watermark) {
+			starts = append(starts, start)
+		}
+	}
+	if len(starts) == 0 {
+		return nil
+	}
+	sort.Slice(starts, func(a, b int) bool { return starts[a] < starts[b] })
+
+	flush, err := i.opts.persistManager.StartIndexPersist()
+	if err != nil {
+		return err
+	}
+
+	for _, start := range starts {
+		if err := i.persistIndexBlock(flush, start.ToTime(), i.indexBlocks[start]); err != nil {
+			flush.DoneIndex()
+			return err
+		}
+		delete(i.indexBlocks, start)
+	}
+
+	return flush.DoneIndex()
+}
+
+func (i *importer) persistIndexBlock(
+	flush persist.IndexFlush,
+	blockStart time.Time,
+	seg segment.MutableSegment,
+) error {
+	prepared, err := flush.PrepareIndex(persist.IndexPrepareOptions{
+		NamespaceMetadata: i.opts.nsMetadata,
+		BlockStart:        blockStart,
+		FileSetType:       persist.FileSetFlushType,
+		Shards:            i.opts.shards,
+	})
+	if err != nil {
+		return err
+	}
+
+	if _, err := seg.Seal(); err != nil {
+		prepared.Close()
+		return err
+	}
+
+	if err := prepared.Persist(seg); err != nil {
+		prepared.Close()
+		return err
+	}
+
+	persisted, err := prepared.Close()
+	if err != nil {
+		return err
+	}
+	for _, s := range persisted {
+		s.Close()
+	}
+
+	i.opts.logger.Infof("wrote index fileset for block %s with %d series",
+		blockStart.String(), seg.Size())
+	return seg.Close()
+}
+
+func msToTime(t int64) time.Time {
+	return time.Unix(0, t*int64(time.Millisecond))
+}
@@ -0,0 +1,252 @@
+// Copyright (c) 2018 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package main
+
+import (
+	"bytes"
+	"io"
+	"io/ioutil"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/m3db/m3/src/cmd/tools"
+	"github.com/m3db/m3/src/dbnode/encoding"
+	"github.com/m3db/m3/src/dbnode/encoding/m3tsz"
+	"github.com/m3db/m3/src/dbnode/persist"
+	"github.com/m3db/m3/src/dbnode/persist/fs"
+	"github.com/m3db/m3/src/dbnode/retention"
+	"github.com/m3db/m3/src/dbnode/storage/namespace"
+	"github.com/m3db/m3x/ident"
+	xlog "github.com/m3db/m3x/log"
+	xtime "github.com/m3db/m3x/time"
+
+	"github.com/prometheus/tsdb/chunkenc"
+	"github.com/prometheus/tsdb/labels"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	testNamespace      = "metrics"
+	testBlockSize      = 2 * time.Hour
+	testIndexBlockSize = 4 * time.Hour
+)
+
+// testStart is 2018-01-01 UTC, in the local time zone like decoded timestamps
+var testStart = time.Unix(1514764800, 0)
+
+type testSample struct {
+	t time.Time
+	v float64
+}
+
+func newTestImporter(t *testing.T, dir string, shard uint32) *importer {
+	nsMetadata, err := namespace.NewMetadata(ident.StringID(testNamespace), namespace.NewOptions().
+		SetRetentionOptions(retention.NewOptions().SetBlockSize(testBlockSize)).
+		SetIndexOptions(namespace.NewIndexOptions().
+			SetEnabled(true).
+			SetBlockSize(testIndexBlockSize)))
+	require.NoError(t, err)
+
+	pm, err := fs.NewPersistManager(fs.NewOptions().SetFilePathPrefix(dir))
+	require.NoError(t, err)
+
+	return newImporter(importerOptions{
+		nsMetadata:     nsMetadata,
+		persistManager: pm,
+		hashFn:         func(ident.ID) uint32 { return shard },
+		shards:         map[uint32]struct{}{0: {}},
+		encodingOpts:   newTestEncodingOptions(),
+		logger:         xlog.NullLogger,
+	})
+}
+
+func newTestEncodingOptions() encoding.Options {
+	bytesPool := tools.NewCheckedBytesPool()
+	bytesPool.Init()
+	return encoding.NewOptions().SetBytesPool(bytesPool)
+}
+
+func newTestChunkIterator(t *testing.T, samples ...testSample) chunkenc.Iterator {
+	chunk := chunkenc.NewXORChunk()
+	app, err := chunk.Appender()
+	require.NoError(t, err)
+	for _, s := range samples {
+		app.Append(timeToMs(s.t), s.v)
+	}
+	return chunk.Iterator()
+}
+
+func timeToMs(t time.Time) int64 {
+	return t.UnixNano() / int64(time.Millisecond)
+}
+
+func readTestDataFileSet(
+	t *testing.T,
+	dir string,
+	blockStart time.Time,
+) map[string][]testSample {
+	bytesPool := tools.NewCheckedBytesPool()
+	bytesPool.Init()
+	reader, err := fs.NewReader(bytesPool, fs.NewOptions().SetFilePathPrefix(dir))
+	require.NoError(t, err)
+
+	require.NoError(t, reader.Open(fs.DataReaderOpenOptions{
+		Identifier: fs.FileSetFileIdentifier{
+			Namespace:  ident.StringID(testNamespace),
+			Shard:      0,
+			BlockStart: blockStart,
+		},
+		FileSetType: persist.FileSetFlushType,
+	}))
+	defer reader.Close()
+
+	result := make(map[string][]testSample)
+	for {
+		id, _, data, _, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		require.NoError(t, err)
+
+		data.IncRef()
+		iter := m3tsz.NewReaderIterator(bytes.NewReader(data.Bytes()),
+			m3tsz.DefaultIntOptimizationEnabled, newTestEncodingOptions())
+		for iter.Next() {
+			dp, unit, _ := iter.Current()
+			assert.Equal(t, xtime.Millisecond, unit)
+			sample := testSample{t: time.Unix(0, dp.Timestamp.UnixNano()), v: dp.Value}
+			result[id.String()] = append(result[id.String()], sample)
+		}
+		require.NoError(t, iter.Err())
+		iter.Close()
+		data.DecRef()
+		data.Finalize()
+	}
+	return result
+}
+
+func TestImporterTagMapping(t *testing.T) {
+	dir, err := ioutil.TempDir("", "import-prom-tsdb")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	i := newTestImporter(t, dir, 0)
+	lset := labels.FromStrings("job", "api", "__name__", "http_requests_total")
+	iter := newTestChunkIterator(t, testSample{t: testStart, v: 1})
+	require.NoError(t, i.importSeries(lset, iter))
+
+	// Series use the same ID and sorted tags as the remote write handler
+	block, ok := i.dataBlocks[xtime.ToUnixNano(testStart)]
+	require.True(t, ok)
+	require.Len(t, block.shards[0], 1)
+	series, ok := block.shards[0]["__name__=http_requests_total,job=api,"]
+	require.True(t, ok)
+	assert.Equal(t, "__name__=http_requests_total,job=api,", series.id.String())
+
+	tags := series.tags.Values()
+	require.Len(t, tags, 2)
+	assert.Equal(t, "__name__", tags[0].Name.String())
+	assert.Equal(t, "http_requests_total", tags[0].Value.String())
+	assert.Equal(t, "job", tags[1].Name.String())
+	assert.Equal(t, "api", tags[1].Value.String())
+
+	// The series is indexed in the index block containing the data block
+	seg, ok := i.indexBlocks[xtime.ToUnixNano(testStart)]
+	require.True(t, ok)
+	exists, err := seg.ContainsID(series.id.Bytes())
+	require.NoError(t, err)
+	assert.True(t, exists)
+}
+
+func TestImporterSkipsSeriesOfOtherShards(t *testing.T) {
+	dir, err := ioutil.TempDir("", "import-prom-tsdb")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	i := newTestImporter(t, dir, 1)
+	iter := newTestChunkIterator(t, testSample{t: testStart, v: 1})
+	require.NoError(t, i.importSeries(labels.FromStrings("__name__", "foo"), iter))
+
+	assert.Equal(t, 0, i.numSeries)
+	assert.Len(t, i.dataBlocks, 0)
+	assert.Len(t, i.indexBlocks, 0)
+}
+
+func TestImporterSeriesConversion(t *testing.T) {
+	dir, err := ioutil.TempDir("", "import-prom-tsdb")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	i := newTestImporter(t, dir, 0)
+	lset := labels.FromStrings("__name__", "foo")
+	samples := []testSample{
+		{t: testStart, v: 1},
+		{t: testStart.Add(time.Minute), v: 2.5},
+		{t: testStart.Add(testBlockSize), v: 3},
+		{t: testStart.Add(testBlockSize + time.Minute), v: 4},
+	}
+	require.NoError(t, i.importSeries(lset, newTestChunkIterator(t, samples...)))
+
+	// Overlapping Prometheus blocks may contain the same samples again, only
+	// samples after the last imported sample of a block are kept
+	overlapping := []testSample{
+		{t: testStart.Add(time.Minute), v: 20},
+		{t: testStart.Add(2 * time.Minute), v: 5},
+	}
+	require.NoError(t, i.importSeries(lset, newTestChunkIterator(t, overlapping...)))
+
+	// Samples are split into the data blocks containing them
+	assert.Equal(t, 1, i.numSeries)
+	assert.Equal(t, 5, i.numSamples)
+	assert.Equal(t, 1, i.numSkipped)
+	require.Len(t, i.dataBlocks, 2)
+
+	// Only blocks that end before the watermark are flushed and the index
+	// block is flushed once both of its data blocks could be flushed
+	require.NoError(t, i.flushBefore(testStart.Add(testBlockSize)))
+	assert.Len(t, i.dataBlocks, 1)
+	assert.Len(t, i.indexBlocks, 1)
+
+	exists, err := fs.DataFileSetExistsAt(dir, ident.StringID(testNamespace), 0, testStart)
+	require.NoError(t, err)
+	assert.True(t, exists)
+	exists, err = fs.DataFileSetExistsAt(dir, ident.StringID(testNamespace), 0,
+		testStart.Add(testBlockSize))
+	require.NoError(t, err)
+	assert.False(t, exists)
+
+	require.NoError(t, i.flushBefore(testStart.Add(testIndexBlockSize)))
+	assert.Len(t, i.dataBlocks, 0)
+	assert.Len(t, i.indexBlocks, 0)
+
+	indexFileSets, err := fs.IndexFileSetsAt(dir, ident.StringID(testNamespace), testStart)
+	require.NoError(t, err)
+	assert.Len(t, indexFileSets, 1)
+
+	assert.Equal(t, map[string][]testSample{
+		"__name__=foo,": {samples[0], samples[1], overlapping[1]},
+	}, readTestDataFileSet(t, dir, testStart))
+	assert.Equal(t, map[string][]testSample{
+		"__name__=foo,": {samples[2], samples[3]},
+	}, readTestDataFileSet(t, dir, testStart.Add(testBlockSize)))
+}
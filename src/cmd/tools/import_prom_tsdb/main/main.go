@@ -0,0 +1,139 @@
+// Copyright (c) 2018 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package main
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/m3db/m3/src/cmd/tools"
+	"github.com/m3db/m3/src/dbnode/encoding"
+	"github.com/m3db/m3/src/dbnode/persist/fs"
+	"github.com/m3db/m3/src/dbnode/retention"
+	"github.com/m3db/m3/src/dbnode/sharding"
+	"github.com/m3db/m3/src/dbnode/storage/namespace"
+	"github.com/m3db/m3x/ident"
+	xlog "github.com/m3db/m3x/log"
+
+	"github.com/pborman/getopt"
+)
+
+func main() {
+	var (
+		optPromPath       = getopt.StringLong("prom-tsdb-path", 'i', "", "Prometheus TSDB data directory or single block directory [e.g. /prometheus/data]")
+		optPathPrefix     = getopt.StringLong("path-prefix", 'p', "", "Output path prefix [e.g. /var/lib/m3db]")
+		optNamespace      = getopt.StringLong("namespace", 'n', "", "Namespace [e.g. metrics]")
+		optBlockSize      = getopt.StringLong("block-size", 'b', "2h", "Namespace block size [e.g. 2h]")
+		optIndexBlockSize = getopt.StringLong("index-block-size", 'x', "", "Namespace index block size, defaults to the block size (optional)")
+		optNumShards      = getopt.Uint32Long("num-shards", 's', 0, "Total number of shards in the namespace placement [e.g. 64]")
+		optShards         = getopt.StringLong("shards", 'l', "", "Comma separated shards to write [e.g. 0,1,2], defaults to all shards (optional)")
+		log               = xlog.NewLogger(os.Stderr)
+	)
+	getopt.Parse()
+
+	if *optPromPath == "" ||
+		*optPathPrefix == "" ||
+		*optNamespace == "" ||
+		*optNumShards == 0 {
+		getopt.Usage()
+		os.Exit(1)
+	}
+
+	blockSize, err := time.ParseDuration(*optBlockSize)
+	if err != nil {
+		log.Fatalf("invalid block size: %v", err)
+	}
+	indexBlockSize := blockSize
+	if *optIndexBlockSize != "" {
+		indexBlockSize, err = time.ParseDuration(*optIndexBlockSize)
+		if err != nil {
+			log.Fatalf("invalid index block size: %v", err)
+		}
+	}
+
+	shards, err := parseShards(*optShards, *optNumShards)
+	if err != nil {
+		log.Fatalf("invalid shards: %v", err)
+	}
+
+	nsMetadata, err := namespace.NewMetadata(ident.StringID(*optNamespace), namespace.NewOptions().
+		SetRetentionOptions(retention.NewOptions().SetBlockSize(blockSize)).
+		SetIndexOptions(namespace.NewIndexOptions().
+			SetEnabled(true).
+			SetBlockSize(indexBlockSize)))
+	if err != nil {
+		log.Fatalf("invalid namespace options: %v", err)
+	}
+
+	blocks, err := readPromBlocks(*optPromPath)
+	if err != nil {
+		log.Fatalf("unable to read prometheus blocks: %v", err)
+	}
+	if len(blocks) == 0 {
+		log.Fatalf("no prometheus blocks found in: %s", *optPromPath)
+	}
+
+	bytesPool := tools.NewCheckedBytesPool()
+	bytesPool.Init()
+
+	fsOpts := fs.NewOptions().SetFilePathPrefix(*optPathPrefix)
+	pm, err := fs.NewPersistManager(fsOpts)
+	if err != nil {
+		log.Fatalf("could not create persist manager: %v", err)
+	}
+
+	importer := newImporter(importerOptions{
+		nsMetadata:     nsMetadata,
+		persistManager: pm,
+		hashFn:         sharding.DefaultHashFn(int(*optNumShards)),
+		shards:         shards,
+		encodingOpts:   encoding.NewOptions().SetBytesPool(bytesPool),
+		logger:         log,
+	})
+	if err := importer.Import(blocks); err != nil {
+		log.Fatalf("import failed: %v", err)
+	}
+}
+
+func parseShards(value string, numShards uint32) (map[uint32]struct{}, error) {
+	shards := make(map[uint32]struct{})
+	if value == "" {
+		for shard := uint32(0); shard < numShards; shard++ {
+			shards[shard] = struct{}{}
+		}
+		return shards, nil
+	}
+
+	for _, str := range strings.Split(value, ",") {
+		shard, err := strconv.ParseUint(strings.TrimSpace(str), 10, 32)
+		if err != nil {
+			return nil, err
+		}
+		if uint32(shard) >= numShards {
+			return nil, fmt.Errorf("shard %d exceeds number of shards %d", shard, numShards)
+		}
+		shards[uint32(shard)] = struct{}{}
+	}
+	return shards, nil
+}
@@ -0,0 +1,154 @@
+// Copyright (c) 2018 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path"
+	"sort"
+
+	"github.com/prometheus/tsdb/chunkenc"
+	"github.com/prometheus/tsdb/chunks"
+	"github.com/prometheus/tsdb/index"
+	"github.com/prometheus/tsdb/labels"
+)
+
+const (
+	promBlockMetaFileName  = "meta.json"
+	promBlockIndexFileName = "index"
+	promBlockChunksDirName = "chunks"
+)
+
+// promBlockMeta is the subset of a Prometheus TSDB block's meta.json
+// required to order and import the block.
+type promBlockMeta struct {
+	ULID    string `json:"ulid"`
+	MinTime int64  `json:"minTime"`
+	MaxTime int64  `json:"maxTime"`
+	Version int    `json:"version"`
+}
+
+type promBlock struct {
+	dir  string
+	meta promBlockMeta
+}
+
+type promSeriesFn func(lset labels.Labels, iter chunkenc.Iterator) error
+
+// readPromBlocks returns the Prometheus TSDB blocks found in dir ordered by
+// their min time, dir may either be a single block directory or a TSDB data
+// directory containing many blocks.
+func readPromBlocks(dir string) ([]promBlock, error) {
+	if block, ok, err := readPromBlock(dir); err != nil {
+		return nil, err
+	} else if ok {
+		return []promBlock{block}, nil
+	}
+
+	entries, err := ioutil.ReadDir(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	var blocks []promBlock
+	for _, entry := range entries {
+		if !entry.IsDir() {
+			continue
+		}
+		block, ok, err := readPromBlock(path.Join(dir, entry.Name()))
+		if err != nil {
+			return nil, err
+		}
+		if ok {
+			blocks = append(blocks, block)
+		}
+	}
+
+	sort.Slice(blocks, func(i, j int) bool {
+		return blocks[i].meta.MinTime < blocks[j].meta.MinTime
+	})
+	return blocks, nil
+}
+
+func readPromBlock(dir string) (promBlock, bool, error) {
+	b, err := ioutil.ReadFile(path.Join(dir, promBlockMetaFileName))
+	if os.IsNotExist(err) {
+		return promBlock{}, false, nil
+	}
+	if err != nil {
+		return promBlock{}, false, err
+	}
+
+	var meta promBlockMeta
+	if err := json.Unmarshal(b, &meta); err != nil {
+		return promBlock{}, false, fmt.Errorf("unable to decode %s: %v",
+			path.Join(dir, promBlockMetaFileName), err)
+	}
+
+	return promBlock{dir: dir, meta: meta}, true, nil
+}
+
+// forEachSeries calls fn with the labels and a sample iterator for every
+// chunk of every series in the block, chunks for a series are visited in
+// time order.
+func (b promBlock) forEachSeries(pool chunkenc.Pool, fn promSeriesFn) error {
+	ir, err := index.NewFileReader(path.Join(b.dir, promBlockIndexFileName))
+	if err != nil {
+		return err
+	}
+	defer ir.Close()
+
+	cr, err := chunks.NewDirReader(path.Join(b.dir, promBlockChunksDirName), pool)
+	if err != nil {
+		return err
+	}
+	defer cr.Close()
+
+	postings, err := ir.Postings(index.AllPostingsKey())
+	if err != nil {
+		return err
+	}
+
+	var (
+		lset       labels.Labels
+		chunkMetas []chunks.Meta
+	)
+	for postings.Next() {
+		if err := ir.Series(postings.At(), &lset, &chunkMetas); err != nil {
+			return err
+		}
+
+		for _, chunkMeta := range chunkMetas {
+			chunk, err := cr.Chunk(chunkMeta.Ref)
+			if err != nil {
+				return err
+			}
+			if err := fn(lset, chunk.Iterator()); err != nil {
+				return err
+			}
+		}
+	}
+
+	return postings.Err()
+}
@@ -0,0 +1,180 @@
+// Copyright (c) 2018 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package main
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path"
+	"testing"
+	"time"
+
+	"github.com/prometheus/tsdb/chunkenc"
+	"github.com/prometheus/tsdb/chunks"
+	"github.com/prometheus/tsdb/index"
+	"github.com/prometheus/tsdb/labels"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func writeTestPromBlockMeta(t *testing.T, dir string, meta promBlockMeta) {
+	require.NoError(t, os.MkdirAll(dir, 0755))
+	data := fmt.Sprintf(`{"ulid":%q,"minTime":%d,"maxTime":%d,"version":%d}`,
+		meta.ULID, meta.MinTime, meta.MaxTime, meta.Version)
+	require.NoError(t, ioutil.WriteFile(path.Join(dir, promBlockMetaFileName), []byte(data), 0644))
+}
+
+// writeTestPromBlock writes a Prometheus TSDB block with a chunk for each of
+// the given samples of each series, series must be sorted by labels.
+func writeTestPromBlock(
+	t *testing.T,
+	dir string,
+	series []labels.Labels,
+	samples [][][]testSample,
+) {
+	writeTestPromBlockMeta(t, dir, promBlockMeta{ULID: path.Base(dir), Version: 1})
+
+	chunkWriter, err := chunks.NewWriter(path.Join(dir, promBlockChunksDirName))
+	require.NoError(t, err)
+
+	chunkMetas := make([][]chunks.Meta, len(series))
+	symbols := make(map[string]struct{})
+	for i, lset := range series {
+		for _, l := range lset {
+			symbols[l.Name] = struct{}{}
+			symbols[l.Value] = struct{}{}
+		}
+		for _, chunkSamples := range samples[i] {
+			chunk := chunkenc.NewXORChunk()
+			app, err := chunk.Appender()
+			require.NoError(t, err)
+			for _, s := range chunkSamples {
+				app.Append(timeToMs(s.t), s.v)
+			}
+			chunkMetas[i] = append(chunkMetas[i], chunks.Meta{
+				Chunk:   chunk,
+				MinTime: timeToMs(chunkSamples[0].t),
+				MaxTime: timeToMs(chunkSamples[len(chunkSamples)-1].t),
+			})
+		}
+		require.NoError(t, chunkWriter.WriteChunks(chunkMetas[i]...))
+	}
+	require.NoError(t, chunkWriter.Close())
+
+	indexWriter, err := index.NewWriter(path.Join(dir, promBlockIndexFileName))
+	require.NoError(t, err)
+	require.NoError(t, indexWriter.AddSymbols(symbols))
+	refs := make([]uint64, 0, len(series))
+	for i, lset := range series {
+		ref := uint64(i)
+		require.NoError(t, indexWriter.AddSeries(ref, lset, chunkMetas[i]...))
+		refs = append(refs, ref)
+	}
+	allPostingsName, allPostingsValue := index.AllPostingsKey()
+	require.NoError(t, indexWriter.WritePostings(allPostingsName, allPostingsValue,
+		index.NewListPostings(refs)))
+	require.NoError(t, indexWriter.Close())
+}
+
+func TestReadPromBlocks(t *testing.T) {
+	dir, err := ioutil.TempDir("", "import-prom-tsdb")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	writeTestPromBlockMeta(t, path.Join(dir, "b"), promBlockMeta{ULID: "b", MinTime: 200, MaxTime: 300})
+	writeTestPromBlockMeta(t, path.Join(dir, "a"), promBlockMeta{ULID: "a", MinTime: 100, MaxTime: 200})
+	require.NoError(t, os.MkdirAll(path.Join(dir, "wal"), 0755))
+	require.NoError(t, ioutil.WriteFile(path.Join(dir, "lock"), nil, 0644))
+
+	// Blocks of a data directory are ordered by min time, other entries are
+	// ignored
+	blocks, err := readPromBlocks(dir)
+	require.NoError(t, err)
+	require.Len(t, blocks, 2)
+	assert.Equal(t, "b", blocks[1].meta.ULID)
+	assert.Equal(t, promBlock{
+		dir:  path.Join(dir, "a"),
+		meta: promBlockMeta{ULID: "a", MinTime: 100, MaxTime: 200},
+	}, blocks[0])
+
+	// A single block directory is imported as is
+	blocks, err = readPromBlocks(path.Join(dir, "b"))
+	require.NoError(t, err)
+	require.Len(t, blocks, 1)
+	assert.Equal(t, "b", blocks[0].meta.ULID)
+
+	require.NoError(t, ioutil.WriteFile(path.Join(dir, "a", promBlockMetaFileName), []byte("{"), 0644))
+	_, err = readPromBlocks(dir)
+	assert.Error(t, err)
+}
+
+func TestPromBlockForEachSeries(t *testing.T) {
+	dir, err := ioutil.TempDir("", "import-prom-tsdb")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	series := []labels.Labels{
+		labels.FromStrings("__name__", "bar", "job", "api"),
+		labels.FromStrings("__name__", "foo"),
+	}
+	samples := [][][]testSample{
+		{
+			{{t: testStart, v: 1}, {t: testStart.Add(time.Minute), v: 2}},
+			{{t: testStart.Add(time.Hour), v: 3}},
+		},
+		{
+			{{t: testStart, v: 4}},
+		},
+	}
+	writeTestPromBlock(t, path.Join(dir, "block"), series, samples)
+
+	blocks, err := readPromBlocks(dir)
+	require.NoError(t, err)
+	require.Len(t, blocks, 1)
+
+	// Every chunk of every series is visited in order
+	var (
+		visitedLabels  []labels.Labels
+		visitedSamples [][]testSample
+	)
+	err = blocks[0].forEachSeries(chunkenc.NewPool(), func(lset labels.Labels, iter chunkenc.Iterator) error {
+		var chunkSamples []testSample
+		for iter.Next() {
+			ts, v := iter.At()
+			chunkSamples = append(chunkSamples, testSample{t: msToTime(ts), v: v})
+		}
+		visitedLabels = append(visitedLabels, lset.Copy())
+		visitedSamples = append(visitedSamples, chunkSamples)
+		return iter.Err()
+	})
+	require.NoError(t, err)
+
+	assert.Equal(t, []labels.Labels{series[0], series[0], series[1]}, visitedLabels)
+	assert.Equal(t, [][]testSample{samples[0][0], samples[0][1], samples[1][0]}, visitedSamples)
+
+	// Errors returned while visiting series stop the iteration
+	visitErr := fmt.Errorf("visit error")
+	err = blocks[0].forEachSeries(chunkenc.NewPool(), func(labels.Labels, chunkenc.Iterator) error {
+		return visitErr
+	})
+	assert.Equal(t, visitErr, err)
+}
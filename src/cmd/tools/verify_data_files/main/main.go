@@ -0,0 +1,153 @@
+// Copyright (c) 2018 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package main
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/m3db/m3/src/cmd/tools"
+	"github.com/m3db/m3/src/dbnode/persist/fs"
+	"github.com/m3db/m3x/ident"
+	xlog "github.com/m3db/m3x/log"
+
+	"github.com/pborman/getopt"
+)
+
+func main() {
+	var (
+		optPathPrefix = getopt.StringLong("path-prefix", 'p', "", "Path prefix [e.g. /var/lib/m3db]")
+		optNamespace  = getopt.StringLong("namespace", 'n', "", "Namespace [e.g. metrics]")
+		optShard      = getopt.Int64Long("shard", 's', -1, "Shard [expected format uint32], defaults to all shards (optional)")
+		optBlockstart = getopt.Int64Long("block-start", 'b', 0, "Block Start Time [in nsec], defaults to all blocks (optional)")
+		log           = xlog.NewLogger(os.Stderr)
+	)
+	getopt.Parse()
+
+	if *optPathPrefix == "" ||
+		*optNamespace == "" ||
+		*optShard < -1 ||
+		*optBlockstart < 0 {
+		getopt.Usage()
+		os.Exit(1)
+	}
+
+	namespace := ident.StringID(*optNamespace)
+
+	var shards []uint32
+	if *optShard >= 0 {
+		shards = []uint32{uint32(*optShard)}
+	} else {
+		var err error
+		shards, err = namespaceShards(*optPathPrefix, namespace)
+		if err != nil {
+			log.Fatalf("unable to list shards: %v", err)
+		}
+	}
+
+	bytesPool := tools.NewCheckedBytesPool()
+	bytesPool.Init()
+
+	fsOpts := fs.NewOptions().SetFilePathPrefix(*optPathPrefix)
+	reader, err := fs.NewReader(bytesPool, fsOpts)
+	if err != nil {
+		log.Fatalf("could not create new reader: %v", err)
+	}
+
+	var numVerified, numCorrupt int
+	for _, shard := range shards {
+		results := fs.ReadInfoFiles(*optPathPrefix, namespace, shard,
+			fsOpts.InfoReaderBufferSize(), fsOpts.DecodingOptions())
+		for _, result := range results {
+			if err := result.Err.Error(); err != nil {
+				numCorrupt++
+				// Use fmt package so it goes to stdout instead of stderr
+				fmt.Printf("{shard: %d, path: %s, status: corrupt, error: %v}\n",
+					shard, result.Err.Filepath(), err)
+				continue
+			}
+
+			blockStart := time.Unix(0, result.Info.BlockStart)
+			if *optBlockstart > 0 && blockStart.UnixNano() != *optBlockstart {
+				continue
+			}
+
+			openOpts := fs.DataReaderOpenOptions{
+				Identifier: fs.FileSetFileIdentifier{
+					Namespace:  namespace,
+					Shard:      shard,
+					BlockStart: blockStart,
+				},
+			}
+			numVerified++
+			if err := reader.Open(openOpts); err != nil {
+				numCorrupt++
+				fmt.Printf("{shard: %d, blockStart: %d, status: corrupt, error: %v}\n",
+					shard, blockStart.UnixNano(), err)
+				continue
+			}
+
+			verifyResult := fs.VerifyDataFileSet(reader)
+			if err := verifyResult.Err(); err != nil {
+				numCorrupt++
+				fmt.Printf("{shard: %d, blockStart: %d, status: corrupt, entries: %d, entriesRead: %d, error: %v}\n",
+					shard, blockStart.UnixNano(), verifyResult.Entries, verifyResult.EntriesRead, err)
+			} else {
+				fmt.Printf("{shard: %d, blockStart: %d, status: ok, entries: %d}\n",
+					shard, blockStart.UnixNano(), verifyResult.Entries)
+			}
+
+			if err := reader.Close(); err != nil {
+				log.Fatalf("unable to close reader: %v", err)
+			}
+		}
+	}
+
+	log.Infof("verified %d filesets, %d corrupt", numVerified, numCorrupt)
+	if numCorrupt > 0 {
+		os.Exit(1)
+	}
+}
+
+func namespaceShards(filePathPrefix string, namespace ident.ID) ([]uint32, error) {
+	entries, err := ioutil.ReadDir(fs.NamespaceDataDirPath(filePathPrefix, namespace))
+	if err != nil {
+		return nil, err
+	}
+
+	shards := make([]uint32, 0, len(entries))
+	for _, entry := range entries {
+		if !entry.IsDir() {
+			continue
+		}
+		shard, err := strconv.ParseUint(entry.Name(), 10, 32)
+		if err != nil {
+			continue
+		}
+		shards = append(shards, uint32(shard))
+	}
+	sort.Slice(shards, func(i, j int) bool { return shards[i] < shards[j] })
+	return shards, nil
+}
@@ -31,6 +31,7 @@ import (
 	"github.com/m3db/m3/src/dbnode/environment"
 	"github.com/m3db/m3/src/dbnode/topology"
 	"github.com/m3db/m3/src/dbnode/x/tchannel"
+	xtls "github.com/m3db/m3/src/dbnode/x/tls"
 	"github.com/m3db/m3x/instrument"
 	"github.com/m3db/m3x/retry"
 )
@@ -79,6 +80,127 @@ type Configuration struct {
 
 	// HashingConfiguration is the configuration for hashing of IDs to shards.
 	HashingConfiguration HashingConfiguration `yaml:"hashing"`
+
+	// TLS is the TLS configuration for connections to nodes, if not set
+	// connections are plaintext.
+	TLS *xtls.Configuration `yaml:"tls"`
+
+	// HedgedReads is the hedged read configuration, if not set fetches are
+	// sent to all replicas at once.
+	HedgedReads *HedgedReadConfiguration `yaml:"hedgedReads"`
+
+	// ReadRepair is the read repair configuration, if not set replicas that
+	// return divergent data for a series when fetched are not repaired.
+	ReadRepair *ReadRepairConfiguration `yaml:"readRepair"`
+
+	// HintedHandoff is the hinted handoff configuration, if not set writes
+	// that fail for a replica are not kept to be replayed to it.
+	HintedHandoff *HintedHandoffConfiguration `yaml:"hintedHandoff"`
+}
+
+// HedgedReadConfiguration is the configuration for hedged reads, fields
+// that are not set use the default hedged read policy.
+type HedgedReadConfiguration struct {
+	// Percentile of recent fetch latencies to wait for before hedging.
+	Percentile float64 `yaml:"percentile" validate:"min=0,max=1"`
+
+	// MinDelay is the minimum delay before hedging.
+	MinDelay time.Duration `yaml:"minDelay" validate:"min=0"`
+
+	// MaxDelay is the maximum delay before hedging.
+	MaxDelay time.Duration `yaml:"maxDelay" validate:"min=0"`
+}
+
+// NewPolicy returns a new hedged read policy with hedging enabled.
+func (c HedgedReadConfiguration) NewPolicy() HedgedReadPolicy {
+	policy := defaultHedgedReadPolicy
+	policy.Enabled = true
+	if c.Percentile > 0 {
+		policy.Percentile = c.Percentile
+	}
+	if c.MinDelay > 0 {
+		policy.MinDelay = c.MinDelay
+	}
+	if c.MaxDelay > 0 {
+		policy.MaxDelay = c.MaxDelay
+	}
+	return policy
+}
+
+// ReadRepairConfiguration is the configuration for read repair, fields
+// that are not set use the default read repair policy.
+type ReadRepairConfiguration struct {
+	// SampleRate is the fraction of fetched series compared across replicas.
+	SampleRate float64 `yaml:"sampleRate" validate:"min=0,max=1"`
+
+	// MaxSeriesPerSecond is the maximum number of series repaired per second.
+	MaxSeriesPerSecond int `yaml:"maxSeriesPerSecond" validate:"min=0"`
+
+	// QueueSize is the maximum number of series waiting to be compared.
+	QueueSize int `yaml:"queueSize" validate:"min=0"`
+}
+
+// NewPolicy returns a new read repair policy with read repair enabled.
+func (c ReadRepairConfiguration) NewPolicy() ReadRepairPolicy {
+	policy := defaultReadRepairPolicy
+	policy.Enabled = true
+	if c.SampleRate > 0 {
+		policy.SampleRate = c.SampleRate
+	}
+	if c.MaxSeriesPerSecond > 0 {
+		policy.MaxSeriesPerSecond = c.MaxSeriesPerSecond
+	}
+	if c.QueueSize > 0 {
+		policy.QueueSize = c.QueueSize
+	}
+	return policy
+}
+
+// HintedHandoffConfiguration is the configuration for hinted handoff, fields
+// other than the directory that are not set use the default hinted handoff
+// policy.
+type HintedHandoffConfiguration struct {
+	// Directory is the directory hint logs are kept in, must not be shared
+	// with any other client.
+	Directory string `yaml:"directory" validate:"nonzero"`
+
+	// TTL is how long hints are kept for before they are dropped.
+	TTL time.Duration `yaml:"ttl" validate:"min=0"`
+
+	// MaxBytesPerHost is the maximum size of the hint log of a host.
+	MaxBytesPerHost int64 `yaml:"maxBytesPerHost" validate:"min=0"`
+
+	// MaxReplayPerSecond is the maximum number of hints replayed per second.
+	MaxReplayPerSecond int `yaml:"maxReplayPerSecond" validate:"min=0"`
+
+	// CheckInterval is the interval at which hosts are checked for replay.
+	CheckInterval time.Duration `yaml:"checkInterval" validate:"min=0"`
+
+	// QueueSize is the maximum number of hints waiting to be written.
+	QueueSize int `yaml:"queueSize" validate:"min=0"`
+}
+
+// NewPolicy returns a new hinted handoff policy with hinted handoff enabled.
+func (c HintedHandoffConfiguration) NewPolicy() HintedHandoffPolicy {
+	policy := defaultHintedHandoffPolicy
+	policy.Enabled = true
+	policy.Directory = c.Directory
+	if c.TTL > 0 {
+		policy.TTL = c.TTL
+	}
+	if c.MaxBytesPerHost > 0 {
+		policy.MaxBytesPerHost = c.MaxBytesPerHost
+	}
+	if c.MaxReplayPerSecond > 0 {
+		policy.MaxReplayPerSecond = c.MaxReplayPerSecond
+	}
+	if c.CheckInterval > 0 {
+		policy.CheckInterval = c.CheckInterval
+	}
+	if c.QueueSize > 0 {
+		policy.QueueSize = c.QueueSize
+	}
+	return policy
 }
 
 // HashingConfiguration is the configuration for hashing
@@ -175,6 +297,17 @@ func (c Configuration) NewAdminClient(
 		}
 	}
 
+	channelOpts := xtchannel.NewDefaultChannelOptions()
+	if c.TLS != nil {
+		// NB: the certificate manager reloads certificates for the lifetime
+		// of the process so that rotated certificates are used by new connections.
+		certManager, err := c.TLS.NewCertificateManager(iopts)
+		if err != nil {
+			return nil, fmt.Errorf("unable to create tls certificate manager, err: %v", err)
+		}
+		channelOpts.Dialer = certManager.DialContext
+	}
+
 	v := NewAdminOptions().
 		SetTopologyInitializer(envCfg.TopologyInitializer).
 		SetWriteConsistencyLevel(c.WriteConsistencyLevel).
@@ -187,9 +320,19 @@ func (c Configuration) NewAdminClient(
 		SetClusterConnectTimeout(c.ConnectTimeout).
 		SetWriteRetrier(c.WriteRetry.NewRetrier(writeRequestScope)).
 		SetFetchRetrier(c.FetchRetry.NewRetrier(fetchRequestScope)).
-		SetChannelOptions(xtchannel.NewDefaultChannelOptions()).
+		SetChannelOptions(channelOpts).
 		SetInstrumentOptions(iopts)
 
+	if c.HedgedReads != nil {
+		v = v.SetHedgedReadPolicy(c.HedgedReads.NewPolicy())
+	}
+	if c.ReadRepair != nil {
+		v = v.SetReadRepairPolicy(c.ReadRepair.NewPolicy())
+	}
+	if c.HintedHandoff != nil {
+		v = v.SetHintedHandoffPolicy(c.HintedHandoff.NewPolicy())
+	}
+
 	encodingOpts := params.EncodingOptions
 	if encodingOpts == nil {
 		encodingOpts = encoding.NewOptions()
@@ -27,6 +27,7 @@ import (
 	"time"
 
 	"github.com/m3db/m3/src/dbnode/topology"
+	xtls "github.com/m3db/m3/src/dbnode/x/tls"
 	xconfig "github.com/m3db/m3x/config"
 	"github.com/m3db/m3x/retry"
 
@@ -56,6 +57,11 @@ backgroundHealthCheckFailLimit: 4
 backgroundHealthCheckFailThrottleFactor: 0.5
 hashing:
   seed: 42
+tls:
+  certFile: /etc/m3db/client.pem
+  keyFile: /etc/m3db/client-key.pem
+  caFile: /etc/m3db/ca.pem
+  reloadInterval: 30s
 `
 
 	fd, err := ioutil.TempFile("", "config.yaml")
@@ -97,6 +103,12 @@ hashing:
 		HashingConfiguration: HashingConfiguration{
 			Seed: 42,
 		},
+		TLS: &xtls.Configuration{
+			CertFile:       "/etc/m3db/client.pem",
+			KeyFile:        "/etc/m3db/client-key.pem",
+			CAFile:         "/etc/m3db/ca.pem",
+			ReloadInterval: 30 * time.Second,
+		},
 	}
 
 	assert.Equal(t, expected, cfg)
@@ -53,6 +53,12 @@ type fetchState struct {
 	err                  error
 	done                 bool
 
+	// hedge sends the fetch to hedgeHosts, hedgeWon is set if the response
+	// of a hedged host completed the fetch.
+	hedge      *readHedge
+	hedgeHosts []topology.Host
+	hedgeWon   bool
+
 	pool fetchStatePool
 }
 
@@ -77,6 +83,12 @@ func (f *fetchState) close() {
 	}
 	f.err = nil
 	f.done = false
+	f.hedge = nil
+	for i := range f.hedgeHosts {
+		f.hedgeHosts[i] = nil
+	}
+	f.hedgeHosts = f.hedgeHosts[:0]
+	f.hedgeWon = false
 	f.tagResultAccumulator.Clear()
 
 	if f.pool == nil {
@@ -121,12 +133,32 @@ func (f *fetchState) completionFn(
 		return
 	}
 
+	if resultErr != nil && f.hedge != nil {
+		// Send the hedged fetches without waiting for the delay
+		f.hedge.trigger()
+	}
+
 	done, err := f.tagResultAccumulator.Add(opts, resultErr)
 	if done {
+		if err == nil && resultErr == nil && f.isHedgeHostWithLock(opts.host) {
+			f.hedgeWon = true
+		}
 		f.markDoneWithLock(err)
 	}
 }
 
+func (f *fetchState) isHedgeHostWithLock(host topology.Host) bool {
+	if host == nil {
+		return false
+	}
+	for _, h := range f.hedgeHosts {
+		if h.ID() == host.ID() {
+			return true
+		}
+	}
+	return false
+}
+
 func (f *fetchState) markDoneWithLock(err error) {
 	f.done = true
 	f.err = err
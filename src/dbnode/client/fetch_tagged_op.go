@@ -21,6 +21,8 @@
 package client
 
 import (
+	"context"
+
 	"github.com/m3db/m3/src/dbnode/generated/thrift/rpc"
 	"github.com/m3db/m3x/pool"
 )
@@ -31,6 +33,7 @@ var (
 
 type fetchTaggedOp struct {
 	refCounter
+	ctx          context.Context
 	request      rpc.FetchTaggedRequest
 	completionFn completionFn
 
@@ -40,7 +43,12 @@ type fetchTaggedOp struct {
 func (f *fetchTaggedOp) Size() int                  { return 1 }
 func (f *fetchTaggedOp) CompletionFn() completionFn { return f.completionFn }
 
-func (f *fetchTaggedOp) update(req rpc.FetchTaggedRequest, fn completionFn) {
+func (f *fetchTaggedOp) update(
+	ctx context.Context,
+	req rpc.FetchTaggedRequest,
+	fn completionFn,
+) {
+	f.ctx = ctx
 	f.request = req
 	f.completionFn = fn
 }
@@ -53,6 +61,7 @@ func (f *fetchTaggedOp) requestLimit(defaultValue int) int {
 }
 
 func (f *fetchTaggedOp) close() {
+	f.ctx = nil
 	f.completionFn = nil
 	f.request = fetchTaggedOpRequestZeroed
 	// return to pool
@@ -0,0 +1,237 @@
+// Copyright (c) 2018 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package client
+
+import (
+	"errors"
+	"math"
+	"sort"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/m3db/m3/src/dbnode/topology"
+)
+
+const (
+	// hedgeDelaySamples is the number of recent fetch latencies the hedge
+	// delay is estimated from.
+	hedgeDelaySamples = 1024
+
+	// hedgeDelayMinSamples is the number of fetch latencies that must be
+	// observed before the hedge delay is estimated, until then the max delay
+	// of the policy is used.
+	hedgeDelayMinSamples = 64
+
+	// hedgeDelayRecomputeEvery is the number of fetch latencies observed
+	// between each estimate of the hedge delay.
+	hedgeDelayRecomputeEvery = 64
+)
+
+var (
+	errHedgedReadPercentileInvalid = errors.New("hedged read percentile must be greater than 0 and less than 1")
+	errHedgedReadDelayNegative     = errors.New("hedged read min and max delay must be non-negative")
+	errHedgedReadMinDelayAboveMax  = errors.New("hedged read min delay must not be greater than max delay")
+	errHedgedReadTopologyChanged   = errors.New("hedged read not sent as topology changed")
+	errHedgedReadCancelled         = errors.New("hedged read not sent as fetch already completed")
+)
+
+// HedgedReadPolicy is the policy for hedging fetches. When enabled a fetch is
+// first sent only to as many replicas as are required to satisfy the read
+// consistency level, and is sent to the remaining replicas if it has not
+// completed after a delay or as soon as any of the first replicas fail.
+type HedgedReadPolicy struct {
+	// Enabled enables hedged fetches.
+	Enabled bool
+
+	// Percentile is the percentile of recently observed fetch latencies
+	// used as the delay before sending a fetch to the remaining replicas.
+	Percentile float64
+
+	// MinDelay is the minimum delay before sending a fetch to the
+	// remaining replicas.
+	MinDelay time.Duration
+
+	// MaxDelay is the maximum delay before sending a fetch to the remaining
+	// replicas, it is also the delay used until enough fetch latencies have
+	// been observed to estimate the percentile.
+	MaxDelay time.Duration
+}
+
+// Validate validates the hedged read policy.
+func (p HedgedReadPolicy) Validate() error {
+	if !p.Enabled {
+		return nil
+	}
+	if p.Percentile <= 0 || p.Percentile >= 1 {
+		return errHedgedReadPercentileInvalid
+	}
+	if p.MinDelay < 0 || p.MaxDelay < 0 {
+		return errHedgedReadDelayNegative
+	}
+	if p.MinDelay > p.MaxDelay {
+		return errHedgedReadMinDelayAboveMax
+	}
+	return nil
+}
+
+// hedgedReadPrimaries returns the number of replicas a hedged fetch is first
+// sent to for a read consistency level.
+func hedgedReadPrimaries(
+	level topology.ReadConsistencyLevel,
+	majority, replicas int,
+) int {
+	var n int
+	switch level {
+	case topology.ReadConsistencyLevelNone, topology.ReadConsistencyLevelOne:
+		n = 1
+	case topology.ReadConsistencyLevelUnstrictMajority, topology.ReadConsistencyLevelMajority:
+		n = majority
+	default:
+		n = replicas
+	}
+	if n > replicas {
+		n = replicas
+	}
+	return n
+}
+
+// hedgeDelayEstimator estimates the delay before hedging a fetch from the
+// latencies of recent fetches.
+type hedgeDelayEstimator struct {
+	sync.Mutex
+
+	policy         HedgedReadPolicy
+	samples        []time.Duration
+	sorted         []time.Duration
+	next           int
+	sinceRecompute int
+
+	// Delay must use atomic store and load to access.
+	delay int64
+}
+
+func newHedgeDelayEstimator(policy HedgedReadPolicy) *hedgeDelayEstimator {
+	return &hedgeDelayEstimator{
+		policy:  policy,
+		samples: make([]time.Duration, 0, hedgeDelaySamples),
+		sorted:  make([]time.Duration, 0, hedgeDelaySamples),
+		delay:   int64(policy.MaxDelay),
+	}
+}
+
+// Record records the latency of a fetch.
+func (e *hedgeDelayEstimator) Record(latency time.Duration) {
+	e.Lock()
+	defer e.Unlock()
+
+	if len(e.samples) < cap(e.samples) {
+		e.samples = append(e.samples, latency)
+	} else {
+		e.samples[e.next] = latency
+	}
+	e.next = (e.next + 1) % cap(e.samples)
+
+	e.sinceRecompute++
+	if len(e.samples) < hedgeDelayMinSamples ||
+		e.sinceRecompute < hedgeDelayRecomputeEvery {
+		return
+	}
+	e.sinceRecompute = 0
+
+	e.sorted = append(e.sorted[:0], e.samples...)
+	sort.Slice(e.sorted, func(i, j int) bool {
+		return e.sorted[i] < e.sorted[j]
+	})
+	idx := int(math.Ceil(e.policy.Percentile*float64(len(e.sorted)))) - 1
+	if idx < 0 {
+		idx = 0
+	}
+	delay := e.sorted[idx]
+	if delay < e.policy.MinDelay {
+		delay = e.policy.MinDelay
+	}
+	if delay > e.policy.MaxDelay {
+		delay = e.policy.MaxDelay
+	}
+	atomic.StoreInt64(&e.delay, int64(delay))
+}
+
+// Delay returns the current delay before hedging a fetch.
+func (e *hedgeDelayEstimator) Delay() time.Duration {
+	return time.Duration(atomic.LoadInt64(&e.delay))
+}
+
+const (
+	readHedgePending int32 = iota
+	readHedgeSent
+	readHedgeCancelled
+)
+
+// readHedge sends the hedged requests of a fetch once after a delay, or as
+// soon as it is triggered, unless it is cancelled first in which case the
+// hedged requests are released without being sent.
+type readHedge struct {
+	state    int32
+	timer    *time.Timer
+	sendFn   func()
+	cancelFn func()
+}
+
+func newReadHedge(
+	delay time.Duration,
+	sendFn func(),
+	cancelFn func(),
+) *readHedge {
+	h := &readHedge{
+		sendFn:   sendFn,
+		cancelFn: cancelFn,
+	}
+	h.timer = time.AfterFunc(delay, h.fire)
+	return h
+}
+
+// trigger sends the hedged requests without waiting for the delay, it is
+// safe to call with locks held as the requests are sent asynchronously.
+func (h *readHedge) trigger() {
+	if atomic.LoadInt32(&h.state) == readHedgePending {
+		go h.fire()
+	}
+}
+
+func (h *readHedge) fire() {
+	if atomic.CompareAndSwapInt32(&h.state, readHedgePending, readHedgeSent) {
+		h.sendFn()
+	}
+}
+
+// cancel releases the hedged requests if they have not yet been sent.
+func (h *readHedge) cancel() {
+	if atomic.CompareAndSwapInt32(&h.state, readHedgePending, readHedgeCancelled) {
+		h.timer.Stop()
+		h.cancelFn()
+	}
+}
+
+// sent returns whether the hedged requests have been sent.
+func (h *readHedge) sent() bool {
+	return atomic.LoadInt32(&h.state) == readHedgeSent
+}
@@ -0,0 +1,136 @@
+// Copyright (c) 2018 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package client
+
+import (
+	"testing"
+	"time"
+
+	"github.com/m3db/m3/src/dbnode/topology"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHedgedReadPolicyValidate(t *testing.T) {
+	policy := defaultHedgedReadPolicy
+	require.NoError(t, policy.Validate())
+
+	policy.Enabled = true
+	require.NoError(t, policy.Validate())
+
+	invalid := policy
+	invalid.Percentile = 1
+	assert.Equal(t, errHedgedReadPercentileInvalid, invalid.Validate())
+
+	invalid = policy
+	invalid.MinDelay = -time.Millisecond
+	assert.Equal(t, errHedgedReadDelayNegative, invalid.Validate())
+
+	invalid = policy
+	invalid.MinDelay = 2 * invalid.MaxDelay
+	assert.Equal(t, errHedgedReadMinDelayAboveMax, invalid.Validate())
+}
+
+func TestHedgedReadPrimaries(t *testing.T) {
+	assert.Equal(t, 1, hedgedReadPrimaries(topology.ReadConsistencyLevelOne, 2, 3))
+	assert.Equal(t, 2, hedgedReadPrimaries(topology.ReadConsistencyLevelUnstrictMajority, 2, 3))
+	assert.Equal(t, 2, hedgedReadPrimaries(topology.ReadConsistencyLevelMajority, 2, 3))
+	assert.Equal(t, 3, hedgedReadPrimaries(topology.ReadConsistencyLevelAll, 2, 3))
+	assert.Equal(t, 1, hedgedReadPrimaries(topology.ReadConsistencyLevelMajority, 2, 1))
+}
+
+func TestHedgeDelayEstimatorUsesPercentileWithinBounds(t *testing.T) {
+	policy := HedgedReadPolicy{
+		Enabled:    true,
+		Percentile: 0.9,
+		MinDelay:   5 * time.Millisecond,
+		MaxDelay:   80 * time.Millisecond,
+	}
+	e := newHedgeDelayEstimator(policy)
+
+	// Max delay is used until enough latencies are observed.
+	assert.Equal(t, policy.MaxDelay, e.Delay())
+	for i := 0; i < hedgeDelayMinSamples-1; i++ {
+		e.Record(time.Millisecond)
+	}
+	assert.Equal(t, policy.MaxDelay, e.Delay())
+
+	// Latencies of 1ms to 100ms with 1ms to 28ms observed twice, the 90th
+	// percentile is 87ms.
+	policy.MaxDelay = time.Second
+	e = newHedgeDelayEstimator(policy)
+	for i := 1; i <= 128; i++ {
+		e.Record(time.Duration(1+(i-1)%100) * time.Millisecond)
+	}
+	assert.Equal(t, 87*time.Millisecond, e.Delay())
+
+	// Estimate is clamped to the min delay.
+	for i := 0; i < hedgeDelaySamples; i++ {
+		e.Record(time.Microsecond)
+	}
+	assert.Equal(t, policy.MinDelay, e.Delay())
+}
+
+func TestReadHedgeSendsOnceAndCancelReleases(t *testing.T) {
+	var sent, cancelled int
+	done := make(chan struct{})
+	h := newReadHedge(time.Hour, func() {
+		sent++
+		close(done)
+	}, func() {
+		cancelled++
+	})
+
+	h.trigger()
+	<-done
+	h.trigger()
+	h.fire()
+	h.cancel()
+	assert.True(t, h.sent())
+	assert.Equal(t, 1, sent)
+	assert.Equal(t, 0, cancelled)
+
+	h = newReadHedge(time.Hour, func() {
+		sent++
+	}, func() {
+		cancelled++
+	})
+	h.cancel()
+	h.fire()
+	assert.False(t, h.sent())
+	assert.Equal(t, 1, sent)
+	assert.Equal(t, 1, cancelled)
+}
+
+func TestHedgedFetchTaggedHostIdxs(t *testing.T) {
+	shardSet := sessionTestShardSet()
+	topoMap := topology.NewStaticMap(topology.NewStaticOptions().
+		SetReplicas(sessionTestReplicas).
+		SetShardSet(shardSet).
+		SetHostShardSets(sessionTestHostAndShards(shardSet)))
+
+	// Every host owns every shard so a single primary host is required.
+	assert.Equal(t, []int{1, 2}, hedgedFetchTaggedHostIdxs(topoMap, 1, 0))
+	assert.Equal(t, []int{0, 2}, hedgedFetchTaggedHostIdxs(topoMap, 1, 1))
+	assert.Equal(t, []int{0}, hedgedFetchTaggedHostIdxs(topoMap, 2, 1))
+	assert.Nil(t, hedgedFetchTaggedHostIdxs(topoMap, 3, 0))
+}
@@ -0,0 +1,752 @@
+// Copyright (c) 2018 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package client
+
+import (
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/url"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/m3db/m3/src/dbnode/clock"
+	"github.com/m3db/m3/src/dbnode/generated/thrift/rpc"
+	"github.com/m3db/m3/src/dbnode/network/server/tchannelthrift/convert"
+	"github.com/m3db/m3/src/dbnode/persist/fs"
+	"github.com/m3db/m3/src/dbnode/persist/fs/commitlog"
+	"github.com/m3db/m3/src/dbnode/serialize"
+	"github.com/m3db/m3/src/dbnode/ts"
+	"github.com/m3db/m3x/checked"
+	"github.com/m3db/m3x/context"
+	xerrors "github.com/m3db/m3x/errors"
+	"github.com/m3db/m3x/ident"
+	"github.com/m3db/m3x/instrument"
+	xlog "github.com/m3db/m3x/log"
+	xtime "github.com/m3db/m3x/time"
+
+	"github.com/uber-go/tally"
+)
+
+const (
+	// hintLogBlockSize is the duration of each hint log file, hints are
+	// expired a hint log file at a time.
+	hintLogBlockSize = time.Minute
+
+	// hintEntryOverheadBytes is the estimated size of a hint excluding the
+	// series metadata and annotation, used to enforce the max bytes of a hint
+	// log between the sizes of the hint log files being read from disk.
+	hintEntryOverheadBytes = 64
+)
+
+var (
+	errHintedHandoffDirectoryEmpty           = errors.New("hinted handoff directory must be set")
+	errHintedHandoffTTLTooShort              = fmt.Errorf("hinted handoff ttl must be greater than %v", hintLogBlockSize)
+	errHintedHandoffMaxBytesNonPositive      = errors.New("hinted handoff max bytes per host must be positive")
+	errHintedHandoffMaxReplayNegative        = errors.New("hinted handoff max replay per second must be non-negative")
+	errHintedHandoffCheckIntervalNonPositive = errors.New("hinted handoff check interval must be positive")
+	errHintedHandoffQueueSizeNonPositive     = errors.New("hinted handoff queue size must be positive")
+	errHintedHandoffClosed                   = errors.New("hinted handoff is closed")
+)
+
+// HintedHandoffPolicy is the policy for hinted handoff of writes. When enabled
+// a write that fails for a replica is kept as a hint in a disk backed hint log
+// for that host, using the commit log encoding, and the hints are replayed to
+// the host once the topology reports all of its shards available and the
+// session has connections to it.
+//
+// Hints are replayed at least once, a hint log file that is partially replayed
+// when a replay fails is replayed again from the start.
+type HintedHandoffPolicy struct {
+	// Enabled enables hinted handoff.
+	Enabled bool
+
+	// Directory is the directory hint logs are kept in, each host has its
+	// own hint log in a sub-directory. The directory must not be shared
+	// with any other session.
+	Directory string
+
+	// TTL is how long hints are kept for before they are dropped, must be
+	// greater than the duration of a hint log file.
+	TTL time.Duration
+
+	// MaxBytesPerHost is the maximum size of the hint log of a host, hints
+	// for the host are dropped while its hint log is at the maximum size.
+	MaxBytesPerHost int64
+
+	// MaxReplayPerSecond is the maximum number of hints replayed per second,
+	// zero means unlimited.
+	MaxReplayPerSecond int
+
+	// CheckInterval is the interval at which hint logs are expired and hosts
+	// checked for whether their hints can be replayed.
+	CheckInterval time.Duration
+
+	// QueueSize is the maximum number of hints waiting to be written to the
+	// hint logs, hints for failed writes are dropped when the queue is full.
+	QueueSize int
+}
+
+// Validate validates the hinted handoff policy.
+func (p HintedHandoffPolicy) Validate() error {
+	if !p.Enabled {
+		return nil
+	}
+	if p.Directory == "" {
+		return errHintedHandoffDirectoryEmpty
+	}
+	if p.TTL <= hintLogBlockSize {
+		return errHintedHandoffTTLTooShort
+	}
+	if p.MaxBytesPerHost <= 0 {
+		return errHintedHandoffMaxBytesNonPositive
+	}
+	if p.MaxReplayPerSecond < 0 {
+		return errHintedHandoffMaxReplayNegative
+	}
+	if p.CheckInterval <= 0 {
+		return errHintedHandoffCheckIntervalNonPositive
+	}
+	if p.QueueSize <= 0 {
+		return errHintedHandoffQueueSizeNonPositive
+	}
+	return nil
+}
+
+// hintedHandoffHealthyFn returns whether hints can be replayed to a host.
+type hintedHandoffHealthyFn func(hostID string) bool
+
+// hintedHandoffWriteFn writes a hint to a single host, the tags are nil for
+// hints of untagged writes.
+type hintedHandoffWriteFn func(
+	hostID string,
+	namespace, id ident.ID,
+	tags ident.TagIterator,
+	dp ts.Datapoint,
+	unit xtime.Unit,
+	annotation ts.Annotation,
+) error
+
+type hintedHandoffMetrics struct {
+	hints        tally.Counter
+	dropped      tally.Counter
+	queueFull    tally.Counter
+	errors       tally.Counter
+	expired      tally.Counter
+	replays      tally.Counter
+	replayed     tally.Counter
+	replayErrors tally.Counter
+	bytes        tally.Gauge
+}
+
+func newHintedHandoffMetrics(scope tally.Scope) hintedHandoffMetrics {
+	return hintedHandoffMetrics{
+		hints:        scope.Counter("hints"),
+		dropped:      scope.Counter("hints-dropped"),
+		queueFull:    scope.Counter("queue-full"),
+		errors:       scope.Counter("hint-errors"),
+		expired:      scope.Counter("expired-files"),
+		replays:      scope.Counter("replays"),
+		replayed:     scope.Counter("hints-replayed"),
+		replayErrors: scope.Counter("replay-errors"),
+		bytes:        scope.Gauge("bytes"),
+	}
+}
+
+// hintLog is the hint log of a single host.
+type hintLog struct {
+	sync.Mutex
+
+	hostID string
+	opts   commitlog.Options
+	writer commitlog.CommitLog
+
+	// indexes assigns the series of the current writer unique indexes so
+	// series metadata is only written once per hint log file.
+	indexes map[hintSeriesKey]uint64
+
+	// bytes is the size of the hint log files on disk when last read plus
+	// the estimated size of the hints written since.
+	bytes int64
+
+	lastHintAt time.Time
+}
+
+// closeWriterWithLock closes the current writer, if any, which flushes and
+// closes the hint log file being written.
+func (l *hintLog) closeWriterWithLock() error {
+	if l.writer == nil {
+		return nil
+	}
+	err := l.writer.Close()
+	l.writer = nil
+	l.indexes = make(map[hintSeriesKey]uint64)
+	return err
+}
+
+func (l *hintLog) hasWriter() bool {
+	l.Lock()
+	defer l.Unlock()
+	return l.writer != nil
+}
+
+type hintSeriesKey struct {
+	namespace string
+	id        string
+}
+
+// hintRequest is a write that failed for a host waiting to be written to the
+// hint log of the host.
+type hintRequest struct {
+	hostID      string
+	namespace   string
+	id          string
+	encodedTags []byte
+	shard       uint32
+	datapoint   rpc.Datapoint
+}
+
+// hintedHandoff keeps the hint logs of hosts and replays them, hints are
+// written by one background goroutine and hint logs are expired and replayed
+// by another so that hints keep being written while a hint log is replayed.
+type hintedHandoff struct {
+	sync.Mutex
+
+	policy         HintedHandoffPolicy
+	opts           commitlog.Options
+	nowFn          clock.NowFn
+	log            xlog.Logger
+	metrics        hintedHandoffMetrics
+	tagDecoderPool serialize.TagDecoderPool
+	contextPool    context.Pool
+	healthyFn      hintedHandoffHealthyFn
+	writeFn        hintedHandoffWriteFn
+
+	logs    map[string]*hintLog
+	queue   chan hintRequest
+	closed  bool
+	closeCh chan struct{}
+	wg      sync.WaitGroup
+
+	// The replay rate limit window and count are only accessed by the
+	// replaying goroutine and so are not synchronized.
+	replayWindow time.Time
+	replayCount  int
+}
+
+// newHintedHandoff returns a new hinted handoff with the hint logs already
+// on disk loaded so that hints are kept across restarts.
+func newHintedHandoff(
+	policy HintedHandoffPolicy,
+	healthyFn hintedHandoffHealthyFn,
+	writeFn hintedHandoffWriteFn,
+	tagDecoderPool serialize.TagDecoderPool,
+	contextPool context.Pool,
+	nowFn clock.NowFn,
+	iopts instrument.Options,
+) (*hintedHandoff, error) {
+	scope := iopts.MetricsScope().SubScope("hinted-handoff")
+	h := &hintedHandoff{
+		policy: policy,
+		opts: commitlog.NewOptions().
+			SetBlockSize(hintLogBlockSize).
+			SetStrategy(commitlog.StrategyWriteBehind).
+			SetInstrumentOptions(iopts.SetMetricsScope(scope.SubScope("hint-log"))),
+		nowFn:          nowFn,
+		log:            iopts.Logger(),
+		metrics:        newHintedHandoffMetrics(scope),
+		tagDecoderPool: tagDecoderPool,
+		contextPool:    contextPool,
+		healthyFn:      healthyFn,
+		writeFn:        writeFn,
+		logs:           make(map[string]*hintLog),
+		queue:          make(chan hintRequest, policy.QueueSize),
+		closeCh:        make(chan struct{}),
+	}
+
+	if err := os.MkdirAll(policy.Directory, h.opts.FilesystemOptions().NewDirectoryMode()); err != nil {
+		return nil, err
+	}
+	entries, err := ioutil.ReadDir(policy.Directory)
+	if err != nil {
+		return nil, err
+	}
+	for _, entry := range entries {
+		if !entry.IsDir() {
+			continue
+		}
+		hostID, err := url.PathUnescape(entry.Name())
+		if err != nil {
+			continue
+		}
+		log := h.newHintLog(hostID)
+		if _, _, err := h.hintLogFiles(log); err != nil {
+			return nil, err
+		}
+		h.logs[hostID] = log
+	}
+
+	return h, nil
+}
+
+func (h *hintedHandoff) newHintLog(hostID string) *hintLog {
+	dir := filepath.Join(h.policy.Directory, url.PathEscape(hostID))
+	return &hintLog{
+		hostID: hostID,
+		opts: h.opts.SetFilesystemOptions(
+			h.opts.FilesystemOptions().SetFilePathPrefix(dir)),
+		indexes: make(map[hintSeriesKey]uint64),
+	}
+}
+
+func (h *hintedHandoff) open() {
+	h.wg.Add(2)
+	go h.writeLoop()
+	go h.checkLoop()
+}
+
+// close stops the background goroutines, writes the hints still queued and
+// closes the hint log writers, flushing any hints they have buffered.
+func (h *hintedHandoff) close() error {
+	h.Lock()
+	h.closed = true
+	h.Unlock()
+
+	close(h.closeCh)
+	h.wg.Wait()
+
+	h.writeQueued()
+
+	h.Lock()
+	logs := h.logs
+	h.Unlock()
+
+	var multiErr xerrors.MultiError
+	for _, log := range logs {
+		log.Lock()
+		multiErr = multiErr.Add(log.closeWriterWithLock())
+		log.Unlock()
+	}
+	return multiErr.FinalError()
+}
+
+// hintLog returns the hint log for a host.
+func (h *hintedHandoff) hintLog(hostID string) *hintLog {
+	h.Lock()
+	defer h.Unlock()
+	log, ok := h.logs[hostID]
+	if !ok {
+		log = h.newHintLog(hostID)
+		h.logs[hostID] = log
+	}
+	return log
+}
+
+// record queues a write that failed for a host to be kept as a hint, the
+// namespace, ID, encoded tags and datapoint are copied as the caller retains
+// ownership. The hint is written to the hint log by the writing goroutine
+// as record is called when completing writes.
+func (h *hintedHandoff) record(
+	hostID string,
+	namespace, id ident.ID,
+	encodedTags []byte,
+	shard uint32,
+	datapoint *rpc.Datapoint,
+) {
+	req := hintRequest{
+		hostID:    hostID,
+		namespace: string(namespace.Bytes()),
+		id:        string(id.Bytes()),
+		shard:     shard,
+		datapoint: *datapoint,
+	}
+	if len(encodedTags) > 0 {
+		req.encodedTags = append([]byte(nil), encodedTags...)
+	}
+	if len(datapoint.Annotation) > 0 {
+		req.datapoint.Annotation = append([]byte(nil), datapoint.Annotation...)
+	}
+
+	// Queue while holding the lock so that no hint is queued once closed
+	// and the queued hints are being written.
+	h.Lock()
+	defer h.Unlock()
+	if h.closed {
+		return
+	}
+	select {
+	case h.queue <- req:
+	default:
+		h.metrics.queueFull.Inc(1)
+	}
+}
+
+// writeQueued writes the hints queued so far.
+func (h *hintedHandoff) writeQueued() {
+	for {
+		select {
+		case req := <-h.queue:
+			h.write(req)
+		default:
+			return
+		}
+	}
+}
+
+// write writes a hint to the hint log of its host.
+func (h *hintedHandoff) write(req hintRequest) {
+	datapoint := req.datapoint
+	timestamp, err := convert.ToTime(datapoint.Timestamp, datapoint.TimestampTimeType)
+	if err != nil {
+		h.metrics.errors.Inc(1)
+		return
+	}
+	unit, err := convert.ToUnit(datapoint.TimestampTimeType)
+	if err != nil {
+		h.metrics.errors.Inc(1)
+		return
+	}
+
+	log := h.hintLog(req.hostID)
+	log.Lock()
+	defer log.Unlock()
+
+	key := hintSeriesKey{
+		namespace: req.namespace,
+		id:        req.id,
+	}
+	index, seen := log.indexes[key]
+	size := int64(hintEntryOverheadBytes + len(datapoint.Annotation))
+	if !seen {
+		size += int64(len(key.namespace) + len(key.id) + len(req.encodedTags))
+	}
+	if log.bytes+size > h.policy.MaxBytesPerHost {
+		h.metrics.dropped.Inc(1)
+		return
+	}
+
+	tags, err := h.decodeTags(req.encodedTags)
+	if err != nil {
+		h.metrics.errors.Inc(1)
+		h.log.Errorf("hinted handoff could not decode tags of series %s: %v", key.id, err)
+		return
+	}
+
+	if log.writer == nil {
+		writer, err := commitlog.NewCommitLog(log.opts)
+		if err == nil {
+			err = writer.Open()
+		}
+		if err != nil {
+			h.metrics.errors.Inc(1)
+			h.log.Errorf("hinted handoff could not open hint log for host %s: %v", req.hostID, err)
+			return
+		}
+		log.writer = writer
+	}
+
+	if !seen {
+		index = uint64(len(log.indexes))
+		log.indexes[key] = index
+	}
+
+	series := commitlog.Series{
+		UniqueIndex: index,
+		Namespace:   ident.StringID(key.namespace),
+		ID:          ident.StringID(key.id),
+		Tags:        tags,
+		Shard:       req.shard,
+	}
+	dp := ts.Datapoint{
+		Timestamp: timestamp,
+		Value:     datapoint.Value,
+	}
+
+	ctx := h.contextPool.Get()
+	err = log.writer.Write(ctx, series, dp, unit, ts.Annotation(datapoint.Annotation))
+	ctx.Close()
+	if err != nil {
+		h.metrics.errors.Inc(1)
+		h.log.Errorf("hinted handoff could not write hint for host %s: %v", req.hostID, err)
+		return
+	}
+
+	log.bytes += size
+	log.lastHintAt = h.nowFn()
+	h.metrics.hints.Inc(1)
+}
+
+func (h *hintedHandoff) decodeTags(encodedTags []byte) (ident.Tags, error) {
+	if len(encodedTags) == 0 {
+		return ident.Tags{}, nil
+	}
+
+	decoder := h.tagDecoderPool.Get()
+	defer decoder.Close()
+
+	decoder.Reset(checked.NewBytes(encodedTags, nil))
+	var tags []ident.Tag
+	for decoder.Next() {
+		tag := decoder.Current()
+		tags = append(tags, ident.StringTag(tag.Name.String(), tag.Value.String()))
+	}
+	if err := decoder.Err(); err != nil {
+		return ident.Tags{}, err
+	}
+	return ident.NewTags(tags...), nil
+}
+
+// writeLoop writes the queued hints until closed.
+func (h *hintedHandoff) writeLoop() {
+	defer h.wg.Done()
+
+	for {
+		select {
+		case req := <-h.queue:
+			h.write(req)
+		case <-h.closeCh:
+			return
+		}
+	}
+}
+
+// checkLoop expires and replays the hint logs every check interval until
+// closed.
+func (h *hintedHandoff) checkLoop() {
+	defer h.wg.Done()
+
+	ticker := time.NewTicker(h.policy.CheckInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			h.check()
+		case <-h.closeCh:
+			return
+		}
+	}
+}
+
+// check expires the hint logs and replays the hint logs of healthy hosts.
+func (h *hintedHandoff) check() {
+	h.Lock()
+	logs := make([]*hintLog, 0, len(h.logs))
+	for _, log := range h.logs {
+		logs = append(logs, log)
+	}
+	h.Unlock()
+
+	var bytes int64
+	for _, log := range logs {
+		if h.isClosing() {
+			return
+		}
+
+		files, logBytes, err := h.hintLogFiles(log)
+		if err != nil {
+			h.log.Errorf("hinted handoff could not read hint log for host %s: %v", log.hostID, err)
+			continue
+		}
+		if (len(files) > 0 || log.hasWriter()) && h.healthyFn(log.hostID) {
+			h.replay(log)
+			if _, logBytes, err = h.hintLogFiles(log); err != nil {
+				h.log.Errorf("hinted handoff could not read hint log for host %s: %v", log.hostID, err)
+			}
+		}
+		bytes += logBytes
+	}
+	h.metrics.bytes.Update(float64(bytes))
+}
+
+func (h *hintedHandoff) isClosing() bool {
+	select {
+	case <-h.closeCh:
+		return true
+	default:
+		return false
+	}
+}
+
+// hintLogFiles removes the expired files of a hint log and returns the
+// remaining files that can be read along with the size of all remaining
+// files, which becomes the size of the hint log. Files that cannot be read are
+// either still being written or were not closed cleanly, the latter are
+// removed once older than the TTL.
+func (h *hintedHandoff) hintLogFiles(log *hintLog) ([]commitlog.File, int64, error) {
+	log.Lock()
+	defer log.Unlock()
+	return h.hintLogFilesWithLock(log)
+}
+
+func (h *hintedHandoff) hintLogFilesWithLock(log *hintLog) ([]commitlog.File, int64, error) {
+	now := h.nowFn()
+	if log.lastHintAt.Before(now.Add(-hintLogBlockSize)) {
+		// Close idle writers as the commit log only moves to a new file on
+		// a write, otherwise the file being written could become old enough
+		// to be expired.
+		if err := log.closeWriterWithLock(); err != nil {
+			h.log.Errorf("hinted handoff could not close hint log for host %s: %v", log.hostID, err)
+		}
+	}
+
+	dir := fs.CommitLogsDirPath(log.opts.FilesystemOptions().FilePathPrefix())
+	filePaths, err := fs.SortedCommitLogFiles(dir)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	var (
+		expireBefore = now.Add(-h.policy.TTL)
+		files        []commitlog.File
+		bytes        int64
+	)
+	for _, filePath := range filePaths {
+		info, err := os.Stat(filePath)
+		if err != nil {
+			return nil, 0, err
+		}
+
+		start, duration, index, err := commitlog.ReadLogInfo(filePath, log.opts)
+		expired := info.ModTime().Before(expireBefore)
+		if err == nil {
+			expired = start.Add(duration).Before(expireBefore)
+		}
+		if expired {
+			if err := os.Remove(filePath); err != nil {
+				return nil, 0, err
+			}
+			h.metrics.expired.Inc(1)
+			continue
+		}
+
+		bytes += info.Size()
+		if err != nil {
+			continue
+		}
+		files = append(files, commitlog.File{
+			FilePath: filePath,
+			Start:    start,
+			Duration: duration,
+			Index:    index,
+		})
+	}
+
+	log.bytes = bytes
+	return files, bytes, nil
+}
+
+// replay replays the hints of a host written so far and removes the replayed
+// hint log files, hints written while replaying go to new hint log files.
+func (h *hintedHandoff) replay(log *hintLog) {
+	log.Lock()
+	if err := log.closeWriterWithLock(); err != nil {
+		h.log.Errorf("hinted handoff could not close hint log for host %s: %v", log.hostID, err)
+	}
+	// The files are listed while still holding the lock so that a hint log
+	// file opened once the writer is closed, which cannot be read until its
+	// header is flushed, is neither replayed nor removed.
+	files, _, err := h.hintLogFilesWithLock(log)
+	log.Unlock()
+	if err != nil {
+		h.metrics.replayErrors.Inc(1)
+		h.log.Errorf("hinted handoff could not read hint log for host %s: %v", log.hostID, err)
+		return
+	}
+
+	h.metrics.replays.Inc(1)
+	for _, file := range files {
+		if err := h.replayFile(log.hostID, file); err != nil {
+			if err != errHintedHandoffClosed {
+				h.metrics.replayErrors.Inc(1)
+				h.log.Errorf("hinted handoff could not replay hints to host %s: %v", log.hostID, err)
+			}
+			return
+		}
+		if err := os.Remove(file.FilePath); err != nil {
+			h.log.Errorf("hinted handoff could not remove replayed hint log file %s: %v",
+				file.FilePath, err)
+		}
+	}
+}
+
+func (h *hintedHandoff) replayFile(hostID string, file commitlog.File) error {
+	iter, err := commitlog.NewIterator(commitlog.IteratorOpts{
+		CommitLogOptions:      h.opts,
+		FileFilterPredicate:   commitlog.ReadAllPredicate(),
+		SeriesFilterPredicate: commitlog.ReadAllSeriesPredicate(),
+		Files:                 []commitlog.File{file},
+	})
+	if err != nil {
+		return err
+	}
+	defer iter.Close()
+
+	for iter.Next() {
+		if err := h.waitReplay(); err != nil {
+			return err
+		}
+
+		series, dp, unit, annotation := iter.Current()
+		var tags ident.TagIterator
+		if len(series.Tags.Values()) > 0 {
+			tags = ident.NewTagsIterator(series.Tags)
+		}
+		err := h.writeFn(hostID, series.Namespace, series.ID, tags, dp, unit, annotation)
+		if err != nil {
+			return err
+		}
+		h.metrics.replayed.Inc(1)
+	}
+	return iter.Err()
+}
+
+// waitReplay waits until a hint may be replayed without exceeding the max
+// hints replayed per second.
+func (h *hintedHandoff) waitReplay() error {
+	for {
+		if h.isClosing() {
+			return errHintedHandoffClosed
+		}
+		if h.policy.MaxReplayPerSecond <= 0 {
+			return nil
+		}
+
+		now := h.nowFn()
+		window := now.Truncate(time.Second)
+		if !window.Equal(h.replayWindow) {
+			h.replayWindow = window
+			h.replayCount = 0
+		}
+		if h.replayCount < h.policy.MaxReplayPerSecond {
+			h.replayCount++
+			return nil
+		}
+
+		select {
+		case <-time.After(window.Add(time.Second).Sub(now)):
+		case <-h.closeCh:
+			return errHintedHandoffClosed
+		}
+	}
+}
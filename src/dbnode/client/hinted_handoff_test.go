@@ -0,0 +1,481 @@
+// Copyright (c) 2018 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package client
+
+import (
+	"io/ioutil"
+	"os"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/m3db/m3/src/dbnode/generated/thrift/rpc"
+	"github.com/m3db/m3/src/dbnode/persist/fs"
+	"github.com/m3db/m3/src/dbnode/serialize"
+	"github.com/m3db/m3/src/dbnode/ts"
+	"github.com/m3db/m3x/context"
+	"github.com/m3db/m3x/ident"
+	"github.com/m3db/m3x/instrument"
+	"github.com/m3db/m3x/pool"
+	xtime "github.com/m3db/m3x/time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/uber-go/tally"
+)
+
+type testHint struct {
+	hostID    string
+	namespace string
+	id        string
+	tags      map[string]string
+	value     float64
+	t         time.Time
+	unit      xtime.Unit
+}
+
+type testHintedHandoff struct {
+	sync.Mutex
+	healthy bool
+	hints   []testHint
+	now     time.Time
+	scope   tally.TestScope
+	onWrite func()
+}
+
+func (t *testHintedHandoff) healthyFn(hostID string) bool {
+	t.Lock()
+	defer t.Unlock()
+	return t.healthy
+}
+
+func (t *testHintedHandoff) setHealthy(value bool) {
+	t.Lock()
+	t.healthy = value
+	t.Unlock()
+}
+
+func (t *testHintedHandoff) nowFn() time.Time {
+	t.Lock()
+	defer t.Unlock()
+	return t.now
+}
+
+func (t *testHintedHandoff) writeFn(
+	hostID string,
+	namespace, id ident.ID,
+	tags ident.TagIterator,
+	dp ts.Datapoint,
+	unit xtime.Unit,
+	annotation ts.Annotation,
+) error {
+	hint := testHint{
+		hostID:    hostID,
+		namespace: namespace.String(),
+		id:        id.String(),
+		value:     dp.Value,
+		t:         dp.Timestamp,
+		unit:      unit,
+	}
+	if tags != nil {
+		hint.tags = make(map[string]string)
+		for tags.Next() {
+			tag := tags.Current()
+			hint.tags[tag.Name.String()] = tag.Value.String()
+		}
+	}
+	t.Lock()
+	t.hints = append(t.hints, hint)
+	onWrite := t.onWrite
+	t.Unlock()
+	if onWrite != nil {
+		onWrite()
+	}
+	return nil
+}
+
+func newTestHintedHandoffPolicy(t *testing.T) HintedHandoffPolicy {
+	dir, err := ioutil.TempDir("", "hinted-handoff")
+	require.NoError(t, err)
+
+	policy := defaultHintedHandoffPolicy
+	policy.Enabled = true
+	policy.Directory = dir
+	policy.MaxReplayPerSecond = 0
+	return policy
+}
+
+func newTestHintedHandoff(
+	t *testing.T,
+	policy HintedHandoffPolicy,
+) (*hintedHandoff, *testHintedHandoff) {
+	test := &testHintedHandoff{
+		now:   time.Now(),
+		scope: tally.NewTestScope("", nil),
+	}
+	tagDecoderPool := serialize.NewTagDecoderPool(serialize.NewTagDecoderOptions(),
+		pool.NewObjectPoolOptions().SetSize(1))
+	tagDecoderPool.Init()
+	contextPool := context.NewPool(context.NewOptions())
+	iopts := instrument.NewOptions().SetMetricsScope(test.scope)
+
+	h, err := newHintedHandoff(policy, test.healthyFn, test.writeFn,
+		tagDecoderPool, contextPool, test.nowFn, iopts)
+	require.NoError(t, err)
+	// The background goroutines are not started, queued hints are written
+	// and checks are triggered by the tests
+	return h, test
+}
+
+func testHintEncodedTags(t *testing.T, tags ident.Tags) []byte {
+	encoderPool := serialize.NewTagEncoderPool(serialize.NewTagEncoderOptions(),
+		pool.NewObjectPoolOptions().SetSize(1))
+	encoderPool.Init()
+	encoder := encoderPool.Get()
+	require.NoError(t, encoder.Encode(ident.NewTagsIterator(tags)))
+	data, ok := encoder.Data()
+	require.True(t, ok)
+	return append([]byte(nil), data.Bytes()...)
+}
+
+func testHintDatapoint(t time.Time, value float64) *rpc.Datapoint {
+	return &rpc.Datapoint{
+		Timestamp:         t.Unix(),
+		TimestampTimeType: rpc.TimeType_UNIX_SECONDS,
+		Value:             value,
+	}
+}
+
+// testWaitHintsWritten waits for the writing goroutine to have written a
+// number of hints, returning false if it did not in time.
+func testWaitHintsWritten(test *testHintedHandoff, hints int64) bool {
+	deadline := time.Now().Add(5 * time.Second)
+	for time.Now().Before(deadline) {
+		counter, ok := test.scope.Snapshot().Counters()["hinted-handoff.hints+"]
+		if ok && counter.Value() == hints {
+			return true
+		}
+		time.Sleep(time.Millisecond)
+	}
+	return false
+}
+
+func testHintLogFiles(t *testing.T, h *hintedHandoff, hostID string) []string {
+	log := h.newHintLog(hostID)
+	files, err := fs.SortedCommitLogFiles(
+		fs.CommitLogsDirPath(log.opts.FilesystemOptions().FilePathPrefix()))
+	require.NoError(t, err)
+	return files
+}
+
+func TestHintedHandoffPolicyValidate(t *testing.T) {
+	assert.NoError(t, HintedHandoffPolicy{}.Validate())
+	assert.NoError(t, defaultHintedHandoffPolicy.Validate())
+
+	policy := defaultHintedHandoffPolicy
+	policy.Enabled = true
+	assert.Equal(t, errHintedHandoffDirectoryEmpty, policy.Validate())
+
+	policy.Directory = "/var/lib/m3/hints"
+	assert.NoError(t, policy.Validate())
+
+	invalid := policy
+	invalid.TTL = hintLogBlockSize
+	assert.Equal(t, errHintedHandoffTTLTooShort, invalid.Validate())
+
+	invalid = policy
+	invalid.MaxBytesPerHost = 0
+	assert.Equal(t, errHintedHandoffMaxBytesNonPositive, invalid.Validate())
+
+	invalid = policy
+	invalid.MaxReplayPerSecond = -1
+	assert.Equal(t, errHintedHandoffMaxReplayNegative, invalid.Validate())
+
+	invalid = policy
+	invalid.CheckInterval = 0
+	assert.Equal(t, errHintedHandoffCheckIntervalNonPositive, invalid.Validate())
+
+	invalid = policy
+	invalid.QueueSize = 0
+	assert.Equal(t, errHintedHandoffQueueSizeNonPositive, invalid.Validate())
+}
+
+func TestHintedHandoffReplaysHintsOnceHostHealthy(t *testing.T) {
+	policy := newTestHintedHandoffPolicy(t)
+	defer os.RemoveAll(policy.Directory)
+
+	h, test := newTestHintedHandoff(t, policy)
+	defer h.close()
+
+	start := time.Now().Truncate(time.Second)
+	tags := ident.NewTags(ident.StringTag("city", "nyc"))
+	h.record("a", ident.StringID(testNamespaceName), ident.StringID("foo"),
+		nil, 0, testHintDatapoint(start, 1))
+	h.record("a", ident.StringID(testNamespaceName), ident.StringID("bar"),
+		testHintEncodedTags(t, tags), 1, testHintDatapoint(start.Add(time.Second), 2))
+	h.record("b", ident.StringID(testNamespaceName), ident.StringID("foo"),
+		nil, 0, testHintDatapoint(start, 3))
+	h.writeQueued()
+
+	// Hints are not replayed to unhealthy hosts
+	h.check()
+	assert.Equal(t, 0, len(test.hints))
+
+	test.setHealthy(true)
+	h.check()
+
+	require.Equal(t, 3, len(test.hints))
+	byHost := make(map[string][]testHint)
+	for _, hint := range test.hints {
+		byHost[hint.hostID] = append(byHost[hint.hostID], hint)
+	}
+	require.Equal(t, 2, len(byHost["a"]))
+	require.Equal(t, 1, len(byHost["b"]))
+
+	foo, bar := byHost["a"][0], byHost["a"][1]
+	assert.Equal(t, testNamespaceName, foo.namespace)
+	assert.Equal(t, "foo", foo.id)
+	assert.Nil(t, foo.tags)
+	assert.Equal(t, 1.0, foo.value)
+	assert.True(t, start.Equal(foo.t))
+	assert.Equal(t, xtime.Second, foo.unit)
+	assert.Equal(t, "bar", bar.id)
+	assert.Equal(t, map[string]string{"city": "nyc"}, bar.tags)
+	assert.Equal(t, 2.0, bar.value)
+	assert.Equal(t, 3.0, byHost["b"][0].value)
+
+	// Replayed hint log files are removed
+	assert.Equal(t, 0, len(testHintLogFiles(t, h, "a")))
+	assert.Equal(t, 0, len(testHintLogFiles(t, h, "b")))
+
+	counters := test.scope.Snapshot().Counters()
+	assert.Equal(t, int64(3), counters["hinted-handoff.hints+"].Value())
+	assert.Equal(t, int64(3), counters["hinted-handoff.hints-replayed+"].Value())
+}
+
+func TestHintedHandoffKeepsHintsAcrossRestarts(t *testing.T) {
+	policy := newTestHintedHandoffPolicy(t)
+	defer os.RemoveAll(policy.Directory)
+
+	h, _ := newTestHintedHandoff(t, policy)
+	start := time.Now().Truncate(time.Second)
+	h.record("host/a", ident.StringID(testNamespaceName), ident.StringID("foo"),
+		nil, 0, testHintDatapoint(start, 1))
+	require.NoError(t, h.close())
+
+	h, test := newTestHintedHandoff(t, policy)
+	defer h.close()
+
+	test.setHealthy(true)
+	h.check()
+
+	require.Equal(t, 1, len(test.hints))
+	assert.Equal(t, "host/a", test.hints[0].hostID)
+	assert.Equal(t, "foo", test.hints[0].id)
+}
+
+func TestHintedHandoffDropsHintsWhenFull(t *testing.T) {
+	policy := newTestHintedHandoffPolicy(t)
+	defer os.RemoveAll(policy.Directory)
+	policy.MaxBytesPerHost = 2 * hintEntryOverheadBytes
+
+	h, test := newTestHintedHandoff(t, policy)
+	defer h.close()
+
+	start := time.Now().Truncate(time.Second)
+	for i := 0; i < 3; i++ {
+		h.record("a", ident.StringID(testNamespaceName), ident.StringID("foo"),
+			nil, 0, testHintDatapoint(start.Add(time.Duration(i)*time.Second), float64(i)))
+	}
+	h.writeQueued()
+
+	counters := test.scope.Snapshot().Counters()
+	assert.Equal(t, int64(1), counters["hinted-handoff.hints+"].Value())
+	assert.Equal(t, int64(2), counters["hinted-handoff.hints-dropped+"].Value())
+}
+
+func TestHintedHandoffDropsHintsWhenQueueFull(t *testing.T) {
+	policy := newTestHintedHandoffPolicy(t)
+	defer os.RemoveAll(policy.Directory)
+	policy.QueueSize = 1
+
+	h, test := newTestHintedHandoff(t, policy)
+	defer h.close()
+
+	start := time.Now().Truncate(time.Second)
+	h.record("a", ident.StringID(testNamespaceName), ident.StringID("foo"),
+		nil, 0, testHintDatapoint(start, 1))
+	h.record("a", ident.StringID(testNamespaceName), ident.StringID("foo"),
+		nil, 0, testHintDatapoint(start.Add(time.Second), 2))
+	h.writeQueued()
+
+	counters := test.scope.Snapshot().Counters()
+	assert.Equal(t, int64(1), counters["hinted-handoff.hints+"].Value())
+	assert.Equal(t, int64(1), counters["hinted-handoff.queue-full+"].Value())
+}
+
+func TestHintedHandoffWritesQueuedHintsInBackground(t *testing.T) {
+	policy := newTestHintedHandoffPolicy(t)
+	defer os.RemoveAll(policy.Directory)
+
+	h, test := newTestHintedHandoff(t, policy)
+	h.open()
+
+	start := time.Now().Truncate(time.Second)
+	h.record("a", ident.StringID(testNamespaceName), ident.StringID("foo"),
+		nil, 0, testHintDatapoint(start, 1))
+	require.NoError(t, h.close())
+
+	// Hints recorded before closing are written either by the background
+	// goroutine or when closing
+	counters := test.scope.Snapshot().Counters()
+	assert.Equal(t, int64(1), counters["hinted-handoff.hints+"].Value())
+	assert.Equal(t, 1, len(testHintLogFiles(t, h, "a")))
+
+	// Hints recorded once closed are not kept
+	h.record("a", ident.StringID(testNamespaceName), ident.StringID("bar"),
+		nil, 0, testHintDatapoint(start, 2))
+	assert.Equal(t, 0, len(h.queue))
+}
+
+func TestHintedHandoffWritesHintsWhileReplaying(t *testing.T) {
+	policy := newTestHintedHandoffPolicy(t)
+	defer os.RemoveAll(policy.Directory)
+	policy.CheckInterval = 10 * time.Millisecond
+
+	h, test := newTestHintedHandoff(t, policy)
+	h.open()
+	defer h.close()
+
+	start := time.Now().Truncate(time.Second)
+	h.record("a", ident.StringID(testNamespaceName), ident.StringID("foo"),
+		nil, 0, testHintDatapoint(start, 1))
+	require.True(t, testWaitHintsWritten(test, 1))
+
+	// Block the replay once it started
+	replaying := make(chan struct{})
+	release := make(chan struct{})
+	test.Lock()
+	test.healthy = true
+	test.onWrite = func() {
+		test.Lock()
+		test.onWrite = nil
+		test.Unlock()
+		close(replaying)
+		<-release
+	}
+	test.Unlock()
+	<-replaying
+
+	// Hints recorded while replaying are still written
+	h.record("a", ident.StringID(testNamespaceName), ident.StringID("bar"),
+		nil, 0, testHintDatapoint(start, 2))
+	assert.True(t, testWaitHintsWritten(test, 2))
+	close(release)
+}
+
+func TestHintedHandoffReplayKeepsHintsWrittenDuringReplay(t *testing.T) {
+	policy := newTestHintedHandoffPolicy(t)
+	defer os.RemoveAll(policy.Directory)
+
+	h, test := newTestHintedHandoff(t, policy)
+	defer h.close()
+
+	start := time.Now().Truncate(time.Second)
+	h.record("a", ident.StringID(testNamespaceName), ident.StringID("foo"),
+		nil, 0, testHintDatapoint(start, 1))
+	h.writeQueued()
+
+	// A hint written while replaying opens a new hint log file that must be
+	// neither replayed nor removed by the replay in progress
+	test.Lock()
+	test.healthy = true
+	test.onWrite = func() {
+		test.Lock()
+		test.onWrite = nil
+		test.Unlock()
+		h.record("a", ident.StringID(testNamespaceName), ident.StringID("bar"),
+			nil, 0, testHintDatapoint(start, 2))
+		h.writeQueued()
+	}
+	test.Unlock()
+	h.check()
+
+	require.Equal(t, 1, len(test.hints))
+	assert.Equal(t, "foo", test.hints[0].id)
+	assert.Equal(t, 1, len(testHintLogFiles(t, h, "a")))
+
+	h.check()
+	require.Equal(t, 2, len(test.hints))
+	assert.Equal(t, "bar", test.hints[1].id)
+	assert.Equal(t, 0, len(testHintLogFiles(t, h, "a")))
+}
+
+func TestHintedHandoffExpiresHints(t *testing.T) {
+	policy := newTestHintedHandoffPolicy(t)
+	defer os.RemoveAll(policy.Directory)
+
+	h, test := newTestHintedHandoff(t, policy)
+	defer h.close()
+
+	start := time.Now().Truncate(time.Second)
+	h.record("a", ident.StringID(testNamespaceName), ident.StringID("foo"),
+		nil, 0, testHintDatapoint(start, 1))
+	h.writeQueued()
+
+	test.Lock()
+	test.now = test.now.Add(policy.TTL + 2*hintLogBlockSize)
+	test.healthy = true
+	test.Unlock()
+	h.check()
+
+	assert.Equal(t, 0, len(test.hints))
+	assert.Equal(t, 0, len(testHintLogFiles(t, h, "a")))
+
+	counters := test.scope.Snapshot().Counters()
+	assert.Equal(t, int64(1), counters["hinted-handoff.expired-files+"].Value())
+}
+
+func TestHintedHandoffReplayRateLimit(t *testing.T) {
+	policy := newTestHintedHandoffPolicy(t)
+	defer os.RemoveAll(policy.Directory)
+	policy.MaxReplayPerSecond = 2
+
+	h, test := newTestHintedHandoff(t, policy)
+	test.now = time.Now().Add(time.Hour).Truncate(time.Second)
+
+	assert.NoError(t, h.waitReplay())
+	assert.NoError(t, h.waitReplay())
+
+	// The clock does not advance so the next replay waits until closed
+	errCh := make(chan error, 1)
+	go func() {
+		errCh <- h.waitReplay()
+	}()
+	select {
+	case err := <-errCh:
+		require.FailNow(t, "replay was not rate limited", "err: %v", err)
+	case <-time.After(100 * time.Millisecond):
+	}
+
+	require.NoError(t, h.close())
+	assert.Equal(t, errHintedHandoffClosed, <-errCh)
+}
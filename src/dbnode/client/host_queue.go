@@ -29,6 +29,7 @@ import (
 	"github.com/m3db/m3/src/dbnode/clock"
 	"github.com/m3db/m3/src/dbnode/generated/thrift/rpc"
 	"github.com/m3db/m3/src/dbnode/topology"
+	"github.com/m3db/m3/src/x/tracing"
 	"github.com/m3db/m3x/ident"
 	"github.com/m3db/m3x/pool"
 
@@ -309,6 +310,10 @@ func (q *queue) asyncTaggedWrite(
 		}
 
 		ctx, _ := thrift.NewContext(q.opts.WriteRequestTimeout())
+		if headers := taggedWriteTraceHeaders(ops); headers != nil {
+			// Propagate the trace context to the node
+			ctx = thrift.WithHeaders(ctx, headers)
+		}
 		err = client.WriteTaggedBatchRaw(ctx, req)
 		if err == nil {
 			// All succeeded
@@ -342,6 +347,22 @@ func (q *queue) asyncTaggedWrite(
 	}()
 }
 
+// taggedWriteTraceHeaders returns the headers carrying the trace context of
+// the first traced write of a batch, a batch request can only continue a
+// single trace.
+func taggedWriteTraceHeaders(ops []op) map[string]string {
+	for _, op := range ops {
+		wop, ok := op.(*writeTaggedOperation)
+		if !ok || wop.ctx == nil {
+			continue
+		}
+		if headers := tracing.InjectHeaders(wop.ctx); headers != nil {
+			return headers
+		}
+	}
+	return nil
+}
+
 func (q *queue) asyncWrite(
 	namespace ident.ID,
 	ops []op,
@@ -471,6 +492,10 @@ func (q *queue) asyncFetchTagged(op *fetchTaggedOp) {
 		}
 
 		ctx, _ := thrift.NewContext(q.opts.FetchRequestTimeout())
+		if headers := tracing.InjectHeaders(op.ctx); headers != nil {
+			// Propagate the trace context to the node
+			ctx = thrift.WithHeaders(ctx, headers)
+		}
 		result, err := client.FetchTagged(ctx, &op.request)
 		if err != nil {
 			op.CompletionFn()(fetchTaggedResultAccumulatorOpts{host: q.host}, err)
@@ -21,6 +21,7 @@
 package client
 
 import (
+	"context"
 	"fmt"
 	"sort"
 	"sync"
@@ -31,6 +32,8 @@ import (
 	"github.com/m3db/m3x/ident"
 
 	"github.com/golang/mock/gomock"
+	opentracing "github.com/opentracing/opentracing-go"
+	"github.com/opentracing/opentracing-go/mocktracer"
 	"github.com/stretchr/testify/assert"
 	"github.com/uber/tchannel-go/thrift"
 )
@@ -489,6 +492,23 @@ func TestHostQueueDrainOnCloseTaggedWrite(t *testing.T) {
 	}
 }
 
+func TestHostQueueWriteTaggedTraceHeaders(t *testing.T) {
+	tracer := mocktracer.New()
+	span := tracer.StartSpan("write")
+	ctx := opentracing.ContextWithSpan(context.Background(), span)
+
+	untraced := &writeTaggedOperation{}
+	traced := &writeTaggedOperation{ctx: ctx}
+	assert.Nil(t, taggedWriteTraceHeaders([]op{untraced}))
+
+	// The trace context of the first traced write of the batch is propagated
+	headers := taggedWriteTraceHeaders([]op{untraced, traced})
+	parent, err := tracer.Extract(opentracing.TextMap,
+		opentracing.TextMapCarrier(headers))
+	assert.NoError(t, err)
+	assert.Equal(t, span.Context(), parent)
+}
+
 func testWriteTaggedOp(
 	namespace string,
 	id string,
@@ -169,6 +169,35 @@ var (
 	// defaultFetchRetrier is the default fetch retrier for fetch attempts
 	defaultFetchRetrier = xretry.NewRetrier(xretry.NewOptions().SetMaxRetries(0))
 
+	// defaultHedgedReadPolicy is the default hedged read policy, hedging is
+	// disabled by default
+	defaultHedgedReadPolicy = HedgedReadPolicy{
+		Enabled:    false,
+		Percentile: 0.95,
+		MinDelay:   5 * time.Millisecond,
+		MaxDelay:   time.Second,
+	}
+
+	// defaultReadRepairPolicy is the default read repair policy, read repair
+	// is disabled by default
+	defaultReadRepairPolicy = ReadRepairPolicy{
+		Enabled:            false,
+		SampleRate:         0.01,
+		MaxSeriesPerSecond: 100,
+		QueueSize:          1024,
+	}
+
+	// defaultHintedHandoffPolicy is the default hinted handoff policy, hinted
+	// handoff is disabled by default
+	defaultHintedHandoffPolicy = HintedHandoffPolicy{
+		Enabled:            false,
+		TTL:                3 * time.Hour,
+		MaxBytesPerHost:    1 << 30,
+		MaxReplayPerSecond: 10000,
+		CheckInterval:      10 * time.Second,
+		QueueSize:          4096,
+	}
+
 	// defaultStreamBlocksRetrier is the default retrier for streaming blocks
 	defaultStreamBlocksRetrier = xretry.NewRetrier(
 		xretry.NewOptions().
@@ -211,6 +240,9 @@ type options struct {
 	tagDecoderPoolSize                      int
 	writeRetrier                            xretry.Retrier
 	fetchRetrier                            xretry.Retrier
+	hedgedReadPolicy                        HedgedReadPolicy
+	readRepairPolicy                        ReadRepairPolicy
+	hintedHandoffPolicy                     HintedHandoffPolicy
 	streamBlocksRetrier                     xretry.Retrier
 	readerIteratorAllocate                  encoding.ReaderIteratorAllocate
 	writeOperationPoolSize                  int
@@ -287,6 +319,9 @@ func newOptions() *options {
 		backgroundHealthCheckFailThrottleFactor: defaultBackgroundHealthCheckFailThrottleFactor,
 		writeRetrier:                            defaultWriteRetrier,
 		fetchRetrier:                            defaultFetchRetrier,
+		hedgedReadPolicy:                        defaultHedgedReadPolicy,
+		readRepairPolicy:                        defaultReadRepairPolicy,
+		hintedHandoffPolicy:                     defaultHintedHandoffPolicy,
 		tagEncoderPoolSize:                      defaultTagEncoderPoolSize,
 		tagEncoderOpts:                          serialize.NewTagEncoderOptions(),
 		tagDecoderPoolSize:                      defaultTagDecoderPoolSize,
@@ -336,6 +371,15 @@ func (o *options) Validate() error {
 	); err != nil {
 		return err
 	}
+	if err := o.hedgedReadPolicy.Validate(); err != nil {
+		return err
+	}
+	if err := o.readRepairPolicy.Validate(); err != nil {
+		return err
+	}
+	if err := o.hintedHandoffPolicy.Validate(); err != nil {
+		return err
+	}
 	return topology.ValidateConnectConsistencyLevel(
 		o.clusterConnectConsistencyLevel,
 	)
@@ -589,6 +633,36 @@ func (o *options) FetchRetrier() xretry.Retrier {
 	return o.fetchRetrier
 }
 
+func (o *options) SetHedgedReadPolicy(value HedgedReadPolicy) Options {
+	opts := *o
+	opts.hedgedReadPolicy = value
+	return &opts
+}
+
+func (o *options) HedgedReadPolicy() HedgedReadPolicy {
+	return o.hedgedReadPolicy
+}
+
+func (o *options) SetReadRepairPolicy(value ReadRepairPolicy) Options {
+	opts := *o
+	opts.readRepairPolicy = value
+	return &opts
+}
+
+func (o *options) ReadRepairPolicy() ReadRepairPolicy {
+	return o.readRepairPolicy
+}
+
+func (o *options) SetHintedHandoffPolicy(value HintedHandoffPolicy) Options {
+	opts := *o
+	opts.hintedHandoffPolicy = value
+	return &opts
+}
+
+func (o *options) HintedHandoffPolicy() HintedHandoffPolicy {
+	return o.hintedHandoffPolicy
+}
+
 func (o *options) SetTagEncoderOptions(value serialize.TagEncoderOptions) Options {
 	opts := *o
 	opts.tagEncoderOpts = value
@@ -0,0 +1,456 @@
+// Copyright (c) 2018 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package client
+
+import (
+	"errors"
+	"math"
+	"math/rand"
+	"time"
+
+	"github.com/m3db/m3/src/dbnode/clock"
+	"github.com/m3db/m3/src/dbnode/digest"
+	"github.com/m3db/m3/src/dbnode/encoding"
+	"github.com/m3db/m3/src/dbnode/generated/thrift/rpc"
+	"github.com/m3db/m3/src/dbnode/topology"
+	"github.com/m3db/m3/src/dbnode/ts"
+	"github.com/m3db/m3x/ident"
+	xlog "github.com/m3db/m3x/log"
+	xtime "github.com/m3db/m3x/time"
+
+	"github.com/uber-go/tally"
+)
+
+var (
+	errReadRepairSampleRateInvalid          = errors.New("read repair sample rate must be greater than 0 and at most 1")
+	errReadRepairMaxSeriesPerSecondNegative = errors.New("read repair max series per second must be non-negative")
+	errReadRepairQueueSizeInvalid           = errors.New("read repair queue size must be positive")
+	errReadRepairHostNotReplica             = errors.New("read repair host is not a replica of the series")
+)
+
+// ReadRepairPolicy is the policy for repairing replicas that return divergent
+// data for a series when fetched. When enabled a sample of fetched series have
+// the data returned by each replica compared once all replicas responded, and
+// the datapoints a replica is missing or that differ from the merged result
+// are written back to that replica asynchronously.
+//
+// Fetches do not return the tags of a series, so datapoints are only written
+// back to replicas that returned some data for the series as writing to a
+// replica without the series could index it without its tags.
+type ReadRepairPolicy struct {
+	// Enabled enables read repair.
+	Enabled bool
+
+	// SampleRate is the fraction of fetched series that are compared across
+	// replicas, must be greater than 0 and at most 1.
+	SampleRate float64
+
+	// MaxSeriesPerSecond is the maximum number of divergent series repaired
+	// per second, divergent series past the limit are not repaired. Zero
+	// means unlimited.
+	MaxSeriesPerSecond int
+
+	// QueueSize is the maximum number of sampled series waiting to be
+	// compared, series sampled when the queue is full are not compared.
+	QueueSize int
+}
+
+// Validate validates the read repair policy.
+func (p ReadRepairPolicy) Validate() error {
+	if !p.Enabled {
+		return nil
+	}
+	if p.SampleRate <= 0 || p.SampleRate > 1 {
+		return errReadRepairSampleRateInvalid
+	}
+	if p.MaxSeriesPerSecond < 0 {
+		return errReadRepairMaxSeriesPerSecondNegative
+	}
+	if p.QueueSize <= 0 {
+		return errReadRepairQueueSizeInvalid
+	}
+	return nil
+}
+
+// readRepairReplica is the data returned by a replica for a sampled series.
+type readRepairReplica struct {
+	host     topology.Host
+	segments []*rpc.Segments
+}
+
+type readRepairRequest struct {
+	namespace ident.ID
+	id        ident.ID
+	start     time.Time
+	end       time.Time
+	replicas  []readRepairReplica
+}
+
+type readRepairDatapoint struct {
+	dp         ts.Datapoint
+	unit       xtime.Unit
+	annotation ts.Annotation
+}
+
+// readRepairIterFn returns an iterator over the data returned by a replica.
+type readRepairIterFn func(segments []*rpc.Segments) encoding.MultiReaderIterator
+
+// readRepairWriteFn writes a datapoint of a series to a single replica.
+type readRepairWriteFn func(
+	namespace, id ident.ID,
+	host topology.Host,
+	dp ts.Datapoint,
+	unit xtime.Unit,
+	annotation ts.Annotation,
+) error
+
+type readRepairMetrics struct {
+	sampled            tally.Counter
+	queueFull          tally.Counter
+	consistent         tally.Counter
+	divergent          tally.Counter
+	rateLimited        tally.Counter
+	skippedEmpty       tally.Counter
+	decodeErrors       tally.Counter
+	writeErrors        tally.Counter
+	repairedSeries     tally.Counter
+	repairedDatapoints tally.Counter
+}
+
+func newReadRepairMetrics(scope tally.Scope) readRepairMetrics {
+	return readRepairMetrics{
+		sampled:            scope.Counter("sampled"),
+		queueFull:          scope.Counter("queue-full"),
+		consistent:         scope.Counter("consistent"),
+		divergent:          scope.Counter("divergent"),
+		rateLimited:        scope.Counter("rate-limited"),
+		skippedEmpty:       scope.Counter("skipped-empty-replica"),
+		decodeErrors:       scope.Counter("decode-errors"),
+		writeErrors:        scope.Counter("write-errors"),
+		repairedSeries:     scope.Counter("repaired-series"),
+		repairedDatapoints: scope.Counter("repaired-datapoints"),
+	}
+}
+
+// readRepairer compares the data returned by replicas for sampled series and
+// writes back the datapoints lagging replicas are missing, comparisons and
+// repairs are performed sequentially by a single background goroutine.
+type readRepairer struct {
+	policy   ReadRepairPolicy
+	nowFn    clock.NowFn
+	sampleFn func() float64
+	iterFn   readRepairIterFn
+	writeFn  readRepairWriteFn
+	log      xlog.Logger
+	metrics  readRepairMetrics
+
+	queue   chan readRepairRequest
+	closeCh chan struct{}
+	doneCh  chan struct{}
+
+	// The rate limit window and count are only accessed by the background
+	// goroutine and so are not synchronized.
+	limitWindow time.Time
+	limitCount  int
+}
+
+func newReadRepairer(
+	policy ReadRepairPolicy,
+	iterFn readRepairIterFn,
+	writeFn readRepairWriteFn,
+	nowFn clock.NowFn,
+	log xlog.Logger,
+	scope tally.Scope,
+) *readRepairer {
+	return &readRepairer{
+		policy:   policy,
+		nowFn:    nowFn,
+		sampleFn: rand.Float64,
+		iterFn:   iterFn,
+		writeFn:  writeFn,
+		log:      log,
+		metrics:  newReadRepairMetrics(scope),
+		queue:    make(chan readRepairRequest, policy.QueueSize),
+		closeCh:  make(chan struct{}),
+		doneCh:   make(chan struct{}),
+	}
+}
+
+func (r *readRepairer) open() {
+	go r.run()
+}
+
+// close stops the background goroutine, sampled series that are still
+// queued are not compared.
+func (r *readRepairer) close() {
+	close(r.closeCh)
+	<-r.doneCh
+}
+
+// sample returns whether a fetched series should be compared across replicas.
+func (r *readRepairer) sample() bool {
+	if r.sampleFn() >= r.policy.SampleRate {
+		return false
+	}
+	r.metrics.sampled.Inc(1)
+	return true
+}
+
+// enqueue queues a sampled series to be compared, the namespace and ID are
+// copied as the caller retains ownership of them.
+func (r *readRepairer) enqueue(
+	namespace, id ident.ID,
+	start, end time.Time,
+	replicas []readRepairReplica,
+) {
+	if len(replicas) < 2 {
+		// Nothing to compare
+		return
+	}
+	req := readRepairRequest{
+		namespace: ident.BytesID(append([]byte(nil), namespace.Bytes()...)),
+		id:        ident.BytesID(append([]byte(nil), id.Bytes()...)),
+		start:     start,
+		end:       end,
+		replicas:  replicas,
+	}
+	select {
+	case r.queue <- req:
+	default:
+		r.metrics.queueFull.Inc(1)
+	}
+}
+
+func (r *readRepairer) run() {
+	defer close(r.doneCh)
+	for {
+		select {
+		case req := <-r.queue:
+			r.repair(req)
+		case <-r.closeCh:
+			return
+		}
+	}
+}
+
+func (r *readRepairer) repair(req readRepairRequest) {
+	if readRepairReplicasEqual(req.replicas) {
+		r.metrics.consistent.Inc(1)
+		return
+	}
+
+	// The replicas returned different bytes, which can also be the same
+	// datapoints encoded differently so compare the datapoints themselves.
+	replicaDatapoints := make([][]readRepairDatapoint, 0, len(req.replicas))
+	for _, replica := range req.replicas {
+		datapoints, err := r.datapoints(req, replica.segments)
+		if err != nil {
+			r.metrics.decodeErrors.Inc(1)
+			r.log.Errorf("read repair could not decode series %s from host %s: %v",
+				req.id.String(), replica.host.ID(), err)
+			return
+		}
+		replicaDatapoints = append(replicaDatapoints, datapoints)
+	}
+	merged, err := r.mergedDatapoints(req)
+	if err != nil {
+		r.metrics.decodeErrors.Inc(1)
+		r.log.Errorf("read repair could not merge series %s: %v", req.id.String(), err)
+		return
+	}
+
+	var (
+		divergent bool
+		lagging   []int
+		missing   [][]readRepairDatapoint
+	)
+	for i, datapoints := range replicaDatapoints {
+		replicaMissing := readRepairMissingDatapoints(merged, datapoints)
+		if len(replicaMissing) == 0 {
+			continue
+		}
+		divergent = true
+		if len(datapoints) == 0 {
+			r.metrics.skippedEmpty.Inc(1)
+			continue
+		}
+		lagging = append(lagging, i)
+		missing = append(missing, replicaMissing)
+	}
+	if !divergent {
+		r.metrics.consistent.Inc(1)
+		return
+	}
+	r.metrics.divergent.Inc(1)
+	if len(lagging) == 0 {
+		return
+	}
+	if !r.allow() {
+		r.metrics.rateLimited.Inc(1)
+		return
+	}
+
+	for i, replicaIdx := range lagging {
+		host := req.replicas[replicaIdx].host
+		for _, datapoint := range missing[i] {
+			err := r.writeFn(req.namespace, req.id, host,
+				datapoint.dp, datapoint.unit, datapoint.annotation)
+			if err != nil {
+				// Do not attempt the rest of the datapoints for this host as
+				// they are likely to fail too
+				r.metrics.writeErrors.Inc(1)
+				r.log.Errorf("read repair could not write series %s to host %s: %v",
+					req.id.String(), host.ID(), err)
+				break
+			}
+			r.metrics.repairedDatapoints.Inc(1)
+		}
+	}
+	r.metrics.repairedSeries.Inc(1)
+}
+
+// datapoints returns the datapoints returned by a single replica.
+func (r *readRepairer) datapoints(
+	req readRepairRequest,
+	segments []*rpc.Segments,
+) ([]readRepairDatapoint, error) {
+	iter := r.iterFn(segments)
+	defer iter.Close()
+	return readRepairCollect(iter, req.start, req.end)
+}
+
+// mergedDatapoints returns the datapoints of all replicas merged the same way
+// as they are when returned by a fetch.
+func (r *readRepairer) mergedDatapoints(
+	req readRepairRequest,
+) ([]readRepairDatapoint, error) {
+	replicas := make([]encoding.MultiReaderIterator, 0, len(req.replicas))
+	for _, replica := range req.replicas {
+		replicas = append(replicas, r.iterFn(replica.segments))
+	}
+	iter := encoding.NewSeriesIterator(encoding.SeriesIteratorOptions{
+		Replicas:       replicas,
+		StartInclusive: req.start,
+		EndExclusive:   req.end,
+	}, nil)
+	defer iter.Close()
+	return readRepairCollect(iter, req.start, req.end)
+}
+
+// allow returns whether a divergent series may be repaired without exceeding
+// the max series repaired per second.
+func (r *readRepairer) allow() bool {
+	if r.policy.MaxSeriesPerSecond <= 0 {
+		return true
+	}
+	window := r.nowFn().Truncate(time.Second)
+	if !window.Equal(r.limitWindow) {
+		r.limitWindow = window
+		r.limitCount = 0
+	}
+	if r.limitCount >= r.policy.MaxSeriesPerSecond {
+		return false
+	}
+	r.limitCount++
+	return true
+}
+
+// readRepairReplicasEqual returns whether all replicas returned the same bytes.
+func readRepairReplicasEqual(replicas []readRepairReplica) bool {
+	first := readRepairChecksum(replicas[0].segments)
+	for _, replica := range replicas[1:] {
+		if readRepairChecksum(replica.segments) != first {
+			return false
+		}
+	}
+	return true
+}
+
+func readRepairChecksum(segments []*rpc.Segments) uint32 {
+	d := digest.NewDigest()
+	update := func(segment *rpc.Segment) {
+		if segment == nil {
+			return
+		}
+		d = d.Update(segment.Head)
+		d = d.Update(segment.Tail)
+	}
+	for _, s := range segments {
+		if s == nil {
+			continue
+		}
+		update(s.Merged)
+		for _, segment := range s.Unmerged {
+			update(segment)
+		}
+	}
+	return d.Sum32()
+}
+
+type readRepairIterator interface {
+	Next() bool
+	Current() (ts.Datapoint, xtime.Unit, ts.Annotation)
+	Err() error
+}
+
+func readRepairCollect(
+	iter readRepairIterator,
+	start, end time.Time,
+) ([]readRepairDatapoint, error) {
+	var datapoints []readRepairDatapoint
+	for iter.Next() {
+		dp, unit, annotation := iter.Current()
+		if dp.Timestamp.Before(start) || !dp.Timestamp.Before(end) {
+			continue
+		}
+		var annotationCopy ts.Annotation
+		if len(annotation) > 0 {
+			// The annotation is only valid until the iterator is advanced
+			annotationCopy = append(ts.Annotation(nil), annotation...)
+		}
+		datapoints = append(datapoints, readRepairDatapoint{
+			dp:         dp,
+			unit:       unit,
+			annotation: annotationCopy,
+		})
+	}
+	return datapoints, iter.Err()
+}
+
+// readRepairMissingDatapoints returns the merged datapoints that a replica is
+// missing or has a different value for.
+func readRepairMissingDatapoints(
+	merged, replica []readRepairDatapoint,
+) []readRepairDatapoint {
+	values := make(map[int64]uint64, len(replica))
+	for _, datapoint := range replica {
+		values[datapoint.dp.Timestamp.UnixNano()] = math.Float64bits(datapoint.dp.Value)
+	}
+	var missing []readRepairDatapoint
+	for _, datapoint := range merged {
+		value, ok := values[datapoint.dp.Timestamp.UnixNano()]
+		if ok && value == math.Float64bits(datapoint.dp.Value) {
+			continue
+		}
+		missing = append(missing, datapoint)
+	}
+	return missing
+}
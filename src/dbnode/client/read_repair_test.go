@@ -0,0 +1,274 @@
+// Copyright (c) 2018 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package client
+
+import (
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/m3db/m3/src/dbnode/encoding"
+	"github.com/m3db/m3/src/dbnode/encoding/m3tsz"
+	"github.com/m3db/m3/src/dbnode/generated/thrift/rpc"
+	"github.com/m3db/m3/src/dbnode/topology"
+	"github.com/m3db/m3/src/dbnode/ts"
+	"github.com/m3db/m3x/ident"
+	xtime "github.com/m3db/m3x/time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/uber-go/tally"
+)
+
+type testReadRepairWrite struct {
+	host  string
+	id    string
+	value float64
+	t     time.Time
+}
+
+type testReadRepairWriter struct {
+	sync.Mutex
+	writes []testReadRepairWrite
+}
+
+func (w *testReadRepairWriter) write(
+	namespace, id ident.ID,
+	host topology.Host,
+	dp ts.Datapoint,
+	unit xtime.Unit,
+	annotation ts.Annotation,
+) error {
+	w.Lock()
+	defer w.Unlock()
+	w.writes = append(w.writes, testReadRepairWrite{
+		host:  host.ID(),
+		id:    id.String(),
+		value: dp.Value,
+		t:     dp.Timestamp,
+	})
+	return nil
+}
+
+func newTestReadRepairer(
+	policy ReadRepairPolicy,
+	writer *testReadRepairWriter,
+	scope tally.Scope,
+) *readRepairer {
+	opts := newSessionTestOptions()
+	iterFn := func(segments []*rpc.Segments) encoding.MultiReaderIterator {
+		iter := encoding.NewMultiReaderIterator(opts.ReaderIteratorAllocate(), nil)
+		iter.ResetSliceOfSlices(newReaderSliceOfSlicesIterator(segments, nil))
+		return iter
+	}
+	return newReadRepairer(policy, iterFn, writer.write, time.Now,
+		opts.InstrumentOptions().Logger(), scope)
+}
+
+func testReadRepairSegments(values []testValue) []*rpc.Segments {
+	if len(values) == 0 {
+		return nil
+	}
+	encoder := m3tsz.NewEncoder(values[0].t, nil, true, nil)
+	for _, value := range values {
+		dp := ts.Datapoint{Timestamp: value.t, Value: value.value}
+		encoder.Encode(dp, value.unit, value.annotation)
+	}
+	seg := encoder.Discard()
+	return []*rpc.Segments{&rpc.Segments{
+		Merged: &rpc.Segment{Head: seg.Head.Bytes(), Tail: seg.Tail.Bytes()},
+	}}
+}
+
+func testReadRepairRequest(
+	start time.Time,
+	replicas map[string][]testValue,
+) readRepairRequest {
+	req := readRepairRequest{
+		namespace: ident.StringID(testNamespaceName),
+		id:        ident.StringID("foo"),
+		start:     start,
+		end:       start.Add(time.Hour),
+	}
+	for _, host := range []string{"a", "b", "c"} {
+		values, ok := replicas[host]
+		if !ok {
+			continue
+		}
+		req.replicas = append(req.replicas, readRepairReplica{
+			host:     topology.NewHost(host, host+":9000"),
+			segments: testReadRepairSegments(values),
+		})
+	}
+	return req
+}
+
+func TestReadRepairPolicyValidate(t *testing.T) {
+	assert.NoError(t, ReadRepairPolicy{}.Validate())
+	assert.NoError(t, defaultReadRepairPolicy.Validate())
+
+	policy := defaultReadRepairPolicy
+	policy.Enabled = true
+	assert.NoError(t, policy.Validate())
+
+	invalid := policy
+	invalid.SampleRate = 0
+	assert.Equal(t, errReadRepairSampleRateInvalid, invalid.Validate())
+
+	invalid = policy
+	invalid.SampleRate = 1.5
+	assert.Equal(t, errReadRepairSampleRateInvalid, invalid.Validate())
+
+	invalid = policy
+	invalid.MaxSeriesPerSecond = -1
+	assert.Equal(t, errReadRepairMaxSeriesPerSecondNegative, invalid.Validate())
+
+	invalid = policy
+	invalid.QueueSize = 0
+	assert.Equal(t, errReadRepairQueueSizeInvalid, invalid.Validate())
+}
+
+func TestReadRepairMissingDatapoints(t *testing.T) {
+	start := time.Now().Truncate(time.Hour)
+	dp := func(offset time.Duration, value float64) readRepairDatapoint {
+		return readRepairDatapoint{
+			dp:   ts.Datapoint{Timestamp: start.Add(offset), Value: value},
+			unit: xtime.Second,
+		}
+	}
+
+	merged := []readRepairDatapoint{dp(time.Second, 1), dp(2*time.Second, 2), dp(3*time.Second, 3)}
+	assert.Nil(t, readRepairMissingDatapoints(merged, merged))
+	assert.Equal(t, merged, readRepairMissingDatapoints(merged, nil))
+	assert.Equal(t, []readRepairDatapoint{dp(2*time.Second, 2), dp(3*time.Second, 3)},
+		readRepairMissingDatapoints(merged, []readRepairDatapoint{
+			dp(time.Second, 1), dp(3*time.Second, 4),
+		}))
+}
+
+func TestReadRepairerRepairsLaggingReplica(t *testing.T) {
+	scope := tally.NewTestScope("", nil)
+	writer := &testReadRepairWriter{}
+	policy := defaultReadRepairPolicy
+	policy.Enabled = true
+	r := newTestReadRepairer(policy, writer, scope)
+
+	start := time.Now().Truncate(time.Hour)
+	values := []testValue{
+		{1.0, start.Add(1 * time.Second), xtime.Second, nil},
+		{2.0, start.Add(2 * time.Second), xtime.Second, nil},
+		{3.0, start.Add(3 * time.Second), xtime.Second, nil},
+	}
+	r.repair(testReadRepairRequest(start, map[string][]testValue{
+		"a": values,
+		"b": []testValue{values[0], values[2]},
+		"c": nil,
+	}))
+
+	// Only the replica that returned some data for the series is repaired
+	require.Equal(t, 1, len(writer.writes))
+	assert.Equal(t, "b", writer.writes[0].host)
+	assert.Equal(t, "foo", writer.writes[0].id)
+	assert.Equal(t, 2.0, writer.writes[0].value)
+	assert.True(t, values[1].t.Equal(writer.writes[0].t))
+
+	counters := scope.Snapshot().Counters()
+	assert.Equal(t, int64(1), counters["divergent+"].Value())
+	assert.Equal(t, int64(1), counters["skipped-empty-replica+"].Value())
+	assert.Equal(t, int64(1), counters["repaired-series+"].Value())
+	assert.Equal(t, int64(1), counters["repaired-datapoints+"].Value())
+}
+
+func TestReadRepairerConsistentReplicas(t *testing.T) {
+	scope := tally.NewTestScope("", nil)
+	writer := &testReadRepairWriter{}
+	policy := defaultReadRepairPolicy
+	policy.Enabled = true
+	r := newTestReadRepairer(policy, writer, scope)
+
+	start := time.Now().Truncate(time.Hour)
+	values := []testValue{
+		{1.0, start.Add(1 * time.Second), xtime.Second, nil},
+		{2.0, start.Add(2 * time.Second), xtime.Second, nil},
+	}
+	r.repair(testReadRepairRequest(start, map[string][]testValue{
+		"a": values,
+		"b": values,
+		"c": values,
+	}))
+
+	assert.Equal(t, 0, len(writer.writes))
+	counters := scope.Snapshot().Counters()
+	assert.Equal(t, int64(1), counters["consistent+"].Value())
+	assert.Equal(t, int64(0), counters["divergent+"].Value())
+}
+
+func TestReadRepairerRateLimit(t *testing.T) {
+	scope := tally.NewTestScope("", nil)
+	writer := &testReadRepairWriter{}
+	policy := defaultReadRepairPolicy
+	policy.Enabled = true
+	policy.MaxSeriesPerSecond = 1
+	r := newTestReadRepairer(policy, writer, scope)
+
+	now := time.Now().Truncate(time.Second)
+	r.nowFn = func() time.Time { return now }
+
+	start := now.Truncate(time.Hour)
+	values := []testValue{
+		{1.0, start.Add(1 * time.Second), xtime.Second, nil},
+		{2.0, start.Add(2 * time.Second), xtime.Second, nil},
+	}
+	req := testReadRepairRequest(start, map[string][]testValue{
+		"a": values,
+		"b": values[:1],
+	})
+
+	r.repair(req)
+	r.repair(req)
+	assert.Equal(t, 1, len(writer.writes))
+
+	// Repairs are allowed again in the next second
+	now = now.Add(time.Second)
+	r.repair(req)
+	assert.Equal(t, 2, len(writer.writes))
+
+	counters := scope.Snapshot().Counters()
+	assert.Equal(t, int64(3), counters["divergent+"].Value())
+	assert.Equal(t, int64(1), counters["rate-limited+"].Value())
+}
+
+func TestReadRepairerSample(t *testing.T) {
+	scope := tally.NewTestScope("", nil)
+	policy := defaultReadRepairPolicy
+	policy.Enabled = true
+	policy.SampleRate = 0.5
+	r := newTestReadRepairer(policy, &testReadRepairWriter{}, scope)
+
+	sample := 0.0
+	r.sampleFn = func() float64 { return sample }
+	assert.True(t, r.sample())
+	sample = 0.5
+	assert.False(t, r.sample())
+
+	counters := scope.Snapshot().Counters()
+	assert.Equal(t, int64(1), counters["sampled+"].Value())
+}
@@ -22,6 +22,7 @@ package client
 
 import (
 	"bytes"
+	goctx "context"
 	"errors"
 	"fmt"
 	"math"
@@ -48,6 +49,7 @@ import (
 	"github.com/m3db/m3/src/dbnode/ts"
 	"github.com/m3db/m3/src/dbnode/x/xio"
 	"github.com/m3db/m3/src/dbnode/x/xpool"
+	"github.com/m3db/m3/src/x/tracing"
 	"github.com/m3db/m3cluster/shard"
 	"github.com/m3db/m3x/checked"
 	xclose "github.com/m3db/m3x/close"
@@ -61,10 +63,17 @@ import (
 	xsync "github.com/m3db/m3x/sync"
 	xtime "github.com/m3db/m3x/time"
 
+	opentracing "github.com/opentracing/opentracing-go"
 	"github.com/uber-go/tally"
 	"github.com/uber/tchannel-go/thrift"
 )
 
+const (
+	fetchTaggedSpanName      = "client.session.FetchTagged"
+	writeTaggedSpanName      = "client.session.WriteTagged"
+	writeTaggedBatchSpanName = "client.session.WriteTaggedBatch"
+)
+
 const (
 	clusterConnectWaitInterval           = 10 * time.Millisecond
 	blocksMetadataChannelInitialCapacity = 4096
@@ -128,6 +137,9 @@ var (
 	// errUnableToEncodeTags is raised when the server is unable to encode provided tags
 	// to be sent over the wire.
 	errUnableToEncodeTags = errors.New("unable to include tags")
+	// errSessionHostNotReplica is raised when writing to a host that is not
+	// a replica of the series written.
+	errSessionHostNotReplica = errors.New("session host is not a replica of the series")
 	// errNoTopologyMap is returned when the session does not have a topology. Should never happen
 	// in practice.
 	errNoTopologyMap = fmt.Errorf("%s session does not have a topology map", instrument.InvariantViolatedMetricName)
@@ -175,7 +187,14 @@ type session struct {
 	streamBlocksBatchSize            int
 	streamBlocksMetadataBatchTimeout time.Duration
 	streamBlocksBatchTimeout         time.Duration
+	hedgeDelay                       *hedgeDelayEstimator
+	readRepair                       *readRepairer
+	hints                            *hintedHandoff
 	metrics                          sessionMetrics
+
+	// hedgeRotation rotates the replicas hedged fetches are first sent to,
+	// must use atomic add to access.
+	hedgeRotation uint32
 }
 
 type shardMetricsKey struct {
@@ -191,6 +210,10 @@ type sessionMetrics struct {
 	fetchSuccess               tally.Counter
 	fetchErrors                tally.Counter
 	fetchNodesRespondingErrors []tally.Counter
+	fetchHedgesSent            tally.Counter
+	fetchHedgesWon             tally.Counter
+	fetchTaggedHedgesSent      tally.Counter
+	fetchTaggedHedgesWon       tally.Counter
 	topologyUpdatedSuccess     tally.Counter
 	topologyUpdatedError       tally.Counter
 	streamFromPeersMetrics     map[shardMetricsKey]streamFromPeersMetrics
@@ -202,6 +225,10 @@ func newSessionMetrics(scope tally.Scope) sessionMetrics {
 		writeErrors:            scope.Counter("write.errors"),
 		fetchSuccess:           scope.Counter("fetch.success"),
 		fetchErrors:            scope.Counter("fetch.errors"),
+		fetchHedgesSent:        scope.Counter("fetch.hedges-sent"),
+		fetchHedgesWon:         scope.Counter("fetch.hedges-won"),
+		fetchTaggedHedgesSent:  scope.Counter("fetch-tagged.hedges-sent"),
+		fetchTaggedHedgesWon:   scope.Counter("fetch-tagged.hedges-won"),
 		topologyUpdatedSuccess: scope.Counter("topology.updated-success"),
 		topologyUpdatedError:   scope.Counter("topology.updated-error"),
 		streamFromPeersMetrics: make(map[shardMetricsKey]streamFromPeersMetrics),
@@ -270,6 +297,13 @@ func newSession(opts Options) (clientSession, error) {
 		},
 		metrics: newSessionMetrics(scope),
 	}
+	if policy := opts.HedgedReadPolicy(); policy.Enabled {
+		s.hedgeDelay = newHedgeDelayEstimator(policy)
+	}
+	if policy := opts.ReadRepairPolicy(); policy.Enabled {
+		s.readRepair = newReadRepairer(policy, s.readRepairIter, s.readRepairWrite,
+			s.nowFn, s.log, scope.SubScope("read-repair"))
+	}
 	s.reattemptStreamBlocksFromPeersFn = s.streamBlocksReattemptFromPeers
 	s.pickBestPeerFn = s.streamBlocksPickBestPeer
 	writeAttemptPoolOpts := pool.NewObjectPoolOptions().
@@ -319,6 +353,14 @@ func newSession(opts Options) (clientSession, error) {
 	s.pools.checkedBytesWrapper = xpool.NewCheckedBytesWrapperPool(wrapperPoolOpts)
 	s.pools.checkedBytesWrapper.Init()
 
+	if policy := opts.HintedHandoffPolicy(); policy.Enabled {
+		s.hints, err = newHintedHandoff(policy, s.hostHealthy, s.writeToHost,
+			s.pools.tagDecoder, s.pools.context, s.nowFn, opts.InstrumentOptions())
+		if err != nil {
+			return nil, err
+		}
+	}
+
 	if opts, ok := opts.(AdminOptions); ok {
 		s.state.bootstrapLevel = opts.BootstrapConsistencyLevel()
 		s.origin = opts.Origin()
@@ -540,6 +582,13 @@ func (s *session) Open() error {
 	s.state.status = statusOpen
 	s.state.Unlock()
 
+	if s.readRepair != nil {
+		s.readRepair.open()
+	}
+	if s.hints != nil {
+		s.hints.open()
+	}
+
 	go func() {
 		for range watch.C() {
 			s.log.Info("received update for topology")
@@ -587,6 +636,21 @@ func (s *session) BorrowConnection(hostID string, fn withConnectionFn) error {
 	return err
 }
 
+func (s *session) NodeHealth(hostID string) (*rpc.NodeHealthResult_, error) {
+	var (
+		result *rpc.NodeHealthResult_
+		err    error
+	)
+	borrowErr := s.BorrowConnection(hostID, func(client rpc.TChanNode) {
+		tctx, _ := thrift.NewContext(s.opts.HostConnectTimeout())
+		result, err = client.Health(tctx)
+	})
+	if borrowErr != nil {
+		return nil, borrowErr
+	}
+	return result, err
+}
+
 func (s *session) hostQueues(
 	topoMap topology.Map,
 	existing []hostQueue,
@@ -881,8 +945,21 @@ func (s *session) WriteTagged(
 	value float64,
 	unit xtime.Unit,
 	annotation []byte,
+) error {
+	return s.writeTagged(nil, namespace, id, tags, t, value, unit, annotation)
+}
+
+func (s *session) writeTagged(
+	ctx goctx.Context,
+	namespace, id ident.ID,
+	tags ident.TagIterator,
+	t time.Time,
+	value float64,
+	unit xtime.Unit,
+	annotation []byte,
 ) error {
 	w := s.pools.writeAttempt.Get()
+	w.args.ctx = ctx
 	w.args.attemptType = taggedWriteAttemptType
 	w.args.namespace, w.args.id, w.args.tags = namespace, id, tags
 	w.args.t, w.args.value, w.args.unit, w.args.annotation =
@@ -892,7 +969,113 @@ func (s *session) WriteTagged(
 	return err
 }
 
+// WriteTaggedContext writes a value for an ID and given tags, tracing the
+// write as a child of the span in the context. The trace context is carried
+// by the write to the host queues, which propagate the trace context of one
+// of the traced writes of each batch request to the nodes.
+func (s *session) WriteTaggedContext(
+	ctx goctx.Context,
+	namespace, id ident.ID,
+	tags ident.TagIterator,
+	t time.Time,
+	value float64,
+	unit xtime.Unit,
+	annotation []byte,
+) error {
+	span, ctx := opentracing.StartSpanFromContext(ctx, writeTaggedSpanName)
+	span.SetTag("namespace", namespace.String())
+	err := s.writeTagged(ctx, namespace, id, tags, t, value, unit, annotation)
+	tracing.FinishSpan(span, err)
+	return err
+}
+
+// WriteTaggedBatch writes a batch of values for IDs and given tags, tracing
+// the batch as a child of the span in the context.
+func (s *session) WriteTaggedBatch(
+	ctx goctx.Context,
+	namespace ident.ID,
+	writes []TaggedWrite,
+) error {
+	span, ctx := opentracing.StartSpanFromContext(ctx, writeTaggedBatchSpanName)
+	span.SetTag("namespace", namespace.String())
+	span.SetTag("writes", len(writes))
+	err := s.writeTaggedBatch(ctx, namespace, writes)
+	tracing.FinishSpan(span, err)
+	return err
+}
+
+func (s *session) writeTaggedBatch(
+	ctx goctx.Context,
+	namespace ident.ID,
+	writes []TaggedWrite,
+) error {
+	type enqueuedWrite struct {
+		state              *writeState
+		majority, enqueued int32
+	}
+
+	var (
+		pending  = make([]enqueuedWrite, 0, len(writes))
+		multiErr xerrors.MultiError
+	)
+	s.state.RLock()
+	if s.state.status != statusOpen {
+		s.state.RUnlock()
+		return errSessionStatusNotOpen
+	}
+
+	// Enqueue all the writes before waiting for any of them so that the host
+	// queues can send them in as few batch requests as possible
+	for _, write := range writes {
+		timeType, err := convert.ToTimeType(write.Unit)
+		if err != nil {
+			multiErr = multiErr.Add(err)
+			continue
+		}
+
+		timestamp, err := convert.ToValue(write.Timestamp, timeType)
+		if err != nil {
+			multiErr = multiErr.Add(err)
+			continue
+		}
+
+		state, majority, enqueued, err := s.writeAttemptWithRLock(ctx,
+			taggedWriteAttemptType, namespace, write.ID, write.Tags, timestamp,
+			write.Value, timeType, write.Annotation)
+		if err != nil {
+			s.incWriteMetrics(err, 0)
+			multiErr = multiErr.Add(err)
+			continue
+		}
+		pending = append(pending, enqueuedWrite{
+			state:    state,
+			majority: majority,
+			enqueued: enqueued,
+		})
+	}
+	s.state.RUnlock()
+
+	for _, write := range pending {
+		// It's safe to Wait() here, as we still hold the lock on each state
+		// returned from writeAttemptWithRLock.
+		state := write.state
+		state.Wait()
+
+		err := s.writeConsistencyResult(state.consistencyLevel, write.majority,
+			write.enqueued, write.enqueued-state.pending,
+			int32(len(state.errors)), state.errors)
+		s.incWriteMetrics(err, int32(len(state.errors)))
+		multiErr = multiErr.Add(err)
+
+		state.Unlock()
+		state.decRef()
+	}
+
+	return multiErr.FinalError()
+}
+
 func (s *session) writeAttempt(
+	ctx goctx.Context,
 	wType writeAttemptType,
 	namespace, id ident.ID,
 	inputTags ident.TagIterator,
@@ -917,7 +1100,7 @@ func (s *session) writeAttempt(
 		return errSessionStatusNotOpen
 	}
 
-	state, majority, enqueued, err := s.writeAttemptWithRLock(
+	state, majority, enqueued, err := s.writeAttemptWithRLock(ctx,
 		wType, namespace, id, inputTags, timestamp, value, timeType, annotation)
 	s.state.RUnlock()
 
@@ -946,6 +1129,7 @@ func (s *session) writeAttempt(
 // is transferred to the calling function, and is expected to manage the lifecycle of
 // of the object (including releasing the lock/decRef'ing it).
 func (s *session) writeAttemptWithRLock(
+	ctx goctx.Context,
 	wType writeAttemptType,
 	namespace, id ident.ID,
 	inputTags ident.TagIterator,
@@ -953,6 +1137,26 @@ func (s *session) writeAttemptWithRLock(
 	value float64,
 	timeType rpc.TimeType,
 	annotation []byte,
+) (*writeState, int32, int32, error) {
+	return s.writeAttemptToHostsWithRLock(ctx, wType, namespace, id, inputTags,
+		timestamp, value, timeType, annotation, s.state.writeLevel, nil)
+}
+
+// writeAttemptToHostsWithRLock is the same as writeAttemptWithRLock but with
+// the consistency level to wait for and, if set, a filter for the replicas
+// written to. The returned writeState has no enqueued writes if no replicas
+// pass the filter and so must not be waited on.
+func (s *session) writeAttemptToHostsWithRLock(
+	ctx goctx.Context,
+	wType writeAttemptType,
+	namespace, id ident.ID,
+	inputTags ident.TagIterator,
+	timestamp int64,
+	value float64,
+	timeType rpc.TimeType,
+	annotation []byte,
+	consistencyLevel topology.ConsistencyLevel,
+	hostFilter func(host topology.Host) bool,
 ) (*writeState, int32, int32, error) {
 	var (
 		majority = int32(s.state.majority)
@@ -989,6 +1193,7 @@ func (s *session) writeAttemptWithRLock(
 		op = wop
 	case taggedWriteAttemptType:
 		wop := s.pools.writeTaggedOperation.Get()
+		wop.ctx = ctx
 		wop.namespace = nsID
 		wop.shardID = s.state.topoMap.ShardSet().Lookup(tsID)
 		wop.request.ID = tsID.Bytes()
@@ -1008,7 +1213,12 @@ func (s *session) writeAttemptWithRLock(
 	}
 
 	state := s.pools.writeState.Get()
-	state.consistencyLevel = s.state.writeLevel
+	state.consistencyLevel = consistencyLevel
+	if hostFilter == nil {
+		// Only writes to all replicas keep hints for the replicas they fail
+		// for, writes to specific replicas are repairs or replays themselves
+		state.hints = s.hints
+	}
 	state.topoMap = s.state.topoMap
 	state.incRef()
 
@@ -1018,6 +1228,9 @@ func (s *session) writeAttemptWithRLock(
 	op.SetCompletionFn(state.completionFn)
 
 	if err := s.state.topoMap.RouteForEach(tsID, func(idx int, host topology.Host) {
+		if hostFilter != nil && !hostFilter(host) {
+			return
+		}
 		// Count pending write requests before we enqueue the completion fns,
 		// which rely on the count when executing
 		state.pending++
@@ -1083,19 +1296,39 @@ func (s *session) FetchIDs(
 func (s *session) FetchTagged(
 	ns ident.ID, q index.Query, opts index.QueryOptions,
 ) (encoding.SeriesIterators, bool, error) {
+	return s.FetchTaggedContext(goctx.Background(), ns, q, opts)
+}
+
+// FetchTaggedContext resolves the query to known IDs and fetches the data for
+// them, tracing the fetch as a child of the span in the context and
+// propagating the trace context to the nodes.
+func (s *session) FetchTaggedContext(
+	ctx goctx.Context, ns ident.ID, q index.Query, opts index.QueryOptions,
+) (encoding.SeriesIterators, bool, error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, fetchTaggedSpanName)
+	span.SetTag("namespace", ns.String())
+
 	f := s.pools.fetchTaggedAttempt.Get()
+	f.args.ctx = ctx
 	f.args.ns = ns
 	f.args.query = q
 	f.args.opts = opts
 	err := s.fetchRetrier.Attempt(f.dataAttemptFn)
 	iters, exhaustive := f.dataResultIters, f.dataResultExhaustive
 	s.pools.fetchTaggedAttempt.Put(f)
+
+	if err == nil {
+		span.SetTag("series", iters.Len())
+		span.SetTag("exhaustive", exhaustive)
+	}
+	tracing.FinishSpan(span, err)
 	return iters, exhaustive, err
 }
 
 func (s *session) fetchTaggedAttempt(
-	ns ident.ID, q index.Query, opts index.QueryOptions,
+	ctx goctx.Context, ns ident.ID, q index.Query, opts index.QueryOptions,
 ) (encoding.SeriesIterators, bool, error) {
+	start := s.nowFn()
 	s.state.RLock()
 	if s.state.status != statusOpen {
 		s.state.RUnlock()
@@ -1103,7 +1336,7 @@ func (s *session) fetchTaggedAttempt(
 	}
 
 	const fetchData = true
-	fetchState, err := s.fetchTaggedAttemptWithRLock(ns, q, opts, fetchData)
+	fetchState, err := s.fetchTaggedAttemptWithRLock(ctx, ns, q, opts, fetchData)
 	s.state.RUnlock()
 
 	if err != nil {
@@ -1113,6 +1346,7 @@ func (s *session) fetchTaggedAttempt(
 	// it's safe to Wait() here, as we still hold the lock on fetchState, after it's
 	// returned from fetchTaggedAttemptWithRLock.
 	fetchState.Wait()
+	s.fetchTaggedHedgeDoneWithLock(fetchState, start)
 
 	// must Unlock before calling `asEncodingSeriesIterators` as the latter needs to acquire
 	// the fetchState Lock
@@ -1130,6 +1364,7 @@ func (s *session) FetchTaggedIDs(
 	ns ident.ID, q index.Query, opts index.QueryOptions,
 ) (TaggedIDsIterator, bool, error) {
 	f := s.pools.fetchTaggedAttempt.Get()
+	f.args.ctx = goctx.Background()
 	f.args.ns = ns
 	f.args.query = q
 	f.args.opts = opts
@@ -1140,8 +1375,9 @@ func (s *session) FetchTaggedIDs(
 }
 
 func (s *session) fetchTaggedIDsAttempt(
-	ns ident.ID, q index.Query, opts index.QueryOptions,
+	ctx goctx.Context, ns ident.ID, q index.Query, opts index.QueryOptions,
 ) (TaggedIDsIterator, bool, error) {
+	start := s.nowFn()
 	s.state.RLock()
 	if s.state.status != statusOpen {
 		s.state.RUnlock()
@@ -1149,7 +1385,7 @@ func (s *session) fetchTaggedIDsAttempt(
 	}
 
 	const fetchData = false
-	fetchState, err := s.fetchTaggedAttemptWithRLock(ns, q, opts, fetchData)
+	fetchState, err := s.fetchTaggedAttemptWithRLock(ctx, ns, q, opts, fetchData)
 	s.state.RUnlock()
 
 	if err != nil {
@@ -1159,6 +1395,7 @@ func (s *session) fetchTaggedIDsAttempt(
 	// it's safe to Wait() here, as we still hold the lock on fetchState, after it's
 	// returned from fetchTaggedAttemptWithRLock.
 	fetchState.Wait()
+	s.fetchTaggedHedgeDoneWithLock(fetchState, start)
 
 	// must Unlock before calling `asIndexQueryResults` as the latter needs to acquire
 	// the fetchState Lock
@@ -1176,6 +1413,7 @@ func (s *session) fetchTaggedIDsAttempt(
 // is transferred to the calling function, and is expected to manage the lifecycle of
 // of the object (including releasing the lock/decRef'ing it).
 func (s *session) fetchTaggedAttemptWithRLock(
+	ctx goctx.Context,
 	ns ident.ID,
 	q index.Query,
 	opts index.QueryOptions,
@@ -1204,11 +1442,30 @@ func (s *session) fetchTaggedAttemptWithRLock(
 	fetchState.nsID = nsClone // transfer ownership to `fetchState`
 	fetchState.incRef()       // indicate current go-routine has a reference to the fetchState
 	op.incRef()               // indicate current go-routine has a reference to the op
-	op.update(req, fetchState.completionFn)
+	op.update(ctx, req, fetchState.completionFn)
 
 	fetchState.Reset(opts.StartInclusive, opts.EndExclusive, op, topoMap, s.state.majority, s.state.readLevel)
 	fetchState.Lock()
-	for _, hq := range s.state.queues {
+
+	// When hedging only the primary hosts are sent the fetch initially, the
+	// remaining hosts are sent the fetch by the hedge.
+	var hedgeHostIdxs []int
+	if s.hedgeDelay != nil {
+		primaries := hedgedReadPrimaries(s.state.readLevel, s.state.majority, s.state.replicas)
+		if primaries < s.state.replicas {
+			rotation := int(atomic.AddUint32(&s.hedgeRotation, 1))
+			hedgeHostIdxs = hedgedFetchTaggedHostIdxs(topoMap, primaries, rotation)
+		}
+	}
+
+	nextHedgeHostIdx := 0
+	for idx, hq := range s.state.queues {
+		if nextHedgeHostIdx < len(hedgeHostIdxs) && hedgeHostIdxs[nextHedgeHostIdx] == idx {
+			nextHedgeHostIdx++
+			fetchState.hedgeHosts = append(fetchState.hedgeHosts, hq.Host())
+			continue
+		}
+
 		// inc to indicate the hostQueue has a reference to `op` which has a ref to the fetchState
 		fetchState.incRef()
 		if err := hq.Enqueue(op); err != nil {
@@ -1227,11 +1484,122 @@ func (s *session) fetchTaggedAttemptWithRLock(
 
 	op.decRef() // release the ref for the current go-routine
 
+	if len(hedgeHostIdxs) > 0 {
+		fetchState.incRef() // indicate the hedge has a reference to the fetchState
+		fetchState.hedge = newReadHedge(s.hedgeDelay.Delay(), func() {
+			s.metrics.fetchTaggedHedgesSent.Inc(1)
+			s.enqueueHedgedFetchTaggedOps(topoMap, fetchState, hedgeHostIdxs)
+			fetchState.decRef() // release the ref for the hedge
+		}, func() {
+			fetchState.decRef() // release the ref for the hedge
+		})
+	}
+
 	// NB(prateek): the calling go-routine still holds the lock and a ref
 	// on the returned fetchState object.
 	return fetchState, nil
 }
 
+// hedgedFetchTaggedHostIdxs returns the indexes of the hosts, in ascending
+// order, that a hedged fetch tagged is not sent to initially. Hosts are
+// selected in rotated order until each shard has enough primary replicas.
+func hedgedFetchTaggedHostIdxs(
+	topoMap topology.Map,
+	primaries int,
+	rotation int,
+) []int {
+	var (
+		hostShardSets = topoMap.HostShardSets()
+		numHosts      = len(hostShardSets)
+		shardPrimary  = make([]int, 1+int(topoMap.ShardSet().Max()))
+		hedged        = make([]bool, numHosts)
+		numHedged     int
+	)
+	for i := 0; i < numHosts; i++ {
+		idx := (i + rotation) % numHosts
+		shards := hostShardSets[idx].ShardSet().All()
+		needed := false
+		for _, hostShard := range shards {
+			if hostShard.State() == shard.Available && shardPrimary[hostShard.ID()] < primaries {
+				needed = true
+				break
+			}
+		}
+		if !needed {
+			hedged[idx] = true
+			numHedged++
+			continue
+		}
+		for _, hostShard := range shards {
+			shardPrimary[hostShard.ID()]++
+		}
+	}
+
+	if numHedged == 0 {
+		return nil
+	}
+	idxs := make([]int, 0, numHedged)
+	for idx, isHedged := range hedged {
+		if isHedged {
+			idxs = append(idxs, idx)
+		}
+	}
+	return idxs
+}
+
+// enqueueHedgedFetchTaggedOps enqueues the fetch tagged op to the hedged
+// hosts, hosts that cannot be enqueued to complete with an error so the
+// fetch does not wait on them.
+func (s *session) enqueueHedgedFetchTaggedOps(
+	topoMap topology.Map,
+	fetchState *fetchState,
+	hostIdxs []int,
+) {
+	s.state.RLock()
+	defer s.state.RUnlock()
+
+	fetchState.Lock()
+	done, op := fetchState.done, fetchState.op
+	fetchState.Unlock()
+	if done {
+		return
+	}
+
+	// NB: the host queues are only valid for the topology the fetch was
+	// started with.
+	valid := s.state.status == statusOpen && s.state.topoMap == topoMap
+	hosts := topoMap.Hosts()
+	for _, idx := range hostIdxs {
+		// inc to indicate the hostQueue has a reference to `op` which has a ref to the fetchState
+		fetchState.incRef()
+		err := errHedgedReadTopologyChanged
+		if valid {
+			err = s.state.queues[idx].Enqueue(op)
+		}
+		if err != nil {
+			// completionFn releases the ref for the hostQueue
+			fetchState.completionFn(fetchTaggedResultAccumulatorOpts{host: hosts[idx]}, err)
+		}
+	}
+}
+
+// fetchTaggedHedgeDoneWithLock releases the hedge of a completed fetch tagged
+// and records the outcome, the fetch state lock must be held.
+func (s *session) fetchTaggedHedgeDoneWithLock(fetchState *fetchState, start time.Time) {
+	if s.hedgeDelay == nil {
+		return
+	}
+	if fetchState.hedge != nil {
+		fetchState.hedge.cancel()
+		if fetchState.hedgeWon {
+			s.metrics.fetchTaggedHedgesWon.Inc(1)
+		}
+	}
+	if fetchState.err == nil {
+		s.hedgeDelay.Record(s.nowFn().Sub(start))
+	}
+}
+
 func (s *session) fetchIDsAttempt(
 	inputNamespace ident.ID,
 	inputIDs ident.Iterator,
@@ -1248,6 +1616,10 @@ func (s *session) fetchIDsAttempt(
 		majority               int32
 		consistencyLevel       topology.ReadConsistencyLevel
 		fetchBatchOpsByHostIdx [][]*fetchBatchOp
+		hedgeBatchOpsByHostIdx [][]*fetchBatchOp
+		hedge                  *readHedge
+		hedgeWon               int32
+		start                  = s.nowFn()
 		success                = false
 	)
 
@@ -1297,6 +1669,27 @@ func (s *session) fetchIDsAttempt(
 	consistencyLevel = s.state.readLevel
 	majority = int32(s.state.majority)
 
+	// When hedging only the primary replicas of each ID are sent the fetch
+	// initially, the remaining replicas are sent the fetch by the hedge.
+	var (
+		topoMap         = s.state.topoMap
+		replicas        = s.state.replicas
+		primaries       = replicas
+		hedgeRotation   int
+		hedgingEnabled  = s.hedgeDelay != nil
+		hedgeReplicasFn = func(replica int) bool {
+			return (replica+hedgeRotation)%replicas >= primaries
+		}
+	)
+	if hedgingEnabled {
+		primaries = hedgedReadPrimaries(consistencyLevel, int(majority), replicas)
+		hedgingEnabled = primaries < replicas
+	}
+	if hedgingEnabled {
+		hedgeBatchOpsByHostIdx = s.pools.fetchBatchOpArrayArray.Get()
+		hedgeRotation = int(atomic.AddUint32(&s.hedgeRotation, 1))
+	}
+
 	// NB(prateek): namespaceAccessors tracks the number of pending accessors for nsID.
 	// It is set to incremented by `replica` for each requested ID during fetch enqueuing,
 	// and once by initial request, and is decremented for each replica retrieved, inside
@@ -1324,6 +1717,12 @@ func (s *session) fetchIDsAttempt(
 			success          int32
 			errors           []error
 			errs             int32
+
+			// When sampled for read repair the data returned by each replica
+			// is retained, guarded by resultsLock, to compare once all
+			// replicas have responded.
+			repairSampled  = s.readRepair != nil && s.readRepair.sample()
+			repairReplicas []readRepairReplica
 		)
 
 		// increment namespaceAccesors by 1 to indicate it still needs to be handled by the
@@ -1381,9 +1780,13 @@ func (s *session) fetchIDsAttempt(
 			}
 			wg.Done()
 		}
-		completionFn := func(result interface{}, err error) {
+		complete := func(result interface{}, err error, hedged bool, host topology.Host) {
 			var snapshotSuccess int32
 			if err != nil {
+				if hedge != nil {
+					// Send the hedged fetches without waiting for the delay
+					hedge.trigger()
+				}
 				atomic.AddInt32(&errs, 1)
 				// NB(r): reuse the error lock here as we do not want to create
 				// a whole lot of locks for every single ID fetched due to size
@@ -1403,6 +1806,12 @@ func (s *session) fetchIDsAttempt(
 				results[success] = multiIter
 				success++
 				snapshotSuccess = success
+				if host != nil {
+					repairReplicas = append(repairReplicas, readRepairReplica{
+						host:     host,
+						segments: result.([]*rpc.Segments),
+					})
+				}
 				resultsLock.Unlock()
 			}
 			// NB(xichen): decrementing pending and checking remaining against zero must
@@ -1412,8 +1821,19 @@ func (s *session) fetchIDsAttempt(
 			remaining := atomic.AddInt32(&pending, -1)
 			shouldTerminate := topology.ReadConsistencyTermination(s.state.readLevel, majority, remaining, snapshotSuccess)
 			if shouldTerminate && atomic.CompareAndSwapInt32(&wgIsDone, 0, 1) {
+				if hedged && err == nil && atomic.CompareAndSwapInt32(&hedgeWon, 0, 1) {
+					s.metrics.fetchHedgesWon.Inc(1)
+				}
 				allCompletionFn()
 			}
+			if remaining == 0 && repairSampled {
+				// Compare the replicas once all have responded, including
+				// those that responded after the fetch completed
+				resultsLock.RLock()
+				replicas := repairReplicas
+				resultsLock.RUnlock()
+				s.readRepair.enqueue(namespace, tsID, startInclusive, endExclusive, replicas)
+			}
 
 			if atomic.AddInt32(&resultsAccessors, -1) == 0 {
 				s.pools.multiReaderIteratorArray.Put(results)
@@ -1425,7 +1845,17 @@ func (s *session) fetchIDsAttempt(
 				namespace.Finalize()
 			}
 		}
+		primaryCompletionFn := func(result interface{}, err error) {
+			complete(result, err, false, nil)
+		}
+		var hedgedCompletionFn completionFn
+		if hedgingEnabled {
+			hedgedCompletionFn = func(result interface{}, err error) {
+				complete(result, err, true, nil)
+			}
+		}
 
+		replica := 0
 		if err := s.state.topoMap.RouteForEach(tsID, func(hostIdx int, host topology.Host) {
 			// Inc safely as this for each is sequential
 			enqueued++
@@ -1435,7 +1865,19 @@ func (s *session) fetchIDsAttempt(
 			namespaceAccessors++
 			idAccessors++
 
-			ops := fetchBatchOpsByHostIdx[hostIdx]
+			opsByHostIdx, fn, hedged := fetchBatchOpsByHostIdx, primaryCompletionFn, false
+			if hedgingEnabled && hedgeReplicasFn(replica+idx) {
+				opsByHostIdx, fn, hedged = hedgeBatchOpsByHostIdx, hedgedCompletionFn, true
+			}
+			replica++
+			if repairSampled {
+				// Read repair needs to know which replica returned which data
+				fn = func(result interface{}, err error) {
+					complete(result, err, hedged, host)
+				}
+			}
+
+			ops := opsByHostIdx[hostIdx]
 
 			var f *fetchBatchOp
 			if len(ops) > 0 {
@@ -1449,14 +1891,14 @@ func (s *session) fetchIDsAttempt(
 				// they know when their use is complete.
 				f = s.pools.fetchBatchOp.Get()
 				f.IncRef()
-				fetchBatchOpsByHostIdx[hostIdx] = append(fetchBatchOpsByHostIdx[hostIdx], f)
+				opsByHostIdx[hostIdx] = append(opsByHostIdx[hostIdx], f)
 				f.request.RangeStart = rangeStart
 				f.request.RangeEnd = rangeEnd
 				f.request.RangeTimeType = rpc.TimeType_UNIX_NANOSECONDS
 			}
 
 			// Append IDWithNamespace to this request
-			f.append(namespace.Bytes(), tsID.Bytes(), completionFn)
+			f.append(namespace.Bytes(), tsID.Bytes(), fn)
 		}); err != nil {
 			routeErr = err
 			break
@@ -1472,6 +1914,16 @@ func (s *session) fetchIDsAttempt(
 		return nil, routeErr
 	}
 
+	if hedgingEnabled {
+		hedgeOps := hedgeBatchOpsByHostIdx
+		hedge = newReadHedge(s.hedgeDelay.Delay(), func() {
+			s.metrics.fetchHedgesSent.Inc(1)
+			s.enqueueHedgedFetchBatchOps(topoMap, hedgeOps)
+		}, func() {
+			s.cancelHedgedFetchBatchOps(topoMap, hedgeOps)
+		})
+	}
+
 	// Enqueue fetch ops
 	for idx := range fetchBatchOpsByHostIdx {
 		for _, f := range fetchBatchOpsByHostIdx[idx] {
@@ -1490,22 +1942,227 @@ func (s *session) fetchIDsAttempt(
 	s.state.RUnlock()
 
 	if enqueueErr != nil {
+		if hedge != nil {
+			hedge.cancel()
+		}
 		s.log.Errorf("failed to enqueue fetch: %v", enqueueErr)
 		return nil, enqueueErr
 	}
 
 	wg.Wait()
 
+	if hedge != nil {
+		// Release the hedged fetches if they were never needed
+		hedge.cancel()
+	}
+
 	resultErrLock.RLock()
 	retErr := resultErr
 	resultErrLock.RUnlock()
 	if retErr != nil {
 		return nil, retErr
 	}
+	if s.hedgeDelay != nil {
+		s.hedgeDelay.Record(s.nowFn().Sub(start))
+	}
 	success = true
 	return iters, nil
 }
 
+// enqueueHedgedFetchBatchOps enqueues the hedged fetch ops of a fetch, any
+// ops that cannot be enqueued are failed so the fetch does not wait on them.
+func (s *session) enqueueHedgedFetchBatchOps(
+	topoMap topology.Map,
+	opsByHostIdx [][]*fetchBatchOp,
+) {
+	s.state.RLock()
+	defer s.state.RUnlock()
+
+	// NB: the host queues are only valid for the topology the ops were
+	// created with, and the pooled array must only be returned while the
+	// pool is for the same topology.
+	valid := s.state.status == statusOpen && s.state.topoMap == topoMap
+	for idx := range opsByHostIdx {
+		for _, f := range opsByHostIdx[idx] {
+			// Passing ownership of the op itself to the host queue
+			f.DecRef()
+			if !valid {
+				f.completeAll(nil, errHedgedReadTopologyChanged)
+				f.Finalize()
+				continue
+			}
+			if err := s.state.queues[idx].Enqueue(f); err != nil {
+				s.log.Errorf("failed to enqueue hedged fetch: %v", err)
+				f.completeAll(nil, err)
+			}
+		}
+	}
+	if valid {
+		s.pools.fetchBatchOpArrayArray.Put(opsByHostIdx)
+	}
+}
+
+// cancelHedgedFetchBatchOps releases the hedged fetch ops of a fetch that
+// completed before they were sent.
+func (s *session) cancelHedgedFetchBatchOps(
+	topoMap topology.Map,
+	opsByHostIdx [][]*fetchBatchOp,
+) {
+	s.state.RLock()
+	defer s.state.RUnlock()
+
+	for idx := range opsByHostIdx {
+		for _, f := range opsByHostIdx[idx] {
+			f.DecRef()
+			f.completeAll(nil, errHedgedReadCancelled)
+			f.Finalize()
+		}
+	}
+	if s.state.status == statusOpen && s.state.topoMap == topoMap {
+		s.pools.fetchBatchOpArrayArray.Put(opsByHostIdx)
+	}
+}
+
+// readRepairIter returns an iterator over the segments returned by a replica
+// for a series sampled for read repair.
+func (s *session) readRepairIter(segments []*rpc.Segments) encoding.MultiReaderIterator {
+	slicesIter := s.pools.readerSliceOfSlicesIterator.Get()
+	slicesIter.Reset(segments)
+	multiIter := s.pools.multiReaderIterator.Get()
+	multiIter.ResetSliceOfSlices(slicesIter)
+	return multiIter
+}
+
+// readRepairWrite writes a datapoint of a series to a single replica to repair
+// the replica, the write waits for the replica to respond.
+func (s *session) readRepairWrite(
+	namespace, id ident.ID,
+	host topology.Host,
+	dp ts.Datapoint,
+	unit xtime.Unit,
+	annotation ts.Annotation,
+) error {
+	timeType, timeTypeErr := convert.ToTimeType(unit)
+	if timeTypeErr != nil {
+		return timeTypeErr
+	}
+
+	timestamp, timestampErr := convert.ToValue(dp.Timestamp, timeType)
+	if timestampErr != nil {
+		return timestampErr
+	}
+
+	s.state.RLock()
+	if s.state.status != statusOpen {
+		s.state.RUnlock()
+		return errSessionStatusNotOpen
+	}
+
+	hostID := host.ID()
+	state, majority, enqueued, err := s.writeAttemptToHostsWithRLock(nil,
+		untaggedWriteAttemptType, namespace, id, nil, timestamp, dp.Value,
+		timeType, annotation, topology.ConsistencyLevelAll,
+		func(host topology.Host) bool {
+			return host.ID() == hostID
+		})
+	s.state.RUnlock()
+
+	if err != nil {
+		return err
+	}
+
+	if enqueued == 0 {
+		err = errReadRepairHostNotReplica
+	} else {
+		state.Wait()
+		err = s.writeConsistencyResult(state.consistencyLevel, majority, enqueued,
+			enqueued-state.pending, int32(len(state.errors)), state.errors)
+	}
+
+	state.Unlock()
+	state.decRef()
+
+	return err
+}
+
+// hostHealthy returns whether a host has all of its shards available in the
+// topology and the session has connections to it.
+func (s *session) hostHealthy(hostID string) bool {
+	s.state.RLock()
+	defer s.state.RUnlock()
+
+	if s.state.status != statusOpen {
+		return false
+	}
+	hostShardSet, ok := s.state.topoMap.LookupHostShardSet(hostID)
+	if !ok {
+		return false
+	}
+	for _, hostShard := range hostShardSet.ShardSet().All() {
+		if hostShard.State() != shard.Available {
+			return false
+		}
+	}
+	queue, ok := s.state.queuesByHostID[hostID]
+	return ok && queue.ConnectionCount() > 0
+}
+
+// writeToHost writes a datapoint of a series to a single replica, the write
+// is tagged if tags are set and waits for the replica to respond.
+func (s *session) writeToHost(
+	hostID string,
+	namespace, id ident.ID,
+	tags ident.TagIterator,
+	dp ts.Datapoint,
+	unit xtime.Unit,
+	annotation ts.Annotation,
+) error {
+	timeType, timeTypeErr := convert.ToTimeType(unit)
+	if timeTypeErr != nil {
+		return timeTypeErr
+	}
+
+	timestamp, timestampErr := convert.ToValue(dp.Timestamp, timeType)
+	if timestampErr != nil {
+		return timestampErr
+	}
+
+	wType := untaggedWriteAttemptType
+	if tags != nil {
+		wType = taggedWriteAttemptType
+	}
+
+	s.state.RLock()
+	if s.state.status != statusOpen {
+		s.state.RUnlock()
+		return errSessionStatusNotOpen
+	}
+
+	state, majority, enqueued, err := s.writeAttemptToHostsWithRLock(nil,
+		wType, namespace, id, tags, timestamp, dp.Value, timeType, annotation,
+		topology.ConsistencyLevelAll, func(host topology.Host) bool {
+			return host.ID() == hostID
+		})
+	s.state.RUnlock()
+
+	if err != nil {
+		return err
+	}
+
+	if enqueued == 0 {
+		err = errSessionHostNotReplica
+	} else {
+		state.Wait()
+		err = s.writeConsistencyResult(state.consistencyLevel, majority, enqueued,
+			enqueued-state.pending, int32(len(state.errors)), state.errors)
+	}
+
+	state.Unlock()
+	state.decRef()
+
+	return err
+}
+
 func (s *session) writeConsistencyResult(
 	level topology.ConsistencyLevel,
 	majority, enqueued, responded, resultErrs int32,
@@ -1553,6 +2210,15 @@ func (s *session) Close() error {
 	topo := s.state.topo
 	s.state.Unlock()
 
+	if s.readRepair != nil {
+		s.readRepair.close()
+	}
+	if s.hints != nil {
+		if err := s.hints.close(); err != nil {
+			s.log.Errorf("failed to close hinted handoff: %v", err)
+		}
+	}
+
 	for _, q := range queues {
 		q.Close()
 	}
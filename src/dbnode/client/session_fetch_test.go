@@ -308,6 +308,74 @@ func TestSessionFetchReadConsistencyLevelOne(t *testing.T) {
 	testFetchConsistencyLevel(t, ctrl, topology.ReadConsistencyLevelOne, 3, outcomeFail)
 }
 
+func TestSessionFetchIDsHedgedReadWinsOverSlowReplica(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	scope := tally.NewTestScope("", nil)
+	opts := newSessionTestOptions().
+		SetReadConsistencyLevel(topology.ReadConsistencyLevelOne).
+		SetHedgedReadPolicy(HedgedReadPolicy{
+			Enabled:    true,
+			Percentile: 0.95,
+			MinDelay:   time.Millisecond,
+			MaxDelay:   10 * time.Millisecond,
+		})
+	opts = opts.SetInstrumentOptions(opts.InstrumentOptions().
+		SetMetricsScope(scope))
+
+	s, err := newSession(opts)
+	assert.NoError(t, err)
+	session := s.(*session)
+
+	start := time.Now().Truncate(time.Hour)
+	end := start.Add(2 * time.Hour)
+
+	fetches := testFetches([]testFetch{
+		{"foo", []testValue{
+			{1.0, start.Add(1 * time.Second), xtime.Second, []byte{1, 2, 3}},
+			{2.0, start.Add(2 * time.Second), xtime.Second, nil},
+		}},
+	})
+
+	// The primary replica never responds until the fetch has completed, the
+	// hedged replicas respond straight away.
+	var (
+		lock    sync.Mutex
+		primary *fetchBatchOp
+	)
+	enqueueFn := func(idx int, op op) {
+		fetch, ok := op.(*fetchBatchOp)
+		assert.True(t, ok)
+		lock.Lock()
+		defer lock.Unlock()
+		if primary == nil {
+			primary = fetch
+			return
+		}
+		go fulfillTszFetchBatchOps(t, fetches, []*fetchBatchOp{fetch}, 0)
+	}
+	enqueueWg := mockHostQueues(ctrl, session, sessionTestReplicas, []testEnqueueFn{enqueueFn})
+
+	assert.NoError(t, session.Open())
+
+	results, err := session.FetchIDs(ident.StringID(testNamespaceName),
+		fetches.IDsIter(), start, end)
+	require.NoError(t, err)
+	assertFetchResults(t, start, end, fetches, results)
+
+	enqueueWg.Wait()
+	lock.Lock()
+	primary.completeAll(nil, fmt.Errorf("timed out"))
+	lock.Unlock()
+
+	assert.NoError(t, session.Close())
+
+	counters := scope.Snapshot().Counters()
+	assert.Equal(t, int64(1), counters["fetch.hedges-sent+"].Value())
+	assert.Equal(t, int64(1), counters["fetch.hedges-won+"].Value())
+}
+
 func testFetchConsistencyLevel(
 	t *testing.T,
 	ctrl *gomock.Controller,
@@ -27,6 +27,7 @@ import (
 	"testing"
 
 	"github.com/m3db/m3/src/dbnode/encoding"
+	"github.com/m3db/m3/src/dbnode/generated/thrift/rpc"
 	"github.com/m3db/m3/src/dbnode/serialize"
 	"github.com/m3db/m3/src/dbnode/sharding"
 	"github.com/m3db/m3/src/dbnode/topology"
@@ -295,6 +296,48 @@ func testSessionClusterConnectConsistencyLevel(
 	}
 }
 
+func TestSessionNodeHealth(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	s, err := newSession(newSessionTestOptions())
+	require.NoError(t, err)
+	session := s.(*session)
+
+	health := &rpc.NodeHealthResult_{
+		Ok:                 true,
+		Status:             "up",
+		BootstrappedShards: []int32{0, 1},
+	}
+	session.newHostQueueFn = func(
+		host topology.Host,
+		opts hostQueueOpts,
+	) hostQueue {
+		// The health of a node is checked over the connections of the session
+		client := rpc.NewMockTChanNode(ctrl)
+		client.EXPECT().Health(gomock.Any()).Return(health, nil).AnyTimes()
+		hostQueue := NewMockhostQueue(ctrl)
+		hostQueue.EXPECT().Open()
+		hostQueue.EXPECT().Host().Return(host).AnyTimes()
+		hostQueue.EXPECT().ConnectionCount().Return(opts.opts.MinConnectionCount()).AnyTimes()
+		hostQueue.EXPECT().BorrowConnection(gomock.Any()).Do(func(fn withConnectionFn) {
+			fn(client)
+		}).Return(nil).AnyTimes()
+		hostQueue.EXPECT().Close()
+		return hostQueue
+	}
+	require.NoError(t, session.Open())
+
+	result, err := session.NodeHealth(testHostName(0))
+	require.NoError(t, err)
+	assert.Equal(t, health, result)
+
+	_, err = session.NodeHealth("unknown")
+	assert.Equal(t, errSessionHasNoHostQueueForHost, err)
+
+	assert.NoError(t, session.Close())
+}
+
 func mockHostQueues(
 	ctrl *gomock.Controller,
 	s *session,
@@ -21,6 +21,7 @@
 package client
 
 import (
+	goctx "context"
 	"time"
 
 	"github.com/m3db/m3/src/dbnode/clock"
@@ -58,6 +59,44 @@ type Client interface {
 	DefaultSessionActive() bool
 }
 
+// ContextSession is implemented by sessions that trace fetches and writes,
+// spans are started as children of the span in the context and the trace
+// context is propagated to the nodes with each fetch tagged request.
+type ContextSession interface {
+	// FetchTaggedContext resolves the provided query to known IDs, and
+	// fetches the data for them.
+	FetchTaggedContext(ctx goctx.Context, namespace ident.ID, q index.Query, opts index.QueryOptions) (results encoding.SeriesIterators, exhaustive bool, err error)
+
+	// WriteTaggedContext writes a value to the database for an ID and given tags.
+	WriteTaggedContext(ctx goctx.Context, namespace, id ident.ID, tags ident.TagIterator, t time.Time, value float64, unit xtime.Unit, annotation []byte) error
+}
+
+// BatchSession is implemented by sessions that can write a batch of tagged
+// datapoints, all writes of the batch are enqueued before waiting for any of
+// them so that they are sent to each host in as few requests as possible.
+type BatchSession interface {
+	// WriteTaggedBatch writes a batch of values to the database for IDs and
+	// given tags, each write is attempted once.
+	WriteTaggedBatch(ctx goctx.Context, namespace ident.ID, writes []TaggedWrite) error
+}
+
+// NodeHealthSession is implemented by sessions that can check the health of
+// the nodes of the cluster over the connections of the session.
+type NodeHealthSession interface {
+	// NodeHealth returns the health of the node of a host.
+	NodeHealth(hostID string) (*rpc.NodeHealthResult_, error)
+}
+
+// TaggedWrite is a value written for an ID and given tags.
+type TaggedWrite struct {
+	ID         ident.ID
+	Tags       ident.TagIterator
+	Timestamp  time.Time
+	Value      float64
+	Unit       xtime.Unit
+	Annotation []byte
+}
+
 // Session can write and read to a cluster
 type Session interface {
 	// Write value to the database for an ID
@@ -360,6 +399,30 @@ type Options interface {
 	// a fetch operation. Only retryable errors are retried.
 	FetchRetrier() xretry.Retrier
 
+	// SetHedgedReadPolicy sets the policy for hedging fetches to replicas
+	// beyond those required to satisfy the read consistency level.
+	SetHedgedReadPolicy(value HedgedReadPolicy) Options
+
+	// HedgedReadPolicy returns the policy for hedging fetches to replicas
+	// beyond those required to satisfy the read consistency level.
+	HedgedReadPolicy() HedgedReadPolicy
+
+	// SetReadRepairPolicy sets the policy for repairing replicas that return
+	// divergent data for a series when fetched.
+	SetReadRepairPolicy(value ReadRepairPolicy) Options
+
+	// ReadRepairPolicy returns the policy for repairing replicas that return
+	// divergent data for a series when fetched.
+	ReadRepairPolicy() ReadRepairPolicy
+
+	// SetHintedHandoffPolicy sets the policy for keeping writes that fail for
+	// a replica as hints and replaying them once the replica is healthy.
+	SetHintedHandoffPolicy(value HintedHandoffPolicy) Options
+
+	// HintedHandoffPolicy returns the policy for keeping writes that fail for
+	// a replica as hints and replaying them once the replica is healthy.
+	HintedHandoffPolicy() HintedHandoffPolicy
+
 	// SetTagEncoderOptions sets the TagEncoderOptions.
 	SetTagEncoderOptions(value serialize.TagEncoderOptions) Options
 
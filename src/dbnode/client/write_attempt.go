@@ -21,6 +21,7 @@
 package client
 
 import (
+	"context"
 	"time"
 
 	xerrors "github.com/m3db/m3x/errors"
@@ -50,6 +51,7 @@ type writeAttempt struct {
 }
 
 type writeAttemptArgs struct {
+	ctx         context.Context
 	namespace   ident.ID
 	id          ident.ID
 	tags        ident.TagIterator
@@ -65,7 +67,7 @@ func (w *writeAttempt) reset() {
 }
 
 func (w *writeAttempt) perform() error {
-	err := w.session.writeAttempt(w.args.attemptType,
+	err := w.session.writeAttempt(w.args.ctx, w.args.attemptType,
 		w.args.namespace, w.args.id, w.args.tags, w.args.t,
 		w.args.value, w.args.unit, w.args.annotation)
 
@@ -54,6 +54,7 @@ type writeState struct {
 	nsID              ident.ID
 	tsID              ident.ID
 	tagEncoder        serialize.TagEncoder
+	hints             *hintedHandoff
 	majority, pending int32
 	success           int32
 	errors            []error
@@ -87,7 +88,7 @@ func (w *writeState) close() {
 	}
 
 	w.op, w.majority, w.pending, w.success = nil, 0, 0, 0
-	w.nsID, w.tsID, w.tagEncoder = nil, nil, nil
+	w.nsID, w.tsID, w.tagEncoder, w.hints = nil, nil, nil, nil
 
 	for i := range w.errors {
 		w.errors[i] = nil
@@ -116,6 +117,9 @@ func (w *writeState) completionFn(result interface{}, err error) {
 
 	if err != nil {
 		wErr = xerrors.NewRenamedError(err, fmt.Errorf("error writing to host %s: %v", hostID, err))
+		if w.hints != nil && !IsBadRequestError(err) {
+			w.hint(hostID)
+		}
 	} else if hostShardSet, ok := w.topoMap.LookupHostShardSet(hostID); !ok {
 		errStr := "missing host shard in writeState completionFn: %s"
 		wErr = xerrors.NewRetryableError(fmt.Errorf(errStr, hostID))
@@ -161,6 +165,17 @@ func (w *writeState) completionFn(result interface{}, err error) {
 	w.decRef()
 }
 
+// hint keeps the write as a hint for a host it failed for.
+func (w *writeState) hint(hostID string) {
+	switch op := w.op.(type) {
+	case *writeOperation:
+		w.hints.record(hostID, w.nsID, w.tsID, nil, op.shardID, op.request.Datapoint)
+	case *writeTaggedOperation:
+		w.hints.record(hostID, w.nsID, w.tsID, op.request.EncodedTags,
+			op.shardID, op.request.Datapoint)
+	}
+}
+
 type writeStatePool struct {
 	pool           pool.ObjectPool
 	tagEncoderPool serialize.TagEncoderPool
@@ -21,6 +21,7 @@
 package client
 
 import (
+	"context"
 	"math"
 
 	"github.com/m3db/m3/src/dbnode/generated/thrift/rpc"
@@ -34,6 +35,8 @@ var (
 )
 
 type writeTaggedOperation struct {
+	// ctx carries the trace context of the write, if any
+	ctx          context.Context
 	namespace    ident.ID
 	shardID      uint32
 	request      rpc.WriteTaggedBatchRawRequestElement
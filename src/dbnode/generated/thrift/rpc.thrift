@@ -291,6 +291,7 @@ struct NodeHealthResult {
 	1: required bool ok
 	2: required string status
 	3: required bool bootstrapped
+	4: optional list<i32> bootstrappedShards
 }
 
 struct NodePersistRateLimitResult {
@@ -7416,10 +7416,12 @@ func (p *TruncateResult_) String() string {
 //  - Ok
 //  - Status
 //  - Bootstrapped
+//  - BootstrappedShards
 type NodeHealthResult_ struct {
-	Ok           bool   `thrift:"ok,1,required" db:"ok" json:"ok"`
-	Status       string `thrift:"status,2,required" db:"status" json:"status"`
-	Bootstrapped bool   `thrift:"bootstrapped,3,required" db:"bootstrapped" json:"bootstrapped"`
+	Ok                 bool    `thrift:"ok,1,required" db:"ok" json:"ok"`
+	Status             string  `thrift:"status,2,required" db:"status" json:"status"`
+	Bootstrapped       bool    `thrift:"bootstrapped,3,required" db:"bootstrapped" json:"bootstrapped"`
+	BootstrappedShards []int32 `thrift:"bootstrappedShards,4" db:"bootstrappedShards" json:"bootstrappedShards,omitempty"`
 }
 
 func NewNodeHealthResult_() *NodeHealthResult_ {
@@ -7437,6 +7439,16 @@ func (p *NodeHealthResult_) GetStatus() string {
 func (p *NodeHealthResult_) GetBootstrapped() bool {
 	return p.Bootstrapped
 }
+
+var NodeHealthResult__BootstrappedShards_DEFAULT []int32
+
+func (p *NodeHealthResult_) GetBootstrappedShards() []int32 {
+	return p.BootstrappedShards
+}
+func (p *NodeHealthResult_) IsSetBootstrappedShards() bool {
+	return p.BootstrappedShards != nil
+}
+
 func (p *NodeHealthResult_) Read(iprot thrift.TProtocol) error {
 	if _, err := iprot.ReadStructBegin(); err != nil {
 		return thrift.PrependError(fmt.Sprintf("%T read error: ", p), err)
@@ -7470,6 +7482,10 @@ func (p *NodeHealthResult_) Read(iprot thrift.TProtocol) error {
 				return err
 			}
 			issetBootstrapped = true
+		case 4:
+			if err := p.ReadField4(iprot); err != nil {
+				return err
+			}
 		default:
 			if err := iprot.Skip(fieldTypeId); err != nil {
 				return err
@@ -7521,6 +7537,28 @@ func (p *NodeHealthResult_) ReadField3(iprot thrift.TProtocol) error {
 	return nil
 }
 
+func (p *NodeHealthResult_) ReadField4(iprot thrift.TProtocol) error {
+	_, size, err := iprot.ReadListBegin()
+	if err != nil {
+		return thrift.PrependError("error reading list begin: ", err)
+	}
+	tSlice := make([]int32, 0, size)
+	p.BootstrappedShards = tSlice
+	for i := 0; i < size; i++ {
+		var _elem23 int32
+		if v, err := iprot.ReadI32(); err != nil {
+			return thrift.PrependError("error reading field 0: ", err)
+		} else {
+			_elem23 = v
+		}
+		p.BootstrappedShards = append(p.BootstrappedShards, _elem23)
+	}
+	if err := iprot.ReadListEnd(); err != nil {
+		return thrift.PrependError("error reading list end: ", err)
+	}
+	return nil
+}
+
 func (p *NodeHealthResult_) Write(oprot thrift.TProtocol) error {
 	if err := oprot.WriteStructBegin("NodeHealthResult"); err != nil {
 		return thrift.PrependError(fmt.Sprintf("%T write struct begin error: ", p), err)
@@ -7535,6 +7573,9 @@ func (p *NodeHealthResult_) Write(oprot thrift.TProtocol) error {
 		if err := p.writeField3(oprot); err != nil {
 			return err
 		}
+		if err := p.writeField4(oprot); err != nil {
+			return err
+		}
 	}
 	if err := oprot.WriteFieldStop(); err != nil {
 		return thrift.PrependError("write field stop error: ", err)
@@ -7584,6 +7625,29 @@ func (p *NodeHealthResult_) writeField3(oprot thrift.TProtocol) (err error) {
 	return err
 }
 
+func (p *NodeHealthResult_) writeField4(oprot thrift.TProtocol) (err error) {
+	if p.IsSetBootstrappedShards() {
+		if err := oprot.WriteFieldBegin("bootstrappedShards", thrift.LIST, 4); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field begin error 4:bootstrappedShards: ", p), err)
+		}
+		if err := oprot.WriteListBegin(thrift.I32, len(p.BootstrappedShards)); err != nil {
+			return thrift.PrependError("error writing list begin: ", err)
+		}
+		for _, v := range p.BootstrappedShards {
+			if err := oprot.WriteI32(int32(v)); err != nil {
+				return thrift.PrependError(fmt.Sprintf("%T. (0) field write error: ", p), err)
+			}
+		}
+		if err := oprot.WriteListEnd(); err != nil {
+			return thrift.PrependError("error writing list end: ", err)
+		}
+		if err := oprot.WriteFieldEnd(); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field end error 4:bootstrappedShards: ", p), err)
+		}
+	}
+	return err
+}
+
 func (p *NodeHealthResult_) String() string {
 	if p == nil {
 		return "<nil>"
@@ -33,6 +33,11 @@ const (
 	// configuration specifying a hard limit for a cluster new series insertions.
 	ClusterNewSeriesInsertLimitKey = "m3db.node.cluster-new-series-insert-limit"
 
+	// PostingsListCacheSizeKey is the KV config key for the runtime
+	// configuration specifying the maximum number of postings lists held
+	// by the index postings list cache.
+	PostingsListCacheSizeKey = "m3db.node.postings-list-cache-size"
+
 	// ClientBootstrapConsistencyLevel is the KV config key for the runtime
 	// configuration specifying the client bootstrap consistency level
 	ClientBootstrapConsistencyLevel = "m3db.client.bootstrap-consistency-level"
@@ -21,13 +21,13 @@
 package cluster
 
 import (
-	"net"
 	"net/http"
 
 	"github.com/m3db/m3/src/dbnode/client"
 	ns "github.com/m3db/m3/src/dbnode/network/server"
 	"github.com/m3db/m3/src/dbnode/network/server/httpjson"
 	ttcluster "github.com/m3db/m3/src/dbnode/network/server/tchannelthrift/cluster"
+	xtls "github.com/m3db/m3/src/dbnode/x/tls"
 	xclose "github.com/m3db/m3x/close"
 	"github.com/m3db/m3x/context"
 )
@@ -66,7 +66,7 @@ func (s *server) ListenAndServe() (ns.Close, error) {
 		return nil, err
 	}
 
-	listener, err := net.Listen("tcp", s.address)
+	listener, err := xtls.Listen(s.address, s.opts.TLSConfig())
 	if err != nil {
 		return nil, err
 	}
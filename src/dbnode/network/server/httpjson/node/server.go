@@ -21,7 +21,6 @@
 package node
 
 import (
-	"net"
 	"net/http"
 
 	ns "github.com/m3db/m3/src/dbnode/network/server"
@@ -29,6 +28,7 @@ import (
 	"github.com/m3db/m3/src/dbnode/network/server/tchannelthrift"
 	ttnode "github.com/m3db/m3/src/dbnode/network/server/tchannelthrift/node"
 	"github.com/m3db/m3/src/dbnode/storage"
+	xtls "github.com/m3db/m3/src/dbnode/x/tls"
 	"github.com/m3db/m3x/context"
 )
 
@@ -70,7 +70,7 @@ func (s *server) ListenAndServe() (ns.Close, error) {
 		return nil, err
 	}
 
-	listener, err := net.Listen("tcp", s.address)
+	listener, err := xtls.Listen(s.address, s.opts.TLSConfig())
 	if err != nil {
 		return nil, err
 	}
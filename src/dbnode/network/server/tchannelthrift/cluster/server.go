@@ -21,10 +21,13 @@
 package cluster
 
 import (
+	"crypto/tls"
+
 	"github.com/m3db/m3/src/dbnode/client"
 	"github.com/m3db/m3/src/dbnode/generated/thrift/rpc"
 	ns "github.com/m3db/m3/src/dbnode/network/server"
 	"github.com/m3db/m3/src/dbnode/network/server/tchannelthrift"
+	xtls "github.com/m3db/m3/src/dbnode/x/tls"
 	xclose "github.com/m3db/m3x/close"
 	"github.com/m3db/m3x/context"
 
@@ -41,14 +44,17 @@ type server struct {
 	address     string
 	contextPool context.Pool
 	opts        *tchannel.ChannelOptions
+	tlsConfig   *tls.Config
 }
 
-// NewServer creates a new cluster TChannel Thrift network service
+// NewServer creates a new cluster TChannel Thrift network service, if a TLS
+// config is provided then the service only accepts TLS connections
 func NewServer(
 	client client.Client,
 	address string,
 	contextPool context.Pool,
 	opts *tchannel.ChannelOptions,
+	tlsConfig *tls.Config,
 ) ns.NetworkService {
 	// Make the opts immutable on the way in
 	if opts != nil {
@@ -60,6 +66,7 @@ func NewServer(
 		client:      client,
 		contextPool: contextPool,
 		opts:        opts,
+		tlsConfig:   tlsConfig,
 	}
 }
 
@@ -72,7 +79,16 @@ func (s *server) ListenAndServe() (ns.Close, error) {
 	service := NewService(s.client)
 	tchannelthrift.RegisterServer(channel, rpc.NewTChanClusterServer(service), s.contextPool)
 
-	channel.ListenAndServe(s.address)
+	listener, err := xtls.Listen(s.address, s.tlsConfig)
+	if err != nil {
+		channel.Close()
+		return nil, err
+	}
+
+	if err := channel.Serve(listener); err != nil {
+		channel.Close()
+		return nil, err
+	}
 
 	return func() {
 		channel.Close()
@@ -21,11 +21,14 @@
 package node
 
 import (
+	"crypto/tls"
+
 	"github.com/m3db/m3/src/dbnode/generated/thrift/rpc"
 	ns "github.com/m3db/m3/src/dbnode/network/server"
 	"github.com/m3db/m3/src/dbnode/network/server/tchannelthrift"
 	"github.com/m3db/m3/src/dbnode/network/server/tchannelthrift/node/channel"
 	"github.com/m3db/m3/src/dbnode/storage"
+	xtls "github.com/m3db/m3/src/dbnode/x/tls"
 	"github.com/m3db/m3x/context"
 
 	"github.com/uber/tchannel-go"
@@ -36,15 +39,18 @@ type server struct {
 	address     string
 	contextPool context.Pool
 	opts        *tchannel.ChannelOptions
+	tlsConfig   *tls.Config
 	ttopts      tchannelthrift.Options
 }
 
-// NewServer creates a new node TChannel Thrift network service
+// NewServer creates a new node TChannel Thrift network service, if a TLS
+// config is provided then the service only accepts TLS connections
 func NewServer(
 	db storage.Database,
 	address string,
 	contextPool context.Pool,
 	opts *tchannel.ChannelOptions,
+	tlsConfig *tls.Config,
 	ttopts tchannelthrift.Options,
 ) ns.NetworkService {
 	// Make the opts immutable on the way in
@@ -60,6 +66,7 @@ func NewServer(
 		address:     address,
 		contextPool: contextPool,
 		opts:        opts,
+		tlsConfig:   tlsConfig,
 		ttopts:      ttopts,
 	}
 }
@@ -73,7 +80,16 @@ func (s *server) ListenAndServe() (ns.Close, error) {
 	service := NewService(s.db, s.ttopts)
 	tchannelthrift.RegisterServer(channel, rpc.NewTChanNodeServer(service), s.contextPool)
 
-	channel.ListenAndServe(s.address)
+	listener, err := xtls.Listen(s.address, s.tlsConfig)
+	if err != nil {
+		channel.Close()
+		return nil, err
+	}
+
+	if err := channel.Serve(listener); err != nil {
+		channel.Close()
+		return nil, err
+	}
 
 	return channel.Close, nil
 }
@@ -23,6 +23,7 @@ package node
 import (
 	"errors"
 	"fmt"
+	"sort"
 	"sync"
 	"time"
 
@@ -38,6 +39,7 @@ import (
 	"github.com/m3db/m3/src/dbnode/storage/index"
 	"github.com/m3db/m3/src/dbnode/x/xio"
 	"github.com/m3db/m3/src/dbnode/x/xpool"
+	"github.com/m3db/m3/src/x/tracing"
 	"github.com/m3db/m3x/checked"
 	"github.com/m3db/m3x/context"
 	xerrors "github.com/m3db/m3x/errors"
@@ -49,10 +51,18 @@ import (
 	xtime "github.com/m3db/m3x/time"
 
 	apachethrift "github.com/apache/thrift/lib/go/thrift"
+	opentracing "github.com/opentracing/opentracing-go"
 	"github.com/uber-go/tally"
 	"github.com/uber/tchannel-go/thrift"
 )
 
+const (
+	fetchTaggedSpanName         = "tchannelthrift/node.service.FetchTagged"
+	indexQuerySpanName          = "tchannelthrift/node.service.indexQuery"
+	writeTaggedSpanName         = "tchannelthrift/node.service.WriteTagged"
+	writeTaggedBatchRawSpanName = "tchannelthrift/node.service.WriteTaggedBatchRaw"
+)
+
 var (
 	// NB(r): pool sizes are vars to help reduce stress on tests.
 	checkedBytesPoolSize        = 65536
@@ -230,7 +240,38 @@ func (s *service) Health(ctx thrift.Context) (*rpc.NodeHealthResult_, error) {
 		health = newHealth
 	}
 
-	return health, nil
+	// NB: shards are assigned to and bootstrapped by the node over time so
+	// the bootstrapped shards are resolved for every request.
+	result := &rpc.NodeHealthResult_{}
+	*result = *health
+	result.BootstrappedShards = bootstrappedShards(s.db.BootstrapState())
+	return result, nil
+}
+
+// bootstrappedShards returns the shards bootstrapped for every namespace.
+func bootstrappedShards(state storage.DatabaseBootstrapState) []int32 {
+	var (
+		namespaces = len(state.NamespaceBootstrapStates)
+		counts     = make(map[uint32]int)
+	)
+	for _, shardStates := range state.NamespaceBootstrapStates {
+		for shard, shardState := range shardStates {
+			if shardState == storage.Bootstrapped {
+				counts[shard]++
+			}
+		}
+	}
+
+	shards := make([]int32, 0, len(counts))
+	for shard, count := range counts {
+		if count == namespaces {
+			shards = append(shards, int32(shard))
+		}
+	}
+	sort.Slice(shards, func(i, j int) bool {
+		return shards[i] < shards[j]
+	})
+	return shards
 }
 
 func (s *service) Query(tctx thrift.Context, req *rpc.QueryRequest) (*rpc.QueryResult_, error) {
@@ -375,6 +416,17 @@ func (s *service) readDatapoints(
 }
 
 func (s *service) FetchTagged(tctx thrift.Context, req *rpc.FetchTaggedRequest) (*rpc.FetchTaggedResult_, error) {
+	span := tracing.StartSpanFromHeaders(tctx.Headers(), fetchTaggedSpanName)
+	result, err := s.fetchTagged(tctx, span, req)
+	tracing.FinishSpan(span, err)
+	return result, err
+}
+
+func (s *service) fetchTagged(
+	tctx thrift.Context,
+	span opentracing.Span,
+	req *rpc.FetchTaggedRequest,
+) (*rpc.FetchTaggedResult_, error) {
 	if s.isOverloaded() {
 		s.metrics.overloadRejected.Inc(1)
 		return nil, tterrors.NewInternalError(errServerIsOverloaded)
@@ -388,7 +440,10 @@ func (s *service) FetchTagged(tctx thrift.Context, req *rpc.FetchTaggedRequest)
 		return nil, tterrors.NewBadRequestError(err)
 	}
 
+	querySpan := span.Tracer().StartSpan(indexQuerySpanName,
+		opentracing.ChildOf(span.Context()))
 	queryResult, err := s.db.QueryIDs(ctx, ns, query, opts)
+	tracing.FinishSpan(querySpan, err)
 	if err != nil {
 		s.metrics.fetchTagged.ReportError(s.nowFn().Sub(callStart))
 		return nil, tterrors.NewInternalError(err)
@@ -399,6 +454,12 @@ func (s *service) FetchTagged(tctx thrift.Context, req *rpc.FetchTaggedRequest)
 	}
 	results := queryResult.Results
 	nsID := results.Namespace()
+	span.SetTag("series", results.Size())
+	span.SetTag("exhaustive", queryResult.Exhaustive)
+
+	// NB: reads carry the span so that retrieving flushed blocks from disk
+	// is traced as a child of the request.
+	readCtx := tracing.ContextWithSpan(ctx, span)
 	tagsIter := ident.NewTagsIterator(ident.Tags{})
 	for _, entry := range results.Map().Iter() {
 		tsID := entry.Key()
@@ -421,7 +482,7 @@ func (s *service) FetchTagged(tctx thrift.Context, req *rpc.FetchTaggedRequest)
 		if !fetchData {
 			continue
 		}
-		segments, rpcErr := s.readEncoded(ctx, nsID, tsID, opts.StartInclusive, opts.EndExclusive)
+		segments, rpcErr := s.readEncoded(readCtx, nsID, tsID, opts.StartInclusive, opts.EndExclusive)
 		if rpcErr != nil {
 			elem.Err = rpcErr
 			continue
@@ -860,6 +921,13 @@ func (s *service) Write(tctx thrift.Context, req *rpc.WriteRequest) error {
 }
 
 func (s *service) WriteTagged(tctx thrift.Context, req *rpc.WriteTaggedRequest) error {
+	span := tracing.StartSpanFromHeaders(tctx.Headers(), writeTaggedSpanName)
+	err := s.writeTagged(tctx, req)
+	tracing.FinishSpan(span, err)
+	return err
+}
+
+func (s *service) writeTagged(tctx thrift.Context, req *rpc.WriteTaggedRequest) error {
 	callStart := s.nowFn()
 	ctx := tchannelthrift.Context(tctx)
 
@@ -973,6 +1041,14 @@ func (s *service) WriteBatchRaw(tctx thrift.Context, req *rpc.WriteBatchRawReque
 }
 
 func (s *service) WriteTaggedBatchRaw(tctx thrift.Context, req *rpc.WriteTaggedBatchRawRequest) error {
+	span := tracing.StartSpanFromHeaders(tctx.Headers(), writeTaggedBatchRawSpanName)
+	span.SetTag("writes", len(req.Elements))
+	err := s.writeTaggedBatchRaw(tctx, req)
+	tracing.FinishSpan(span, err)
+	return err
+}
+
+func (s *service) writeTaggedBatchRaw(tctx thrift.Context, req *rpc.WriteTaggedBatchRawRequest) error {
 	callStart := s.nowFn()
 	ctx := tchannelthrift.Context(tctx)
 
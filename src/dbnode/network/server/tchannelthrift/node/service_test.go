@@ -74,6 +74,7 @@ func TestServiceHealth(t *testing.T) {
 
 	// Assert bootstrapped false
 	mockDB.EXPECT().IsBootstrapped().Return(false)
+	mockDB.EXPECT().BootstrapState().Return(storage.DatabaseBootstrapState{})
 
 	tctx, _ := thrift.NewContext(time.Minute)
 	result, err := service.Health(tctx)
@@ -82,9 +83,25 @@ func TestServiceHealth(t *testing.T) {
 	assert.Equal(t, true, result.Ok)
 	assert.Equal(t, "up", result.Status)
 	assert.Equal(t, false, result.Bootstrapped)
+	assert.Equal(t, []int32{}, result.BootstrappedShards)
 
-	// Assert bootstrapped true
+	// Assert bootstrapped true, only shards bootstrapped for every namespace
+	// are reported as bootstrapped
 	mockDB.EXPECT().IsBootstrapped().Return(true)
+	mockDB.EXPECT().BootstrapState().Return(storage.DatabaseBootstrapState{
+		NamespaceBootstrapStates: storage.NamespaceBootstrapStates{
+			"a": storage.ShardBootstrapStates{
+				0: storage.Bootstrapped,
+				1: storage.Bootstrapped,
+				2: storage.Bootstrapped,
+			},
+			"b": storage.ShardBootstrapStates{
+				0: storage.Bootstrapped,
+				1: storage.Bootstrapping,
+				2: storage.Bootstrapped,
+			},
+		},
+	})
 
 	tctx, _ = thrift.NewContext(time.Minute)
 	result, err = service.Health(tctx)
@@ -93,6 +110,7 @@ func TestServiceHealth(t *testing.T) {
 	assert.Equal(t, true, result.Ok)
 	assert.Equal(t, "up", result.Status)
 	assert.Equal(t, true, result.Bootstrapped)
+	assert.Equal(t, []int32{0, 2}, result.BootstrappedShards)
 }
 
 func TestServiceQuery(t *testing.T) {
@@ -28,6 +28,7 @@ import (
 	"github.com/m3db/m3/src/dbnode/clock"
 	"github.com/m3db/m3/src/dbnode/ts"
 	"github.com/m3db/m3x/context"
+	"github.com/m3db/m3x/instrument"
 	xlog "github.com/m3db/m3x/log"
 	xtime "github.com/m3db/m3x/time"
 
@@ -72,10 +73,12 @@ type commitLog struct {
 	commitLogFailFn      commitLogFailFn
 	writer               commitLogWriter
 
-	// TODO(r): replace buffered channel with concurrent striped
-	// circular buffer to avoid central write lock contention
 	writes chan commitLogWrite
 
+	// openMutex is shared by the stripes of a striped commit log to
+	// serialize selecting the index of the next commit log file
+	openMutex *sync.Mutex
+
 	flushMutex      sync.RWMutex
 	lastFlushAt     time.Time
 	pendingFlushFns []completionFn
@@ -115,24 +118,39 @@ type commitLogWrite struct {
 	completionFn completionFn
 }
 
-// NewCommitLog creates a new commit log
+// NewCommitLog creates a new commit log, when configured with more than one
+// stripe writes are spread across stripes each with their own backlog queue,
+// writer and commit log file
 func NewCommitLog(opts Options) (CommitLog, error) {
 	if err := opts.Validate(); err != nil {
 		return nil, err
 	}
 	iopts := opts.InstrumentOptions().SetMetricsScope(
 		opts.InstrumentOptions().MetricsScope().SubScope("commitlog"))
-	scope := iopts.MetricsScope()
 
+	if opts.NumStripes() > 1 {
+		return newStripedCommitLog(opts, iopts), nil
+	}
+	return newCommitLog(opts, iopts, iopts.MetricsScope(), nil), nil
+}
+
+func newCommitLog(
+	opts Options,
+	iopts instrument.Options,
+	queueScope tally.Scope,
+	openMutex *sync.Mutex,
+) *commitLog {
+	scope := iopts.MetricsScope()
 	commitLog := &commitLog{
 		opts:                 opts,
 		nowFn:                opts.ClockOptions().NowFn(),
 		log:                  iopts.Logger(),
 		newCommitLogWriterFn: newCommitLogWriter,
 		writes:               make(chan commitLogWrite, opts.BacklogQueueSize()),
+		openMutex:            openMutex,
 		closeErr:             make(chan error),
 		metrics: commitLogMetrics{
-			queued:      scope.Gauge("writes.queued"),
+			queued:      queueScope.Gauge("writes.queued"),
 			success:     scope.Counter("writes.success"),
 			errors:      scope.Counter("writes.errors"),
 			openErrors:  scope.Counter("writes.open-errors"),
@@ -149,7 +167,7 @@ func NewCommitLog(opts Options) (CommitLog, error) {
 		commitLog.writeFn = commitLog.writeBehind
 	}
 
-	return commitLog, nil
+	return commitLog
 }
 
 func (l *commitLog) Open() error {
@@ -322,7 +340,14 @@ func (l *commitLog) openWriter(now time.Time) error {
 	blockSize := l.opts.BlockSize()
 	start := now.Truncate(blockSize)
 
-	if err := l.writer.Open(start, blockSize); err != nil {
+	if l.openMutex != nil {
+		l.openMutex.Lock()
+	}
+	err := l.writer.Open(start, blockSize)
+	if l.openMutex != nil {
+		l.openMutex.Unlock()
+	}
+	if err != nil {
 		return err
 	}
 
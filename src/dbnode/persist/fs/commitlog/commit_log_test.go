@@ -296,9 +296,9 @@ type seriesTestWritesAndReadPosition struct {
 	readPosition int
 }
 
-func assertCommitLogWritesByIterating(t *testing.T, l *commitLog, writes []testWrite) {
+func assertCommitLogWritesByIterating(t *testing.T, opts Options, writes []testWrite) {
 	iterOpts := IteratorOpts{
-		CommitLogOptions:      l.opts,
+		CommitLogOptions:      opts,
 		FileFilterPredicate:   ReadAllPredicate(),
 		SeriesFilterPredicate: ReadAllSeriesPredicate(),
 	}
@@ -365,7 +365,7 @@ func TestCommitLogWrite(t *testing.T) {
 	require.NoError(t, commitLog.Close())
 
 	// Assert writes occurred by reading the commit log
-	assertCommitLogWritesByIterating(t, commitLog, writes)
+	assertCommitLogWritesByIterating(t, commitLog.opts, writes)
 }
 
 func TestReadCommitLogMissingMetadata(t *testing.T) {
@@ -460,7 +460,7 @@ func TestCommitLogReaderIsNotReusable(t *testing.T) {
 	require.NoError(t, commitLog.Close())
 
 	// Assert writes occurred by reading the commit log
-	assertCommitLogWritesByIterating(t, commitLog, writes)
+	assertCommitLogWritesByIterating(t, commitLog.opts, writes)
 
 	// Assert commitlog file exists and retrieve path
 	fsopts := opts.FilesystemOptions()
@@ -553,7 +553,70 @@ func TestCommitLogWriteBehind(t *testing.T) {
 	require.NoError(t, commitLog.Close())
 
 	// Assert writes occurred by reading the commit log
-	assertCommitLogWritesByIterating(t, commitLog, writes)
+	assertCommitLogWritesByIterating(t, commitLog.opts, writes)
+}
+
+func TestStripedCommitLogWriteWait(t *testing.T) {
+	opts, scope := newTestOptions(t, overrides{
+		strategy: StrategyWriteWait,
+	})
+	defer cleanup(t, opts)
+
+	numStripes := 4
+	opts = opts.SetNumStripes(numStripes)
+
+	commitLogI, err := NewCommitLog(opts)
+	require.NoError(t, err)
+	commitLog := commitLogI.(*stripedCommitLog)
+	require.NoError(t, commitLog.Open())
+
+	// Ensure a file is present per stripe
+	files, err := Files(opts)
+	require.NoError(t, err)
+	require.Equal(t, numStripes, len(files))
+	for i, f := range files {
+		require.Equal(t, int64(i), f.Index)
+	}
+
+	var writes []testWrite
+	for i := 0; i < 3; i++ {
+		writes = append(writes,
+			testWrite{testSeries(0, "foo.bar", testTags1, 127), time.Now(), float64(i), xtime.Millisecond, nil, nil},
+			testWrite{testSeries(1, "foo.baz", testTags2, 150), time.Now(), float64(i), xtime.Millisecond, nil, nil},
+			testWrite{testSeries(2, "foo.qux", testTags3, 291), time.Now(), float64(i), xtime.Millisecond, nil, nil},
+			testWrite{testSeries(5, "foo.quux", testTags1, 127), time.Now(), float64(i), xtime.Millisecond, nil, nil},
+		)
+	}
+
+	// Call write sync
+	writeCommitLogs(t, scope, commitLog, writes).Wait()
+
+	gauges := scope.Snapshot().Gauges()
+	for i := 0; i < numStripes; i++ {
+		_, ok := gauges[fmt.Sprintf("commitlog.writes.queued+stripe=%d", i)]
+		require.True(t, ok)
+	}
+
+	// Close the commit log and consequently flush
+	require.NoError(t, commitLog.Close())
+
+	// Assert writes occurred by reading the merged stripes
+	assertCommitLogWritesByIterating(t, opts, writes)
+
+	iter, err := NewIterator(IteratorOpts{
+		CommitLogOptions:      opts,
+		FileFilterPredicate:   ReadAllPredicate(),
+		SeriesFilterPredicate: ReadAllSeriesPredicate(),
+	})
+	require.NoError(t, err)
+	defer iter.Close()
+
+	read := 0
+	for iter.Next() {
+		read++
+	}
+	require.NoError(t, iter.Err())
+	require.Equal(t, len(writes), read)
 }
 
 func TestCommitLogWriteErrorOnClosed(t *testing.T) {
@@ -613,7 +676,7 @@ func TestCommitLogWriteErrorOnFull(t *testing.T) {
 	require.NoError(t, commitLog.Close())
 
 	// Assert write flushed by reading the commit log
-	assertCommitLogWritesByIterating(t, commitLog, writes)
+	assertCommitLogWritesByIterating(t, commitLog.opts, writes)
 }
 
 func TestCommitLogExpiresWriter(t *testing.T) {
@@ -657,7 +720,7 @@ func TestCommitLogExpiresWriter(t *testing.T) {
 	require.NoError(t, commitLog.Close())
 
 	// Assert write flushed by reading the commit log
-	assertCommitLogWritesByIterating(t, commitLog, writes)
+	assertCommitLogWritesByIterating(t, commitLog.opts, writes)
 }
 
 func TestCommitLogFailOnWriteError(t *testing.T) {
@@ -104,7 +104,10 @@ func Files(opts Options) ([]File, error) {
 	}
 
 	sort.Slice(commitLogFiles, func(i, j int) bool {
-		return commitLogFiles[i].Start.Before(commitLogFiles[j].Start)
+		if !commitLogFiles[i].Start.Equal(commitLogFiles[j].Start) {
+			return commitLogFiles[i].Start.Before(commitLogFiles[j].Start)
+		}
+		return commitLogFiles[i].Index < commitLogFiles[j].Index
 	})
 
 	return commitLogFiles, nil
@@ -25,6 +25,7 @@ import (
 	"io"
 
 	"github.com/m3db/m3/src/dbnode/ts"
+	xerrors "github.com/m3db/m3x/errors"
 	xlog "github.com/m3db/m3x/log"
 	xtime "github.com/m3db/m3x/time"
 
@@ -41,13 +42,19 @@ type iteratorMetrics struct {
 	readsErrors tally.Counter
 }
 
+// iterator reads all the commit log files for a block start concurrently,
+// since a striped commit log writes one file per stripe for each block start,
+// and merges their entries ordered by datapoint timestamp. Entries from a
+// single file are always returned in the order they were written so writes
+// for a series, which are always assigned the same stripe, retain their order.
 type iterator struct {
 	opts       Options
 	scope      tally.Scope
 	metrics    iteratorMetrics
 	log        xlog.Logger
 	files      []File
-	reader     commitLogReader
+	readers    []iteratorReader
+	current    int
 	read       iteratorRead
 	err        error
 	seriesPred SeriesFilterPredicate
@@ -55,6 +62,11 @@ type iterator struct {
 	closed     bool
 }
 
+type iteratorReader struct {
+	reader commitLogReader
+	read   iteratorRead
+}
+
 type iteratorRead struct {
 	series     Series
 	datapoint  ts.Datapoint
@@ -74,9 +86,13 @@ func NewIterator(iterOpts IteratorOpts) (Iterator, error) {
 	iops := opts.InstrumentOptions()
 	iops = iops.SetMetricsScope(iops.MetricsScope().SubScope("iterator"))
 
-	files, err := Files(opts)
-	if err != nil {
-		return nil, err
+	files := iterOpts.Files
+	if files == nil {
+		var err error
+		files, err = Files(opts)
+		if err != nil {
+			return nil, err
+		}
 	}
 	filteredFiles := filterFiles(opts, files, iterOpts.FileFilterPredicate)
 
@@ -89,6 +105,7 @@ func NewIterator(iterOpts IteratorOpts) (Iterator, error) {
 		},
 		log:        iops.Logger(),
 		files:      filteredFiles,
+		current:    -1,
 		seriesPred: iterOpts.SeriesFilterPredicate,
 	}, nil
 }
@@ -97,32 +114,31 @@ func (i *iterator) Next() bool {
 	if i.hasError() || i.closed {
 		return false
 	}
-	if i.reader == nil {
-		if !i.nextReader() {
+	if i.current >= 0 {
+		// Advance the reader of the previously returned read
+		idx := i.current
+		i.current = -1
+		if !i.advance(idx) {
 			return false
 		}
 	}
-	var err error
-	i.read.series, i.read.datapoint, i.read.unit, i.read.annotation, err = i.reader.Read()
-	if err == io.EOF {
-		closeErr := i.closeAndResetReader()
-		if closeErr != nil {
-			i.err = closeErr
+	for len(i.readers) == 0 {
+		if !i.nextReaders() {
+			return false
 		}
-		// Try the next reader
-		return i.Next()
 	}
-	if err != nil {
-		// Try the next reader, this enables restoring with best effort from commit logs
-		i.metrics.readsErrors.Inc(1)
-		i.log.Errorf("commit log reader returned error, iterator moving to next file: %v", err)
-		i.err = err
-		closeErr := i.closeAndResetReader()
-		if closeErr != nil {
-			i.err = closeErr
+
+	// Select the earliest read, ties are broken by file order
+	earliest := 0
+	for idx := 1; idx < len(i.readers); idx++ {
+		t := i.readers[idx].read.datapoint.Timestamp
+		if t.Before(i.readers[earliest].read.datapoint.Timestamp) {
+			earliest = idx
 		}
-		return i.Next()
 	}
+
+	i.current = earliest
+	i.read = i.readers[earliest].read
 	i.setRead = true
 	return true
 }
@@ -145,49 +161,91 @@ func (i *iterator) Close() {
 		return
 	}
 	i.closed = true
-	i.closeAndResetReader()
+	i.closeAndResetReaders()
 }
 
 func (i *iterator) hasError() bool {
 	return i.err != nil
 }
 
-func (i *iterator) nextReader() bool {
+// nextReaders opens readers for all the remaining files sharing the start
+// of the next file and reads their first entries.
+func (i *iterator) nextReaders() bool {
 	if len(i.files) == 0 {
 		return false
 	}
 
-	err := i.closeAndResetReader()
+	err := i.closeAndResetReaders()
 	if err != nil {
 		i.err = err
 		return false
 	}
 
-	file := i.files[0]
-	i.files = i.files[1:]
+	n := 1
+	for n < len(i.files) && i.files[n].Start.Equal(i.files[0].Start) {
+		n++
+	}
+	files := i.files[:n]
+	i.files = i.files[n:]
 
-	t, idx := file.Start, file.Index
-	reader := newCommitLogReader(i.opts, i.seriesPred)
-	start, duration, index, err := reader.Open(file.FilePath)
-	if err != nil {
-		i.err = err
-		return false
+	for _, file := range files {
+		t, idx := file.Start, file.Index
+		reader := newCommitLogReader(i.opts, i.seriesPred)
+		start, duration, index, err := reader.Open(file.FilePath)
+		if err != nil {
+			i.err = err
+			return false
+		}
+		// Track the reader immediately so it is closed if any checks fail
+		i.readers = append(i.readers, iteratorReader{reader: reader})
+		if !t.Equal(start) {
+			i.err = errStartDoesNotMatch
+			return false
+		}
+		if duration != i.opts.BlockSize() {
+			i.err = errDurationDoesNotMatch
+			return false
+		}
+		if index != idx {
+			i.err = errIndexDoesNotMatch
+			return false
+		}
 	}
-	if !t.Equal(start) {
-		i.err = errStartDoesNotMatch
-		return false
+
+	// Read the first entry of each reader, advance removes readers of
+	// files with no entries so iterate from the back
+	for idx := len(i.readers) - 1; idx >= 0; idx-- {
+		if !i.advance(idx) {
+			return false
+		}
 	}
-	if duration != i.opts.BlockSize() {
-		i.err = errDurationDoesNotMatch
-		return false
+	return true
+}
+
+// advance reads the next entry of a reader, closing and removing the reader
+// once it has been exhausted.
+func (i *iterator) advance(idx int) bool {
+	var (
+		r   = &i.readers[idx]
+		err error
+	)
+	r.read.series, r.read.datapoint, r.read.unit, r.read.annotation, err = r.reader.Read()
+	if err == nil {
+		return true
 	}
-	if index != idx {
-		i.err = errIndexDoesNotMatch
-		return false
+
+	if err != io.EOF {
+		i.metrics.readsErrors.Inc(1)
+		i.log.Errorf("commit log reader returned error, iterator stopping: %v", err)
+		i.err = err
 	}
 
-	i.reader = reader
-	return true
+	closeErr := r.reader.Close()
+	i.readers = append(i.readers[:idx], i.readers[idx+1:]...)
+	if closeErr != nil {
+		i.err = closeErr
+	}
+	return !i.hasError()
 }
 
 func filterFiles(opts Options, files []File, predicate FileFilterPredicate) []File {
@@ -200,11 +258,12 @@ func filterFiles(opts Options, files []File, predicate FileFilterPredicate) []Fi
 	return filteredFiles
 }
 
-func (i *iterator) closeAndResetReader() error {
-	if i.reader == nil {
-		return nil
+func (i *iterator) closeAndResetReaders() error {
+	var multiErr xerrors.MultiError
+	for _, r := range i.readers {
+		multiErr = multiErr.Add(r.reader.Close())
 	}
-	reader := i.reader
-	i.reader = nil
-	return reader.Close()
+	i.readers = i.readers[:0]
+	i.current = -1
+	return multiErr.FinalError()
 }
@@ -47,6 +47,9 @@ const (
 
 	// defaultReadConcurrency is the default read concurrency
 	defaultReadConcurrency = 4
+
+	// defaultNumStripes is the default number of commit log stripes
+	defaultNumStripes = 1
 )
 
 var (
@@ -58,6 +61,7 @@ var (
 	errFlushIntervalNonNegative = errors.New("flush interval must be non-negative")
 	errBlockSizePositive        = errors.New("block size must be a positive duration")
 	errReadConcurrencyPositive  = errors.New("read concurrency must be a positive integer")
+	errNumStripesPositive       = errors.New("number of stripes must be a positive integer")
 )
 
 type options struct {
@@ -69,6 +73,7 @@ type options struct {
 	flushSize        int
 	flushInterval    time.Duration
 	backlogQueueSize int
+	numStripes       int
 	bytesPool        pool.CheckedBytesPool
 	identPool        ident.Pool
 	readConcurrency  int
@@ -85,6 +90,7 @@ func NewOptions() Options {
 		flushSize:        defaultFlushSize,
 		flushInterval:    defaultFlushInterval,
 		backlogQueueSize: defaultBacklogQueueSize,
+		numStripes:       defaultNumStripes,
 		bytesPool: pool.NewCheckedBytesPool(nil, nil, func(s []pool.Bucket) pool.BytesPool {
 			return pool.NewBytesPool(s, nil)
 		}),
@@ -105,6 +111,9 @@ func (o *options) Validate() error {
 	if o.ReadConcurrency() <= 0 {
 		return errReadConcurrencyPositive
 	}
+	if o.NumStripes() <= 0 {
+		return errNumStripesPositive
+	}
 	return nil
 }
 
@@ -188,6 +197,16 @@ func (o *options) BacklogQueueSize() int {
 	return o.backlogQueueSize
 }
 
+func (o *options) SetNumStripes(value int) Options {
+	opts := *o
+	opts.numStripes = value
+	return &opts
+}
+
+func (o *options) NumStripes() int {
+	return o.numStripes
+}
+
 func (o *options) SetBytesPool(value pool.CheckedBytesPool) Options {
 	opts := *o
 	opts.bytesPool = value
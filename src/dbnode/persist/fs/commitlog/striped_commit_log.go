@@ -0,0 +1,90 @@
+// Copyright (c) 2018 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package commitlog
+
+import (
+	"strconv"
+	"sync"
+
+	"github.com/m3db/m3/src/dbnode/ts"
+	"github.com/m3db/m3x/context"
+	xerrors "github.com/m3db/m3x/errors"
+	"github.com/m3db/m3x/instrument"
+	xtime "github.com/m3db/m3x/time"
+)
+
+// stripedCommitLog spreads writes across a set of commit logs each with their
+// own backlog queue, writer goroutine and commit log file to avoid contending
+// on a single queue. Writes for a series are always assigned the same stripe
+// so that they are written in order to a single file.
+type stripedCommitLog struct {
+	stripes []*commitLog
+}
+
+func newStripedCommitLog(opts Options, iopts instrument.Options) *stripedCommitLog {
+	var (
+		numStripes = opts.NumStripes()
+		queueSize  = (opts.BacklogQueueSize() + numStripes - 1) / numStripes
+		stripeOpts = opts.SetBacklogQueueSize(queueSize)
+		scope      = iopts.MetricsScope()
+		openMutex  sync.Mutex
+		l          = &stripedCommitLog{stripes: make([]*commitLog, 0, numStripes)}
+	)
+	for i := 0; i < numStripes; i++ {
+		queueScope := scope.Tagged(map[string]string{
+			"stripe": strconv.Itoa(i),
+		})
+		stripe := newCommitLog(stripeOpts, iopts, queueScope, &openMutex)
+		l.stripes = append(l.stripes, stripe)
+	}
+	return l
+}
+
+func (l *stripedCommitLog) Open() error {
+	for i, stripe := range l.stripes {
+		if err := stripe.Open(); err != nil {
+			for _, opened := range l.stripes[:i] {
+				opened.Close()
+			}
+			return err
+		}
+	}
+	return nil
+}
+
+func (l *stripedCommitLog) Write(
+	ctx context.Context,
+	series Series,
+	datapoint ts.Datapoint,
+	unit xtime.Unit,
+	annotation ts.Annotation,
+) error {
+	stripe := l.stripes[series.UniqueIndex%uint64(len(l.stripes))]
+	return stripe.Write(ctx, series, datapoint, unit, annotation)
+}
+
+func (l *stripedCommitLog) Close() error {
+	var multiErr xerrors.MultiError
+	for _, stripe := range l.stripes {
+		multiErr = multiErr.Add(stripe.Close())
+	}
+	return multiErr.FinalError()
+}
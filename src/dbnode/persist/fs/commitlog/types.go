@@ -86,6 +86,10 @@ type IteratorOpts struct {
 	CommitLogOptions      Options
 	FileFilterPredicate   FileFilterPredicate
 	SeriesFilterPredicate SeriesFilterPredicate
+
+	// Files are the commit log files to read, if nil the commit log files
+	// on disk are listed and read.
+	Files []File
 }
 
 // Series describes a series in the commit log
@@ -159,6 +163,14 @@ type Options interface {
 	// BacklogQueueSize returns the backlog queue size
 	BacklogQueueSize() int
 
+	// SetNumStripes sets the number of stripes writes are spread across, each
+	// stripe has its own backlog queue, writer and commit log file, the
+	// backlog queue size is divided evenly between the stripes
+	SetNumStripes(value int) Options
+
+	// NumStripes returns the number of stripes writes are spread across
+	NumStripes() int
+
 	// SetBytesPool sets the checked bytes pool
 	SetBytesPool(value pool.CheckedBytesPool) Options
 
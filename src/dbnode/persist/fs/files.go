@@ -49,6 +49,7 @@ const (
 	indexDirName      = "index"
 	snapshotDirName   = "snapshots"
 	commitLogsDirName = "commitlogs"
+	quarantineDirName = "quarantine"
 
 	commitLogComponentPosition    = 2
 	indexFileSetComponentPosition = 2
@@ -569,6 +570,11 @@ func ReadInfoFiles(
 		func(filepath string, id FileSetFileIdentifier, data []byte) {
 			decoder.Reset(msgpack.NewDecoderStream(data))
 			info, err := decoder.DecodeIndexInfo()
+			if err != nil {
+				// The info file passed digest validation so failing to
+				// decode it means its contents are corrupt
+				err = NewCorruptFileSetError(err)
+			}
 			infoFileResults = append(infoFileResults, ReadInfoFileResult{
 				Info: info,
 				Err: readInfoFileResultError{
@@ -721,6 +727,51 @@ func DeleteFileSetAt(filePathPrefix string, namespace ident.ID, shard uint32, t
 	return DeleteFiles(fileset.AbsoluteFilepaths)
 }
 
+// QuarantineFileSetAt moves all data fileset files for a given namespace/shard/blockStart
+// combination into the quarantine directory, whether or not the fileset is complete, and
+// returns the paths of the moved files. The checkpoint file is moved first so that a
+// partially moved fileset is never considered complete.
+func QuarantineFileSetAt(
+	filePathPrefix string,
+	newDirectoryMode os.FileMode,
+	namespace ident.ID,
+	shard uint32,
+	blockStart time.Time,
+) ([]string, error) {
+	shardDir := ShardDataDirPath(filePathPrefix, namespace, shard)
+	matched, err := filepath.Glob(path.Join(shardDir, filesetFileForTime(blockStart, anyLowerCaseCharsPattern)))
+	if err != nil {
+		return nil, err
+	}
+	if len(matched) == 0 {
+		return nil, fmt.Errorf("fileset for blockStart: %d does not exist", blockStart.Unix())
+	}
+
+	checkpointPath := filesetPathFromTime(shardDir, blockStart, checkpointFileSuffix)
+	for i := range matched {
+		if matched[i] == checkpointPath {
+			matched[0], matched[i] = matched[i], matched[0]
+			break
+		}
+	}
+
+	quarantineDir := ShardQuarantineDirPath(filePathPrefix, namespace, shard)
+	if err := os.MkdirAll(quarantineDir, newDirectoryMode); err != nil {
+		return nil, err
+	}
+
+	moved := make([]string, 0, len(matched))
+	for _, filePath := range matched {
+		quarantinePath := path.Join(quarantineDir, filepath.Base(filePath))
+		if err := os.Rename(filePath, quarantinePath); err != nil {
+			return moved, err
+		}
+		moved = append(moved, quarantinePath)
+	}
+
+	return moved, nil
+}
+
 // DataFileSetsBefore returns all the flush data fileset files whose timestamps are earlier than a given time.
 func DataFileSetsBefore(filePathPrefix string, namespace ident.ID, shard uint32, t time.Time) ([]string, error) {
 	matched, err := filesetFiles(filesetFilesSelector{
@@ -1026,6 +1077,16 @@ func ShardSnapshotsDirPath(prefix string, namespace ident.ID, shard uint32) stri
 	return path.Join(namespacePath, strconv.Itoa(int(shard)))
 }
 
+// QuarantineDirPath returns the path to the quarantine directory belonging to a db
+func QuarantineDirPath(prefix string) string {
+	return path.Join(prefix, quarantineDirName)
+}
+
+// ShardQuarantineDirPath returns the path to the quarantine directory for a given shard.
+func ShardQuarantineDirPath(prefix string, namespace ident.ID, shard uint32) string {
+	return path.Join(prefix, quarantineDirName, dataDirName, namespace.String(), strconv.Itoa(int(shard)))
+}
+
 // CommitLogsDirPath returns the path to commit logs.
 func CommitLogsDirPath(prefix string) string {
 	return path.Join(prefix, commitLogsDirName)
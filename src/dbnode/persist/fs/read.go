@@ -50,6 +50,34 @@ var (
 	errReadNotExpectedSize = errors.New("next read not expected size")
 )
 
+// CorruptFileSetError is returned when the contents of a fileset fail digest
+// validation or cannot be decoded, as opposed to the files being missing or
+// failing to be read.
+type CorruptFileSetError struct {
+	err error
+}
+
+// NewCorruptFileSetError returns a new error marking a fileset as corrupt.
+func NewCorruptFileSetError(err error) error {
+	return CorruptFileSetError{err: err}
+}
+
+func (e CorruptFileSetError) Error() string {
+	return e.err.Error()
+}
+
+// InnerError returns the error that caused the fileset to be deemed corrupt.
+func (e CorruptFileSetError) InnerError() error {
+	return e.err
+}
+
+// IsCorruptFileSetError returns whether an error indicates that the contents
+// of a fileset are corrupt.
+func IsCorruptFileSetError(err error) bool {
+	_, ok := err.(CorruptFileSetError)
+	return ok
+}
+
 type reader struct {
 	opts          Options
 	hugePagesOpts mmap.HugeTLBOptions
@@ -276,7 +304,7 @@ func (r *reader) readDigest() error {
 
 	err = r.digestFdWithDigestContents.Validate(r.expectedDigestOfDigest)
 	if err != nil {
-		return err
+		return NewCorruptFileSetError(err)
 	}
 
 	// Note that we skip over the summaries file digest here which is available,
@@ -298,7 +326,7 @@ func (r *reader) readInfo(size int) error {
 	r.decoder.Reset(msgpack.NewDecoderStream(buf[:n]))
 	info, err := r.decoder.DecodeIndexInfo()
 	if err != nil {
-		return err
+		return NewCorruptFileSetError(err)
 	}
 	r.start = xtime.FromNanoseconds(info.BlockStart)
 	r.blockSize = time.Duration(info.BlockSize)
@@ -314,7 +342,7 @@ func (r *reader) readIndexAndSortByOffsetAsc() error {
 	for i := 0; i < r.entries; i++ {
 		entry, err := r.decoder.DecodeIndexEntry()
 		if err != nil {
-			return err
+			return NewCorruptFileSetError(err)
 		}
 		r.indexEntriesByOffsetAsc = append(r.indexEntriesByOffsetAsc, entry)
 	}
@@ -286,7 +286,7 @@ func TestReadNoCheckpointFile(t *testing.T) {
 	require.Equal(t, ErrCheckpointFileNotFound, err)
 }
 
-func testReadOpen(t *testing.T, fileData map[string][]byte) {
+func testReadOpen(t *testing.T, fileData map[string][]byte) error {
 	filePathPrefix := createTempDir(t)
 	defer os.RemoveAll(filePathPrefix)
 
@@ -328,11 +328,13 @@ func testReadOpen(t *testing.T, fileData map[string][]byte) {
 			BlockStart: time.Unix(1000, 0),
 		},
 	}
-	require.Error(t, r.Open(rOpenOpts))
+	err := r.Open(rOpenOpts)
+	require.Error(t, err)
+	return err
 }
 
 func TestReadOpenDigestOfDigestMismatch(t *testing.T) {
-	testReadOpen(
+	err := testReadOpen(
 		t,
 		map[string][]byte{
 			infoFileSuffix:       []byte{0x1},
@@ -342,6 +344,7 @@ func TestReadOpenDigestOfDigestMismatch(t *testing.T) {
 			checkpointFileSuffix: []byte{0x12, 0x0, 0x7a, 0x0},
 		},
 	)
+	assert.True(t, IsCorruptFileSetError(err))
 }
 
 func TestReadOpenInfoDigestMismatch(t *testing.T) {
@@ -42,11 +42,14 @@ import (
 	"github.com/m3db/m3/src/dbnode/storage/namespace"
 	"github.com/m3db/m3/src/dbnode/ts"
 	"github.com/m3db/m3/src/dbnode/x/xio"
+	"github.com/m3db/m3/src/x/tracing"
 	"github.com/m3db/m3x/checked"
 	"github.com/m3db/m3x/context"
 	"github.com/m3db/m3x/ident"
 	"github.com/m3db/m3x/log"
 	"github.com/m3db/m3x/pool"
+
+	opentracing "github.com/opentracing/opentracing-go"
 )
 
 var (
@@ -57,6 +60,8 @@ var (
 )
 
 const (
+	retrieveSpanName = "fs.blockRetriever.retrieve"
+
 	defaultRetrieveRequestQueueCapacity = 4096
 )
 
@@ -273,6 +278,7 @@ func (r *blockRetriever) fetchBatch(
 	// Sort the requests by offset into the file before seeking
 	// to ensure all seeks are in ascending order
 	for _, req := range reqs {
+		req.startSpan(shard, blockStart)
 		entry, err := seeker.SeekIndexEntry(req.id)
 		if err != nil && err != errSeekIDNotFound {
 			req.onError(err)
@@ -374,6 +380,7 @@ func (r *blockRetriever) Stream(
 	req.blockSize = r.blockSize
 
 	req.onRetrieve = onRetrieve
+	req.parentSpan = tracing.SpanFromContext(ctx)
 	req.resultWg.Add(1)
 
 	// Ensure to finalize at the end of request
@@ -524,6 +531,11 @@ type retrieveRequest struct {
 	indexEntry IndexEntry
 	reader     xio.SegmentReader
 
+	// parentSpan is the span of the request the block is retrieved for, if
+	// traced, and span the span of retrieving the block from disk.
+	parentSpan opentracing.Span
+	span       opentracing.Span
+
 	err error
 
 	// Finalize requires two calls to finalize (once both the user of the
@@ -535,12 +547,33 @@ type retrieveRequest struct {
 	notFound bool
 }
 
+func (req *retrieveRequest) startSpan(shard uint32, blockStart time.Time) {
+	if req.parentSpan == nil {
+		return
+	}
+	req.span = req.parentSpan.Tracer().StartSpan(retrieveSpanName,
+		opentracing.ChildOf(req.parentSpan.Context()))
+	req.span.SetTag("shard", shard)
+	req.span.SetTag("blockStart", blockStart.Unix())
+}
+
+func (req *retrieveRequest) finishSpan(err error) {
+	if req.span == nil {
+		return
+	}
+	req.span.SetTag("notFound", req.notFound)
+	tracing.FinishSpan(req.span, err)
+	req.span = nil
+}
+
 func (req *retrieveRequest) onError(err error) {
+	req.finishSpan(err)
 	req.err = err
 	req.resultWg.Done()
 }
 
 func (req *retrieveRequest) onRetrieved(segment ts.Segment) {
+	req.finishSpan(nil)
 	req.Reset(segment)
 }
 
@@ -623,6 +656,8 @@ func (req *retrieveRequest) resetForReuse() {
 	req.onRetrieve = nil
 	req.indexEntry = IndexEntry{}
 	req.reader = nil
+	req.parentSpan = nil
+	req.span = nil
 	req.err = nil
 	req.notFound = false
 }
@@ -0,0 +1,105 @@
+// Copyright (c) 2018 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package fs
+
+import (
+	"fmt"
+
+	"github.com/m3db/m3/src/dbnode/digest"
+)
+
+// VerifyResult is the outcome of verifying a single data fileset.
+type VerifyResult struct {
+	// Entries is the number of entries the fileset info file declares.
+	Entries int
+	// EntriesRead is the number of entries successfully read from the data file.
+	EntriesRead int
+	// ChecksumMismatches contains the IDs of entries whose data does not match
+	// the checksum stored in the index.
+	ChecksumMismatches []string
+	// BloomFilterMisses contains the IDs of entries that are missing from the
+	// bloom filter.
+	BloomFilterMisses []string
+	// ReadErr is set if the fileset could not be read in full.
+	ReadErr error
+	// ValidateErr is set if the file digests did not match.
+	ValidateErr error
+}
+
+// Err returns an error describing the first problem found with the fileset,
+// or nil if the fileset is intact.
+func (r VerifyResult) Err() error {
+	if r.ReadErr != nil {
+		return r.ReadErr
+	}
+	if r.ValidateErr != nil {
+		return r.ValidateErr
+	}
+	if n := len(r.ChecksumMismatches); n > 0 {
+		return fmt.Errorf("%d entries failed checksum verification, first: %s",
+			n, r.ChecksumMismatches[0])
+	}
+	if n := len(r.BloomFilterMisses); n > 0 {
+		return fmt.Errorf("%d entries missing from bloom filter, first: %s",
+			n, r.BloomFilterMisses[0])
+	}
+	return nil
+}
+
+// VerifyDataFileSet reads every entry of an opened data fileset, checking each
+// entry's data against its stored checksum and the bloom filter, and then
+// validates the digests of all the fileset files. The caller is responsible
+// for opening the reader before and closing it after verification.
+func VerifyDataFileSet(reader DataFileSetReader) VerifyResult {
+	result := VerifyResult{Entries: reader.Entries()}
+
+	bloomFilter, err := reader.ReadBloomFilter()
+	if err != nil {
+		result.ReadErr = fmt.Errorf("unable to read bloom filter: %v", err)
+		return result
+	}
+	defer bloomFilter.Close()
+
+	for i := 0; i < result.Entries; i++ {
+		id, tags, data, checksum, err := reader.Read()
+		if err != nil {
+			result.ReadErr = fmt.Errorf("unable to read entry %d: %v", i, err)
+			return result
+		}
+
+		data.IncRef()
+		if digest.Checksum(data.Bytes()) != checksum {
+			result.ChecksumMismatches = append(result.ChecksumMismatches, id.String())
+		}
+		if !bloomFilter.Test(id.Bytes()) {
+			result.BloomFilterMisses = append(result.BloomFilterMisses, id.String())
+		}
+		data.DecRef()
+		data.Finalize()
+		id.Finalize()
+		tags.Close()
+
+		result.EntriesRead++
+	}
+
+	result.ValidateErr = reader.Validate()
+	return result
+}
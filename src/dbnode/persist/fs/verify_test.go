@@ -0,0 +1,127 @@
+// Copyright (c) 2018 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package fs
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/m3db/m3/src/dbnode/persist"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var verifyTestEntries = []testEntry{
+	{"foo", nil, []byte{1, 2, 3}},
+	{"bar", nil, []byte{4, 5, 6}},
+	{"baz", map[string]string{"qux": "qaz"}, []byte{7, 8, 9}},
+}
+
+func openVerifyTestReader(t *testing.T, filePathPrefix string) DataFileSetReader {
+	r := newTestReader(t, filePathPrefix)
+	err := r.Open(DataReaderOpenOptions{
+		Identifier: FileSetFileIdentifier{
+			Namespace:  testNs1ID,
+			Shard:      0,
+			BlockStart: testWriterStart,
+		},
+	})
+	require.NoError(t, err)
+	return r
+}
+
+func TestVerifyDataFileSet(t *testing.T) {
+	dir := createTempDir(t)
+	filePathPrefix := filepath.Join(dir, "")
+	defer os.RemoveAll(dir)
+
+	w := newTestWriter(t, filePathPrefix)
+	writeTestData(t, w, 0, testWriterStart, verifyTestEntries, persist.FileSetFlushType)
+
+	r := openVerifyTestReader(t, filePathPrefix)
+	defer r.Close()
+
+	result := VerifyDataFileSet(r)
+	require.NoError(t, result.Err())
+	assert.Equal(t, len(verifyTestEntries), result.Entries)
+	assert.Equal(t, len(verifyTestEntries), result.EntriesRead)
+}
+
+func TestVerifyDataFileSetCorruptData(t *testing.T) {
+	dir := createTempDir(t)
+	filePathPrefix := filepath.Join(dir, "")
+	defer os.RemoveAll(dir)
+
+	w := newTestWriter(t, filePathPrefix)
+	writeTestData(t, w, 0, testWriterStart, verifyTestEntries, persist.FileSetFlushType)
+
+	// Flip a byte in the data file without touching the index
+	shardDir := ShardDataDirPath(filePathPrefix, testNs1ID, 0)
+	dataFilePath := filesetPathFromTime(shardDir, testWriterStart, dataFileSuffix)
+	data, err := ioutil.ReadFile(dataFilePath)
+	require.NoError(t, err)
+	data[len(data)-1] ^= 0xff
+	require.NoError(t, ioutil.WriteFile(dataFilePath, data, defaultNewFileMode))
+
+	r := openVerifyTestReader(t, filePathPrefix)
+	defer r.Close()
+
+	result := VerifyDataFileSet(r)
+	require.Error(t, result.Err())
+	assert.Equal(t, len(verifyTestEntries), result.EntriesRead)
+	assert.Equal(t, 1, len(result.ChecksumMismatches))
+	assert.Error(t, result.ValidateErr)
+}
+
+func TestQuarantineFileSetAt(t *testing.T) {
+	dir := createTempDir(t)
+	filePathPrefix := filepath.Join(dir, "")
+	defer os.RemoveAll(dir)
+
+	w := newTestWriter(t, filePathPrefix)
+	writeTestData(t, w, 0, testWriterStart, verifyTestEntries, persist.FileSetFlushType)
+
+	exists, err := DataFileSetExistsAt(filePathPrefix, testNs1ID, 0, testWriterStart)
+	require.NoError(t, err)
+	require.True(t, exists)
+
+	moved, err := QuarantineFileSetAt(filePathPrefix, defaultNewDirectoryMode,
+		testNs1ID, 0, testWriterStart)
+	require.NoError(t, err)
+	require.Equal(t, 7, len(moved))
+
+	quarantineDir := ShardQuarantineDirPath(filePathPrefix, testNs1ID, 0)
+	assert.Equal(t, filesetPathFromTime(quarantineDir, testWriterStart, checkpointFileSuffix), moved[0])
+	for _, p := range moved {
+		require.True(t, mustFileExists(t, p))
+	}
+
+	exists, err = DataFileSetExistsAt(filePathPrefix, testNs1ID, 0, testWriterStart)
+	require.NoError(t, err)
+	require.False(t, exists)
+
+	_, err = QuarantineFileSetAt(filePathPrefix, defaultNewDirectoryMode,
+		testNs1ID, 0, testWriterStart)
+	require.Error(t, err)
+}
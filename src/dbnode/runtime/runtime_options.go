@@ -50,6 +50,8 @@ const (
 	defaultTickPerSeriesSleepDuration           = 100 * time.Microsecond
 	defaultTickMinimumInterval                  = time.Minute
 	defaultMaxWiredBlocks                       = uint(1 << 18) // 262,144
+	defaultMaxWiredBytes                        = uint64(0)
+	defaultPostingsListCacheSize                = 0
 )
 
 var (
@@ -61,6 +63,8 @@ var (
 		"tick series batch size must be positive")
 	errTickPerSeriesSleepDurationMustBePositive = errors.New(
 		"tick per series sleep duration must be positive")
+	errPostingsListCacheSizeIsNegative = errors.New(
+		"postings list cache size cannot be negative")
 )
 
 type options struct {
@@ -72,10 +76,12 @@ type options struct {
 	tickPerSeriesSleepDuration           time.Duration
 	tickMinimumInterval                  time.Duration
 	maxWiredBlocks                       uint
+	maxWiredBytes                        uint64
 	clientBootstrapConsistencyLevel      topology.ReadConsistencyLevel
 	clientReadConsistencyLevel           topology.ReadConsistencyLevel
 	clientWriteConsistencyLevel          topology.ConsistencyLevel
 	flushIndexBlockNumSegments           uint
+	postingsListCacheSize                int
 }
 
 // NewOptions creates a new set of runtime options with defaults
@@ -89,10 +95,12 @@ func NewOptions() Options {
 		tickPerSeriesSleepDuration:           defaultTickPerSeriesSleepDuration,
 		tickMinimumInterval:                  defaultTickMinimumInterval,
 		maxWiredBlocks:                       defaultMaxWiredBlocks,
+		maxWiredBytes:                        defaultMaxWiredBytes,
 		clientBootstrapConsistencyLevel:      DefaultBootstrapConsistencyLevel,
 		clientReadConsistencyLevel:           DefaultReadConsistencyLevel,
 		clientWriteConsistencyLevel:          DefaultWriteConsistencyLevel,
 		flushIndexBlockNumSegments:           DefaultFlushIndexBlockNumSegments,
+		postingsListCacheSize:                defaultPostingsListCacheSize,
 	}
 }
 
@@ -118,6 +126,11 @@ func (o *options) Validate() error {
 
 	// tickMinimumInterval can be zero if user desires
 
+	// postingsListCacheSize can be zero to disable the cache
+	if o.postingsListCacheSize < 0 {
+		return errPostingsListCacheSizeIsNegative
+	}
+
 	return nil
 }
 
@@ -201,6 +214,16 @@ func (o *options) MaxWiredBlocks() uint {
 	return o.maxWiredBlocks
 }
 
+func (o *options) SetMaxWiredBytes(value uint64) Options {
+	opts := *o
+	opts.maxWiredBytes = value
+	return &opts
+}
+
+func (o *options) MaxWiredBytes() uint64 {
+	return o.maxWiredBytes
+}
+
 func (o *options) SetClientBootstrapConsistencyLevel(value topology.ReadConsistencyLevel) Options {
 	opts := *o
 	opts.clientBootstrapConsistencyLevel = value
@@ -240,3 +263,13 @@ func (o *options) SetFlushIndexBlockNumSegments(value uint) Options {
 func (o *options) FlushIndexBlockNumSegments() uint {
 	return o.flushIndexBlockNumSegments
 }
+
+func (o *options) SetPostingsListCacheSize(value int) Options {
+	opts := *o
+	opts.postingsListCacheSize = value
+	return &opts
+}
+
+func (o *options) PostingsListCacheSize() int {
+	return o.postingsListCacheSize
+}
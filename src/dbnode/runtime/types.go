@@ -131,6 +131,16 @@ type Options interface {
 	// can also not be unwired. This means that the limit is best effort.
 	MaxWiredBlocks() uint
 
+	// SetMaxWiredBytes sets the max bytes of blocks to keep wired; zero is
+	// used to specify no limit. As with the max wired blocks this only bounds
+	// blocks cached by the wired list and as such is best effort.
+	SetMaxWiredBytes(value uint64) Options
+
+	// MaxWiredBytes returns the max bytes of blocks to keep wired; zero is
+	// used to specify no limit. As with the max wired blocks this only bounds
+	// blocks cached by the wired list and as such is best effort.
+	MaxWiredBytes() uint64
+
 	// SetClientBootstrapConsistencyLevel sets the client bootstrap
 	// consistency level used when bootstrapping from peers. Setting this
 	// will take effect immediately, and as such can be used to finish a
@@ -172,6 +182,14 @@ type Options interface {
 	// greater amount of segments that need to be searched independently but
 	// a higher number reduces the memory pressure when flushing an index block.
 	FlushIndexBlockNumSegments() uint
+
+	// SetPostingsListCacheSize sets the maximum number of postings lists held
+	// by the index postings list cache, zero disables the cache.
+	SetPostingsListCacheSize(value int) Options
+
+	// PostingsListCacheSize returns the maximum number of postings lists held
+	// by the index postings list cache, zero disables the cache.
+	PostingsListCacheSize() int
 }
 
 // OptionsManager updates and supplies runtime options.
@@ -21,6 +21,7 @@
 package server
 
 import (
+	"crypto/tls"
 	"fmt"
 	"io"
 	"math"
@@ -38,6 +39,7 @@ import (
 	"github.com/m3db/m3/src/dbnode/encoding/m3tsz"
 	"github.com/m3db/m3/src/dbnode/environment"
 	"github.com/m3db/m3/src/dbnode/kvconfig"
+	"github.com/m3db/m3/src/dbnode/network/server/httpjson"
 	hjcluster "github.com/m3db/m3/src/dbnode/network/server/httpjson/cluster"
 	hjnode "github.com/m3db/m3/src/dbnode/network/server/httpjson/node"
 	"github.com/m3db/m3/src/dbnode/network/server/tchannelthrift"
@@ -53,6 +55,7 @@ import (
 	"github.com/m3db/m3/src/dbnode/storage/block"
 	"github.com/m3db/m3/src/dbnode/storage/cluster"
 	"github.com/m3db/m3/src/dbnode/storage/index"
+	"github.com/m3db/m3/src/dbnode/storage/memory"
 	"github.com/m3db/m3/src/dbnode/storage/namespace"
 	"github.com/m3db/m3/src/dbnode/storage/repair"
 	"github.com/m3db/m3/src/dbnode/storage/series"
@@ -142,6 +145,10 @@ func Run(runOpts RunOptions) {
 		logger.Fatalf("could not connect to metrics: %v", err)
 	}
 
+	if _, err := cfg.Tracing.InitGlobalTracer(); err != nil {
+		logger.Fatalf("could not create tracer: %v", err)
+	}
+
 	hostID, err := cfg.HostID.Resolve()
 	if err != nil {
 		logger.Fatalf("could not resolve local host ID: %v", err)
@@ -221,8 +228,12 @@ func Run(runOpts RunOptions) {
 		SetWriteNewSeriesAsync(cfg.WriteNewSeriesAsync).
 		SetWriteNewSeriesBackoffDuration(cfg.WriteNewSeriesBackoffDuration)
 	if lruCfg := cfg.Cache.SeriesConfiguration().LRU; lruCfg != nil {
-		runtimeOpts = runtimeOpts.SetMaxWiredBlocks(lruCfg.MaxBlocks)
+		if lruCfg.MaxBlocks > 0 {
+			runtimeOpts = runtimeOpts.SetMaxWiredBlocks(lruCfg.MaxBlocks)
+		}
+		runtimeOpts = runtimeOpts.SetMaxWiredBytes(lruCfg.MaxBytes)
 	}
+	runtimeOpts = runtimeOpts.SetPostingsListCacheSize(cfg.Index.PostingsListCacheSize)
 
 	// FOLLOWUP(prateek): remove this once we have the runtime options<->index wiring done
 	indexOpts := opts.IndexOptions()
@@ -230,8 +241,22 @@ func Run(runOpts RunOptions) {
 	if cfg.WriteNewSeriesAsync {
 		insertMode = index.InsertAsync
 	}
-	opts = opts.SetIndexOptions(
-		indexOpts.SetInsertMode(insertMode))
+	indexOpts = indexOpts.SetInsertMode(insertMode)
+	// NB: the postings list cache is always created, even when disabled with
+	// a size of zero, so that it can be enabled at runtime with the postings
+	// list cache size KV key.
+	postingsListCache, err := index.NewPostingsListCache(
+		cfg.Index.PostingsListCacheSize, iopts)
+	if err != nil {
+		logger.Fatalf("could not create postings list cache: %v", err)
+	}
+	indexOpts = indexOpts.SetPostingsListCache(postingsListCache)
+	opts = opts.SetIndexOptions(indexOpts)
+
+	if budgetCfg := cfg.MemoryBudget; budgetCfg != nil {
+		budget := memory.NewBudget(int64(budgetCfg.MaxBytes), iopts)
+		opts = opts.SetMemoryBudget(budget)
+	}
 
 	if tick := cfg.Tick; tick != nil {
 		runtimeOpts = runtimeOpts.
@@ -246,6 +271,9 @@ func Run(runOpts RunOptions) {
 	}
 	defer runtimeOptsMgr.Close()
 
+	postingsListCacheCloser := runtimeOptsMgr.RegisterListener(postingsListCache)
+	defer postingsListCacheCloser.Close()
+
 	opts = opts.SetRuntimeOptionsManager(runtimeOptsMgr)
 
 	newFileMode, err := cfg.Filesystem.ParseNewFileMode()
@@ -319,6 +347,10 @@ func Run(runOpts RunOptions) {
 		SetFlushInterval(cfg.CommitLog.FlushEvery).
 		SetBacklogQueueSize(commitLogQueueSize).
 		SetBlockSize(cfg.CommitLog.BlockSize))
+	if cfg.CommitLog.NumStripes > 0 {
+		opts = opts.SetCommitLogOptions(opts.CommitLogOptions().
+			SetNumStripes(cfg.CommitLog.NumStripes))
+	}
 
 	// Set the series cache policy
 	seriesCachePolicy := cfg.Cache.SeriesConfiguration().Policy
@@ -435,6 +467,8 @@ func Run(runOpts RunOptions) {
 	clientAdminOpts := m3dbClient.Options().(client.AdminOptions)
 	kvWatchClientConsistencyLevels(envCfg.KVStore, logger,
 		clientAdminOpts, runtimeOptsMgr)
+	kvWatchPostingsListCacheSize(envCfg.KVStore, logger,
+		runtimeOptsMgr, cfg.Index.PostingsListCacheSize)
 
 	// Set bootstrap options
 	bs, err := cfg.Bootstrap.New(opts, m3dbClient)
@@ -510,9 +544,20 @@ func Run(runOpts RunOptions) {
 
 	contextPool := opts.ContextPool()
 
+	var tlsConfig *tls.Config
+	if cfg.TLS != nil {
+		certManager, err := cfg.TLS.NewCertificateManager(iopts)
+		if err != nil {
+			logger.Fatalf("could not create tls certificate manager: %v", err)
+		}
+		defer certManager.Close()
+		tlsConfig = certManager.ServerConfig()
+		logger.Infof("tls enabled, client cert auth: %v", cfg.TLS.ClientCertAuth)
+	}
+
 	tchannelOpts := xtchannel.NewDefaultChannelOptions()
 	tchannelthriftNodeClose, err := ttnode.NewServer(db,
-		cfg.ListenAddress, contextPool, tchannelOpts, ttopts).ListenAndServe()
+		cfg.ListenAddress, contextPool, tchannelOpts, tlsConfig, ttopts).ListenAndServe()
 	if err != nil {
 		logger.Fatalf("could not open tchannelthrift interface on %s: %v",
 			cfg.ListenAddress, err)
@@ -521,7 +566,7 @@ func Run(runOpts RunOptions) {
 	logger.Infof("node tchannelthrift: listening on %v", cfg.ListenAddress)
 
 	tchannelthriftClusterClose, err := ttcluster.NewServer(m3dbClient,
-		cfg.ClusterListenAddress, contextPool, tchannelOpts).ListenAndServe()
+		cfg.ClusterListenAddress, contextPool, tchannelOpts, tlsConfig).ListenAndServe()
 	if err != nil {
 		logger.Fatalf("could not open tchannelthrift interface on %s: %v",
 			cfg.ClusterListenAddress, err)
@@ -529,8 +574,9 @@ func Run(runOpts RunOptions) {
 	defer tchannelthriftClusterClose()
 	logger.Infof("cluster tchannelthrift: listening on %v", cfg.ClusterListenAddress)
 
+	httpjsonOpts := httpjson.NewServerOptions().SetTLSConfig(tlsConfig)
 	httpjsonNodeClose, err := hjnode.NewServer(db,
-		cfg.HTTPNodeListenAddress, contextPool, nil, ttopts).ListenAndServe()
+		cfg.HTTPNodeListenAddress, contextPool, httpjsonOpts, ttopts).ListenAndServe()
 	if err != nil {
 		logger.Fatalf("could not open httpjson interface on %s: %v",
 			cfg.HTTPNodeListenAddress, err)
@@ -539,7 +585,7 @@ func Run(runOpts RunOptions) {
 	logger.Infof("node httpjson: listening on %v", cfg.HTTPNodeListenAddress)
 
 	httpjsonClusterClose, err := hjcluster.NewServer(m3dbClient,
-		cfg.HTTPClusterListenAddress, contextPool, nil).ListenAndServe()
+		cfg.HTTPClusterListenAddress, contextPool, httpjsonOpts).ListenAndServe()
 	if err != nil {
 		logger.Fatalf("could not open httpjson interface on %s: %v",
 			cfg.HTTPClusterListenAddress, err)
@@ -746,6 +792,59 @@ func kvWatchClientConsistencyLevels(
 		})
 }
 
+func kvWatchPostingsListCacheSize(
+	store kv.Store,
+	logger xlog.Logger,
+	runtimeOptsMgr m3dbruntime.OptionsManager,
+	defaultSize int,
+) {
+	setSize := func(size int) error {
+		runtimeOpts := runtimeOptsMgr.Get()
+		if runtimeOpts.PostingsListCacheSize() == size {
+			// Not changed, no need to set the value and trigger a runtime options update
+			return nil
+		}
+		return runtimeOptsMgr.Update(runtimeOpts.SetPostingsListCacheSize(size))
+	}
+
+	value, err := store.Get(kvconfig.PostingsListCacheSizeKey)
+	if err == nil {
+		protoValue := &commonpb.Int64Proto{}
+		if err := value.Unmarshal(protoValue); err != nil {
+			logger.Warnf("unable to parse postings list cache size: %v", err)
+		} else if err := setSize(int(protoValue.Value)); err != nil {
+			logger.Warnf("unable to set postings list cache size: %v", err)
+		}
+	} else if err != kv.ErrNotFound {
+		logger.Warnf("error resolving postings list cache size: %v", err)
+	}
+
+	watch, err := store.Watch(kvconfig.PostingsListCacheSizeKey)
+	if err != nil {
+		logger.Errorf("could not watch postings list cache size: %v", err)
+		return
+	}
+
+	go func() {
+		protoValue := &commonpb.Int64Proto{}
+		for range watch.C() {
+			size := defaultSize
+			if newValue := watch.Get(); newValue != nil {
+				if err := newValue.Unmarshal(protoValue); err != nil {
+					logger.Warnf("unable to parse new postings list cache size: %v", err)
+					continue
+				}
+				size = int(protoValue.Value)
+			}
+
+			if err := setSize(size); err != nil {
+				logger.Warnf("unable to set postings list cache size: %v", err)
+				continue
+			}
+		}
+	}()
+}
+
 func kvWatchStringValue(
 	store kv.Store,
 	logger xlog.Logger,
@@ -1009,6 +1108,7 @@ func withEncodingAndPoolingOptions(
 		if lruCfg != nil && lruCfg.EventsChannelSize > 0 {
 			wiredListOpts.EventsChannelSize = int(lruCfg.EventsChannelSize)
 		}
+		wiredListOpts.MemoryBudget = opts.MemoryBudget()
 		wiredList := block.NewWiredList(wiredListOpts)
 		blockOpts = blockOpts.SetWiredList(wiredList)
 	}
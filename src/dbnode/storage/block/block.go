@@ -76,6 +76,7 @@ type listState struct {
 	next                  DatabaseBlock
 	prev                  DatabaseBlock
 	enteredListAtUnixNano int64
+	wiredBytes            int64
 }
 
 // NewDatabaseBlock creates a new DatabaseBlock instance.
@@ -497,6 +498,16 @@ func (b *dbBlock) setEnteredListAtUnixNano(value int64) {
 	b.listState.enteredListAtUnixNano = value
 }
 
+// Should only be used by the WiredList.
+func (b *dbBlock) wiredBytes() int64 {
+	return b.listState.wiredBytes
+}
+
+// Should only be used by the WiredList.
+func (b *dbBlock) setWiredBytes(value int64) {
+	b.listState.wiredBytes = value
+}
+
 // wiredListEntry is a snapshot of a subset of the block's state that the WiredList
 // uses to determine if a block is eligible for inclusion in the WiredList.
 type wiredListEntry struct {
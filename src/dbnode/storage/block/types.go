@@ -220,6 +220,8 @@ type databaseBlock interface {
 	setPrev(block DatabaseBlock)
 	enteredListAtUnixNano() int64
 	setEnteredListAtUnixNano(value int64)
+	wiredBytes() int64
+	setWiredBytes(value int64)
 	wiredListEntry() wiredListEntry
 }
 
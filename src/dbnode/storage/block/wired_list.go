@@ -57,6 +57,7 @@ import (
 
 	"github.com/m3db/m3/src/dbnode/clock"
 	"github.com/m3db/m3/src/dbnode/runtime"
+	"github.com/m3db/m3/src/dbnode/storage/memory"
 	"github.com/m3db/m3x/instrument"
 	xlog "github.com/m3db/m3x/log"
 
@@ -79,15 +80,21 @@ type WiredList struct {
 
 	nowFn clock.NowFn
 
-	// Max wired blocks, must use atomic store and load to access.
-	maxWired int64
+	// Max wired blocks and bytes, must use atomic store and load to access.
+	maxWired      int64
+	maxWiredBytes int64
 
 	root          dbBlock
 	length        int
+	bytes         int64
 	updatesChSize int
 	updatesCh     chan DatabaseBlock
+	evictCh       chan struct{}
 	doneCh        chan struct{}
 
+	budget         *memory.Budget
+	budgetReporter *memory.Reporter
+
 	metrics wiredListMetrics
 	iOpts   instrument.Options
 }
@@ -95,6 +102,8 @@ type WiredList struct {
 type wiredListMetrics struct {
 	unwireable           tally.Gauge
 	limit                tally.Gauge
+	unwireableBytes      tally.Gauge
+	limitBytes           tally.Gauge
 	evicted              tally.Counter
 	pushedBack           tally.Counter
 	inserted             tally.Counter
@@ -106,6 +115,9 @@ func newWiredListMetrics(scope tally.Scope) wiredListMetrics {
 		// Keeps track of how many blocks are in the list
 		unwireable: scope.Gauge("unwireable"),
 		limit:      scope.Gauge("limit"),
+		// Keeps track of how many bytes of blocks are in the list
+		unwireableBytes: scope.Gauge("unwireable-bytes"),
+		limitBytes:      scope.Gauge("limit-bytes"),
 		// Incremented when a block is evicted
 		evicted: scope.Counter("evicted"),
 		// Incremented when a block is "pushed back" in the list, I.E
@@ -125,6 +137,10 @@ type WiredListOptions struct {
 	InstrumentOptions     instrument.Options
 	ClockOptions          clock.Options
 	EventsChannelSize     int
+	// MemoryBudget is the optional node memory budget, when set the wired
+	// list reports its usage to the budget and evicts blocks while the
+	// budget is exceeded.
+	MemoryBudget *memory.Budget
 }
 
 // NewWiredList returns a new database block wired list.
@@ -135,6 +151,7 @@ func NewWiredList(opts WiredListOptions) *WiredList {
 		nowFn:   opts.ClockOptions.NowFn(),
 		metrics: newWiredListMetrics(scope),
 		iOpts:   opts.InstrumentOptions,
+		evictCh: make(chan struct{}, 1),
 	}
 	if opts.EventsChannelSize > 0 {
 		l.updatesChSize = opts.EventsChannelSize
@@ -143,6 +160,11 @@ func NewWiredList(opts WiredListOptions) *WiredList {
 	}
 	l.root.setNext(&l.root)
 	l.root.setPrev(&l.root)
+	if opts.MemoryBudget != nil {
+		l.budget = opts.MemoryBudget
+		l.budgetReporter = opts.MemoryBudget.NewReporter(memory.BlockCache)
+		opts.MemoryBudget.SetEvictor(l)
+	}
 	opts.RuntimeOptionsManager.RegisterListener(l)
 	return l
 }
@@ -151,6 +173,19 @@ func NewWiredList(opts WiredListOptions) *WiredList {
 // be consumed by the wired list
 func (l *WiredList) SetRuntimeOptions(value runtime.Options) {
 	atomic.StoreInt64(&l.maxWired, int64(value.MaxWiredBlocks()))
+	atomic.StoreInt64(&l.maxWiredBytes, int64(value.MaxWiredBytes()))
+	l.RequestEviction()
+}
+
+// RequestEviction asks the wired list to evict blocks until it is within
+// its limits and the memory budget, if any, is no longer exceeded. It does
+// not block and is a noop if an eviction is already pending.
+func (l *WiredList) RequestEviction() {
+	select {
+	case l.evictCh <- struct{}{}:
+	default:
+		// Eviction already pending
+	}
 }
 
 // Start starts processing the wired list
@@ -163,17 +198,31 @@ func (l *WiredList) Start() error {
 
 	l.updatesCh = make(chan DatabaseBlock, l.updatesChSize)
 	l.doneCh = make(chan struct{}, 1)
+	var (
+		updatesCh = l.updatesCh
+		doneCh    = l.doneCh
+	)
 	go func() {
 		i := 0
-		for v := range l.updatesCh {
-			l.processUpdateBlock(v)
+		for {
+			select {
+			case v, ok := <-updatesCh:
+				if !ok {
+					doneCh <- struct{}{}
+					return
+				}
+				l.processUpdateBlock(v)
+			case <-l.evictCh:
+				l.evict(&l.root)
+			}
 			if i%wiredListSampleGaugesEvery == 0 {
 				l.metrics.unwireable.Update(float64(l.length))
 				l.metrics.limit.Update(float64(atomic.LoadInt64(&l.maxWired)))
+				l.metrics.unwireableBytes.Update(float64(l.bytes))
+				l.metrics.limitBytes.Update(float64(atomic.LoadInt64(&l.maxWiredBytes)))
 			}
 			i++
 		}
-		l.doneCh <- struct{}{}
 	}()
 
 	return nil
@@ -248,8 +297,6 @@ func (l *WiredList) processUpdateBlock(v DatabaseBlock) {
 }
 
 func (l *WiredList) insertAfter(v, at DatabaseBlock) {
-	now := l.nowFn()
-
 	n := at.next()
 	at.setNext(v)
 	v.setPrev(at)
@@ -257,15 +304,34 @@ func (l *WiredList) insertAfter(v, at DatabaseBlock) {
 	n.setPrev(v)
 	l.length++
 
-	maxWired := int(atomic.LoadInt64(&l.maxWired))
-	if maxWired <= 0 {
-		// Not enforcing max wired blocks
-		return
+	size := int64(v.Len())
+	v.setWiredBytes(size)
+	l.addBytes(size)
+
+	// Never evict the block just inserted, it was accessed most recently.
+	l.evict(v)
+}
+
+// overLimits returns true if the wired list exceeds the max wired blocks,
+// the max wired bytes or the node memory budget.
+func (l *WiredList) overLimits() bool {
+	if maxWired := atomic.LoadInt64(&l.maxWired); maxWired > 0 && int64(l.length) > maxWired {
+		return true
 	}
+	if maxWiredBytes := atomic.LoadInt64(&l.maxWiredBytes); maxWiredBytes > 0 && l.bytes > maxWiredBytes {
+		return true
+	}
+	return l.budget != nil && l.budget.Excess() > 0
+}
+
+// evict unwires blocks from the front of the list, stopping at the given
+// block, until the wired list is no longer over its limits.
+func (l *WiredList) evict(stopAt DatabaseBlock) {
+	now := l.nowFn()
 
 	// Try to unwire all blocks possible
 	bl := l.root.next()
-	for l.length > maxWired && bl != &l.root {
+	for bl != stopAt && bl != &l.root && l.overLimits() {
 		entry := bl.wiredListEntry()
 		if !entry.wasRetrievedFromDisk {
 			// This should never happen because processUpdateBlock performs the same
@@ -320,6 +386,16 @@ func (l *WiredList) remove(v DatabaseBlock) {
 	v.setNext(nil) // avoid memory leaks
 	v.setPrev(nil) // avoid memory leaks
 	l.length--
+
+	l.addBytes(-v.wiredBytes())
+	v.setWiredBytes(0)
+}
+
+func (l *WiredList) addBytes(delta int64) {
+	l.bytes += delta
+	if l.budgetReporter != nil {
+		l.budgetReporter.Report(l.bytes)
+	}
 }
 
 func (l *WiredList) pushBack(v DatabaseBlock) {
@@ -28,6 +28,7 @@ import (
 
 	"github.com/m3db/m3/src/dbnode/clock"
 	"github.com/m3db/m3/src/dbnode/runtime"
+	"github.com/m3db/m3/src/dbnode/storage/memory"
 	"github.com/m3db/m3/src/dbnode/ts"
 	"github.com/m3db/m3x/checked"
 	"github.com/m3db/m3x/ident"
@@ -166,6 +167,86 @@ func TestWiredListRemovesUnwiredBlocks(t *testing.T) {
 	require.Equal(t, &l.root, l.root.prev())
 }
 
+func TestWiredListEvictsBlocksOverMaxWiredBytes(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	l, runtimeOptsMgr := newTestWiredList(nil, nil)
+	require.NoError(t, runtimeOptsMgr.Update(runtime.NewOptions().
+		SetMaxWiredBlocks(0).
+		SetMaxWiredBytes(10)))
+
+	opts := testOptions.SetWiredList(l)
+
+	l.Start()
+
+	var blocks []*dbBlock
+	for i := 0; i < 3; i++ {
+		// Each block is 5 bytes so only two fit within the limit.
+		bl := newTestUnwireableBlock(ctrl, fmt.Sprintf("foo.%d", i), opts)
+		blocks = append(blocks, bl)
+	}
+
+	l.BlockingUpdate(blocks[0])
+	l.BlockingUpdate(blocks[1])
+	l.BlockingUpdate(blocks[2])
+
+	l.Stop()
+
+	require.Equal(t, 2, l.length)
+	require.Equal(t, int64(10), l.bytes)
+	require.Equal(t, blocks[1], l.root.next())
+	require.Equal(t, blocks[2], l.root.next().next())
+	require.True(t, blocks[0].closed)
+}
+
+func TestWiredListEvictsBlocksWhenMemoryBudgetExceeded(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	var (
+		runtimeOptsMgr = runtime.NewOptionsManager()
+		iopts          = instrument.NewOptions()
+		budget         = memory.NewBudget(20, iopts)
+	)
+	l := NewWiredList(WiredListOptions{
+		RuntimeOptionsManager: runtimeOptsMgr,
+		InstrumentOptions:     iopts,
+		ClockOptions:          clock.NewOptions(),
+		EventsChannelSize:     1,
+		MemoryBudget:          budget,
+	})
+
+	opts := testOptions.SetWiredList(l)
+
+	var blocks []*dbBlock
+	for i := 0; i < 2; i++ {
+		bl := newTestUnwireableBlock(ctrl, fmt.Sprintf("foo.%d", i), opts)
+		blocks = append(blocks, bl)
+	}
+
+	l.Start()
+	l.BlockingUpdate(blocks[0])
+	l.BlockingUpdate(blocks[1])
+	l.Stop()
+
+	require.Equal(t, int64(10), budget.Usage(memory.BlockCache))
+
+	// Exceed the budget with another component so the cache has to make room.
+	l.Start()
+	budget.NewReporter(memory.SeriesBuffers).Report(15)
+	for budget.Excess() > 0 {
+		time.Sleep(time.Millisecond)
+	}
+	l.Stop()
+
+	require.Equal(t, 1, l.length)
+	require.Equal(t, blocks[1], l.root.next())
+	require.Equal(t, int64(5), budget.Usage(memory.BlockCache))
+	require.True(t, blocks[0].closed)
+	require.False(t, budget.Overloaded())
+}
+
 // wiredListTestWiredBlocksString is used to debug the order of the wired list
 func wiredListTestWiredBlocksString(l *WiredList) string { // nolint: unused
 	b := bytes.NewBuffer(nil)
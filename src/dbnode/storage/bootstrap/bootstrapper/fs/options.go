@@ -61,6 +61,7 @@ type options struct {
 	blockRetrieverManager       block.DatabaseBlockRetrieverManager
 	runtimeOptsMgr              runtime.OptionsManager
 	identifierPool              ident.Pool
+	quarantineCorruptFileSets   bool
 }
 
 // NewOptions creates new bootstrap options
@@ -179,3 +180,13 @@ func (o *options) SetIdentifierPool(value ident.Pool) Options {
 func (o *options) IdentifierPool() ident.Pool {
 	return o.identifierPool
 }
+
+func (o *options) SetQuarantineCorruptFileSets(value bool) Options {
+	opts := *o
+	opts.quarantineCorruptFileSets = value
+	return &opts
+}
+
+func (o *options) QuarantineCorruptFileSets() bool {
+	return o.quarantineCorruptFileSets
+}
@@ -80,6 +80,14 @@ type persistManager struct {
 type fileSystemSourceMetrics struct {
 	persistedIndexBlocksRead  tally.Counter
 	persistedIndexBlocksWrite tally.Counter
+	quarantinedFileSets       tally.Counter
+	quarantineErrors          tally.Counter
+}
+
+type corruptFileSet struct {
+	shard      uint32
+	blockStart time.Time
+	err        error
 }
 
 func newFileSystemSource(opts Options) bootstrap.Source {
@@ -107,6 +115,8 @@ func newFileSystemSource(opts Options) bootstrap.Source {
 		metrics: fileSystemSourceMetrics{
 			persistedIndexBlocksRead:  scope.Counter("persist-index-blocks-read"),
 			persistedIndexBlocksWrite: scope.Counter("persist-index-blocks-write"),
+			quarantinedFileSets:       scope.Counter("quarantined-filesets"),
+			quarantineErrors:          scope.Counter("quarantine-errors"),
 		},
 	}
 	s.newReaderPoolOpts.alloc = s.newReader
@@ -196,6 +206,12 @@ func (s *fileSystemSource) shardAvailability(
 				xlog.NewField("targetRangesForShard", targetRangesForShard),
 				xlog.NewField("filepath", result.Err.Filepath()),
 			).Error("unable to read info files in shardAvailability")
+			if !isCorruptFileSetErr(result.Err.Error()) {
+				continue
+			}
+			if blockStart, err := fs.TimeFromFileName(result.Err.Filepath()); err == nil {
+				s.quarantineFileSet(namespace, shard, blockStart, result.Err.Error())
+			}
 			continue
 		}
 		info := result.Info
@@ -353,6 +369,9 @@ func (s *fileSystemSource) newShardReaders(
 				xlog.NewField("error", err.Error()),
 			).Error("unable to open fileset files")
 			readerPool.put(r)
+			if isCorruptFileSetErr(err) {
+				s.quarantineFileSet(ns.ID(), shard, blockStart, err)
+			}
 			// Errors are marked unfulfilled by markRunResultErrorsAndUnfulfilled
 			// and will be re-attempted by the next bootstrapper
 			continue
@@ -490,6 +509,7 @@ func (s *fileSystemSource) loadShardReadersDataIntoShardResult(
 		seriesCachePolicy = ropts.SeriesCachePolicy()
 		indexBlockSegment segment.MutableSegment
 		timesWithErrors   []time.Time
+		corruptFileSets   []corruptFileSet
 		shardResult       result.ShardResult
 		shardRetriever    block.DatabaseShardBlockRetriever
 	)
@@ -523,6 +543,7 @@ func (s *fileSystemSource) loadShardReadersDataIntoShardResult(
 				start     = timeRange.Start
 				blockSize = ns.Options().RetentionOptions().BlockSize()
 				err       error
+				corrupt   bool
 			)
 			switch run {
 			case bootstrapDataRunType:
@@ -549,6 +570,9 @@ func (s *fileSystemSource) loadShardReadersDataIntoShardResult(
 					panic(fmt.Errorf("invalid run type: %d", run))
 				}
 			}
+			// Only failures to read or decode an entry mean the fileset is
+			// corrupt, others such as indexing failures are not
+			corrupt = isCorruptFileSetErr(err)
 
 			if err == nil {
 				// Validate the read results
@@ -571,6 +595,7 @@ func (s *fileSystemSource) loadShardReadersDataIntoShardResult(
 				}
 				if validateErr != nil {
 					err = fmt.Errorf("data validation failed: %v", validateErr)
+					corrupt = true
 				}
 			}
 
@@ -590,6 +615,13 @@ func (s *fileSystemSource) loadShardReadersDataIntoShardResult(
 			} else {
 				s.log.Errorf("%v", err)
 				timesWithErrors = append(timesWithErrors, timeRange.Start)
+				if corrupt {
+					corruptFileSets = append(corruptFileSets, corruptFileSet{
+						shard:      shard,
+						blockStart: start,
+						err:        err,
+					})
+				}
 			}
 		}
 	}
@@ -618,10 +650,60 @@ func (s *fileSystemSource) loadShardReadersDataIntoShardResult(
 		}
 	}
 
+	// Quarantine only once the readers are closed so the files are not
+	// moved from underneath an open reader
+	for _, fileSet := range corruptFileSets {
+		s.quarantineFileSet(ns.ID(), fileSet.shard, fileSet.blockStart, fileSet.err)
+	}
+
 	s.markRunResultErrorsAndUnfulfilled(runResult, requestedRanges,
 		remainingRanges, timesWithErrors)
 }
 
+// quarantineFileSet moves a corrupt data fileset out of the data directory
+// if quarantining is enabled so that the next bootstrapper can refill the
+// shard and block, and so that subsequent bootstraps do not trip over it again.
+func (s *fileSystemSource) quarantineFileSet(
+	namespace ident.ID,
+	shard uint32,
+	blockStart time.Time,
+	cause error,
+) {
+	if !s.opts.QuarantineCorruptFileSets() {
+		return
+	}
+
+	moved, err := fs.QuarantineFileSetAt(s.fsopts.FilePathPrefix(),
+		s.fsopts.NewDirectoryMode(), namespace, shard, blockStart)
+	if err != nil {
+		s.metrics.quarantineErrors.Inc(1)
+		s.log.WithFields(
+			xlog.NewField("namespace", namespace.String()),
+			xlog.NewField("shard", shard),
+			xlog.NewField("blockStart", blockStart.String()),
+			xlog.NewField("cause", cause.Error()),
+			xlog.NewField("error", err.Error()),
+		).Error("unable to quarantine corrupt fileset")
+		return
+	}
+
+	s.metrics.quarantinedFileSets.Inc(1)
+	s.log.WithFields(
+		xlog.NewField("namespace", namespace.String()),
+		xlog.NewField("shard", shard),
+		xlog.NewField("blockStart", blockStart.String()),
+		xlog.NewField("cause", cause.Error()),
+		xlog.NewField("files", moved),
+	).Warnf("quarantined corrupt fileset")
+}
+
+// isCorruptFileSetErr returns whether an error reading a fileset indicates
+// the fileset contents are corrupt, as opposed to the files being missing or
+// the filesystem or other resources being temporarily unavailable.
+func isCorruptFileSetErr(err error) bool {
+	return fs.IsCorruptFileSetError(err)
+}
+
 func (s *fileSystemSource) readNextEntryAndRecordBlock(
 	r fs.DataFileSetReader,
 	runResult *runResult,
@@ -647,10 +729,10 @@ func (s *fileSystemSource) readNextEntryAndRecordBlock(
 	case series.CacheAllMetadata:
 		id, tagsIter, length, checksum, err = r.ReadMetadata()
 	default:
-		err = fmt.Errorf("invalid series cache policy: %s", seriesCachePolicy.String())
+		return fmt.Errorf("invalid series cache policy: %s", seriesCachePolicy.String())
 	}
 	if err != nil {
-		return fmt.Errorf("error reading data file: %v", err)
+		return fs.NewCorruptFileSetError(fmt.Errorf("error reading data file: %v", err))
 	}
 
 	var (
@@ -672,7 +754,7 @@ func (s *fileSystemSource) readNextEntryAndRecordBlock(
 	} else {
 		tags, err = convert.TagsFromTagsIter(id, tagsIter, s.idPool)
 		if err != nil {
-			return fmt.Errorf("unable to decode tags: %v", err)
+			return fs.NewCorruptFileSetError(fmt.Errorf("unable to decode tags: %v", err))
 		}
 	}
 	tagsIter.Close()
@@ -708,7 +790,7 @@ func (s *fileSystemSource) readNextEntryAndIndex(
 	// If performing index run, then simply read the metadata and add to segment
 	id, tagsIter, _, _, err := r.ReadMetadata()
 	if err != nil {
-		return err
+		return fs.NewCorruptFileSetError(err)
 	}
 
 	// NB(r): Avoiding defer in the hot path here
@@ -735,7 +817,7 @@ func (s *fileSystemSource) readNextEntryAndIndex(
 	d, err := convert.FromMetricIter(id, tagsIter)
 	release()
 	if err != nil {
-		return err
+		return fs.NewCorruptFileSetError(err)
 	}
 
 	runResult.Lock()
@@ -433,6 +433,66 @@ func TestReadDataCorruptionError(t *testing.T) {
 	validateTimeRanges(t, res.Unfulfilled()[testShard], strs[testShard])
 }
 
+func TestReadDataCorruptionQuarantine(t *testing.T) {
+	dir := createTempDir(t)
+	defer os.RemoveAll(dir)
+
+	shard := uint32(0)
+	writeTSDBFiles(t, dir, testNs1ID, shard, testStart, []testSeries{
+		{"foo", nil, []byte{0x1}},
+	})
+	// Intentionally corrupt the data file
+	writeDataFile(t, dir, testNs1ID, shard, testStart, []byte{0x2})
+
+	src := newFileSystemSource(newTestOptions(dir).SetQuarantineCorruptFileSets(true))
+	strs := testShardTimeRanges()
+	res, err := src.ReadData(testNsMetadata(t), strs, testDefaultRunOpts)
+	require.NoError(t, err)
+	require.NotNil(t, res)
+	require.Equal(t, 0, len(res.ShardResults()))
+	require.Equal(t, 1, len(res.Unfulfilled()))
+	validateTimeRanges(t, res.Unfulfilled()[testShard], strs[testShard])
+
+	// Ensure the fileset was moved out of the data directory
+	exists, err := fs.DataFileSetExistsAt(dir, testNs1ID, shard, testStart)
+	require.NoError(t, err)
+	require.False(t, exists)
+
+	quarantined, err := ioutil.ReadDir(fs.ShardQuarantineDirPath(dir, testNs1ID, shard))
+	require.NoError(t, err)
+	require.True(t, len(quarantined) > 0)
+
+	// Ensure a subsequent bootstrap no longer sees the corrupt fileset
+	available := src.AvailableData(testNsMetadata(t), strs, testDefaultRunOpts)
+	require.True(t, available[testShard].IsEmpty())
+}
+
+func TestReadDataMissingFileNoQuarantine(t *testing.T) {
+	dir := createTempDir(t)
+	defer os.RemoveAll(dir)
+
+	shard := uint32(0)
+	writeTSDBFiles(t, dir, testNs1ID, shard, testStart, []testSeries{
+		{"foo", nil, []byte{0x1}},
+	})
+	// Failing to open the files does not mean the fileset is corrupt
+	shardDir := fs.ShardDataDirPath(dir, testNs1ID, shard)
+	dataFile := path.Join(shardDir, fmt.Sprintf("fileset-%d-data.db", xtime.ToNanoseconds(testStart)))
+	require.NoError(t, os.Remove(dataFile))
+
+	src := newFileSystemSource(newTestOptions(dir).SetQuarantineCorruptFileSets(true))
+	strs := testShardTimeRanges()
+	res, err := src.ReadData(testNsMetadata(t), strs, testDefaultRunOpts)
+	require.NoError(t, err)
+	require.NotNil(t, res)
+	require.Equal(t, 1, len(res.Unfulfilled()))
+	validateTimeRanges(t, res.Unfulfilled()[testShard], strs[testShard])
+
+	exists, err := fs.DataFileSetExistsAt(dir, testNs1ID, shard, testStart)
+	require.NoError(t, err)
+	require.True(t, exists)
+}
+
 func validateReadResults(
 	t *testing.T,
 	src bootstrap.Source,
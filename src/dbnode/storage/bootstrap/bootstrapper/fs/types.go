@@ -105,4 +105,14 @@ type Options interface {
 
 	// IdentifierPool returns the identifier pool.
 	IdentifierPool() ident.Pool
+
+	// SetQuarantineCorruptFileSets sets whether data filesets that fail to
+	// open, read or validate are moved into the quarantine directory so that
+	// subsequent bootstrappers can refill the affected shards and blocks.
+	SetQuarantineCorruptFileSets(value bool) Options
+
+	// QuarantineCorruptFileSets returns whether data filesets that fail to
+	// open, read or validate are moved into the quarantine directory so that
+	// subsequent bootstrappers can refill the affected shards and blocks.
+	QuarantineCorruptFileSets() bool
 }
@@ -37,6 +37,7 @@ import (
 	m3dberrors "github.com/m3db/m3/src/dbnode/storage/errors"
 	"github.com/m3db/m3/src/dbnode/storage/index"
 	"github.com/m3db/m3/src/dbnode/storage/index/convert"
+	"github.com/m3db/m3/src/dbnode/storage/memory"
 	"github.com/m3db/m3/src/dbnode/storage/namespace"
 	"github.com/m3db/m3/src/m3ninx/doc"
 	m3ninxindex "github.com/m3db/m3/src/m3ninx/index"
@@ -88,6 +89,7 @@ type nsIndex struct {
 	opts                Options
 	nsMetadata          namespace.Metadata
 	runtimeOptsListener xclose.SimpleCloser
+	memoryReporter      *memory.Reporter
 
 	metrics nsIndexMetrics
 }
@@ -234,6 +236,9 @@ func newNamespaceIndexWithOptions(
 	if runtimeOptsMgr != nil {
 		idx.runtimeOptsListener = runtimeOptsMgr.RegisterListener(idx)
 	}
+	if budget := newIndexOpts.opts.MemoryBudget(); budget != nil {
+		idx.memoryReporter = budget.NewReporter(memory.IndexMutableSegments)
+	}
 
 	// allocate indexing queue and start it up.
 	queue := newIndexQueueFn(idx.writeBatches, nowFn, scope)
@@ -487,6 +492,7 @@ func (i *nsIndex) Tick(c context.Cancellable, tickStart time.Time) (namespaceInd
 		multiErr = multiErr.Add(tickErr)
 		result.NumSegments += blockTickResult.NumSegments
 		result.NumTotalDocs += blockTickResult.NumDocs
+		result.MutableSegmentBytes += blockTickResult.MutableSegmentBytes
 
 		// seal any blocks that are sealable
 		if !blockStart.ToTime().After(lastSealableBlockStart) && !block.IsSealed() {
@@ -495,6 +501,10 @@ func (i *nsIndex) Tick(c context.Cancellable, tickStart time.Time) (namespaceInd
 		}
 	}
 
+	if i.memoryReporter != nil {
+		i.memoryReporter.Report(result.MutableSegmentBytes)
+	}
+
 	return result, multiErr.FinalError()
 }
 
@@ -928,6 +938,10 @@ func (i *nsIndex) Close() error {
 		i.runtimeOptsListener = nil
 	}
 
+	if i.memoryReporter != nil {
+		i.memoryReporter.Close()
+	}
+
 	return multiErr.FinalError()
 }
 
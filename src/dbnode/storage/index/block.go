@@ -28,8 +28,10 @@ import (
 
 	"github.com/m3db/m3/src/dbnode/storage/bootstrap/result"
 	"github.com/m3db/m3/src/dbnode/storage/namespace"
+	"github.com/m3db/m3/src/m3ninx/doc"
 	m3ninxindex "github.com/m3db/m3/src/m3ninx/index"
 	"github.com/m3db/m3/src/m3ninx/index/segment"
+	"github.com/m3db/m3/src/m3ninx/index/segment/fst"
 	"github.com/m3db/m3/src/m3ninx/index/segment/mem"
 	"github.com/m3db/m3/src/m3ninx/postings"
 	"github.com/m3db/m3/src/m3ninx/search"
@@ -68,6 +70,10 @@ type block struct {
 	activeSegment       segment.MutableSegment
 	shardRangesSegments []blockShardRangesSegments
 
+	// activeSegmentBytes is an estimate of the bytes held by the active
+	// segment, computed from the documents inserted into it.
+	activeSegmentBytes int64
+
 	newExecutorFn newExecutorFn
 	startTime     time.Time
 	endTime       time.Time
@@ -147,11 +153,15 @@ func (b *block) WriteBatch(inserts *WriteBatch) (WriteBatchResult, error) {
 		}, err
 	}
 
+	docs := inserts.PendingDocs()
 	err := b.activeSegment.InsertBatch(m3ninxindex.Batch{
-		Docs:                inserts.PendingDocs(),
+		Docs:                docs,
 		AllowPartialUpdates: true,
 	})
 	if err == nil {
+		for _, d := range docs {
+			b.activeSegmentBytes += estimatedDocBytes(d)
+		}
 		inserts.MarkUnmarkedEntriesSuccess()
 		return WriteBatchResult{
 			NumSuccess: int64(inserts.Len()),
@@ -167,9 +177,15 @@ func (b *block) WriteBatch(inserts *WriteBatch) (WriteBatchResult, error) {
 	}
 
 	numErr := len(partialErr.Errs())
+	for _, d := range docs {
+		b.activeSegmentBytes += estimatedDocBytes(d)
+	}
 	for _, err := range partialErr.Errs() {
 		// Avoid marking these as success
 		inserts.MarkUnmarkedEntryError(err.Err, err.Idx)
+		if err.Idx >= 0 && err.Idx < len(docs) {
+			b.activeSegmentBytes -= estimatedDocBytes(docs[err.Idx])
+		}
 	}
 
 	// mark all non-error inserts success, so we don't repeatedly index them
@@ -336,7 +352,7 @@ func (b *block) AddResults(
 
 	entry := blockShardRangesSegments{
 		shardTimeRanges: results.Fulfilled(),
-		segments:        results.Segments(),
+		segments:        b.withPostingsListCache(results.Segments()),
 	}
 
 	// First see if this block can cover all our current blocks covering shard
@@ -369,6 +385,24 @@ func (b *block) AddResults(
 	return multiErr.FinalError()
 }
 
+// withPostingsListCache wraps any immutable FST segments so that postings
+// lists resolved by queries against them are cached, the cache entries are
+// purged when the segments are replaced or the block is closed.
+func (b *block) withPostingsListCache(segments []segment.Segment) []segment.Segment {
+	cache := b.opts.PostingsListCache()
+	if cache == nil {
+		return segments
+	}
+	wrapped := make([]segment.Segment, 0, len(segments))
+	for _, seg := range segments {
+		if fstSeg, ok := seg.(fst.Segment); ok {
+			seg = NewReadThroughSegment(fstSeg, cache)
+		}
+		wrapped = append(wrapped, seg)
+	}
+	return wrapped
+}
+
 func (b *block) Tick(c context.Cancellable, tickStart time.Time) (BlockTickResult, error) {
 	b.RLock()
 	defer b.RUnlock()
@@ -381,6 +415,7 @@ func (b *block) Tick(c context.Cancellable, tickStart time.Time) (BlockTickResul
 	if b.activeSegment != nil {
 		result.NumSegments++
 		result.NumDocs += b.activeSegment.Size()
+		result.MutableSegmentBytes += b.activeSegmentBytes
 	}
 
 	// any other segments
@@ -471,6 +506,7 @@ func (b *block) EvictMutableSegments() (EvictMutableSegmentResults, error) {
 		results.NumDocs += b.activeSegment.Size()
 		multiErr = multiErr.Add(b.activeSegment.Close())
 		b.activeSegment = nil
+		b.activeSegmentBytes = 0
 	}
 
 	// close any other mutable segments too.
@@ -506,6 +542,7 @@ func (b *block) Close() error {
 	if b.activeSegment != nil {
 		multiErr = multiErr.Add(b.activeSegment.Close())
 		b.activeSegment = nil
+		b.activeSegmentBytes = 0
 	}
 
 	// close any other added segments too.
@@ -566,3 +603,14 @@ func (c *safeCloser) Close() error {
 	c.closed = true
 	return c.closable.Close()
 }
+
+// estimatedDocBytes estimates the bytes held by a mutable segment for a
+// document, the terms dictionary and postings lists are not accounted for
+// so this is a lower bound.
+func estimatedDocBytes(d doc.Document) int64 {
+	size := int64(len(d.ID))
+	for _, f := range d.Fields {
+		size += int64(len(f.Name) + len(f.Value))
+	}
+	return size
+}
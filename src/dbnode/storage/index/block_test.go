@@ -33,9 +33,11 @@ import (
 	"github.com/m3db/m3/src/m3ninx/idx"
 	"github.com/m3db/m3/src/m3ninx/index"
 	"github.com/m3db/m3/src/m3ninx/index/segment"
+	"github.com/m3db/m3/src/m3ninx/index/segment/fst"
 	"github.com/m3db/m3/src/m3ninx/index/segment/mem"
 	"github.com/m3db/m3/src/m3ninx/search"
 	"github.com/m3db/m3x/ident"
+	"github.com/m3db/m3x/instrument"
 	xtime "github.com/m3db/m3x/time"
 
 	"github.com/golang/mock/gomock"
@@ -259,6 +261,9 @@ func TestBlockWriteActualSegmentPartialFailure(t *testing.T) {
 	require.Equal(t, int64(1), res.NumSuccess)
 	require.Equal(t, int64(1), res.NumError)
 
+	// Only the successfully inserted document is accounted for.
+	require.Equal(t, estimatedDocBytes(testDoc1()), b.activeSegmentBytes)
+
 	verified := 0
 	batch.ForEach(func(
 		idx int,
@@ -861,6 +866,38 @@ func TestBlockAddResultsAddsSegment(t *testing.T) {
 	require.Equal(t, seg1, b.shardRangesSegments[0].segments[0])
 }
 
+func TestBlockAddResultsWrapsImmutableSegmentsWithPostingsListCache(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	cache, err := NewPostingsListCache(8, instrument.NewOptions())
+	require.NoError(t, err)
+
+	testMD := newTestNSMetadata(t)
+	start := time.Now().Truncate(time.Hour)
+	blk, err := NewBlock(start, testMD, testOpts.SetPostingsListCache(cache))
+	require.NoError(t, err)
+
+	b, ok := blk.(*block)
+	require.True(t, ok)
+
+	seg1 := segment.NewMockMutableSegment(ctrl)
+	seg2 := fst.NewMockSegment(ctrl)
+	require.NoError(t, b.AddResults(
+		result.NewIndexBlock(start, []segment.Segment{seg1, seg2},
+			result.NewShardTimeRanges(start, start.Add(time.Hour), 1, 2, 3))))
+	require.Equal(t, 1, len(b.shardRangesSegments))
+	require.Equal(t, seg1, b.shardRangesSegments[0].segments[0])
+
+	readThrough, ok := b.shardRangesSegments[0].segments[1].(*ReadThroughSegment)
+	require.True(t, ok)
+	require.Equal(t, seg2, readThrough.segment)
+
+	seg1.EXPECT().Close().Return(nil)
+	seg2.EXPECT().Close().Return(nil)
+	require.NoError(t, b.Close())
+}
+
 func TestBlockAddResultsAfterCloseFails(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
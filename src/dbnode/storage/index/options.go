@@ -50,6 +50,7 @@ type opts struct {
 	idPool         ident.Pool
 	bytesPool      pool.CheckedBytesPool
 	resultsPool    ResultsPool
+	postingsCache  *PostingsListCache
 }
 
 var undefinedUUIDFn = func() ([]byte, error) { return nil, errIDGenerationDisabled }
@@ -159,3 +160,13 @@ func (o *opts) SetResultsPool(value ResultsPool) Options {
 func (o *opts) ResultsPool() ResultsPool {
 	return o.resultsPool
 }
+
+func (o *opts) SetPostingsListCache(value *PostingsListCache) Options {
+	opts := *o
+	opts.postingsCache = value
+	return &opts
+}
+
+func (o *opts) PostingsListCache() *PostingsListCache {
+	return o.postingsCache
+}
@@ -0,0 +1,305 @@
+// Copyright (c) 2018 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package index
+
+import (
+	"container/list"
+	"errors"
+	"sync"
+
+	"github.com/m3db/m3/src/dbnode/runtime"
+	"github.com/m3db/m3/src/m3ninx/postings"
+	"github.com/m3db/m3x/instrument"
+
+	"github.com/uber-go/tally"
+)
+
+var (
+	errPostingsListCacheSizeNegative = errors.New("postings list cache size cannot be negative")
+)
+
+// PatternType is the type of query used to produce a cached postings list.
+type PatternType int
+
+const (
+	// PatternTypeTerm is an exact term match.
+	PatternTypeTerm PatternType = iota
+	// PatternTypeRegexp is a regular expression match.
+	PatternTypeRegexp
+	// PatternTypeField is a field existence match.
+	PatternTypeField
+	// PatternTypePrefix is a term prefix match.
+	PatternTypePrefix
+)
+
+func (t PatternType) String() string {
+	switch t {
+	case PatternTypeTerm:
+		return "term"
+	case PatternTypeRegexp:
+		return "regexp"
+	case PatternTypeField:
+		return "field"
+	case PatternTypePrefix:
+		return "prefix"
+	default:
+		return "unknown"
+	}
+}
+
+var patternTypes = []PatternType{
+	PatternTypeTerm,
+	PatternTypeRegexp,
+	PatternTypeField,
+	PatternTypePrefix,
+}
+
+type postingsListCacheKey struct {
+	segmentID   uint64
+	field       string
+	pattern     string
+	patternType PatternType
+}
+
+type postingsListCacheEntry struct {
+	key      postingsListCacheKey
+	postings postings.List
+}
+
+// PostingsListCache is a size bounded LRU cache of postings lists resolved
+// from immutable segments, keyed by the segment, field, pattern and the type
+// of query. Entries for a segment must be purged when the segment is closed
+// since the segment identifier is never reused.
+type PostingsListCache struct {
+	mu        sync.Mutex
+	size      int
+	lru       *list.List
+	entries   map[postingsListCacheKey]*list.Element
+	bySegment map[uint64]map[postingsListCacheKey]struct{}
+
+	metrics postingsListCacheMetrics
+}
+
+// NewPostingsListCache returns a new postings list cache holding at most
+// size postings lists, a size of zero disables caching.
+func NewPostingsListCache(
+	size int,
+	iopts instrument.Options,
+) (*PostingsListCache, error) {
+	if size < 0 {
+		return nil, errPostingsListCacheSizeNegative
+	}
+	return &PostingsListCache{
+		size:      size,
+		lru:       list.New(),
+		entries:   make(map[postingsListCacheKey]*list.Element),
+		bySegment: make(map[uint64]map[postingsListCacheKey]struct{}),
+		metrics:   newPostingsListCacheMetrics(iopts.MetricsScope().SubScope("postings-list-cache")),
+	}, nil
+}
+
+// Get returns the cached postings list for the given segment, field and
+// pattern if present.
+func (c *PostingsListCache) Get(
+	segmentID uint64,
+	field []byte,
+	pattern []byte,
+	patternType PatternType,
+) (postings.List, bool) {
+	key := newPostingsListCacheKey(segmentID, field, pattern, patternType)
+
+	c.mu.Lock()
+	var pl postings.List
+	elem, ok := c.entries[key]
+	if ok {
+		c.lru.MoveToFront(elem)
+		pl = elem.Value.(*postingsListCacheEntry).postings
+	}
+	c.mu.Unlock()
+
+	if !ok {
+		c.metrics.miss(patternType)
+		return nil, false
+	}
+	c.metrics.hit(patternType)
+	return pl, true
+}
+
+// Put caches the postings list for the given segment, field and pattern,
+// evicting the least recently used entries if the cache is full.
+func (c *PostingsListCache) Put(
+	segmentID uint64,
+	field []byte,
+	pattern []byte,
+	patternType PatternType,
+	pl postings.List,
+) {
+	key := newPostingsListCacheKey(segmentID, field, pattern, patternType)
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.size == 0 {
+		return
+	}
+
+	if elem, ok := c.entries[key]; ok {
+		elem.Value.(*postingsListCacheEntry).postings = pl
+		c.lru.MoveToFront(elem)
+		return
+	}
+
+	c.entries[key] = c.lru.PushFront(&postingsListCacheEntry{
+		key:      key,
+		postings: pl,
+	})
+	segmentKeys, ok := c.bySegment[segmentID]
+	if !ok {
+		segmentKeys = make(map[postingsListCacheKey]struct{})
+		c.bySegment[segmentID] = segmentKeys
+	}
+	segmentKeys[key] = struct{}{}
+
+	c.evictWithLock()
+}
+
+// PurgeSegment removes all cached postings lists for the given segment.
+func (c *PostingsListCache) PurgeSegment(segmentID uint64) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	segmentKeys, ok := c.bySegment[segmentID]
+	if !ok {
+		return
+	}
+	for key := range segmentKeys {
+		if elem, ok := c.entries[key]; ok {
+			c.lru.Remove(elem)
+			delete(c.entries, key)
+		}
+	}
+	delete(c.bySegment, segmentID)
+	c.metrics.purges.Inc(int64(len(segmentKeys)))
+}
+
+// SetSize updates the maximum number of postings lists held by the cache,
+// evicting the least recently used entries if the cache is now over size.
+func (c *PostingsListCache) SetSize(size int) error {
+	if size < 0 {
+		return errPostingsListCacheSizeNegative
+	}
+
+	c.setSize(size)
+	return nil
+}
+
+// Size returns the maximum number of postings lists held by the cache.
+func (c *PostingsListCache) Size() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.size
+}
+
+// Len returns the number of postings lists currently held by the cache.
+func (c *PostingsListCache) Len() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.lru.Len()
+}
+
+// SetRuntimeOptions updates the cache size from the runtime options.
+func (c *PostingsListCache) SetRuntimeOptions(value runtime.Options) {
+	// NB: runtime options are validated before being applied so the size
+	// can never be negative here.
+	c.setSize(value.PostingsListCacheSize())
+}
+
+func (c *PostingsListCache) setSize(size int) {
+	c.mu.Lock()
+	c.size = size
+	c.evictWithLock()
+	c.mu.Unlock()
+}
+
+func (c *PostingsListCache) evictWithLock() {
+	for c.lru.Len() > c.size {
+		elem := c.lru.Back()
+		entry := elem.Value.(*postingsListCacheEntry)
+		c.lru.Remove(elem)
+		delete(c.entries, entry.key)
+		if segmentKeys, ok := c.bySegment[entry.key.segmentID]; ok {
+			delete(segmentKeys, entry.key)
+			if len(segmentKeys) == 0 {
+				delete(c.bySegment, entry.key.segmentID)
+			}
+		}
+		c.metrics.evictions.Inc(1)
+	}
+}
+
+func newPostingsListCacheKey(
+	segmentID uint64,
+	field []byte,
+	pattern []byte,
+	patternType PatternType,
+) postingsListCacheKey {
+	return postingsListCacheKey{
+		segmentID:   segmentID,
+		field:       string(field),
+		pattern:     string(pattern),
+		patternType: patternType,
+	}
+}
+
+type postingsListCacheMetrics struct {
+	hits      map[PatternType]tally.Counter
+	misses    map[PatternType]tally.Counter
+	evictions tally.Counter
+	purges    tally.Counter
+}
+
+func newPostingsListCacheMetrics(scope tally.Scope) postingsListCacheMetrics {
+	m := postingsListCacheMetrics{
+		hits:      make(map[PatternType]tally.Counter, len(patternTypes)),
+		misses:    make(map[PatternType]tally.Counter, len(patternTypes)),
+		evictions: scope.Counter("evictions"),
+		purges:    scope.Counter("purges"),
+	}
+	for _, t := range patternTypes {
+		tagged := scope.Tagged(map[string]string{
+			"pattern_type": t.String(),
+		})
+		m.hits[t] = tagged.Counter("hits")
+		m.misses[t] = tagged.Counter("misses")
+	}
+	return m
+}
+
+func (m postingsListCacheMetrics) hit(t PatternType) {
+	if c, ok := m.hits[t]; ok {
+		c.Inc(1)
+	}
+}
+
+func (m postingsListCacheMetrics) miss(t PatternType) {
+	if c, ok := m.misses[t]; ok {
+		c.Inc(1)
+	}
+}
@@ -0,0 +1,155 @@
+// Copyright (c) 2018 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package index
+
+import (
+	"testing"
+
+	"github.com/m3db/m3/src/dbnode/runtime"
+	"github.com/m3db/m3/src/m3ninx/postings"
+	"github.com/m3db/m3/src/m3ninx/postings/roaring"
+	"github.com/m3db/m3x/instrument"
+
+	"github.com/stretchr/testify/require"
+	"github.com/uber-go/tally"
+)
+
+func newTestPostingsList(ids ...postings.ID) postings.List {
+	pl := roaring.NewPostingsList()
+	for _, id := range ids {
+		pl.Insert(id)
+	}
+	return pl
+}
+
+func TestPostingsListCacheNegativeSize(t *testing.T) {
+	_, err := NewPostingsListCache(-1, instrument.NewOptions())
+	require.Error(t, err)
+}
+
+func TestPostingsListCacheGetPut(t *testing.T) {
+	scope := tally.NewTestScope("", nil)
+	cache, err := NewPostingsListCache(4,
+		instrument.NewOptions().SetMetricsScope(scope))
+	require.NoError(t, err)
+
+	_, ok := cache.Get(1, []byte("foo"), []byte("bar"), PatternTypeTerm)
+	require.False(t, ok)
+
+	pl := newTestPostingsList(1, 2, 3)
+	cache.Put(1, []byte("foo"), []byte("bar"), PatternTypeTerm, pl)
+
+	cached, ok := cache.Get(1, []byte("foo"), []byte("bar"), PatternTypeTerm)
+	require.True(t, ok)
+	require.True(t, pl.Equal(cached))
+
+	// Same field and pattern with a different type or segment must miss.
+	_, ok = cache.Get(1, []byte("foo"), []byte("bar"), PatternTypePrefix)
+	require.False(t, ok)
+	_, ok = cache.Get(2, []byte("foo"), []byte("bar"), PatternTypeTerm)
+	require.False(t, ok)
+
+	counters := scope.Snapshot().Counters()
+	hits, ok := counters["postings-list-cache.hits+pattern_type=term"]
+	require.True(t, ok)
+	require.Equal(t, int64(1), hits.Value())
+	misses, ok := counters["postings-list-cache.misses+pattern_type=term"]
+	require.True(t, ok)
+	require.Equal(t, int64(2), misses.Value())
+}
+
+func TestPostingsListCacheEvictsLeastRecentlyUsed(t *testing.T) {
+	cache, err := NewPostingsListCache(2, instrument.NewOptions())
+	require.NoError(t, err)
+
+	cache.Put(1, []byte("f"), []byte("a"), PatternTypeTerm, newTestPostingsList(1))
+	cache.Put(1, []byte("f"), []byte("b"), PatternTypeTerm, newTestPostingsList(2))
+
+	// Touch "a" so that "b" is the least recently used.
+	_, ok := cache.Get(1, []byte("f"), []byte("a"), PatternTypeTerm)
+	require.True(t, ok)
+
+	cache.Put(1, []byte("f"), []byte("c"), PatternTypeTerm, newTestPostingsList(3))
+	require.Equal(t, 2, cache.Len())
+
+	_, ok = cache.Get(1, []byte("f"), []byte("b"), PatternTypeTerm)
+	require.False(t, ok)
+	_, ok = cache.Get(1, []byte("f"), []byte("a"), PatternTypeTerm)
+	require.True(t, ok)
+	_, ok = cache.Get(1, []byte("f"), []byte("c"), PatternTypeTerm)
+	require.True(t, ok)
+}
+
+func TestPostingsListCachePurgeSegment(t *testing.T) {
+	cache, err := NewPostingsListCache(8, instrument.NewOptions())
+	require.NoError(t, err)
+
+	cache.Put(1, []byte("f"), []byte("a"), PatternTypeTerm, newTestPostingsList(1))
+	cache.Put(1, []byte("f"), nil, PatternTypeField, newTestPostingsList(1))
+	cache.Put(2, []byte("f"), []byte("a"), PatternTypeTerm, newTestPostingsList(2))
+
+	cache.PurgeSegment(1)
+	require.Equal(t, 1, cache.Len())
+
+	_, ok := cache.Get(1, []byte("f"), []byte("a"), PatternTypeTerm)
+	require.False(t, ok)
+	_, ok = cache.Get(2, []byte("f"), []byte("a"), PatternTypeTerm)
+	require.True(t, ok)
+}
+
+func TestPostingsListCacheSetSize(t *testing.T) {
+	cache, err := NewPostingsListCache(4, instrument.NewOptions())
+	require.NoError(t, err)
+
+	for _, term := range []string{"a", "b", "c", "d"} {
+		cache.Put(1, []byte("f"), []byte(term), PatternTypeTerm, newTestPostingsList(1))
+	}
+	require.Equal(t, 4, cache.Len())
+
+	require.Error(t, cache.SetSize(-1))
+	require.NoError(t, cache.SetSize(2))
+	require.Equal(t, 2, cache.Len())
+
+	_, ok := cache.Get(1, []byte("f"), []byte("d"), PatternTypeTerm)
+	require.True(t, ok)
+	_, ok = cache.Get(1, []byte("f"), []byte("a"), PatternTypeTerm)
+	require.False(t, ok)
+
+	// A size of zero disables the cache.
+	cache.SetRuntimeOptions(runtime.NewOptions().SetPostingsListCacheSize(0))
+	require.Equal(t, 0, cache.Len())
+	cache.Put(1, []byte("f"), []byte("a"), PatternTypeTerm, newTestPostingsList(1))
+	require.Equal(t, 0, cache.Len())
+}
+
+func TestPostingsListCacheEnabledAtRuntime(t *testing.T) {
+	// A cache created disabled starts caching once resized at runtime.
+	cache, err := NewPostingsListCache(0, instrument.NewOptions())
+	require.NoError(t, err)
+	cache.Put(1, []byte("f"), []byte("a"), PatternTypeTerm, newTestPostingsList(1))
+	require.Equal(t, 0, cache.Len())
+
+	cache.SetRuntimeOptions(runtime.NewOptions().SetPostingsListCacheSize(2))
+	cache.Put(1, []byte("f"), []byte("a"), PatternTypeTerm, newTestPostingsList(1))
+	require.Equal(t, 1, cache.Len())
+	_, ok := cache.Get(1, []byte("f"), []byte("a"), PatternTypeTerm)
+	require.True(t, ok)
+}
@@ -0,0 +1,188 @@
+// Copyright (c) 2018 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package index
+
+import (
+	"errors"
+	"sync"
+	"sync/atomic"
+
+	m3ninxindex "github.com/m3db/m3/src/m3ninx/index"
+	"github.com/m3db/m3/src/m3ninx/index/segment"
+	"github.com/m3db/m3/src/m3ninx/index/segment/fst"
+	"github.com/m3db/m3/src/m3ninx/postings"
+)
+
+var (
+	errReadThroughSegmentClosed = errors.New("read through segment is closed")
+)
+
+// readThroughSegmentIDs generates identifiers for read through segments,
+// identifiers are never reused so stale cache entries can never be returned
+// for a different segment.
+var readThroughSegmentIDs uint64
+
+// ReadThroughSegment wraps an immutable FST segment and caches the postings
+// lists resolved by its readers in a PostingsListCache. The cached postings
+// lists for the segment are purged when the segment is closed.
+type ReadThroughSegment struct {
+	mu     sync.RWMutex
+	closed bool
+
+	id      uint64
+	segment fst.Segment
+	cache   *PostingsListCache
+}
+
+// NewReadThroughSegment returns a segment that caches the postings lists
+// resolved from the given FST segment in the provided cache.
+func NewReadThroughSegment(
+	seg fst.Segment,
+	cache *PostingsListCache,
+) *ReadThroughSegment {
+	return &ReadThroughSegment{
+		id:      atomic.AddUint64(&readThroughSegmentIDs, 1),
+		segment: seg,
+		cache:   cache,
+	}
+}
+
+// Reader returns a point-in-time accessor to search the segment.
+func (s *ReadThroughSegment) Reader() (m3ninxindex.Reader, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	if s.closed {
+		return nil, errReadThroughSegmentClosed
+	}
+	reader, err := s.segment.Reader()
+	if err != nil {
+		return nil, err
+	}
+	return &readThroughSegmentReader{
+		Reader:  reader,
+		segment: s,
+	}, nil
+}
+
+// Close purges the cached postings lists for the segment and closes the
+// underlying segment.
+func (s *ReadThroughSegment) Close() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.closed {
+		return errReadThroughSegmentClosed
+	}
+	s.closed = true
+	s.cache.PurgeSegment(s.id)
+	return s.segment.Close()
+}
+
+// Size returns the number of documents within the segment.
+func (s *ReadThroughSegment) Size() int64 {
+	return s.segment.Size()
+}
+
+// ContainsID returns a bool indicating if the segment contains the provided ID.
+func (s *ReadThroughSegment) ContainsID(id []byte) (bool, error) {
+	return s.segment.ContainsID(id)
+}
+
+// Fields returns an iterator over the list of known fields.
+func (s *ReadThroughSegment) Fields() (segment.FieldsIterator, error) {
+	return s.segment.Fields()
+}
+
+// Terms returns an iterator over the known terms values for the given field.
+func (s *ReadThroughSegment) Terms(field []byte) (segment.TermsIterator, error) {
+	return s.segment.Terms(field)
+}
+
+// match resolves a postings list from the cache, falling back to the given
+// function and caching its result on a miss. The segment read lock is held
+// for the duration so that no entries are cached after the segment has been
+// closed and purged.
+func (s *ReadThroughSegment) match(
+	field []byte,
+	pattern []byte,
+	patternType PatternType,
+	fn func() (postings.List, error),
+) (postings.List, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	if s.closed {
+		return nil, errReadThroughSegmentClosed
+	}
+
+	if pl, ok := s.cache.Get(s.id, field, pattern, patternType); ok {
+		return pl, nil
+	}
+
+	pl, err := fn()
+	if err != nil {
+		return nil, err
+	}
+	s.cache.Put(s.id, field, pattern, patternType, pl)
+	return pl, nil
+}
+
+type readThroughSegmentReader struct {
+	m3ninxindex.Reader
+
+	segment *ReadThroughSegment
+}
+
+func (r *readThroughSegmentReader) MatchTerm(
+	field []byte,
+	term []byte,
+) (postings.List, error) {
+	return r.segment.match(field, term, PatternTypeTerm, func() (postings.List, error) {
+		return r.Reader.MatchTerm(field, term)
+	})
+}
+
+func (r *readThroughSegmentReader) MatchRegexp(
+	field []byte,
+	c m3ninxindex.CompiledRegex,
+) (postings.List, error) {
+	if c.Simple == nil {
+		// Without the source expression there is nothing to key the cache by.
+		return r.Reader.MatchRegexp(field, c)
+	}
+	pattern := []byte(c.Simple.String())
+	return r.segment.match(field, pattern, PatternTypeRegexp, func() (postings.List, error) {
+		return r.Reader.MatchRegexp(field, c)
+	})
+}
+
+func (r *readThroughSegmentReader) MatchField(field []byte) (postings.List, error) {
+	return r.segment.match(field, nil, PatternTypeField, func() (postings.List, error) {
+		return r.Reader.MatchField(field)
+	})
+}
+
+func (r *readThroughSegmentReader) MatchPrefix(
+	field []byte,
+	prefix []byte,
+) (postings.List, error) {
+	return r.segment.match(field, prefix, PatternTypePrefix, func() (postings.List, error) {
+		return r.Reader.MatchPrefix(field, prefix)
+	})
+}
@@ -0,0 +1,117 @@
+// Copyright (c) 2018 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package index
+
+import (
+	"testing"
+
+	m3ninxindex "github.com/m3db/m3/src/m3ninx/index"
+	"github.com/m3db/m3/src/m3ninx/index/segment/fst"
+	"github.com/m3db/m3x/instrument"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReadThroughSegmentMatchTermCaches(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	cache, err := NewPostingsListCache(8, instrument.NewOptions())
+	require.NoError(t, err)
+
+	seg := fst.NewMockSegment(ctrl)
+	reader := m3ninxindex.NewMockReader(ctrl)
+	seg.EXPECT().Reader().Return(reader, nil).Times(2)
+
+	pl := newTestPostingsList(1, 2)
+	reader.EXPECT().MatchTerm([]byte("foo"), []byte("bar")).Return(pl, nil).Times(1)
+
+	readThrough := NewReadThroughSegment(seg, cache)
+	for i := 0; i < 2; i++ {
+		r, err := readThrough.Reader()
+		require.NoError(t, err)
+		result, err := r.MatchTerm([]byte("foo"), []byte("bar"))
+		require.NoError(t, err)
+		require.True(t, pl.Equal(result))
+	}
+	require.Equal(t, 1, cache.Len())
+}
+
+func TestReadThroughSegmentMatchRegexpCaches(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	cache, err := NewPostingsListCache(8, instrument.NewOptions())
+	require.NoError(t, err)
+
+	seg := fst.NewMockSegment(ctrl)
+	reader := m3ninxindex.NewMockReader(ctrl)
+	seg.EXPECT().Reader().Return(reader, nil)
+
+	compiled, err := m3ninxindex.CompileRegex([]byte("ba.*"))
+	require.NoError(t, err)
+
+	pl := newTestPostingsList(3)
+	reader.EXPECT().MatchRegexp([]byte("foo"), compiled).Return(pl, nil).Times(1)
+
+	readThrough := NewReadThroughSegment(seg, cache)
+	r, err := readThrough.Reader()
+	require.NoError(t, err)
+	for i := 0; i < 2; i++ {
+		result, err := r.MatchRegexp([]byte("foo"), compiled)
+		require.NoError(t, err)
+		require.True(t, pl.Equal(result))
+	}
+}
+
+func TestReadThroughSegmentClosePurgesCache(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	cache, err := NewPostingsListCache(8, instrument.NewOptions())
+	require.NoError(t, err)
+
+	seg := fst.NewMockSegment(ctrl)
+	reader := m3ninxindex.NewMockReader(ctrl)
+	seg.EXPECT().Reader().Return(reader, nil)
+	reader.EXPECT().MatchField([]byte("foo")).Return(newTestPostingsList(1), nil)
+	reader.EXPECT().MatchPrefix([]byte("foo"), []byte("b")).Return(newTestPostingsList(1), nil)
+	seg.EXPECT().Close().Return(nil)
+
+	readThrough := NewReadThroughSegment(seg, cache)
+	r, err := readThrough.Reader()
+	require.NoError(t, err)
+	_, err = r.MatchField([]byte("foo"))
+	require.NoError(t, err)
+	_, err = r.MatchPrefix([]byte("foo"), []byte("b"))
+	require.NoError(t, err)
+	require.Equal(t, 2, cache.Len())
+
+	require.NoError(t, readThrough.Close())
+	require.Equal(t, 0, cache.Len())
+
+	_, err = r.MatchField([]byte("foo"))
+	require.Error(t, err)
+	_, err = readThrough.Reader()
+	require.Error(t, err)
+	require.Error(t, readThrough.Close())
+}
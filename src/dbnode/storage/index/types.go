@@ -194,8 +194,9 @@ type WriteBatchResult struct {
 
 // BlockTickResult returns statistics about tick.
 type BlockTickResult struct {
-	NumSegments int64
-	NumDocs     int64
+	NumSegments         int64
+	NumDocs             int64
+	MutableSegmentBytes int64
 }
 
 // WriteBatch is a batch type that allows for building of a slice of documents
@@ -552,4 +553,12 @@ type Options interface {
 
 	// ResultsPool returns the results pool.
 	ResultsPool() ResultsPool
+
+	// SetPostingsListCache sets the postings list cache used by immutable
+	// segments, a nil cache disables postings list caching.
+	SetPostingsListCache(value *PostingsListCache) Options
+
+	// PostingsListCache returns the postings list cache used by immutable
+	// segments.
+	PostingsListCache() *PostingsListCache
 }
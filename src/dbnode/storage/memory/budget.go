@@ -0,0 +1,249 @@
+// Copyright (c) 2018 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+// Package memory provides a node wide memory budget shared by the components
+// of the database that hold series data and index data in memory.
+package memory
+
+import (
+	"errors"
+	"sync"
+	"sync/atomic"
+
+	"github.com/m3db/m3x/instrument"
+
+	"github.com/uber-go/tally"
+)
+
+var (
+	// ErrBudgetExceeded is returned when the node memory budget is exceeded
+	// and cannot be reclaimed by evicting cached blocks. It is not an invalid
+	// params error so that it is surfaced to clients as retryable.
+	ErrBudgetExceeded = errors.New("node memory budget exceeded")
+)
+
+// Component is a consumer of node memory accounted for by a budget.
+type Component int
+
+const (
+	// SeriesBuffers is the memory held by series buffers accepting writes.
+	SeriesBuffers Component = iota
+	// IndexMutableSegments is the memory held by mutable index segments.
+	IndexMutableSegments
+	// BlockCache is the memory held by blocks cached by the wired list.
+	BlockCache
+
+	numComponents
+)
+
+// Components returns all the components accounted for by a budget.
+func Components() []Component {
+	return []Component{SeriesBuffers, IndexMutableSegments, BlockCache}
+}
+
+func (c Component) String() string {
+	switch c {
+	case SeriesBuffers:
+		return "series-buffers"
+	case IndexMutableSegments:
+		return "index-mutable-segments"
+	case BlockCache:
+		return "block-cache"
+	default:
+		return "unknown"
+	}
+}
+
+// Evictor is implemented by a cache that can release memory when the
+// budget is exceeded.
+type Evictor interface {
+	// RequestEviction asks the cache to evict until the budget is no longer
+	// exceeded or the cache is empty, it must not block.
+	RequestEviction()
+}
+
+// Budget tracks memory usage per component against a node wide limit. When
+// the limit is exceeded the block cache is asked to evict first, and only
+// once the usage of the remaining components alone exceeds the limit are
+// new series rejected.
+type Budget struct {
+	sync.RWMutex
+
+	// Limit and usage must use atomic store and load to access.
+	limit int64
+	usage [numComponents]int64
+
+	evictor Evictor
+
+	metrics budgetMetrics
+}
+
+type budgetMetrics struct {
+	usage      [numComponents]tally.Gauge
+	total      tally.Gauge
+	limit      tally.Gauge
+	evictions  tally.Counter
+	rejections tally.Counter
+}
+
+func newBudgetMetrics(scope tally.Scope) budgetMetrics {
+	m := budgetMetrics{
+		total:      scope.Gauge("total-bytes"),
+		limit:      scope.Gauge("limit-bytes"),
+		evictions:  scope.Counter("eviction-requests"),
+		rejections: scope.Counter("rejections"),
+	}
+	for _, c := range Components() {
+		m.usage[c] = scope.Tagged(map[string]string{
+			"component": c.String(),
+		}).Gauge("usage-bytes")
+	}
+	return m
+}
+
+// NewBudget returns a new memory budget with the given limit in bytes, a
+// limit of zero disables enforcement while still reporting usage.
+func NewBudget(limit int64, iopts instrument.Options) *Budget {
+	b := &Budget{
+		metrics: newBudgetMetrics(iopts.MetricsScope().SubScope("memory-budget")),
+	}
+	b.SetLimit(limit)
+	return b
+}
+
+// SetLimit sets the limit of the budget in bytes, zero disables enforcement.
+func (b *Budget) SetLimit(limit int64) {
+	atomic.StoreInt64(&b.limit, limit)
+	b.metrics.limit.Update(float64(limit))
+	b.maybeRequestEviction()
+}
+
+// Limit returns the limit of the budget in bytes.
+func (b *Budget) Limit() int64 {
+	return atomic.LoadInt64(&b.limit)
+}
+
+// SetEvictor sets the cache that is asked to evict when the budget is exceeded.
+func (b *Budget) SetEvictor(evictor Evictor) {
+	b.Lock()
+	b.evictor = evictor
+	b.Unlock()
+}
+
+// Usage returns the current usage in bytes of a component.
+func (b *Budget) Usage(c Component) int64 {
+	return atomic.LoadInt64(&b.usage[c])
+}
+
+// TotalUsage returns the current usage in bytes of all components.
+func (b *Budget) TotalUsage() int64 {
+	var total int64
+	for i := range b.usage {
+		total += atomic.LoadInt64(&b.usage[i])
+	}
+	return total
+}
+
+// Excess returns the number of bytes the usage exceeds the limit by, or
+// zero if the budget is not exceeded or not enforced.
+func (b *Budget) Excess() int64 {
+	limit := b.Limit()
+	if limit <= 0 {
+		return 0
+	}
+	if excess := b.TotalUsage() - limit; excess > 0 {
+		return excess
+	}
+	return 0
+}
+
+// Overloaded returns true if the usage exceeds the limit even with the
+// block cache completely evicted.
+func (b *Budget) Overloaded() bool {
+	limit := b.Limit()
+	if limit <= 0 {
+		return false
+	}
+	return b.TotalUsage()-b.Usage(BlockCache) > limit
+}
+
+// AdmitNewSeries returns ErrBudgetExceeded if the budget is overloaded and
+// a new series should not be created.
+func (b *Budget) AdmitNewSeries() error {
+	if b.Overloaded() {
+		b.metrics.rejections.Inc(1)
+		return ErrBudgetExceeded
+	}
+	return nil
+}
+
+// NewReporter returns a reporter that a single owner can use to report its
+// usage for a component, usage from all reporters of a component is summed.
+func (b *Budget) NewReporter(c Component) *Reporter {
+	return &Reporter{budget: b, component: c}
+}
+
+func (b *Budget) add(c Component, delta int64) {
+	if delta == 0 {
+		return
+	}
+	usage := atomic.AddInt64(&b.usage[c], delta)
+	b.metrics.usage[c].Update(float64(usage))
+	b.metrics.total.Update(float64(b.TotalUsage()))
+	if delta > 0 && c != BlockCache {
+		// NB: the block cache enforces the budget itself as it inserts
+		// blocks so there is no need to request an eviction on its behalf.
+		b.maybeRequestEviction()
+	}
+}
+
+func (b *Budget) maybeRequestEviction() {
+	if b.Excess() <= 0 || b.Usage(BlockCache) <= 0 {
+		return
+	}
+	b.RLock()
+	evictor := b.evictor
+	b.RUnlock()
+	if evictor == nil {
+		return
+	}
+	evictor.RequestEviction()
+	b.metrics.evictions.Inc(1)
+}
+
+// Reporter reports the usage of a single owner of a component to a budget.
+type Reporter struct {
+	budget    *Budget
+	component Component
+
+	// Last reported usage, must use atomic store and load to access.
+	last int64
+}
+
+// Report sets the current usage in bytes of the owner.
+func (r *Reporter) Report(bytes int64) {
+	prev := atomic.SwapInt64(&r.last, bytes)
+	r.budget.add(r.component, bytes-prev)
+}
+
+// Close releases the usage reported by the owner.
+func (r *Reporter) Close() {
+	r.Report(0)
+}
@@ -0,0 +1,120 @@
+// Copyright (c) 2018 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package memory
+
+import (
+	"testing"
+
+	"github.com/m3db/m3x/instrument"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/uber-go/tally"
+)
+
+type testEvictor struct {
+	requests int
+}
+
+func (e *testEvictor) RequestEviction() {
+	e.requests++
+}
+
+func TestBudgetReportersSumUsagePerComponent(t *testing.T) {
+	scope := tally.NewTestScope("", nil)
+	b := NewBudget(0, instrument.NewOptions().SetMetricsScope(scope))
+
+	first := b.NewReporter(SeriesBuffers)
+	second := b.NewReporter(SeriesBuffers)
+	index := b.NewReporter(IndexMutableSegments)
+
+	first.Report(100)
+	second.Report(50)
+	index.Report(25)
+	first.Report(70)
+
+	assert.Equal(t, int64(120), b.Usage(SeriesBuffers))
+	assert.Equal(t, int64(25), b.Usage(IndexMutableSegments))
+	assert.Equal(t, int64(0), b.Usage(BlockCache))
+	assert.Equal(t, int64(145), b.TotalUsage())
+
+	second.Close()
+	assert.Equal(t, int64(70), b.Usage(SeriesBuffers))
+
+	gauges := scope.Snapshot().Gauges()
+	g, ok := gauges["memory-budget.usage-bytes+component=series-buffers"]
+	require.True(t, ok)
+	assert.Equal(t, float64(70), g.Value())
+	g, ok = gauges["memory-budget.total-bytes+"]
+	require.True(t, ok)
+	assert.Equal(t, float64(95), g.Value())
+}
+
+func TestBudgetUnlimitedNeverOverloaded(t *testing.T) {
+	b := NewBudget(0, instrument.NewOptions())
+	evictor := &testEvictor{}
+	b.SetEvictor(evictor)
+
+	b.NewReporter(BlockCache).Report(1 << 30)
+	b.NewReporter(SeriesBuffers).Report(1 << 30)
+
+	assert.Equal(t, int64(0), b.Excess())
+	assert.False(t, b.Overloaded())
+	assert.NoError(t, b.AdmitNewSeries())
+	assert.Equal(t, 0, evictor.requests)
+}
+
+func TestBudgetEvictsCacheBeforeRejecting(t *testing.T) {
+	b := NewBudget(100, instrument.NewOptions())
+	evictor := &testEvictor{}
+	b.SetEvictor(evictor)
+
+	cache := b.NewReporter(BlockCache)
+	buffers := b.NewReporter(SeriesBuffers)
+
+	cache.Report(60)
+	buffers.Report(30)
+	assert.Equal(t, int64(0), b.Excess())
+	assert.Equal(t, 0, evictor.requests)
+
+	// Exceeded but evicting the cache would bring usage under the limit.
+	buffers.Report(80)
+	assert.Equal(t, int64(40), b.Excess())
+	assert.Equal(t, 1, evictor.requests)
+	assert.False(t, b.Overloaded())
+	assert.NoError(t, b.AdmitNewSeries())
+
+	// Exceeded even without the cache.
+	buffers.Report(120)
+	assert.Equal(t, 2, evictor.requests)
+	assert.True(t, b.Overloaded())
+	assert.Equal(t, ErrBudgetExceeded, b.AdmitNewSeries())
+
+	// No eviction requested once the cache is empty.
+	cache.Report(0)
+	buffers.Report(130)
+	assert.Equal(t, 2, evictor.requests)
+
+	// Raising the limit admits new series again.
+	b.SetLimit(200)
+	assert.False(t, b.Overloaded())
+	assert.NoError(t, b.AdmitNewSeries())
+}
@@ -38,6 +38,7 @@ import (
 	"github.com/m3db/m3/src/dbnode/storage/block"
 	"github.com/m3db/m3/src/dbnode/storage/bootstrap"
 	"github.com/m3db/m3/src/dbnode/storage/index"
+	"github.com/m3db/m3/src/dbnode/storage/memory"
 	"github.com/m3db/m3/src/dbnode/storage/namespace"
 	"github.com/m3db/m3/src/dbnode/storage/repair"
 	"github.com/m3db/m3/src/dbnode/storage/series"
@@ -142,6 +143,7 @@ type options struct {
 	fetchBlockMetadataResultsPool  block.FetchBlockMetadataResultsPool
 	fetchBlocksMetadataResultsPool block.FetchBlocksMetadataResultsPool
 	queryIDsWorkerPool             xsync.WorkerPool
+	memoryBudget                   *memory.Budget
 }
 
 // NewOptions creates a new set of storage options with defaults
@@ -622,3 +624,13 @@ func (o *options) SetQueryIDsWorkerPool(value xsync.WorkerPool) Options {
 func (o *options) QueryIDsWorkerPool() xsync.WorkerPool {
 	return o.queryIDsWorkerPool
 }
+
+func (o *options) SetMemoryBudget(value *memory.Budget) Options {
+	opts := *o
+	opts.memoryBudget = value
+	return &opts
+}
+
+func (o *options) MemoryBudget() *memory.Budget {
+	return o.memoryBudget
+}
@@ -31,6 +31,7 @@ type tickResult struct {
 	madeExpiredBlocks      int
 	madeUnwiredBlocks      int
 	mergedOutOfOrderBlocks int
+	bufferBytes            int
 	errors                 int
 }
 
@@ -46,6 +47,7 @@ func (r tickResult) merge(other tickResult) tickResult {
 		madeExpiredBlocks:      r.madeExpiredBlocks + other.madeExpiredBlocks,
 		madeUnwiredBlocks:      r.madeUnwiredBlocks + other.madeUnwiredBlocks,
 		mergedOutOfOrderBlocks: r.mergedOutOfOrderBlocks + other.mergedOutOfOrderBlocks,
+		bufferBytes:            r.bufferBytes + other.bufferBytes,
 		errors:                 r.errors + other.errors,
 	}
 }
@@ -108,6 +108,7 @@ type databaseBuffer interface {
 type bufferStats struct {
 	openBlocks  int
 	wiredBlocks int
+	bytes       int
 }
 
 type drainAndResetResult struct {
@@ -225,6 +226,7 @@ func (b *dbBuffer) Stats() bufferStats {
 			stats.openBlocks++
 		}
 		stats.wiredBlocks++
+		stats.bytes += b.buckets[i].streamsLen()
 	}
 	return stats
 }
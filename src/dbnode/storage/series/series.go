@@ -276,6 +276,7 @@ func (s *dbSeries) updateBlocksWithLock() (updateBlocksResult, error) {
 	result.ActiveBlocks += bufferStats.wiredBlocks
 	result.WiredBlocks += bufferStats.wiredBlocks
 	result.OpenBlocks += bufferStats.openBlocks
+	result.BufferBytes += bufferStats.bytes
 
 	return result, nil
 }
@@ -254,13 +254,14 @@ func TestSeriesTickDrainAndResetBuffer(t *testing.T) {
 	buffer := NewMockdatabaseBuffer(ctrl)
 	series.buffer = buffer
 	buffer.EXPECT().Tick().Return(bufferTickResult{})
-	buffer.EXPECT().Stats().Return(bufferStats{openBlocks: 1, wiredBlocks: 1})
+	buffer.EXPECT().Stats().Return(bufferStats{openBlocks: 1, wiredBlocks: 1, bytes: 32})
 	r, err := series.Tick()
 	require.NoError(t, err)
 	assert.Equal(t, 1, r.ActiveBlocks)
 	assert.Equal(t, 1, r.WiredBlocks)
 	assert.Equal(t, 0, r.UnwiredBlocks)
 	assert.Equal(t, 1, r.OpenBlocks)
+	assert.Equal(t, 32, r.BufferBytes)
 }
 
 func TestSeriesTickNeedsBlockExpiry(t *testing.T) {
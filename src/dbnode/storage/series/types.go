@@ -144,6 +144,8 @@ type TickStatus struct {
 	UnwiredBlocks int
 	// PendingMergeBlocks is the number of blocks pending merges
 	PendingMergeBlocks int
+	// BufferBytes is the number of bytes held by the series buffer
+	BufferBytes int
 }
 
 // TickResult is a set of results from a tick
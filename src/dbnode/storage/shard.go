@@ -41,6 +41,7 @@ import (
 	"github.com/m3db/m3/src/dbnode/storage/bootstrap/result"
 	"github.com/m3db/m3/src/dbnode/storage/index"
 	"github.com/m3db/m3/src/dbnode/storage/index/convert"
+	"github.com/m3db/m3/src/dbnode/storage/memory"
 	"github.com/m3db/m3/src/dbnode/storage/namespace"
 	"github.com/m3db/m3/src/dbnode/storage/repair"
 	"github.com/m3db/m3/src/dbnode/storage/series"
@@ -163,6 +164,8 @@ type dbShard struct {
 	tickWg                   *sync.WaitGroup
 	runtimeOptsListenClosers []xclose.SimpleCloser
 	currRuntimeOptions       dbShardRuntimeOptions
+	memoryBudget             *memory.Budget
+	memoryReporter           *memory.Reporter
 	logger                   xlog.Logger
 	metrics                  dbShardMetrics
 	newSeriesBootstrapped    bool
@@ -280,6 +283,11 @@ func newDatabaseShard(
 	s.insertQueue = newDatabaseShardInsertQueue(s.insertSeriesBatch,
 		s.nowFn, scope)
 
+	if budget := opts.MemoryBudget(); budget != nil {
+		s.memoryBudget = budget
+		s.memoryReporter = budget.NewReporter(memory.SeriesBuffers)
+	}
+
 	registerRuntimeOptionsListener := func(listener runtime.OptionsListener) {
 		elem := opts.RuntimeOptionsManager().RegisterListener(listener)
 		s.runtimeOptsListenClosers = append(s.runtimeOptsListenClosers, elem)
@@ -560,6 +568,11 @@ func (s *dbShard) Close() error {
 	// should be increased.
 	cancellable := context.NewNoOpCanncellable()
 	_, err := s.tickAndExpire(cancellable, tickPolicyCloseShard)
+
+	if s.memoryReporter != nil {
+		// Release the usage of the series buffers now the shard is closed.
+		s.memoryReporter.Close()
+	}
 	return err
 }
 
@@ -678,6 +691,7 @@ func (s *dbShard) tickAndExpire(
 			r.madeExpiredBlocks += result.MadeExpiredBlocks
 			r.madeUnwiredBlocks += result.MadeUnwiredBlocks
 			r.mergedOutOfOrderBlocks += result.MergedOutOfOrderBlocks
+			r.bufferBytes += result.BufferBytes
 			i++
 		}
 
@@ -697,6 +711,12 @@ func (s *dbShard) tickAndExpire(
 		return tickResult{}, errShardClosingTickTerminated
 	}
 
+	// NB: a cancelled tick has only visited some of the series so the buffer
+	// usage it computed would under report the usage of the shard.
+	if s.memoryReporter != nil && !c.IsCancelled() {
+		s.memoryReporter.Report(int64(r.bufferBytes))
+	}
+
 	return r, nil
 }
 
@@ -788,6 +808,14 @@ func (s *dbShard) writeAndIndex(
 
 	writable := entry != nil
 
+	// Reject new series rather than growing the series buffers and index
+	// once evicting cached blocks can no longer keep the node in budget.
+	if !writable && s.memoryBudget != nil {
+		if err := s.memoryBudget.AdmitNewSeries(); err != nil {
+			return err
+		}
+	}
+
 	// If no entry and we are not writing new series asynchronously
 	if !writable && !opts.writeNewSeriesAsync {
 		// Avoid double lookup by enqueueing insert immediately
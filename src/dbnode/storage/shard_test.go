@@ -36,6 +36,7 @@ import (
 	"github.com/m3db/m3/src/dbnode/runtime"
 	"github.com/m3db/m3/src/dbnode/storage/block"
 	"github.com/m3db/m3/src/dbnode/storage/bootstrap/result"
+	"github.com/m3db/m3/src/dbnode/storage/memory"
 	"github.com/m3db/m3/src/dbnode/storage/namespace"
 	"github.com/m3db/m3/src/dbnode/storage/series"
 	"github.com/m3db/m3/src/dbnode/storage/series/lookup"
@@ -737,6 +738,41 @@ func TestPurgeExpiredSeriesNonEmptySeries(t *testing.T) {
 // This tests the scenario where a series is empty when series.Tick() is called,
 // but receives writes after tickForEachSeries finishes but before purgeExpiredSeries
 // starts. The expected behavior is not to expire series in this case.
+func TestShardWriteNewSeriesRejectedWhenMemoryBudgetOverloaded(t *testing.T) {
+	opts := testDatabaseOptions()
+	budget := memory.NewBudget(1<<20, opts.InstrumentOptions())
+	opts = opts.SetMemoryBudget(budget)
+	shard := testDatabaseShard(t, opts)
+
+	ctx := opts.ContextPool().Get()
+	defer ctx.Close()
+
+	nowFn := opts.ClockOptions().NowFn()
+	require.NoError(t, shard.Write(ctx, ident.StringID("foo"), nowFn(), 1.0, xtime.Second, nil))
+
+	// Ticking reports the usage of the series buffers.
+	_, err := shard.tickAndExpire(context.NewNoOpCanncellable(), tickPolicyRegular)
+	require.NoError(t, err)
+	require.True(t, budget.Usage(memory.SeriesBuffers) > 0)
+
+	// Exceed the budget with usage that evicting cached blocks cannot reclaim.
+	other := budget.NewReporter(memory.SeriesBuffers)
+	other.Report(2 << 20)
+
+	err = shard.Write(ctx, ident.StringID("bar"), nowFn(), 2.0, xtime.Second, nil)
+	require.Equal(t, memory.ErrBudgetExceeded, err)
+
+	// Writes to existing series are still accepted.
+	require.NoError(t, shard.Write(ctx, ident.StringID("foo"),
+		nowFn().Add(time.Second), 2.0, xtime.Second, nil))
+
+	other.Close()
+	require.NoError(t, shard.Write(ctx, ident.StringID("bar"), nowFn(), 2.0, xtime.Second, nil))
+
+	require.NoError(t, shard.Close())
+	require.Equal(t, int64(0), budget.Usage(memory.SeriesBuffers))
+}
+
 func TestPurgeExpiredSeriesWriteAfterTicking(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
@@ -35,6 +35,7 @@ import (
 	"github.com/m3db/m3/src/dbnode/storage/bootstrap"
 	"github.com/m3db/m3/src/dbnode/storage/bootstrap/result"
 	"github.com/m3db/m3/src/dbnode/storage/index"
+	"github.com/m3db/m3/src/dbnode/storage/memory"
 	"github.com/m3db/m3/src/dbnode/storage/namespace"
 	"github.com/m3db/m3/src/dbnode/storage/repair"
 	"github.com/m3db/m3/src/dbnode/storage/series"
@@ -493,11 +494,12 @@ type namespaceIndex interface {
 // namespaceIndexTickResult are details about the work performed by the namespaceIndex
 // during a Tick().
 type namespaceIndexTickResult struct {
-	NumBlocks        int64
-	NumBlocksSealed  int64
-	NumBlocksEvicted int64
-	NumSegments      int64
-	NumTotalDocs     int64
+	NumBlocks           int64
+	NumBlocksSealed     int64
+	NumBlocksEvicted    int64
+	NumSegments         int64
+	NumTotalDocs        int64
+	MutableSegmentBytes int64
 }
 
 // namespaceIndexInsertQueue is a queue used in-front of the indexing component
@@ -848,6 +850,12 @@ type Options interface {
 
 	// QueryIDsWorkerPool returns the QueryIDs worker pool.
 	QueryIDsWorkerPool() xsync.WorkerPool
+
+	// SetMemoryBudget sets the node memory budget, nil disables accounting.
+	SetMemoryBudget(value *memory.Budget) Options
+
+	// MemoryBudget returns the node memory budget, nil if accounting is disabled.
+	MemoryBudget() *memory.Budget
 }
 
 // DatabaseBootstrapState stores a snapshot of the bootstrap state for all shards across all
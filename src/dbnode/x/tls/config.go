@@ -0,0 +1,75 @@
+// Copyright (c) 2018 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package xtls
+
+import (
+	"time"
+
+	"github.com/m3db/m3x/instrument"
+)
+
+const (
+	defaultReloadInterval = time.Minute
+)
+
+// Configuration is the TLS configuration for a server or client endpoint.
+type Configuration struct {
+	// CertFile is the path to the PEM encoded certificate, required for
+	// servers and for clients when the server verifies client certificates.
+	CertFile string `yaml:"certFile"`
+
+	// KeyFile is the path to the PEM encoded private key for the certificate.
+	KeyFile string `yaml:"keyFile"`
+
+	// CAFile is the path to the PEM encoded CA bundle used to verify the
+	// certificates of peers, clients use the system roots if not set.
+	CAFile string `yaml:"caFile"`
+
+	// ClientCertAuth requires servers to verify client certificates
+	// against the CA bundle.
+	ClientCertAuth bool `yaml:"clientCertAuth"`
+
+	// ServerName overrides the name clients verify the server certificate
+	// against, by default the host being dialed is used.
+	ServerName string `yaml:"serverName"`
+
+	// InsecureSkipVerify disables verification of server certificates by clients.
+	InsecureSkipVerify bool `yaml:"insecureSkipVerify"`
+
+	// ReloadInterval is how often the certificate, key and CA bundle are
+	// reloaded from disk, by default every minute.
+	ReloadInterval time.Duration `yaml:"reloadInterval" validate:"min=0"`
+}
+
+// NewCertificateManager returns a certificate manager which loads and
+// periodically reloads the configured certificates.
+func (c Configuration) NewCertificateManager(
+	iopts instrument.Options,
+) (*CertificateManager, error) {
+	return NewCertificateManager(c, iopts)
+}
+
+func (c Configuration) reloadInterval() time.Duration {
+	if c.ReloadInterval <= 0 {
+		return defaultReloadInterval
+	}
+	return c.ReloadInterval
+}
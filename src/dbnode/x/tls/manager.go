@@ -0,0 +1,277 @@
+// Copyright (c) 2018 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package xtls
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"io/ioutil"
+	"net"
+	"sync"
+	"time"
+
+	"github.com/m3db/m3x/instrument"
+	xlog "github.com/m3db/m3x/log"
+
+	"github.com/uber-go/tally"
+)
+
+var (
+	errNoCertificate          = errors.New("tls certificate file and key file must be set")
+	errClientCertAuthNoCA     = errors.New("tls client cert auth requires a ca file")
+	errNoCACertificates       = errors.New("tls ca file contains no certificates")
+	errCertificateManagerDone = errors.New("tls certificate manager is closed")
+)
+
+// CertificateManager loads the certificate, key and CA bundle for a TLS
+// configuration and reloads them from disk at the configured interval so
+// certificates can be rotated without a restart. The TLS configs it returns
+// resolve the certificates on each handshake, so new connections always use
+// the most recently loaded certificates.
+type CertificateManager struct {
+	sync.RWMutex
+
+	cfg     Configuration
+	logger  xlog.Logger
+	metrics certificateManagerMetrics
+
+	certificate *tls.Certificate
+	caPool      *x509.CertPool
+	certPEM     []byte
+	keyPEM      []byte
+	caPEM       []byte
+
+	closed  bool
+	closeCh chan struct{}
+}
+
+// NewCertificateManager returns a new certificate manager for the
+// configuration, failing if the certificates cannot be loaded.
+func NewCertificateManager(
+	cfg Configuration,
+	iopts instrument.Options,
+) (*CertificateManager, error) {
+	if (cfg.CertFile == "") != (cfg.KeyFile == "") {
+		return nil, errNoCertificate
+	}
+	if cfg.ClientCertAuth && cfg.CAFile == "" {
+		return nil, errClientCertAuthNoCA
+	}
+
+	m := &CertificateManager{
+		cfg:     cfg,
+		logger:  iopts.Logger(),
+		metrics: newCertificateManagerMetrics(iopts.MetricsScope().SubScope("tls")),
+		closeCh: make(chan struct{}),
+	}
+	if _, err := m.reload(); err != nil {
+		return nil, err
+	}
+
+	go m.reloadLoop()
+	return m, nil
+}
+
+// ServerConfig returns a TLS config for servers, it fails handshakes
+// if no certificate is configured.
+func (m *CertificateManager) ServerConfig() *tls.Config {
+	return &tls.Config{
+		GetConfigForClient: func(*tls.ClientHelloInfo) (*tls.Config, error) {
+			return m.serverConfig()
+		},
+	}
+}
+
+// ClientConfig returns a TLS config for clients using the currently
+// loaded certificates, it must be retrieved for each new connection to
+// pick up reloaded certificates.
+func (m *CertificateManager) ClientConfig() *tls.Config {
+	m.RLock()
+	defer m.RUnlock()
+
+	cfg := &tls.Config{
+		MinVersion:         tls.VersionTLS12,
+		RootCAs:            m.caPool,
+		ServerName:         m.cfg.ServerName,
+		InsecureSkipVerify: m.cfg.InsecureSkipVerify,
+	}
+	if m.certificate != nil {
+		cfg.Certificates = []tls.Certificate{*m.certificate}
+	}
+	return cfg
+}
+
+// DialContext dials the address and performs a TLS handshake, it can be
+// used as the dialer for TChannel channels.
+func (m *CertificateManager) DialContext(
+	ctx context.Context,
+	network string,
+	address string,
+) (net.Conn, error) {
+	var dialer net.Dialer
+	conn, err := dialer.DialContext(ctx, network, address)
+	if err != nil {
+		return nil, err
+	}
+
+	cfg := m.ClientConfig()
+	if cfg.ServerName == "" {
+		if host, _, err := net.SplitHostPort(address); err == nil {
+			cfg.ServerName = host
+		}
+	}
+
+	tlsConn := tls.Client(conn, cfg)
+	if deadline, ok := ctx.Deadline(); ok {
+		tlsConn.SetDeadline(deadline)
+	}
+	if err := tlsConn.Handshake(); err != nil {
+		conn.Close()
+		return nil, err
+	}
+	tlsConn.SetDeadline(time.Time{})
+	return tlsConn, nil
+}
+
+// Close stops reloading the certificates.
+func (m *CertificateManager) Close() error {
+	m.Lock()
+	defer m.Unlock()
+	if m.closed {
+		return errCertificateManagerDone
+	}
+	m.closed = true
+	close(m.closeCh)
+	return nil
+}
+
+func (m *CertificateManager) serverConfig() (*tls.Config, error) {
+	m.RLock()
+	defer m.RUnlock()
+
+	if m.certificate == nil {
+		return nil, errNoCertificate
+	}
+
+	cfg := &tls.Config{
+		MinVersion:   tls.VersionTLS12,
+		Certificates: []tls.Certificate{*m.certificate},
+	}
+	if m.cfg.ClientCertAuth {
+		cfg.ClientAuth = tls.RequireAndVerifyClientCert
+		cfg.ClientCAs = m.caPool
+	}
+	return cfg, nil
+}
+
+func (m *CertificateManager) reloadLoop() {
+	ticker := time.NewTicker(m.cfg.reloadInterval())
+	defer ticker.Stop()
+	for {
+		select {
+		case <-m.closeCh:
+			return
+		case <-ticker.C:
+			reloaded, err := m.reload()
+			if err != nil {
+				m.metrics.reloadErrors.Inc(1)
+				m.logger.Errorf("could not reload tls certificates: %v", err)
+				continue
+			}
+			if reloaded {
+				m.metrics.reloads.Inc(1)
+				m.logger.Infof("reloaded tls certificates")
+			}
+		}
+	}
+}
+
+// reload reads the certificates from disk and swaps them in if any have
+// changed, the previous certificates are kept if any fail to load.
+func (m *CertificateManager) reload() (bool, error) {
+	var (
+		certPEM, keyPEM, caPEM []byte
+		err                    error
+	)
+	if m.cfg.CertFile != "" {
+		if certPEM, err = ioutil.ReadFile(m.cfg.CertFile); err != nil {
+			return false, err
+		}
+		if keyPEM, err = ioutil.ReadFile(m.cfg.KeyFile); err != nil {
+			return false, err
+		}
+	}
+	if m.cfg.CAFile != "" {
+		if caPEM, err = ioutil.ReadFile(m.cfg.CAFile); err != nil {
+			return false, err
+		}
+	}
+
+	m.RLock()
+	unchanged := bytes.Equal(certPEM, m.certPEM) &&
+		bytes.Equal(keyPEM, m.keyPEM) &&
+		bytes.Equal(caPEM, m.caPEM)
+	m.RUnlock()
+	if unchanged {
+		return false, nil
+	}
+
+	var certificate *tls.Certificate
+	if certPEM != nil {
+		cert, err := tls.X509KeyPair(certPEM, keyPEM)
+		if err != nil {
+			return false, err
+		}
+		certificate = &cert
+	}
+
+	var caPool *x509.CertPool
+	if caPEM != nil {
+		caPool = x509.NewCertPool()
+		if !caPool.AppendCertsFromPEM(caPEM) {
+			return false, errNoCACertificates
+		}
+	}
+
+	m.Lock()
+	m.certificate = certificate
+	m.caPool = caPool
+	m.certPEM = certPEM
+	m.keyPEM = keyPEM
+	m.caPEM = caPEM
+	m.Unlock()
+	return true, nil
+}
+
+type certificateManagerMetrics struct {
+	reloads      tally.Counter
+	reloadErrors tally.Counter
+}
+
+func newCertificateManagerMetrics(scope tally.Scope) certificateManagerMetrics {
+	return certificateManagerMetrics{
+		reloads:      scope.Counter("reloads"),
+		reloadErrors: scope.Counter("reload-errors"),
+	}
+}
@@ -0,0 +1,267 @@
+// Copyright (c) 2018 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package xtls
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"io/ioutil"
+	"math/big"
+	"net"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/m3db/m3x/instrument"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type testCA struct {
+	cert *x509.Certificate
+	key  *ecdsa.PrivateKey
+	pem  []byte
+}
+
+func newTestCA(t *testing.T) testCA {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	tmpl := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "test-ca"},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		IsCA:                  true,
+		KeyUsage:              x509.KeyUsageCertSign,
+		BasicConstraintsValid: true,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, tmpl, &key.PublicKey, key)
+	require.NoError(t, err)
+	cert, err := x509.ParseCertificate(der)
+	require.NoError(t, err)
+
+	return testCA{
+		cert: cert,
+		key:  key,
+		pem:  pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}),
+	}
+}
+
+// issue returns a PEM encoded certificate and key signed by the CA.
+func (ca testCA) issue(t *testing.T, serial int64, commonName string) ([]byte, []byte) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	tmpl := &x509.Certificate{
+		SerialNumber: big.NewInt(serial),
+		Subject:      pkix.Name{CommonName: commonName},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth, x509.ExtKeyUsageClientAuth},
+		IPAddresses:  []net.IP{net.ParseIP("127.0.0.1")},
+	}
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, ca.cert, &key.PublicKey, ca.key)
+	require.NoError(t, err)
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	require.NoError(t, err)
+
+	return pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}),
+		pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER})
+}
+
+func writeFile(t *testing.T, dir, name string, data []byte) string {
+	path := filepath.Join(dir, name)
+	require.NoError(t, ioutil.WriteFile(path, data, 0600))
+	return path
+}
+
+type testCerts struct {
+	dir    string
+	ca     testCA
+	server Configuration
+	client Configuration
+}
+
+func newTestCerts(t *testing.T) testCerts {
+	dir, err := ioutil.TempDir("", "xtls")
+	require.NoError(t, err)
+
+	ca := newTestCA(t)
+	caFile := writeFile(t, dir, "ca.pem", ca.pem)
+	serverCert, serverKey := ca.issue(t, 2, "server")
+	clientCert, clientKey := ca.issue(t, 3, "client")
+
+	return testCerts{
+		dir: dir,
+		ca:  ca,
+		server: Configuration{
+			CertFile:       writeFile(t, dir, "server.pem", serverCert),
+			KeyFile:        writeFile(t, dir, "server-key.pem", serverKey),
+			CAFile:         caFile,
+			ClientCertAuth: true,
+		},
+		client: Configuration{
+			CertFile: writeFile(t, dir, "client.pem", clientCert),
+			KeyFile:  writeFile(t, dir, "client-key.pem", clientKey),
+			CAFile:   caFile,
+		},
+	}
+}
+
+// serve accepts a single connection and completes its handshake, returning
+// the address and a channel with the handshake result.
+func serve(t *testing.T, cfg *tls.Config) (string, <-chan error) {
+	listener, err := Listen("127.0.0.1:0", cfg)
+	require.NoError(t, err)
+
+	errCh := make(chan error, 1)
+	go func() {
+		defer listener.Close()
+		conn, err := listener.Accept()
+		if err != nil {
+			errCh <- err
+			return
+		}
+		defer conn.Close()
+		errCh <- conn.(*tls.Conn).Handshake()
+	}()
+	return listener.Addr().String(), errCh
+}
+
+func TestCertificateManagerMutualTLS(t *testing.T) {
+	certs := newTestCerts(t)
+	defer os.RemoveAll(certs.dir)
+
+	server, err := certs.server.NewCertificateManager(instrument.NewOptions())
+	require.NoError(t, err)
+	defer server.Close()
+
+	client, err := certs.client.NewCertificateManager(instrument.NewOptions())
+	require.NoError(t, err)
+	defer client.Close()
+
+	address, errCh := serve(t, server.ServerConfig())
+	conn, err := client.DialContext(context.Background(), "tcp", address)
+	require.NoError(t, err)
+	defer conn.Close()
+	require.NoError(t, <-errCh)
+
+	state := conn.(*tls.Conn).ConnectionState()
+	require.Len(t, state.PeerCertificates, 1)
+	assert.Equal(t, "server", state.PeerCertificates[0].Subject.CommonName)
+}
+
+func TestCertificateManagerRejectsClientWithoutCertificate(t *testing.T) {
+	certs := newTestCerts(t)
+	defer os.RemoveAll(certs.dir)
+
+	server, err := certs.server.NewCertificateManager(instrument.NewOptions())
+	require.NoError(t, err)
+	defer server.Close()
+
+	noCert := certs.client
+	noCert.CertFile, noCert.KeyFile = "", ""
+	client, err := noCert.NewCertificateManager(instrument.NewOptions())
+	require.NoError(t, err)
+	defer client.Close()
+
+	address, errCh := serve(t, server.ServerConfig())
+	conn, err := client.DialContext(context.Background(), "tcp", address)
+	if err == nil {
+		// NB: the client may complete its side of the handshake before
+		// the server rejects the missing certificate.
+		conn.Close()
+	}
+	assert.Error(t, <-errCh)
+}
+
+func TestCertificateManagerReload(t *testing.T) {
+	certs := newTestCerts(t)
+	defer os.RemoveAll(certs.dir)
+
+	server, err := certs.server.NewCertificateManager(instrument.NewOptions())
+	require.NoError(t, err)
+	defer server.Close()
+
+	reloaded, err := server.reload()
+	require.NoError(t, err)
+	assert.False(t, reloaded)
+
+	cert, key := certs.ca.issue(t, 4, "rotated")
+	writeFile(t, certs.dir, "server.pem", cert)
+	writeFile(t, certs.dir, "server-key.pem", key)
+
+	reloaded, err = server.reload()
+	require.NoError(t, err)
+	assert.True(t, reloaded)
+
+	client, err := certs.client.NewCertificateManager(instrument.NewOptions())
+	require.NoError(t, err)
+	defer client.Close()
+
+	address, errCh := serve(t, server.ServerConfig())
+	conn, err := client.DialContext(context.Background(), "tcp", address)
+	require.NoError(t, err)
+	defer conn.Close()
+	require.NoError(t, <-errCh)
+
+	state := conn.(*tls.Conn).ConnectionState()
+	require.Len(t, state.PeerCertificates, 1)
+	assert.Equal(t, "rotated", state.PeerCertificates[0].Subject.CommonName)
+}
+
+func TestCertificateManagerReloadKeepsCertificatesOnError(t *testing.T) {
+	certs := newTestCerts(t)
+	defer os.RemoveAll(certs.dir)
+
+	server, err := certs.server.NewCertificateManager(instrument.NewOptions())
+	require.NoError(t, err)
+	defer server.Close()
+
+	writeFile(t, certs.dir, "server.pem", []byte("not a certificate"))
+	_, err = server.reload()
+	require.Error(t, err)
+
+	cfg, err := server.serverConfig()
+	require.NoError(t, err)
+	assert.Len(t, cfg.Certificates, 1)
+}
+
+func TestNewCertificateManagerInvalidConfiguration(t *testing.T) {
+	for _, cfg := range []Configuration{
+		{CertFile: "cert.pem"},
+		{ClientCertAuth: true},
+		{CAFile: "does-not-exist.pem"},
+	} {
+		_, err := NewCertificateManager(cfg, instrument.NewOptions())
+		assert.Error(t, err)
+	}
+}
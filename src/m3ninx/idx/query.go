@@ -63,6 +63,28 @@ func MustCreateRegexpQuery(field, regexp []byte) Query {
 	}
 }
 
+// NewFieldQuery returns a new query for finding documents which contain the given field.
+func NewFieldQuery(field []byte) Query {
+	return Query{
+		query: query.NewFieldQuery(field),
+	}
+}
+
+// NewPrefixQuery returns a new query for finding documents which contain a term for the
+// given field beginning with the given prefix.
+func NewPrefixQuery(field, prefix []byte) Query {
+	return Query{
+		query: query.NewPrefixQuery(field, prefix),
+	}
+}
+
+// NewAllQuery returns a new query for finding all documents.
+func NewAllQuery() Query {
+	return Query{
+		query: query.NewAllQuery(),
+	}
+}
+
 // NewNegationQuery returns a new query for finding documents which don't match a given query.
 func NewNegationQuery(q Query) Query {
 	return Query{
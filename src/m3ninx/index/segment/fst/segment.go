@@ -32,6 +32,7 @@ import (
 	sgmt "github.com/m3db/m3/src/m3ninx/index/segment"
 	"github.com/m3db/m3/src/m3ninx/index/segment/fst/encoding"
 	"github.com/m3db/m3/src/m3ninx/index/segment/fst/encoding/docs"
+	fstregexp "github.com/m3db/m3/src/m3ninx/index/segment/fst/regexp"
 	"github.com/m3db/m3/src/m3ninx/postings"
 	"github.com/m3db/m3/src/m3ninx/postings/pilosa"
 	"github.com/m3db/m3/src/m3ninx/postings/roaring"
@@ -307,6 +308,43 @@ func (r *fsSegment) MatchRegexp(field []byte, compiled index.CompiledRegex) (pos
 		return nil, errReaderNilRegexp
 	}
 
+	return r.matchTermsWithRLock(field, func(termsFST *vellum.FST) (*vellum.FSTIterator, error) {
+		return termsFST.Search(re, compiled.PrefixBegin, compiled.PrefixEnd)
+	})
+}
+
+func (r *fsSegment) MatchField(field []byte) (postings.List, error) {
+	r.RLock()
+	defer r.RUnlock()
+	if r.closed {
+		return nil, errReaderClosed
+	}
+
+	return r.matchTermsWithRLock(field, func(termsFST *vellum.FST) (*vellum.FSTIterator, error) {
+		return termsFST.Iterator(nil, nil)
+	})
+}
+
+func (r *fsSegment) MatchPrefix(field, prefix []byte) (postings.List, error) {
+	r.RLock()
+	defer r.RUnlock()
+	if r.closed {
+		return nil, errReaderClosed
+	}
+
+	// NB: terms are stored in lexicographic order so the terms beginning with the
+	// prefix are a contiguous range of the terms FST.
+	return r.matchTermsWithRLock(field, func(termsFST *vellum.FST) (*vellum.FSTIterator, error) {
+		return termsFST.Iterator(prefix, fstregexp.IncrementBytes(prefix))
+	})
+}
+
+// matchTermsWithRLock returns the union of the postings lists of the terms returned
+// by the iterator over the terms FST of the given field.
+func (r *fsSegment) matchTermsWithRLock(
+	field []byte,
+	iterFn func(termsFST *vellum.FST) (*vellum.FSTIterator, error),
+) (postings.List, error) {
 	termsFST, exists, err := r.retrieveTermsFSTWithRLock(field)
 	if err != nil {
 		return nil, err
@@ -320,7 +358,7 @@ func (r *fsSegment) MatchRegexp(field []byte, compiled index.CompiledRegex) (pos
 	var (
 		fstCloser     = x.NewSafeCloser(termsFST)
 		pl            = r.opts.PostingsListPool().Get()
-		iter, iterErr = termsFST.Search(re, compiled.PrefixBegin, compiled.PrefixEnd)
+		iter, iterErr = iterFn(termsFST)
 		iterCloser    = x.NewSafeCloser(iter)
 	)
 	defer func() {
@@ -516,6 +554,24 @@ func (sr *fsSegmentReader) MatchRegexp(field []byte, compiled index.CompiledRege
 	return sr.fsSegment.MatchRegexp(field, compiled)
 }
 
+func (sr *fsSegmentReader) MatchField(field []byte) (postings.List, error) {
+	sr.RLock()
+	defer sr.RUnlock()
+	if sr.closed {
+		return nil, errReaderClosed
+	}
+	return sr.fsSegment.MatchField(field)
+}
+
+func (sr *fsSegmentReader) MatchPrefix(field, prefix []byte) (postings.List, error) {
+	sr.RLock()
+	defer sr.RUnlock()
+	if sr.closed {
+		return nil, errReaderClosed
+	}
+	return sr.fsSegment.MatchPrefix(field, prefix)
+}
+
 func (sr *fsSegmentReader) MatchAll() (postings.MutableList, error) {
 	sr.RLock()
 	defer sr.RUnlock()
@@ -299,6 +299,69 @@ func TestPostingsListRegexAll(t *testing.T) {
 	}
 }
 
+func TestPostingsListEqualForMatchField(t *testing.T) {
+	for _, test := range testDocuments {
+		t.Run(test.name, func(t *testing.T) {
+			memSeg, fstSeg := newTestSegments(t, test.docs)
+			memReader, err := memSeg.Reader()
+			require.NoError(t, err)
+			fstReader, err := fstSeg.Reader()
+			require.NoError(t, err)
+
+			fieldsIter, err := memSeg.Fields()
+			require.NoError(t, err)
+			fields := toSlice(t, fieldsIter)
+			fields = append(fields, []byte("unknown"))
+			for _, f := range fields {
+				memPl, err := memReader.MatchField(f)
+				require.NoError(t, err)
+				fstPl, err := fstReader.MatchField(f)
+				require.NoError(t, err)
+				require.True(t, memPl.Equal(fstPl),
+					fmt.Sprintf("%s - [%v] != [%v]", string(f), pprintIter(memPl), pprintIter(fstPl)))
+			}
+		})
+	}
+}
+
+func TestPostingsListEqualForMatchPrefix(t *testing.T) {
+	for _, test := range testDocuments {
+		t.Run(test.name, func(t *testing.T) {
+			memSeg, fstSeg := newTestSegments(t, test.docs)
+			memReader, err := memSeg.Reader()
+			require.NoError(t, err)
+			fstReader, err := fstSeg.Reader()
+			require.NoError(t, err)
+
+			fieldsIter, err := memSeg.Fields()
+			require.NoError(t, err)
+			fields := toSlice(t, fieldsIter)
+			for _, f := range fields {
+				termsIter, err := memSeg.Terms(f)
+				require.NoError(t, err)
+				terms := toSlice(t, termsIter)
+
+				prefixes := map[string]struct{}{"": struct{}{}, "zzz": struct{}{}}
+				for _, term := range terms {
+					for i := 1; i <= len(term) && i <= 3; i++ {
+						prefixes[string(term[:i])] = struct{}{}
+					}
+				}
+
+				for p := range prefixes {
+					prefix := []byte(p)
+					memPl, err := memReader.MatchPrefix(f, prefix)
+					require.NoError(t, err)
+					fstPl, err := fstReader.MatchPrefix(f, prefix)
+					require.NoError(t, err)
+					require.True(t, memPl.Equal(fstPl),
+						fmt.Sprintf("%s:%s - [%v] != [%v]", string(f), string(prefix), pprintIter(memPl), pprintIter(fstPl)))
+				}
+			}
+		})
+	}
+}
+
 func TestSegmentDocs(t *testing.T) {
 	for _, test := range testDocuments {
 		t.Run(test.name, func(t *testing.T) {
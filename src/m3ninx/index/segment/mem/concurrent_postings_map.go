@@ -21,6 +21,7 @@
 package mem
 
 import (
+	"bytes"
 	"regexp"
 	"sync"
 
@@ -102,14 +103,34 @@ func (m *concurrentPostingsMap) Get(key []byte) (postings.List, bool) {
 // GetRegex returns the union of the postings lists whose keys match the
 // provided regexp.
 func (m *concurrentPostingsMap) GetRegex(re *regexp.Regexp) (postings.List, bool) {
+	return m.getMatching(re.Match)
+}
+
+// GetPrefix returns the union of the postings lists whose keys begin with the
+// provided prefix.
+func (m *concurrentPostingsMap) GetPrefix(prefix []byte) (postings.List, bool) {
+	return m.getMatching(func(key []byte) bool {
+		return bytes.HasPrefix(key, prefix)
+	})
+}
+
+// GetAll returns the union of all the postings lists in the map.
+func (m *concurrentPostingsMap) GetAll() (postings.List, bool) {
+	return m.getMatching(func([]byte) bool {
+		return true
+	})
+}
+
+// getMatching returns the union of the postings lists whose keys satisfy the
+// provided predicate.
+func (m *concurrentPostingsMap) getMatching(match func(key []byte) bool) (postings.List, bool) {
 	var pl postings.MutableList
 
 	m.RLock()
 	for _, mapEntry := range m.postingsMap.Iter() {
 		// TODO: Evaluate lock contention caused by holding on to the read lock while
 		// evaluating this predicate.
-		// TODO: Evaluate if performing a prefix match would speed up the common case.
-		if re.Match(mapEntry.Key()) {
+		if match(mapEntry.Key()) {
 			if pl == nil {
 				pl = mapEntry.Value().Clone()
 			} else {
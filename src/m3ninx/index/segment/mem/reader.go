@@ -91,6 +91,28 @@ func (r *reader) MatchRegexp(field []byte, compiled index.CompiledRegex) (postin
 	return r.segment.matchRegexp(field, compileRE)
 }
 
+func (r *reader) MatchField(field []byte) (postings.List, error) {
+	r.RLock()
+	defer r.RUnlock()
+	if r.closed {
+		return nil, errSegmentReaderClosed
+	}
+
+	// NB: as with MatchTerm, IDs greater than the reader's limit are only filtered
+	// out when fetching the documents through a call to Docs.
+	return r.segment.matchField(field)
+}
+
+func (r *reader) MatchPrefix(field, prefix []byte) (postings.List, error) {
+	r.RLock()
+	defer r.RUnlock()
+	if r.closed {
+		return nil, errSegmentReaderClosed
+	}
+
+	return r.segment.matchPrefix(field, prefix)
+}
+
 func (r *reader) MatchAll() (postings.MutableList, error) {
 	r.RLock()
 	defer r.RUnlock()
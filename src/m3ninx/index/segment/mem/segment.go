@@ -333,6 +333,26 @@ func (s *segment) matchRegexp(field []byte, compiled *re.Regexp) (postings.List,
 	return s.termsDict.MatchRegexp(field, compiled), nil
 }
 
+func (s *segment) matchField(field []byte) (postings.List, error) {
+	s.state.RLock()
+	defer s.state.RUnlock()
+	if s.state.closed {
+		return nil, sgmt.ErrClosed
+	}
+
+	return s.termsDict.MatchField(field), nil
+}
+
+func (s *segment) matchPrefix(field, prefix []byte) (postings.List, error) {
+	s.state.RLock()
+	defer s.state.RUnlock()
+	if s.state.closed {
+		return nil, sgmt.ErrClosed
+	}
+
+	return s.termsDict.MatchPrefix(field, prefix), nil
+}
+
 func (s *segment) getDoc(id postings.ID) (doc.Document, error) {
 	s.state.RLock()
 	defer s.state.RUnlock()
@@ -69,6 +69,14 @@ type Readable interface {
 	// regular expression.
 	MatchRegexp(field []byte, c CompiledRegex) (postings.List, error)
 
+	// MatchField returns a postings list over all documents which contain the given
+	// field, regardless of the field's value.
+	MatchField(field []byte) (postings.List, error)
+
+	// MatchPrefix returns a postings list over all documents which contain a term
+	// for the given field beginning with the given prefix.
+	MatchPrefix(field, prefix []byte) (postings.List, error)
+
 	// MatchAll returns a postings list for all documents known to the Reader.
 	MatchAll() (postings.MutableList, error)
 
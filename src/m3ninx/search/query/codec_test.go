@@ -40,6 +40,18 @@ func TestMarshal(t *testing.T) {
 			name:  "regexp query",
 			query: MustCreateRegexpQuery([]byte("fruit"), []byte(".*ple")),
 		},
+		{
+			name:  "field query",
+			query: NewFieldQuery([]byte("fruit")),
+		},
+		{
+			name:  "prefix query",
+			query: NewPrefixQuery([]byte("fruit"), []byte("app")),
+		},
+		{
+			name:  "all query",
+			query: NewAllQuery(),
+		},
 		{
 			name:  "negation query",
 			query: NewNegationQuery(NewTermQuery([]byte("fruit"), []byte("apple"))),
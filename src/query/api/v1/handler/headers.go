@@ -32,4 +32,12 @@ const (
 
 	// DeprecatedHeader is the M3 deprecated header
 	DeprecatedHeader = "M3-Deprecated"
+
+	// LimitHeader is the M3 header set when a query hit a limit and the
+	// returned results are not exhaustive
+	LimitHeader = "M3-Results-Limited"
+
+	// RequireExhaustiveHeader is the M3 header a caller can set to override
+	// whether partial results should be returned as an error
+	RequireExhaustiveHeader = "M3-Require-Exhaustive"
 )
@@ -28,6 +28,7 @@ import (
 
 	"github.com/m3db/m3/src/query/api/v1/handler"
 	"github.com/m3db/m3/src/query/models"
+	"github.com/m3db/m3/src/query/relabel"
 	"github.com/m3db/m3/src/query/storage"
 	"github.com/m3db/m3/src/query/ts"
 	"github.com/m3db/m3/src/query/util"
@@ -47,13 +48,16 @@ const (
 
 // WriteJSONHandler represents a handler for the write json endpoint
 type WriteJSONHandler struct {
-	store storage.Storage
+	store     storage.Storage
+	relabeler *relabel.Relabeler
 }
 
-// NewWriteJSONHandler returns a new instance of handler.
-func NewWriteJSONHandler(store storage.Storage) http.Handler {
+// NewWriteJSONHandler returns a new instance of handler, if a relabeler is
+// set the series is relabeled before being written.
+func NewWriteJSONHandler(store storage.Storage, relabeler *relabel.Relabeler) http.Handler {
 	return &WriteJSONHandler{
-		store: store,
+		store:     store,
+		relabeler: relabeler,
 	}
 }
 
@@ -76,6 +80,16 @@ func (h *WriteJSONHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 	if err != nil {
 		logging.WithContext(r.Context()).Error("Parsing error", zap.Any("err", err))
 		handler.Error(w, err, http.StatusInternalServerError)
+		return
+	}
+
+	if h.relabeler != nil {
+		tags, keep := h.relabeler.Relabel(writeQuery.Tags)
+		if !keep {
+			// Dropped by a relabel rule
+			return
+		}
+		writeQuery.Tags = tags
 	}
 
 	if err := h.store.Write(r.Context(), writeQuery); err != nil {
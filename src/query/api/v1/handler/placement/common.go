@@ -25,6 +25,7 @@ import (
 	"strings"
 
 	"github.com/m3db/m3/src/cmd/services/m3query/config"
+	dbclient "github.com/m3db/m3/src/dbnode/client"
 	"github.com/m3db/m3/src/query/util/logging"
 	clusterclient "github.com/m3db/m3cluster/client"
 	"github.com/m3db/m3cluster/generated/proto/placementpb"
@@ -121,7 +122,12 @@ func ConvertInstancesProto(instancesProto []*placementpb.Instance) ([]placement.
 }
 
 // RegisterRoutes registers the placement routes
-func RegisterRoutes(r *mux.Router, client clusterclient.Client, cfg config.Configuration) {
+func RegisterRoutes(
+	r *mux.Router,
+	client clusterclient.Client,
+	cfg config.Configuration,
+	session dbclient.NodeHealthSession,
+) {
 	logged := logging.WithResponseTimeLogging
 
 	r.HandleFunc(InitURL, logged(NewInitHandler(client, cfg)).ServeHTTP).Methods(InitHTTPMethod)
@@ -129,4 +135,7 @@ func RegisterRoutes(r *mux.Router, client clusterclient.Client, cfg config.Confi
 	r.HandleFunc(DeleteAllURL, logged(NewDeleteAllHandler(client, cfg)).ServeHTTP).Methods(DeleteAllHTTPMethod)
 	r.HandleFunc(AddURL, logged(NewAddHandler(client, cfg)).ServeHTTP).Methods(AddHTTPMethod)
 	r.HandleFunc(DeleteURL, logged(NewDeleteHandler(client, cfg)).ServeHTTP).Methods(DeleteHTTPMethod)
+	r.HandleFunc(ReplaceURL, logged(NewReplaceHandler(client, cfg)).ServeHTTP).Methods(ReplaceHTTPMethod)
+	r.HandleFunc(MarkAvailableURL, logged(NewMarkAvailableHandler(client, cfg)).ServeHTTP).Methods(MarkAvailableHTTPMethod)
+	r.HandleFunc(StatusURL, logged(NewStatusHandler(client, cfg, session)).ServeHTTP).Methods(StatusHTTPMethod)
 }
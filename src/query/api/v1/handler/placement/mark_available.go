@@ -0,0 +1,119 @@
+// Copyright (c) 2018 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package placement
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/m3db/m3/src/cmd/services/m3query/config"
+	"github.com/m3db/m3/src/query/api/v1/handler"
+	"github.com/m3db/m3/src/query/generated/proto/admin"
+	"github.com/m3db/m3/src/query/util/logging"
+	clusterclient "github.com/m3db/m3cluster/client"
+	"github.com/m3db/m3cluster/placement"
+
+	"github.com/gogo/protobuf/jsonpb"
+	"go.uber.org/zap"
+)
+
+const (
+	// MarkAvailableURL is the url for the handler to mark shards as available (with the POST method).
+	MarkAvailableURL = handler.RoutePrefixV1 + "/placement/available"
+
+	// MarkAvailableHTTPMethod is the HTTP method used with this resource.
+	MarkAvailableHTTPMethod = http.MethodPost
+)
+
+var errEmptyInstanceID = errors.New("must specify instance ID to mark available")
+
+// MarkAvailableHandler is the handler to mark shards in a placement as available.
+type MarkAvailableHandler Handler
+
+// NewMarkAvailableHandler returns a new instance of MarkAvailableHandler.
+func NewMarkAvailableHandler(client clusterclient.Client, cfg config.Configuration) *MarkAvailableHandler {
+	return &MarkAvailableHandler{client: client, cfg: cfg}
+}
+
+func (h *MarkAvailableHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	logger := logging.WithContext(ctx)
+
+	req, rErr := h.parseRequest(r)
+	if rErr != nil {
+		handler.Error(w, rErr.Inner(), rErr.Code())
+		return
+	}
+
+	placement, err := h.MarkAvailable(r, req)
+	if err != nil {
+		logger.Error("unable to mark shards as available", zap.Any("error", err))
+		handler.Error(w, err, http.StatusInternalServerError)
+		return
+	}
+
+	placementProto, err := placement.Proto()
+	if err != nil {
+		logger.Error("unable to get placement protobuf", zap.Any("error", err))
+		handler.Error(w, err, http.StatusInternalServerError)
+		return
+	}
+
+	resp := &admin.PlacementGetResponse{
+		Placement: placementProto,
+	}
+
+	handler.WriteProtoMsgJSONResponse(w, resp, logger)
+}
+
+func (h *MarkAvailableHandler) parseRequest(
+	r *http.Request,
+) (*admin.PlacementMarkAvailableRequest, *handler.ParseError) {
+	defer r.Body.Close()
+	markReq := new(admin.PlacementMarkAvailableRequest)
+	if err := jsonpb.Unmarshal(r.Body, markReq); err != nil {
+		return nil, handler.NewParseError(err, http.StatusBadRequest)
+	}
+
+	if markReq.InstanceId == "" {
+		return nil, handler.NewParseError(errEmptyInstanceID, http.StatusBadRequest)
+	}
+
+	return markReq, nil
+}
+
+// MarkAvailable marks the requested shards of an instance as available, or
+// all of its shards if none are specified.
+func (h *MarkAvailableHandler) MarkAvailable(
+	httpReq *http.Request,
+	req *admin.PlacementMarkAvailableRequest,
+) (placement.Placement, error) {
+	service, err := Service(h.client, httpReq.Header)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(req.ShardIds) == 0 {
+		return service.MarkInstanceAvailable(req.InstanceId)
+	}
+
+	return service.MarkShardsAvailable(req.InstanceId, req.ShardIds...)
+}
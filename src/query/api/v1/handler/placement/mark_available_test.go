@@ -0,0 +1,90 @@
+// Copyright (c) 2018 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package placement
+
+import (
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/m3db/m3/src/cmd/services/m3query/config"
+	"github.com/m3db/m3cluster/placement"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPlacementMarkAvailableHandler(t *testing.T) {
+	mockClient, mockPlacementService := SetupPlacementTest(t)
+	handler := NewMarkAvailableHandler(mockClient, config.Configuration{})
+
+	// Test missing instance ID
+	w := httptest.NewRecorder()
+	req := httptest.NewRequest("POST", "/placement/available", strings.NewReader("{}"))
+	require.NotNil(t, req)
+
+	handler.ServeHTTP(w, req)
+
+	resp := w.Result()
+	body, _ := ioutil.ReadAll(resp.Body)
+	assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+	assert.Equal(t, "{\"error\":\"must specify instance ID to mark available\"}\n", string(body))
+
+	// Test marking the whole instance available
+	w = httptest.NewRecorder()
+	req = httptest.NewRequest("POST", "/placement/available", strings.NewReader("{\"instance_id\":\"host1\"}"))
+	require.NotNil(t, req)
+
+	mockPlacementService.EXPECT().MarkInstanceAvailable("host1").Return(placement.NewPlacement(), nil)
+	handler.ServeHTTP(w, req)
+
+	resp = w.Result()
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+
+	// Test marking specific shards available
+	w = httptest.NewRecorder()
+	req = httptest.NewRequest("POST", "/placement/available", strings.NewReader("{\"instance_id\":\"host1\",\"shard_ids\":[1,3]}"))
+	require.NotNil(t, req)
+
+	mockPlacementService.EXPECT().MarkShardsAvailable("host1", uint32(1), uint32(3)).Return(placement.NewPlacement(), nil)
+	handler.ServeHTTP(w, req)
+
+	resp = w.Result()
+	body, _ = ioutil.ReadAll(resp.Body)
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, "{\"placement\":{\"instances\":{},\"replicaFactor\":0,\"numShards\":0,\"isSharded\":false,\"cutoverTime\":\"0\",\"isMirrored\":false,\"maxShardSetId\":0},\"version\":0}", string(body))
+
+	// Test mark available failure
+	w = httptest.NewRecorder()
+	req = httptest.NewRequest("POST", "/placement/available", strings.NewReader("{\"instance_id\":\"host1\",\"shard_ids\":[7]}"))
+	require.NotNil(t, req)
+
+	mockPlacementService.EXPECT().MarkShardsAvailable("host1", uint32(7)).Return(nil, errors.New("shard 7 is not initializing"))
+	handler.ServeHTTP(w, req)
+
+	resp = w.Result()
+	body, _ = ioutil.ReadAll(resp.Body)
+	assert.Equal(t, http.StatusInternalServerError, resp.StatusCode)
+	assert.Equal(t, "{\"error\":\"shard 7 is not initializing\"}\n", string(body))
+}
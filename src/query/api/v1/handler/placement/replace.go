@@ -0,0 +1,130 @@
+// Copyright (c) 2018 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package placement
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/m3db/m3/src/cmd/services/m3query/config"
+	"github.com/m3db/m3/src/query/api/v1/handler"
+	"github.com/m3db/m3/src/query/generated/proto/admin"
+	"github.com/m3db/m3/src/query/util/logging"
+	clusterclient "github.com/m3db/m3cluster/client"
+	"github.com/m3db/m3cluster/placement"
+
+	"github.com/gogo/protobuf/jsonpb"
+	"go.uber.org/zap"
+)
+
+const (
+	// ReplaceURL is the url for the placement replace handler (with the POST method).
+	ReplaceURL = handler.RoutePrefixV1 + "/placement/replace"
+
+	// ReplaceHTTPMethod is the HTTP method used with this resource.
+	ReplaceHTTPMethod = http.MethodPost
+)
+
+var (
+	errEmptyLeavingInstances = errors.New("must specify at least one leaving instance ID")
+	errEmptyCandidates       = errors.New("must specify at least one candidate instance")
+)
+
+// ReplaceHandler is the handler for placement replaces.
+type ReplaceHandler Handler
+
+// NewReplaceHandler returns a new instance of ReplaceHandler.
+func NewReplaceHandler(client clusterclient.Client, cfg config.Configuration) *ReplaceHandler {
+	return &ReplaceHandler{client: client, cfg: cfg}
+}
+
+func (h *ReplaceHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	logger := logging.WithContext(ctx)
+
+	req, rErr := h.parseRequest(r)
+	if rErr != nil {
+		handler.Error(w, rErr.Inner(), rErr.Code())
+		return
+	}
+
+	placement, err := h.Replace(r, req)
+	if err != nil {
+		logger.Error("unable to replace instances in placement", zap.Any("error", err))
+		handler.Error(w, err, http.StatusInternalServerError)
+		return
+	}
+
+	placementProto, err := placement.Proto()
+	if err != nil {
+		logger.Error("unable to get placement protobuf", zap.Any("error", err))
+		handler.Error(w, err, http.StatusInternalServerError)
+		return
+	}
+
+	resp := &admin.PlacementGetResponse{
+		Placement: placementProto,
+	}
+
+	handler.WriteProtoMsgJSONResponse(w, resp, logger)
+}
+
+func (h *ReplaceHandler) parseRequest(r *http.Request) (*admin.PlacementReplaceRequest, *handler.ParseError) {
+	defer r.Body.Close()
+	replaceReq := new(admin.PlacementReplaceRequest)
+	if err := jsonpb.Unmarshal(r.Body, replaceReq); err != nil {
+		return nil, handler.NewParseError(err, http.StatusBadRequest)
+	}
+
+	if len(replaceReq.LeavingInstanceIds) == 0 {
+		return nil, handler.NewParseError(errEmptyLeavingInstances, http.StatusBadRequest)
+	}
+
+	if len(replaceReq.Candidates) == 0 {
+		return nil, handler.NewParseError(errEmptyCandidates, http.StatusBadRequest)
+	}
+
+	return replaceReq, nil
+}
+
+// Replace replaces the leaving instances in a placement with instances
+// chosen from the candidates by the placement algorithm.
+func (h *ReplaceHandler) Replace(
+	httpReq *http.Request,
+	req *admin.PlacementReplaceRequest,
+) (placement.Placement, error) {
+	candidates, err := ConvertInstancesProto(req.Candidates)
+	if err != nil {
+		return nil, err
+	}
+
+	service, err := Service(h.client, httpReq.Header)
+	if err != nil {
+		return nil, err
+	}
+
+	newPlacement, _, err := service.ReplaceInstances(req.LeavingInstanceIds, candidates)
+	if err != nil {
+		return nil, err
+	}
+
+	return newPlacement, nil
+}
@@ -0,0 +1,232 @@
+// Copyright (c) 2018 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package placement
+
+import (
+	"errors"
+	"net/http"
+	"sort"
+	"sync"
+
+	"github.com/m3db/m3/src/cmd/services/m3query/config"
+	dbclient "github.com/m3db/m3/src/dbnode/client"
+	"github.com/m3db/m3/src/dbnode/generated/thrift/rpc"
+	"github.com/m3db/m3/src/query/api/v1/handler"
+	"github.com/m3db/m3/src/query/generated/proto/admin"
+	"github.com/m3db/m3/src/query/util/logging"
+	clusterclient "github.com/m3db/m3cluster/client"
+	"github.com/m3db/m3cluster/placement"
+	"github.com/m3db/m3cluster/shard"
+
+	"go.uber.org/zap"
+)
+
+const (
+	// StatusURL is the url for the placement status handler (with the GET method).
+	StatusURL = handler.RoutePrefixV1 + "/placement/status"
+
+	// StatusHTTPMethod is the HTTP method used with this resource.
+	StatusHTTPMethod = http.MethodGet
+)
+
+var (
+	errNoNodeHealthSession = errors.New("no database session to check node health with")
+)
+
+// nodeHealthFn returns the health of the database node serving an instance.
+type nodeHealthFn func(instance placement.Instance) (*rpc.NodeHealthResult_, error)
+
+// StatusHandler is the handler for placement status, it joins the shard
+// states of the placement with the shards each node reports as bootstrapped
+// so that the progress of a rebalance can be followed.
+type StatusHandler struct {
+	client     clusterclient.Client
+	cfg        config.Configuration
+	nodeHealth nodeHealthFn
+}
+
+// NewStatusHandler returns a new instance of StatusHandler, the health of the
+// nodes is checked over the connections of the database session so that the
+// session's TLS configuration applies.
+func NewStatusHandler(
+	client clusterclient.Client,
+	cfg config.Configuration,
+	session dbclient.NodeHealthSession,
+) *StatusHandler {
+	return &StatusHandler{
+		client:     client,
+		cfg:        cfg,
+		nodeHealth: sessionNodeHealth(session),
+	}
+}
+
+func (h *StatusHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	logger := logging.WithContext(ctx)
+
+	service, err := Service(h.client, r.Header)
+	if err != nil {
+		handler.Error(w, err, http.StatusInternalServerError)
+		return
+	}
+
+	placement, version, err := service.Placement()
+	if err != nil {
+		handler.Error(w, err, http.StatusNotFound)
+		return
+	}
+
+	resp := h.Status(placement, version)
+	for _, instance := range resp.Instances {
+		if instance.Error != "" {
+			logger.Warn("unable to check node health",
+				zap.String("instance", instance.Id),
+				zap.String("endpoint", instance.Endpoint),
+				zap.String("error", instance.Error))
+		}
+	}
+
+	handler.WriteProtoMsgJSONResponse(w, resp, logger)
+}
+
+// Status returns the shard states of each instance in the placement along
+// with the shards the node serving the instance has bootstrapped.
+func (h *StatusHandler) Status(
+	p placement.Placement,
+	version int,
+) *admin.PlacementStatusResponse {
+	var (
+		instances = p.Instances()
+		statuses  = make([]*admin.PlacementInstanceStatus, len(instances))
+		wg        sync.WaitGroup
+	)
+	for i, instance := range instances {
+		i, instance := i, instance
+		wg.Add(1)
+		go func() {
+			statuses[i] = h.instanceStatus(instance)
+			wg.Done()
+		}()
+	}
+	wg.Wait()
+
+	sort.Slice(statuses, func(i, j int) bool {
+		return statuses[i].Id < statuses[j].Id
+	})
+
+	resp := &admin.PlacementStatusResponse{
+		Version:   int32(version),
+		NumShards: uint32(p.NumShards()),
+		Instances: statuses,
+	}
+
+	allBootstrapped := true
+	for _, status := range statuses {
+		resp.InitializingShards += uint32(len(status.InitializingShards))
+		resp.AvailableShards += uint32(len(status.AvailableShards))
+		resp.LeavingShards += uint32(len(status.LeavingShards))
+		if !status.Bootstrapped ||
+			len(status.BootstrappedShards) < len(status.AvailableShards) {
+			allBootstrapped = false
+		}
+	}
+
+	resp.RebalanceComplete = allBootstrapped &&
+		resp.InitializingShards == 0 &&
+		resp.LeavingShards == 0
+
+	return resp
+}
+
+func (h *StatusHandler) instanceStatus(instance placement.Instance) *admin.PlacementInstanceStatus {
+	status := &admin.PlacementInstanceStatus{
+		Id:                 instance.ID(),
+		Endpoint:           instance.Endpoint(),
+		InitializingShards: shardIDsForState(instance, shard.Initializing),
+		AvailableShards:    shardIDsForState(instance, shard.Available),
+		LeavingShards:      shardIDsForState(instance, shard.Leaving),
+	}
+
+	health, err := h.nodeHealth(instance)
+	if err != nil {
+		status.Error = err.Error()
+		return status
+	}
+
+	status.HealthStatus = health.Status
+	status.Bootstrapped = health.Bootstrapped
+	status.BootstrappedShards = bootstrappedShards(instance, health)
+	return status
+}
+
+// bootstrappedShards returns the shards of the instance the node reports as
+// bootstrapped, nodes that do not report their bootstrapped shards are
+// treated as having bootstrapped all of their shards once bootstrapped.
+func bootstrappedShards(
+	instance placement.Instance,
+	health *rpc.NodeHealthResult_,
+) []uint32 {
+	if !health.IsSetBootstrappedShards() {
+		if !health.Bootstrapped {
+			return nil
+		}
+		return shardIDsForState(instance, shard.Initializing, shard.Available, shard.Leaving)
+	}
+
+	ids := make([]uint32, 0, len(health.BootstrappedShards))
+	for _, id := range health.BootstrappedShards {
+		if instance.Shards().Contains(uint32(id)) {
+			ids = append(ids, uint32(id))
+		}
+	}
+
+	sort.Slice(ids, func(i, j int) bool {
+		return ids[i] < ids[j]
+	})
+
+	return ids
+}
+
+func shardIDsForState(instance placement.Instance, states ...shard.State) []uint32 {
+	var ids []uint32
+	for _, state := range states {
+		for _, s := range instance.Shards().ShardsForState(state) {
+			ids = append(ids, s.ID())
+		}
+	}
+
+	sort.Slice(ids, func(i, j int) bool {
+		return ids[i] < ids[j]
+	})
+
+	return ids
+}
+
+// sessionNodeHealth returns a function checking the health of the node
+// serving an instance with the database session.
+func sessionNodeHealth(session dbclient.NodeHealthSession) nodeHealthFn {
+	return func(instance placement.Instance) (*rpc.NodeHealthResult_, error) {
+		if session == nil {
+			return nil, errNoNodeHealthSession
+		}
+		return session.NodeHealth(instance.ID())
+	}
+}
@@ -0,0 +1,193 @@
+// Copyright (c) 2018 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package placement
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/m3db/m3/src/cmd/services/m3query/config"
+	"github.com/m3db/m3/src/dbnode/generated/thrift/rpc"
+	"github.com/m3db/m3/src/query/generated/proto/admin"
+	"github.com/m3db/m3cluster/placement"
+	"github.com/m3db/m3cluster/shard"
+
+	"github.com/gogo/protobuf/jsonpb"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newStatusTestPlacement() placement.Placement {
+	host1 := placement.NewInstance().
+		SetID("host1").
+		SetEndpoint("host1:9000").
+		SetShards(shard.NewShards([]shard.Shard{
+			shard.NewShard(0).SetState(shard.Available),
+			shard.NewShard(1).SetState(shard.Leaving),
+		}))
+	host2 := placement.NewInstance().
+		SetID("host2").
+		SetEndpoint("host2:9000").
+		SetShards(shard.NewShards([]shard.Shard{
+			shard.NewShard(1).SetState(shard.Initializing).SetSourceID("host1"),
+		}))
+
+	return placement.NewPlacement().
+		SetInstances([]placement.Instance{host2, host1}).
+		SetShards([]uint32{0, 1}).
+		SetReplicaFactor(1).
+		SetIsSharded(true)
+}
+
+func TestPlacementStatusHandler(t *testing.T) {
+	mockClient, mockPlacementService := SetupPlacementTest(t)
+	handler := NewStatusHandler(mockClient, config.Configuration{}, nil)
+	handler.nodeHealth = func(instance placement.Instance) (*rpc.NodeHealthResult_, error) {
+		if instance.ID() == "host2" {
+			return nil, errors.New("connection refused")
+		}
+		return &rpc.NodeHealthResult_{
+			Ok:                 true,
+			Status:             "up",
+			Bootstrapped:       true,
+			BootstrappedShards: []int32{1, 0, 2},
+		}, nil
+	}
+
+	w := httptest.NewRecorder()
+	req := httptest.NewRequest("GET", "/placement/status", nil)
+	require.NotNil(t, req)
+
+	mockPlacementService.EXPECT().Placement().Return(newStatusTestPlacement(), 3, nil)
+	handler.ServeHTTP(w, req)
+
+	resp := w.Result()
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+
+	var status admin.PlacementStatusResponse
+	require.NoError(t, jsonpb.Unmarshal(resp.Body, &status))
+	assert.Equal(t, int32(3), status.Version)
+	assert.Equal(t, uint32(2), status.NumShards)
+	assert.Equal(t, uint32(1), status.InitializingShards)
+	assert.Equal(t, uint32(1), status.AvailableShards)
+	assert.Equal(t, uint32(1), status.LeavingShards)
+	assert.False(t, status.RebalanceComplete)
+
+	require.Equal(t, 2, len(status.Instances))
+	assert.Equal(t, "host1", status.Instances[0].Id)
+	assert.Equal(t, []uint32{0}, status.Instances[0].AvailableShards)
+	assert.Equal(t, []uint32{1}, status.Instances[0].LeavingShards)
+	assert.Equal(t, "up", status.Instances[0].HealthStatus)
+	assert.True(t, status.Instances[0].Bootstrapped)
+	assert.Equal(t, []uint32{0, 1}, status.Instances[0].BootstrappedShards)
+	assert.Equal(t, "host2", status.Instances[1].Id)
+	assert.Equal(t, []uint32{1}, status.Instances[1].InitializingShards)
+	assert.False(t, status.Instances[1].Bootstrapped)
+	assert.Nil(t, status.Instances[1].BootstrappedShards)
+	assert.Equal(t, "connection refused", status.Instances[1].Error)
+
+	// Test error case
+	w = httptest.NewRecorder()
+	req = httptest.NewRequest("GET", "/placement/status", nil)
+	require.NotNil(t, req)
+
+	mockPlacementService.EXPECT().Placement().Return(nil, 0, errors.New("key not found"))
+	handler.ServeHTTP(w, req)
+
+	resp = w.Result()
+	assert.Equal(t, http.StatusNotFound, resp.StatusCode)
+}
+
+func TestPlacementStatusRebalanceComplete(t *testing.T) {
+	handler := &StatusHandler{
+		nodeHealth: func(instance placement.Instance) (*rpc.NodeHealthResult_, error) {
+			return &rpc.NodeHealthResult_{Ok: true, Status: "up", Bootstrapped: true}, nil
+		},
+	}
+
+	instance := placement.NewInstance().
+		SetID("host1").
+		SetEndpoint("host1:9000").
+		SetShards(shard.NewShards([]shard.Shard{
+			shard.NewShard(0).SetState(shard.Available),
+			shard.NewShard(1).SetState(shard.Available),
+		}))
+	p := placement.NewPlacement().
+		SetInstances([]placement.Instance{instance}).
+		SetShards([]uint32{0, 1}).
+		SetReplicaFactor(1).
+		SetIsSharded(true)
+
+	status := handler.Status(p, 1)
+	assert.Equal(t, uint32(2), status.AvailableShards)
+	assert.True(t, status.RebalanceComplete)
+
+	// Available shards the node has not bootstrapped yet keep the rebalance
+	// from completing
+	handler.nodeHealth = func(instance placement.Instance) (*rpc.NodeHealthResult_, error) {
+		return &rpc.NodeHealthResult_{
+			Ok:                 true,
+			Status:             "up",
+			Bootstrapped:       true,
+			BootstrappedShards: []int32{1},
+		}, nil
+	}
+	status = handler.Status(p, 1)
+	assert.Equal(t, []uint32{1}, status.Instances[0].BootstrappedShards)
+	assert.False(t, status.RebalanceComplete)
+}
+
+func TestPlacementStatusInitializingShardNotBootstrapped(t *testing.T) {
+	handler := &StatusHandler{
+		nodeHealth: func(instance placement.Instance) (*rpc.NodeHealthResult_, error) {
+			// The node has not bootstrapped the shard it is taking over yet
+			shards := []int32{0}
+			if instance.ID() == "host2" {
+				shards = []int32{}
+			}
+			return &rpc.NodeHealthResult_{
+				Ok:                 true,
+				Status:             "up",
+				Bootstrapped:       true,
+				BootstrappedShards: shards,
+			}, nil
+		},
+	}
+
+	status := handler.Status(newStatusTestPlacement(), 1)
+	require.Equal(t, 2, len(status.Instances))
+	assert.Equal(t, []uint32{0}, status.Instances[0].BootstrappedShards)
+	assert.Equal(t, "host2", status.Instances[1].Id)
+	assert.Equal(t, []uint32{1}, status.Instances[1].InitializingShards)
+	assert.Equal(t, []uint32{}, status.Instances[1].BootstrappedShards)
+	assert.False(t, status.RebalanceComplete)
+}
+
+func TestPlacementStatusNoSession(t *testing.T) {
+	handler := NewStatusHandler(nil, config.Configuration{}, nil)
+
+	instance := placement.NewInstance().SetID("host1").SetEndpoint("host1:9000")
+	status := handler.instanceStatus(instance)
+	assert.Equal(t, errNoNodeHealthSession.Error(), status.Error)
+	assert.False(t, status.Bootstrapped)
+}
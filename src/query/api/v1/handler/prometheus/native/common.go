@@ -29,6 +29,7 @@ import (
 
 	"github.com/m3db/m3/src/query/api/v1/handler"
 	"github.com/m3db/m3/src/query/api/v1/handler/prometheus"
+	"github.com/m3db/m3/src/query/block"
 	"github.com/m3db/m3/src/query/errors"
 	"github.com/m3db/m3/src/query/functions/utils"
 	"github.com/m3db/m3/src/query/models"
@@ -47,6 +48,8 @@ const (
 	stepParam         = "step"
 	debugParam        = "debug"
 	endExclusiveParam = "end-exclusive"
+	explainParam      = "explain"
+	analyzeParam      = "analyze"
 
 	formatErrStr = "error parsing param: %s, error: %v"
 )
@@ -140,6 +143,28 @@ func parseParams(r *http.Request) (models.RequestParams, *handler.ParseError) {
 	return params, nil
 }
 
+// parseExplain parses the explain and analyze flags, analyze implies explain
+func parseExplain(r *http.Request) (bool, bool) {
+	var explain, analyze bool
+	if explainVal := r.FormValue(explainParam); explainVal != "" {
+		v, err := strconv.ParseBool(explainVal)
+		if err != nil {
+			logging.WithContext(r.Context()).Warn("unable to parse explain flag", zap.Any("error", err))
+		}
+		explain = v
+	}
+
+	if analyzeVal := r.FormValue(analyzeParam); analyzeVal != "" {
+		v, err := strconv.ParseBool(analyzeVal)
+		if err != nil {
+			logging.WithContext(r.Context()).Warn("unable to parse analyze flag", zap.Any("error", err))
+		}
+		analyze = v
+	}
+
+	return explain || analyze, analyze
+}
+
 func parseQuery(r *http.Request) (string, error) {
 	queries, ok := r.URL.Query()[queryParam]
 	if !ok || len(queries) == 0 || queries[0] == "" {
@@ -154,13 +179,29 @@ func parseQuery(r *http.Request) (string, error) {
 	return queries[0], nil
 }
 
-func renderResultsJSON(w io.Writer, series []*ts.Series, params models.RequestParams) {
+func renderResultsJSON(
+	w io.Writer,
+	series []*ts.Series,
+	meta block.ResultMetadata,
+	params models.RequestParams,
+) {
 	jw := json.NewWriter(w)
 	jw.BeginObject()
 
 	jw.BeginObjectField("status")
 	jw.WriteString("success")
 
+	// NB: warnings are rendered the same way as Prometheus, only present
+	// when the results are partial.
+	if len(meta.Warnings) > 0 {
+		jw.BeginObjectField("warnings")
+		jw.BeginArray()
+		for _, warning := range meta.Warnings {
+			jw.WriteString(warning.String())
+		}
+		jw.EndArray()
+	}
+
 	jw.BeginObjectField("data")
 	jw.BeginObject()
 
@@ -29,7 +29,9 @@ import (
 	"time"
 
 	xtest "github.com/m3db/m3/src/dbnode/x/test"
+	"github.com/m3db/m3/src/query/block"
 	"github.com/m3db/m3/src/query/models"
+	"github.com/m3db/m3/src/query/test"
 	"github.com/m3db/m3/src/query/ts"
 
 	"github.com/stretchr/testify/assert"
@@ -120,7 +122,7 @@ func TestRenderResultsJSON(t *testing.T) {
 		}),
 	}
 
-	renderResultsJSON(buffer, series, params)
+	renderResultsJSON(buffer, series, block.NewResultMetadata(), params)
 
 	expected := mustPrettyJSON(t, `
 	{
@@ -178,3 +180,42 @@ func mustPrettyJSON(t *testing.T, str string) string {
 	require.NoError(t, err)
 	return string(pretty)
 }
+
+func TestRenderResultsJSONKeepsSeriesOrder(t *testing.T) {
+	bounds := block.Bounds{
+		Start:    time.Unix(1535948880, 0),
+		Duration: 10 * time.Second,
+		StepSize: 10 * time.Second,
+	}
+
+	// NB: series are deliberately not in tag order, as produced by sort_desc.
+	names := []string{"c", "a", "b"}
+	seriesMeta := make([]block.SeriesMeta, 0, len(names))
+	for _, name := range names {
+		seriesMeta = append(seriesMeta, block.SeriesMeta{
+			Name: name,
+			Tags: models.Tags{{Name: "name", Value: name}},
+		})
+	}
+
+	b := test.NewBlockFromValuesWithSeriesMeta(bounds, seriesMeta,
+		[][]float64{{3}, {2}, {1}})
+	series, err := sortedBlocksToSeriesList([]blockWithMeta{{block: b}})
+	require.NoError(t, err)
+
+	buffer := bytes.NewBuffer(nil)
+	renderResultsJSON(buffer, series, block.NewResultMetadata(), models.RequestParams{})
+
+	var rendered struct {
+		Data struct {
+			Result []struct {
+				Metric map[string]string `json:"metric"`
+			} `json:"result"`
+		} `json:"data"`
+	}
+	require.NoError(t, json.Unmarshal(buffer.Bytes(), &rendered))
+	require.Len(t, rendered.Data.Result, len(names))
+	for i, name := range names {
+		assert.Equal(t, name, rendered.Data.Result[i].Metric["name"])
+	}
+}
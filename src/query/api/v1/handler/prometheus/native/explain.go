@@ -0,0 +1,158 @@
+// Copyright (c) 2018 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package native
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/m3db/m3/src/query/api/v1/handler"
+	"github.com/m3db/m3/src/query/executor/transform"
+	"github.com/m3db/m3/src/query/models"
+	"github.com/m3db/m3/src/query/parser"
+	"github.com/m3db/m3/src/query/parser/promql"
+	"github.com/m3db/m3/src/query/plan"
+	"github.com/m3db/m3/src/query/util/logging"
+
+	"go.uber.org/zap"
+)
+
+// ExplainResponse is the response returned when a query is explained
+type ExplainResponse struct {
+	Status string      `json:"status"`
+	Data   Explanation `json:"data"`
+}
+
+// Explanation describes how a query is planned and, if analyzed, how it executed
+type Explanation struct {
+	Query        string                   `json:"query"`
+	LogicalPlan  []plan.StepDescription   `json:"logicalPlan"`
+	PhysicalPlan plan.PhysicalDescription `json:"physicalPlan"`
+	Analysis     *Analysis                `json:"analysis,omitempty"`
+}
+
+// Analysis holds the execution statistics of an analyzed query
+type Analysis struct {
+	ExecutionTime  string         `json:"executionTime"`
+	SeriesReturned int            `json:"seriesReturned"`
+	Fetches        int            `json:"fetches"`
+	FetchTime      string         `json:"fetchTime"`
+	Nodes          []NodeAnalysis `json:"nodes"`
+}
+
+// NodeAnalysis holds the execution statistics of a single node
+type NodeAnalysis struct {
+	ID            parser.NodeID `json:"id"`
+	Type          string        `json:"type"`
+	TotalTime     string        `json:"totalTime"`
+	SelfTime      string        `json:"selfTime"`
+	BlocksIn      int           `json:"blocksIn"`
+	SeriesIn      int           `json:"seriesIn"`
+	DatapointsIn  int           `json:"datapointsIn"`
+	BlocksOut     int           `json:"blocksOut"`
+	SeriesOut     int           `json:"seriesOut"`
+	DatapointsOut int           `json:"datapointsOut"`
+}
+
+func (h *PromReadHandler) serveExplain(
+	ctx context.Context,
+	w http.ResponseWriter,
+	params models.RequestParams,
+	analyze bool,
+) {
+	logger := logging.WithContext(ctx)
+	explanation, err := h.explain(ctx, w, params, analyze)
+	if err != nil {
+		logger.Error("unable to explain query", zap.Error(err))
+		handler.Error(w, err, http.StatusBadRequest)
+		return
+	}
+
+	w.Header().Set("Access-Control-Allow-Origin", "*")
+	handler.WriteJSONResponse(w, ExplainResponse{
+		Status: "success",
+		Data:   explanation,
+	}, logger)
+}
+
+func (h *PromReadHandler) explain(
+	ctx context.Context,
+	w http.ResponseWriter,
+	params models.RequestParams,
+	analyze bool,
+) (Explanation, error) {
+	parser, err := promql.Parse(params.Query)
+	if err != nil {
+		return Explanation{}, err
+	}
+
+	lp, pp, err := h.engine.Plan(ctx, parser, params)
+	if err != nil {
+		return Explanation{}, err
+	}
+
+	explanation := Explanation{
+		Query:        params.Query,
+		LogicalPlan:  lp.Describe(),
+		PhysicalPlan: pp.Describe(),
+	}
+
+	if !analyze {
+		return explanation, nil
+	}
+
+	profile := transform.NewProfile()
+	start := time.Now()
+	series, _, err := h.read(ctx, w, "", params, profile)
+	if err != nil {
+		return Explanation{}, err
+	}
+
+	explanation.Analysis = newAnalysis(profile.Summary(), time.Since(start), len(series))
+	return explanation, nil
+}
+
+func newAnalysis(summary transform.ProfileSummary, took time.Duration, seriesReturned int) *Analysis {
+	nodes := make([]NodeAnalysis, 0, len(summary.Nodes))
+	for _, node := range summary.Nodes {
+		nodes = append(nodes, NodeAnalysis{
+			ID:            node.ID,
+			Type:          node.OpType,
+			TotalTime:     node.Total.String(),
+			SelfTime:      node.Self.String(),
+			BlocksIn:      node.BlocksIn,
+			SeriesIn:      node.SeriesIn,
+			DatapointsIn:  node.DatapointsIn,
+			BlocksOut:     node.BlocksOut,
+			SeriesOut:     node.SeriesOut,
+			DatapointsOut: node.DatapointsOut,
+		})
+	}
+
+	return &Analysis{
+		ExecutionTime:  took.String(),
+		SeriesReturned: seriesReturned,
+		Fetches:        summary.Fetches,
+		FetchTime:      summary.FetchDuration.String(),
+		Nodes:          nodes,
+	}
+}
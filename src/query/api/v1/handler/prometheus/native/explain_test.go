@@ -0,0 +1,108 @@
+// Copyright (c) 2018 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package native
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/m3db/m3/src/query/block"
+	"github.com/m3db/m3/src/query/executor"
+	"github.com/m3db/m3/src/query/storage/mock"
+	"github.com/m3db/m3/src/query/test"
+	"github.com/m3db/m3/src/query/util/logging"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newExplainTestHandler() *PromReadHandler {
+	values, bounds := test.GenerateValuesAndBounds(nil, nil)
+	b := test.NewBlockFromValues(bounds, values)
+
+	mockStorage := mock.NewMockStorage()
+	mockStorage.SetFetchBlocksResult(block.Result{Blocks: []block.Block{b}}, nil)
+
+	return &PromReadHandler{engine: executor.NewEngine(mockStorage)}
+}
+
+func TestPromReadExplain(t *testing.T) {
+	logging.InitWithCores(nil)
+
+	promRead := newExplainTestHandler()
+	req, _ := http.NewRequest("GET", PromReadURL, nil)
+	vals := defaultParams()
+	vals.Add(explainParam, "true")
+	req.URL.RawQuery = vals.Encode()
+
+	w := httptest.NewRecorder()
+	promRead.ServeHTTP(w, req)
+	require.Equal(t, http.StatusOK, w.Code)
+
+	var resp ExplainResponse
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+	assert.Equal(t, "success", resp.Status)
+	assert.Equal(t, promQuery, resp.Data.Query)
+	require.Len(t, resp.Data.LogicalPlan, 1)
+	assert.Equal(t, "fetch", resp.Data.LogicalPlan[0].Type)
+	require.Len(t, resp.Data.PhysicalPlan.Steps, 1)
+	assert.Equal(t, resp.Data.LogicalPlan[0].ID, resp.Data.PhysicalPlan.ResultParent)
+	assert.Nil(t, resp.Data.Analysis)
+}
+
+func TestPromReadExplainAnalyze(t *testing.T) {
+	logging.InitWithCores(nil)
+
+	promRead := newExplainTestHandler()
+	req, _ := http.NewRequest("GET", PromReadURL, nil)
+	vals := defaultParams()
+	vals.Set(queryParam, "sum(http_requests_total)")
+	vals.Add(analyzeParam, "true")
+	req.URL.RawQuery = vals.Encode()
+
+	w := httptest.NewRecorder()
+	promRead.ServeHTTP(w, req)
+	require.Equal(t, http.StatusOK, w.Code)
+
+	var resp ExplainResponse
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+	require.Len(t, resp.Data.LogicalPlan, 2)
+	require.NotNil(t, resp.Data.Analysis)
+
+	analysis := resp.Data.Analysis
+	assert.Equal(t, 1, analysis.SeriesReturned)
+	assert.Equal(t, 1, analysis.Fetches)
+	require.Len(t, analysis.Nodes, 2)
+
+	fetch, sum := analysis.Nodes[0], analysis.Nodes[1]
+	assert.Equal(t, "fetch", fetch.Type)
+	assert.Equal(t, 1, fetch.BlocksOut)
+	assert.Equal(t, 2, fetch.SeriesOut)
+	assert.Equal(t, 10, fetch.DatapointsOut)
+
+	assert.Equal(t, "sum", sum.Type)
+	assert.Equal(t, 1, sum.BlocksIn)
+	assert.Equal(t, 2, sum.SeriesIn)
+	assert.Equal(t, 1, sum.BlocksOut)
+	assert.Equal(t, 1, sum.SeriesOut)
+}
@@ -30,6 +30,7 @@ import (
 	"github.com/m3db/m3/src/query/api/v1/handler"
 	"github.com/m3db/m3/src/query/block"
 	"github.com/m3db/m3/src/query/executor"
+	"github.com/m3db/m3/src/query/executor/transform"
 	"github.com/m3db/m3/src/query/models"
 	"github.com/m3db/m3/src/query/parser/promql"
 	"github.com/m3db/m3/src/query/ts"
@@ -56,7 +57,8 @@ var (
 
 // PromReadHandler represents a handler for prometheus read endpoint.
 type PromReadHandler struct {
-	engine *executor.Engine
+	engine            *executor.Engine
+	requireExhaustive bool
 }
 
 // ReadResponse is the response that gets returned to the user
@@ -69,9 +71,14 @@ type blockWithMeta struct {
 	meta  block.Metadata
 }
 
-// NewPromReadHandler returns a new instance of handler.
-func NewPromReadHandler(engine *executor.Engine) http.Handler {
-	return &PromReadHandler{engine: engine}
+// NewPromReadHandler returns a new instance of handler, if requireExhaustive
+// is set then partial results are returned as an error unless overridden by
+// the request.
+func NewPromReadHandler(engine *executor.Engine, requireExhaustive bool) http.Handler {
+	return &PromReadHandler{
+		engine:            engine,
+		requireExhaustive: requireExhaustive,
+	}
 }
 
 func (h *PromReadHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
@@ -88,24 +95,54 @@ func (h *PromReadHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 		logger.Info("Request params", zap.Any("params", params))
 	}
 
-	result, err := h.read(ctx, w, params)
+	if explain, analyze := parseExplain(r); explain {
+		h.serveExplain(ctx, w, params, analyze)
+		return
+	}
+
+	requireExhaustive, err := handler.RequireExhaustive(r, h.requireExhaustive)
+	if err != nil {
+		handler.Error(w, err, http.StatusBadRequest)
+		return
+	}
+
+	result, meta, err := h.read(ctx, w, r.RemoteAddr, params, nil)
 	if err != nil {
 		logger.Error("unable to fetch data", zap.Error(err))
 		handler.Error(w, err, http.StatusBadRequest)
 		return
 	}
 
+	if requireExhaustive && meta.IsPartial() {
+		err := handler.NewErrPartialResults(meta)
+		logger.Error("partial results not allowed", zap.Error(err))
+		handler.Error(w, err, http.StatusBadRequest)
+		return
+	}
+
 	// TODO: Support multiple result types
+	handler.AddWarningHeaders(w, meta)
 	w.Header().Set("Content-Type", "application/json")
 	w.Header().Set("Access-Control-Allow-Origin", "*")
-	renderResultsJSON(w, result, params)
+	renderResultsJSON(w, result, meta, params)
 }
 
-func (h *PromReadHandler) read(reqCtx context.Context, w http.ResponseWriter, params models.RequestParams) ([]*ts.Series, error) {
+func (h *PromReadHandler) read(
+	reqCtx context.Context,
+	w http.ResponseWriter,
+	caller string,
+	params models.RequestParams,
+	profile *transform.Profile,
+) ([]*ts.Series, block.ResultMetadata, error) {
 	ctx, cancel := context.WithTimeout(reqCtx, params.Timeout)
 	defer cancel()
 
-	opts := &executor.EngineOptions{}
+	collector := executor.NewResultMetadataCollector()
+	opts := &executor.EngineOptions{
+		Profile:        profile,
+		ResultMetadata: collector,
+		Caller:         caller,
+	}
 	// Detect clients closing connections
 	abortCh, _ := handler.CloseWatcher(ctx, w)
 	opts.AbortCh = abortCh
@@ -113,7 +150,7 @@ func (h *PromReadHandler) read(reqCtx context.Context, w http.ResponseWriter, pa
 	// TODO: Capture timing
 	parser, err := promql.Parse(params.Query)
 	if err != nil {
-		return nil, err
+		return nil, block.ResultMetadata{}, err
 	}
 
 	// Results is closed by execute
@@ -178,10 +215,15 @@ func (h *PromReadHandler) read(reqCtx context.Context, w http.ResponseWriter, pa
 	if processErr != nil {
 		// Drain anything remaining
 		drainResultChan(results)
-		return nil, processErr
+		return nil, block.ResultMetadata{}, processErr
+	}
+
+	seriesList, err := sortedBlocksToSeriesList(sortedBlockList)
+	if err != nil {
+		return nil, block.ResultMetadata{}, err
 	}
 
-	return sortedBlocksToSeriesList(sortedBlockList)
+	return seriesList, collector.Metadata(), nil
 }
 
 func drainResultChan(resultsChan chan executor.Query) {
@@ -26,6 +26,7 @@ import (
 	"net/http/httptest"
 	"testing"
 
+	"github.com/m3db/m3/src/query/api/v1/handler"
 	"github.com/m3db/m3/src/query/block"
 	"github.com/m3db/m3/src/query/executor"
 	"github.com/m3db/m3/src/query/storage/mock"
@@ -43,7 +44,10 @@ func TestPromRead(t *testing.T) {
 	b := test.NewBlockFromValues(bounds, values)
 
 	mockStorage := mock.NewMockStorage()
-	mockStorage.SetFetchBlocksResult(block.Result{Blocks: []block.Block{b}}, nil)
+	mockStorage.SetFetchBlocksResult(block.Result{
+		Blocks:   []block.Block{b},
+		Metadata: block.NewResultMetadata(),
+	}, nil)
 
 	promRead := &PromReadHandler{engine: executor.NewEngine(mockStorage)}
 	req, _ := http.NewRequest("GET", PromReadURL, nil)
@@ -51,8 +55,9 @@ func TestPromRead(t *testing.T) {
 
 	r, parseErr := parseParams(req)
 	require.Nil(t, parseErr)
-	seriesList, err := promRead.read(context.TODO(), httptest.NewRecorder(), r)
+	seriesList, meta, err := promRead.read(context.TODO(), httptest.NewRecorder(), "", r, nil)
 	require.NoError(t, err)
+	assert.False(t, meta.IsPartial())
 	require.Len(t, seriesList, 2)
 	s := seriesList[0]
 
@@ -61,3 +66,50 @@ func TestPromRead(t *testing.T) {
 		assert.Equal(t, float64(i), s.Values().ValueAt(i))
 	}
 }
+
+func newPartialResultHandler(requireExhaustive bool) http.Handler {
+	values, bounds := test.GenerateValuesAndBounds(nil, nil)
+	b := test.NewBlockFromValues(bounds, values)
+
+	meta := block.NewResultMetadata()
+	meta.NonExhaustive = true
+	meta.AddWarning("metrics", "limit exceeded")
+
+	mockStorage := mock.NewMockStorage()
+	mockStorage.SetFetchBlocksResult(block.Result{
+		Blocks:   []block.Block{b},
+		Metadata: meta,
+	}, nil)
+
+	return NewPromReadHandler(executor.NewEngine(mockStorage), requireExhaustive)
+}
+
+func TestPromReadPartialResultWarnings(t *testing.T) {
+	logging.InitWithCores(nil)
+
+	req, _ := http.NewRequest("GET", PromReadURL, nil)
+	req.URL.RawQuery = defaultParams().Encode()
+	recorder := httptest.NewRecorder()
+	newPartialResultHandler(false).ServeHTTP(recorder, req)
+
+	require.Equal(t, http.StatusOK, recorder.Code)
+	assert.Equal(t, "true", recorder.Header().Get(handler.LimitHeader))
+	assert.Equal(t, "metrics_limit exceeded", recorder.Header().Get(handler.WarningsHeader))
+	assert.Contains(t, recorder.Body.String(), `"warnings":["metrics_limit exceeded"]`)
+}
+
+func TestPromReadPartialResultRequireExhaustive(t *testing.T) {
+	logging.InitWithCores(nil)
+
+	req, _ := http.NewRequest("GET", PromReadURL, nil)
+	req.URL.RawQuery = defaultParams().Encode()
+	recorder := httptest.NewRecorder()
+	newPartialResultHandler(true).ServeHTTP(recorder, req)
+	assert.Equal(t, http.StatusBadRequest, recorder.Code)
+
+	// Request header overrides the configured default
+	req.Header.Set(handler.RequireExhaustiveHeader, "false")
+	recorder = httptest.NewRecorder()
+	newPartialResultHandler(true).ServeHTTP(recorder, req)
+	assert.Equal(t, http.StatusOK, recorder.Code)
+}
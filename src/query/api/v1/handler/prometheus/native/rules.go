@@ -0,0 +1,112 @@
+// Copyright (c) 2018 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package native
+
+import (
+	"net/http"
+
+	"github.com/m3db/m3/src/query/api/v1/handler"
+	"github.com/m3db/m3/src/query/rules"
+	"github.com/m3db/m3/src/query/util/logging"
+)
+
+const (
+	// PromRulesURL is the url for the rules handler, this matches the
+	// default URL for the rules endpoint found on a Prometheus server
+	PromRulesURL = handler.RoutePrefixV1 + "/rules"
+
+	// PromRulesHTTPMethod is the HTTP method used with the rules resource.
+	PromRulesHTTPMethod = http.MethodGet
+
+	// PromAlertsURL is the url for the alerts handler, this matches the
+	// default URL for the alerts endpoint found on a Prometheus server
+	PromAlertsURL = handler.RoutePrefixV1 + "/alerts"
+
+	// PromAlertsHTTPMethod is the HTTP method used with the alerts resource.
+	PromAlertsHTTPMethod = http.MethodGet
+)
+
+// RulesResponse is the response returned by the rules handler
+type RulesResponse struct {
+	Status string    `json:"status"`
+	Data   RulesData `json:"data"`
+}
+
+// RulesData holds the status of every rule group
+type RulesData struct {
+	Groups []rules.GroupStatus `json:"groups"`
+}
+
+// AlertsResponse is the response returned by the alerts handler
+type AlertsResponse struct {
+	Status string     `json:"status"`
+	Data   AlertsData `json:"data"`
+}
+
+// AlertsData holds all pending and firing alerts
+type AlertsData struct {
+	Alerts []rules.AlertStatus `json:"alerts"`
+}
+
+// PromRulesHandler represents a handler for the rules endpoint.
+type PromRulesHandler struct {
+	manager *rules.Manager
+}
+
+// NewPromRulesHandler returns a new instance of the rules handler.
+func NewPromRulesHandler(manager *rules.Manager) http.Handler {
+	return &PromRulesHandler{manager: manager}
+}
+
+func (h *PromRulesHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	groups := h.manager.Groups()
+	statuses := make([]rules.GroupStatus, 0, len(groups))
+	for _, group := range groups {
+		statuses = append(statuses, group.Status())
+	}
+
+	handler.WriteJSONResponse(w, RulesResponse{
+		Status: "success",
+		Data:   RulesData{Groups: statuses},
+	}, logging.WithContext(r.Context()))
+}
+
+// PromAlertsHandler represents a handler for the alerts endpoint.
+type PromAlertsHandler struct {
+	manager *rules.Manager
+}
+
+// NewPromAlertsHandler returns a new instance of the alerts handler.
+func NewPromAlertsHandler(manager *rules.Manager) http.Handler {
+	return &PromAlertsHandler{manager: manager}
+}
+
+func (h *PromAlertsHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	alerts := []rules.AlertStatus{}
+	for _, rule := range h.manager.AlertingRules() {
+		alerts = append(alerts, rule.Status().Alerts...)
+	}
+
+	handler.WriteJSONResponse(w, AlertsResponse{
+		Status: "success",
+		Data:   AlertsData{Alerts: alerts},
+	}, logging.WithContext(r.Context()))
+}
@@ -0,0 +1,104 @@
+// Copyright (c) 2018 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package native
+
+import (
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/m3db/m3/src/query/models"
+	"github.com/m3db/m3/src/query/rules"
+	"github.com/m3db/m3/src/query/storage/mock"
+	"github.com/m3db/m3x/instrument"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testRuleFile = `
+groups:
+  - name: example
+    rules:
+      - record: job:http_requests:sum
+        expr: sum(http_requests_total)
+      - alert: HighRequestRate
+        expr: http_requests_total > 100
+`
+
+func newTestRuleManager(t *testing.T) *rules.Manager {
+	dir, err := ioutil.TempDir("", "rules")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	file := filepath.Join(dir, "rules.yml")
+	require.NoError(t, ioutil.WriteFile(file, []byte(testRuleFile), 0644))
+
+	manager, err := rules.NewManager(rules.ManagerOptions{
+		Query: func(context.Context, string, time.Time) (rules.Vector, error) {
+			return rules.Vector{{Tags: models.Tags{{Name: "job", Value: "api"}}, Value: 150}}, nil
+		},
+		Appender:          mock.NewMockStorage(),
+		InstrumentOptions: instrument.NewOptions(),
+	})
+	require.NoError(t, err)
+	require.NoError(t, manager.LoadFiles([]string{file}))
+	manager.Groups()[0].Eval(context.Background(), time.Now())
+	return manager
+}
+
+func TestPromRulesHandler(t *testing.T) {
+	req, _ := http.NewRequest(PromRulesHTTPMethod, PromRulesURL, nil)
+	w := httptest.NewRecorder()
+	NewPromRulesHandler(newTestRuleManager(t)).ServeHTTP(w, req)
+	require.Equal(t, http.StatusOK, w.Code)
+
+	var resp RulesResponse
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+	assert.Equal(t, "success", resp.Status)
+	require.Len(t, resp.Data.Groups, 1)
+	group := resp.Data.Groups[0]
+	assert.Equal(t, "example", group.Name)
+	require.Len(t, group.Rules, 2)
+	assert.Equal(t, "recording", group.Rules[0].Type)
+	assert.Equal(t, rules.HealthGood, group.Rules[0].Health)
+	assert.Equal(t, "alerting", group.Rules[1].Type)
+	require.Len(t, group.Rules[1].Alerts, 1)
+}
+
+func TestPromAlertsHandler(t *testing.T) {
+	req, _ := http.NewRequest(PromAlertsHTTPMethod, PromAlertsURL, nil)
+	w := httptest.NewRecorder()
+	NewPromAlertsHandler(newTestRuleManager(t)).ServeHTTP(w, req)
+	require.Equal(t, http.StatusOK, w.Code)
+
+	var resp AlertsResponse
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+	require.Len(t, resp.Data.Alerts, 1)
+	assert.Equal(t, "pending", resp.Data.Alerts[0].State)
+	assert.Equal(t, "HighRequestRate", resp.Data.Alerts[0].Labels["alertname"])
+}
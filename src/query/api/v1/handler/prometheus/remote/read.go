@@ -28,6 +28,7 @@ import (
 
 	"github.com/m3db/m3/src/query/api/v1/handler"
 	"github.com/m3db/m3/src/query/api/v1/handler/prometheus"
+	"github.com/m3db/m3/src/query/block"
 	"github.com/m3db/m3/src/query/executor"
 	"github.com/m3db/m3/src/query/generated/proto/prompb"
 	"github.com/m3db/m3/src/query/storage"
@@ -49,15 +50,23 @@ const (
 
 // PromReadHandler represents a handler for prometheus read endpoint.
 type PromReadHandler struct {
-	engine          *executor.Engine
-	promReadMetrics promReadMetrics
+	engine            *executor.Engine
+	promReadMetrics   promReadMetrics
+	requireExhaustive bool
 }
 
-// NewPromReadHandler returns a new instance of handler.
-func NewPromReadHandler(engine *executor.Engine, scope tally.Scope) http.Handler {
+// NewPromReadHandler returns a new instance of handler, if requireExhaustive
+// is set then partial results are returned as an error unless overridden by
+// the request.
+func NewPromReadHandler(
+	engine *executor.Engine,
+	scope tally.Scope,
+	requireExhaustive bool,
+) http.Handler {
 	return &PromReadHandler{
-		engine:          engine,
-		promReadMetrics: newPromReadMetrics(scope),
+		engine:            engine,
+		promReadMetrics:   newPromReadMetrics(scope),
+		requireExhaustive: requireExhaustive,
 	}
 }
 
@@ -93,7 +102,14 @@ func (h *PromReadHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	result, err := h.read(ctx, w, req, timeout)
+	requireExhaustive, err := handler.RequireExhaustive(r, h.requireExhaustive)
+	if err != nil {
+		h.promReadMetrics.fetchErrorsClient.Inc(1)
+		handler.Error(w, err, http.StatusBadRequest)
+		return
+	}
+
+	result, meta, err := h.read(ctx, w, r.RemoteAddr, req, timeout)
 	if err != nil {
 		h.promReadMetrics.fetchErrorsServer.Inc(1)
 		logger.Error("unable to fetch data", zap.Any("error", err))
@@ -101,6 +117,16 @@ func (h *PromReadHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	if requireExhaustive && meta.IsPartial() {
+		err := handler.NewErrPartialResults(meta)
+		h.promReadMetrics.fetchErrorsClient.Inc(1)
+		logger.Error("partial results not allowed", zap.Any("error", err))
+		handler.Error(w, err, http.StatusBadRequest)
+		return
+	}
+
+	handler.AddWarningHeaders(w, meta)
+
 	resp := &prompb.ReadResponse{
 		Results: result,
 	}
@@ -141,10 +167,16 @@ func (h *PromReadHandler) parseRequest(r *http.Request) (*prompb.ReadRequest, *h
 	return &req, nil
 }
 
-func (h *PromReadHandler) read(reqCtx context.Context, w http.ResponseWriter, r *prompb.ReadRequest, timeout time.Duration) ([]*prompb.QueryResult, error) {
+func (h *PromReadHandler) read(
+	reqCtx context.Context,
+	w http.ResponseWriter,
+	caller string,
+	r *prompb.ReadRequest,
+	timeout time.Duration,
+) ([]*prompb.QueryResult, block.ResultMetadata, error) {
 	// TODO: Handle multi query use case
 	if len(r.Queries) != 1 {
-		return nil, fmt.Errorf("prometheus read endpoint currently only supports one query at a time")
+		return nil, block.ResultMetadata{}, fmt.Errorf("prometheus read endpoint currently only supports one query at a time")
 	}
 
 	ctx, cancel := context.WithTimeout(reqCtx, timeout)
@@ -152,28 +184,30 @@ func (h *PromReadHandler) read(reqCtx context.Context, w http.ResponseWriter, r
 	promQuery := r.Queries[0]
 	query, err := storage.PromReadQueryToM3(promQuery)
 	if err != nil {
-		return nil, err
+		return nil, block.ResultMetadata{}, err
 	}
 
 	// Results is closed by execute
 	results := make(chan *storage.QueryResult)
 
-	opts := &executor.EngineOptions{}
+	opts := &executor.EngineOptions{Caller: caller}
 	// Detect clients closing connections
 	abortCh, closingCh := handler.CloseWatcher(ctx, w)
 	opts.AbortCh = abortCh
 
 	go h.engine.Execute(ctx, query, opts, closingCh, results)
 
+	meta := block.NewResultMetadata()
 	promResults := make([]*prompb.QueryResult, 0, 1)
 	for result := range results {
 		if result.Err != nil {
-			return nil, result.Err
+			return nil, block.ResultMetadata{}, result.Err
 		}
 
+		meta = meta.CombineMetadata(result.FetchResult.Metadata)
 		promRes := storage.FetchResultToPromResult(result.FetchResult)
 		promResults = append(promResults, promRes)
 	}
 
-	return promResults, nil
+	return promResults, meta, nil
 }
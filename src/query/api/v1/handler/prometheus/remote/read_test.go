@@ -29,6 +29,7 @@ import (
 	"testing"
 	"time"
 
+	"github.com/m3db/m3/src/dbnode/encoding"
 	"github.com/m3db/m3/src/dbnode/x/metrics"
 	"github.com/m3db/m3/src/query/executor"
 	"github.com/m3db/m3/src/query/storage"
@@ -89,10 +90,25 @@ func TestPromReadStorageWithFetchError(t *testing.T) {
 	session.EXPECT().FetchTagged(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, true, fmt.Errorf("unable to get data"))
 	promRead := &PromReadHandler{engine: executor.NewEngine(storage), promReadMetrics: promReadTestMetrics}
 	req := test.GeneratePromReadRequest()
-	_, err := promRead.read(context.TODO(), httptest.NewRecorder(), req, time.Hour)
+	_, _, err := promRead.read(context.TODO(), httptest.NewRecorder(), "", req, time.Hour)
 	require.NotNil(t, err, "unable to read from storage")
 }
 
+func TestPromReadNonExhaustiveResult(t *testing.T) {
+	logging.InitWithCores(nil)
+	ctrl := gomock.NewController(t)
+	storage, session := local.NewStorageAndSession(t, ctrl)
+	iters := encoding.NewSeriesIterators([]encoding.SeriesIterator{}, nil)
+	session.EXPECT().FetchTagged(gomock.Any(), gomock.Any(), gomock.Any()).Return(iters, false, nil)
+	promRead := &PromReadHandler{engine: executor.NewEngine(storage), promReadMetrics: promReadTestMetrics}
+	req := test.GeneratePromReadRequest()
+	_, meta, err := promRead.read(context.TODO(), httptest.NewRecorder(), "", req, time.Hour)
+	require.NoError(t, err)
+	assert.True(t, meta.NonExhaustive)
+	require.Len(t, meta.Warnings, 1)
+	assert.Equal(t, "limit exceeded", meta.Warnings[0].Message)
+}
+
 func TestQueryMatchMustBeEqual(t *testing.T) {
 	logging.InitWithCores(nil)
 
@@ -22,14 +22,17 @@ package remote
 
 import (
 	"context"
-	"errors"
+	goerrors "errors"
 	"net/http"
 	"sync"
 
 	"github.com/m3db/m3/src/cmd/services/m3coordinator/downsample"
 	"github.com/m3db/m3/src/query/api/v1/handler"
 	"github.com/m3db/m3/src/query/api/v1/handler/prometheus"
+	"github.com/m3db/m3/src/query/errors"
 	"github.com/m3db/m3/src/query/generated/proto/prompb"
+	"github.com/m3db/m3/src/query/ha"
+	"github.com/m3db/m3/src/query/relabel"
 	"github.com/m3db/m3/src/query/storage"
 	"github.com/m3db/m3/src/query/util/logging"
 	xerrors "github.com/m3db/m3x/errors"
@@ -48,20 +51,27 @@ const (
 )
 
 var (
-	errNoStorageOrDownsampler = errors.New("no storage or downsampler set, requires at least one or both")
+	errNoStorageOrDownsampler = goerrors.New("no storage or downsampler set, requires at least one or both")
 )
 
 // PromWriteHandler represents a handler for prometheus write endpoint.
 type PromWriteHandler struct {
 	store            storage.Storage
 	downsampler      downsample.Downsampler
+	deduper          *ha.Deduper
+	relabeler        *relabel.Relabeler
 	promWriteMetrics promWriteMetrics
 }
 
-// NewPromWriteHandler returns a new instance of handler.
+// NewPromWriteHandler returns a new instance of handler, if a deduper is set
+// only the samples from the elected replica of highly available Prometheus
+// pairs are written and if a relabeler is set series are relabeled before
+// being written.
 func NewPromWriteHandler(
 	store storage.Storage,
 	downsampler downsample.Downsampler,
+	deduper *ha.Deduper,
+	relabeler *relabel.Relabeler,
 	scope tally.Scope,
 ) (http.Handler, error) {
 	if store == nil && downsampler == nil {
@@ -70,21 +80,25 @@ func NewPromWriteHandler(
 	return &PromWriteHandler{
 		store:            store,
 		downsampler:      downsampler,
+		deduper:          deduper,
+		relabeler:        relabeler,
 		promWriteMetrics: newPromWriteMetrics(scope),
 	}, nil
 }
 
 type promWriteMetrics struct {
-	writeSuccess      tally.Counter
-	writeErrorsServer tally.Counter
-	writeErrorsClient tally.Counter
+	writeSuccess         tally.Counter
+	writeErrorsServer    tally.Counter
+	writeErrorsClient    tally.Counter
+	writeErrorsThrottled tally.Counter
 }
 
 func newPromWriteMetrics(scope tally.Scope) promWriteMetrics {
 	return promWriteMetrics{
-		writeSuccess:      scope.Counter("write.success"),
-		writeErrorsServer: scope.Tagged(map[string]string{"code": "5XX"}).Counter("write.errors"),
-		writeErrorsClient: scope.Tagged(map[string]string{"code": "4XX"}).Counter("write.errors"),
+		writeSuccess:         scope.Counter("write.success"),
+		writeErrorsServer:    scope.Tagged(map[string]string{"code": "5XX"}).Counter("write.errors"),
+		writeErrorsClient:    scope.Tagged(map[string]string{"code": "4XX"}).Counter("write.errors"),
+		writeErrorsThrottled: scope.Tagged(map[string]string{"code": "429"}).Counter("write.errors"),
 	}
 }
 
@@ -95,7 +109,33 @@ func (h *PromWriteHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 		handler.Error(w, rErr.Inner(), rErr.Code())
 		return
 	}
+
+	if h.deduper != nil {
+		series, err := h.deduper.Filter(req.Timeseries)
+		if err != nil {
+			// Fail the request so that Prometheus retries it rather than
+			// writing samples from both replicas
+			h.promWriteMetrics.writeErrorsServer.Inc(1)
+			logging.WithContext(r.Context()).Error("HA dedup error", zap.Any("err", err))
+			handler.Error(w, err, http.StatusInternalServerError)
+			return
+		}
+		req.Timeseries = series
+	}
+
+	if h.relabeler != nil {
+		req.Timeseries = h.relabel(req.Timeseries)
+	}
+
 	if err := h.write(r.Context(), req); err != nil {
+		if err == errors.ErrWriteQueueFull {
+			// Ask the client to back off and retry the request
+			h.promWriteMetrics.writeErrorsThrottled.Inc(1)
+			w.Header().Set(handler.RetryHeader, "true")
+			handler.Error(w, err, http.StatusTooManyRequests)
+			return
+		}
+
 		h.promWriteMetrics.writeErrorsServer.Inc(1)
 		logging.WithContext(r.Context()).Error("Write error", zap.Any("err", err))
 		handler.Error(w, err, http.StatusInternalServerError)
@@ -119,6 +159,23 @@ func (h *PromWriteHandler) parseRequest(r *http.Request) (*prompb.WriteRequest,
 	return &req, nil
 }
 
+// relabel applies the relabel rules to the series in place, removing any
+// dropped series.
+func (h *PromWriteHandler) relabel(series []*prompb.TimeSeries) []*prompb.TimeSeries {
+	relabeled := series[:0]
+	for _, ts := range series {
+		tags, keep := h.relabeler.Relabel(storage.PromLabelsToM3Tags(ts.Labels))
+		if !keep {
+			continue
+		}
+
+		ts.Labels = storage.TagsToPromLabels(tags)
+		relabeled = append(relabeled, ts)
+	}
+
+	return relabeled
+}
+
 func (h *PromWriteHandler) write(ctx context.Context, r *prompb.WriteRequest) error {
 	var (
 		wg            sync.WaitGroup
@@ -146,6 +203,11 @@ func (h *PromWriteHandler) write(ctx context.Context, r *prompb.WriteRequest) er
 		wg.Wait()
 	}
 
+	if writeUnaggErr == errors.ErrWriteQueueFull {
+		// Surface backpressure directly so the request can be retried
+		return writeUnaggErr
+	}
+
 	var multiErr xerrors.MultiError
 	multiErr = multiErr.Add(writeUnaggErr)
 	multiErr = multiErr.Add(writeAggErr)
@@ -156,6 +218,10 @@ func (h *PromWriteHandler) writeUnaggregated(
 	ctx context.Context,
 	r *prompb.WriteRequest,
 ) error {
+	if appender, ok := h.store.(storage.BatchAppender); ok {
+		return h.writeUnaggregatedBatch(ctx, appender, r)
+	}
+
 	var (
 		wg       sync.WaitGroup
 		errLock  sync.Mutex
@@ -189,6 +255,23 @@ func (h *PromWriteHandler) writeUnaggregated(
 	return multiErr.FinalError()
 }
 
+func (h *PromWriteHandler) writeUnaggregatedBatch(
+	ctx context.Context,
+	appender storage.BatchAppender,
+	r *prompb.WriteRequest,
+) error {
+	queries := make([]*storage.WriteQuery, 0, len(r.Timeseries))
+	for _, t := range r.Timeseries {
+		write := storage.PromWriteTSToM3(t)
+		write.Attributes = storage.Attributes{
+			MetricsType: storage.UnaggregatedMetricsType,
+		}
+		queries = append(queries, write)
+	}
+
+	return appender.WriteBatch(ctx, queries)
+}
+
 func (h *PromWriteHandler) writeAggregated(
 	_ context.Context,
 	r *prompb.WriteRequest,
@@ -28,7 +28,14 @@ import (
 	"time"
 
 	"github.com/m3db/m3/src/dbnode/x/metrics"
+	"github.com/m3db/m3/src/query/api/v1/handler"
 	"github.com/m3db/m3/src/query/api/v1/handler/prometheus/remote/test/remote"
+	"github.com/m3db/m3/src/query/errors"
+	"github.com/m3db/m3/src/query/generated/proto/prompb"
+	"github.com/m3db/m3/src/query/ha"
+	"github.com/m3db/m3/src/query/relabel"
+	"github.com/m3db/m3/src/query/storage"
+	"github.com/m3db/m3/src/query/storage/mock"
 	"github.com/m3db/m3/src/query/test/local"
 	"github.com/m3db/m3/src/query/util/logging"
 	xclock "github.com/m3db/m3x/clock"
@@ -59,6 +66,7 @@ func TestPromWrite(t *testing.T) {
 
 	ctrl := gomock.NewController(t)
 	storage, session := local.NewStorageAndSession(t, ctrl)
+	session.EXPECT().ShardID(gomock.Any()).Return(uint32(0), nil).AnyTimes()
 	session.EXPECT().WriteTagged(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).AnyTimes()
 
 	promWrite := &PromWriteHandler{store: storage}
@@ -96,3 +104,116 @@ func TestWriteErrorMetricCount(t *testing.T) {
 	}, 5*time.Second)
 	require.True(t, foundMetric)
 }
+
+type queueFullStorage struct {
+	mock.Storage
+}
+
+func (s *queueFullStorage) WriteBatch(context.Context, []*storage.WriteQuery) error {
+	return errors.ErrWriteQueueFull
+}
+
+func TestPromWriteQueueFull(t *testing.T) {
+	logging.InitWithCores(nil)
+
+	store := &queueFullStorage{Storage: mock.NewMockStorage()}
+	promWrite := &PromWriteHandler{
+		store:            store,
+		promWriteMetrics: newPromWriteMetrics(tally.NoopScope),
+	}
+
+	promReq := remote.GeneratePromWriteRequest()
+	promReqBody := remote.GeneratePromWriteRequestBody(t, promReq)
+	req, _ := http.NewRequest("POST", PromWriteURL, promReqBody)
+	recorder := httptest.NewRecorder()
+	promWrite.ServeHTTP(recorder, req)
+
+	require.Equal(t, http.StatusTooManyRequests, recorder.Code)
+	require.Equal(t, "true", recorder.Header().Get(handler.RetryHeader))
+}
+
+type recordingStorage struct {
+	mock.Storage
+	writes []*storage.WriteQuery
+}
+
+func (s *recordingStorage) WriteBatch(_ context.Context, queries []*storage.WriteQuery) error {
+	s.writes = append(s.writes, queries...)
+	return nil
+}
+
+func TestPromWriteHADedup(t *testing.T) {
+	logging.InitWithCores(nil)
+
+	store := &recordingStorage{Storage: mock.NewMockStorage()}
+	promWrite := &PromWriteHandler{
+		store:            store,
+		deduper:          ha.NewDeduper(ha.Options{}),
+		promWriteMetrics: newPromWriteMetrics(tally.NoopScope),
+	}
+
+	newReq := func(replica string) *http.Request {
+		promReq := &prompb.WriteRequest{
+			Timeseries: []*prompb.TimeSeries{{
+				Labels: []*prompb.Label{
+					{Name: "__name__", Value: "up"},
+					{Name: "cluster", Value: "c1"},
+					{Name: "__replica__", Value: replica},
+				},
+				Samples: []*prompb.Sample{{Value: 1, Timestamp: 1}},
+			}},
+		}
+		body := remote.GeneratePromWriteRequestBody(t, promReq)
+		req, _ := http.NewRequest("POST", PromWriteURL, body)
+		return req
+	}
+
+	for _, replica := range []string{"a", "b", "a"} {
+		recorder := httptest.NewRecorder()
+		promWrite.ServeHTTP(recorder, newReq(replica))
+		require.Equal(t, http.StatusOK, recorder.Code)
+	}
+
+	// Only the samples from the elected replica are written, without the
+	// replica label
+	require.Len(t, store.writes, 2)
+	for _, write := range store.writes {
+		_, ok := write.Tags.Get("__replica__")
+		require.False(t, ok)
+		cluster, ok := write.Tags.Get("cluster")
+		require.True(t, ok)
+		require.Equal(t, "c1", cluster)
+	}
+}
+
+func TestPromWriteRelabel(t *testing.T) {
+	logging.InitWithCores(nil)
+
+	dropRegex := "first"
+	rules, err := relabel.NewRules([]relabel.RuleConfiguration{{
+		SourceLabels: []string{"__name__"},
+		Regex:        &dropRegex,
+		Action:       relabel.Drop,
+	}})
+	require.NoError(t, err)
+
+	store := &recordingStorage{Storage: mock.NewMockStorage()}
+	promWrite := &PromWriteHandler{
+		store:            store,
+		relabeler:        relabel.NewRelabeler(rules, tally.NoopScope),
+		promWriteMetrics: newPromWriteMetrics(tally.NoopScope),
+	}
+
+	promReq := remote.GeneratePromWriteRequest()
+	promReqBody := remote.GeneratePromWriteRequestBody(t, promReq)
+	req, _ := http.NewRequest("POST", PromWriteURL, promReqBody)
+	recorder := httptest.NewRecorder()
+	promWrite.ServeHTTP(recorder, req)
+	require.Equal(t, http.StatusOK, recorder.Code)
+
+	// The first series is dropped by name
+	require.Len(t, store.writes, 1)
+	name, ok := store.writes[0].Tags.Get("__name__")
+	require.True(t, ok)
+	require.Equal(t, "second", name)
+}
@@ -0,0 +1,103 @@
+// Copyright (c) 2018 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package handler
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/m3db/m3/src/query/executor"
+	"github.com/m3db/m3/src/query/util/logging"
+
+	"github.com/gorilla/mux"
+	"go.uber.org/zap"
+)
+
+const (
+	queryIDVar = "id"
+
+	// ActiveQueriesURL is the url to list the queries currently executing
+	ActiveQueriesURL = RoutePrefixV1 + "/queries"
+
+	// ActiveQueriesHTTPMethod is the HTTP method used with this resource.
+	ActiveQueriesHTTPMethod = http.MethodGet
+
+	// KillQueryHTTPMethod is the HTTP method used with this resource.
+	KillQueryHTTPMethod = http.MethodDelete
+)
+
+var (
+	// KillQueryURL is the url to kill a query currently executing
+	KillQueryURL = fmt.Sprintf("%s/queries/{%s}", RoutePrefixV1, queryIDVar)
+)
+
+// ActiveQueriesHandler represents a handler for listing running queries
+type ActiveQueriesHandler struct {
+	engine *executor.Engine
+}
+
+// NewActiveQueriesHandler returns a new instance of handler
+func NewActiveQueriesHandler(engine *executor.Engine) http.Handler {
+	return &ActiveQueriesHandler{engine: engine}
+}
+
+func (h *ActiveQueriesHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	logger := logging.WithContext(r.Context())
+	WriteJSONResponse(w, struct {
+		Queries []executor.QueryInfo `json:"queries"`
+	}{
+		Queries: h.engine.ActiveQueries(),
+	}, logger)
+}
+
+// KillQueryHandler represents a handler for killing a running query
+type KillQueryHandler struct {
+	engine *executor.Engine
+}
+
+// NewKillQueryHandler returns a new instance of handler
+func NewKillQueryHandler(engine *executor.Engine) http.Handler {
+	return &KillQueryHandler{engine: engine}
+}
+
+func (h *KillQueryHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	logger := logging.WithContext(r.Context())
+	id, err := strconv.ParseUint(mux.Vars(r)[queryIDVar], 10, 64)
+	if err != nil {
+		Error(w, fmt.Errorf("invalid query id: %v", err), http.StatusBadRequest)
+		return
+	}
+
+	if err := h.engine.KillQuery(id); err != nil {
+		logger.Error("unable to kill query", zap.Uint64("id", id), zap.Error(err))
+		Error(w, err, http.StatusNotFound)
+		return
+	}
+
+	logger.Info("killed query", zap.Uint64("id", id))
+	json.NewEncoder(w).Encode(struct {
+		Killed bool `json:"killed"`
+	}{
+		Killed: true,
+	})
+}
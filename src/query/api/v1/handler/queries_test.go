@@ -0,0 +1,83 @@
+// Copyright (c) 2018 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package handler
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+
+	"github.com/m3db/m3/src/query/executor"
+	"github.com/m3db/m3/src/query/storage/mock"
+	"github.com/m3db/m3/src/query/util/logging"
+
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newQueriesRouter(engine *executor.Engine) *mux.Router {
+	r := mux.NewRouter()
+	r.Handle(ActiveQueriesURL, NewActiveQueriesHandler(engine)).Methods(ActiveQueriesHTTPMethod)
+	r.Handle(KillQueryURL, NewKillQueryHandler(engine)).Methods(KillQueryHTTPMethod)
+	return r
+}
+
+func TestActiveQueriesEmpty(t *testing.T) {
+	logging.InitWithCores(nil)
+	router := newQueriesRouter(executor.NewEngine(mock.NewMockStorage()))
+
+	req := httptest.NewRequest(ActiveQueriesHTTPMethod, ActiveQueriesURL, nil)
+	recorder := httptest.NewRecorder()
+	router.ServeHTTP(recorder, req)
+
+	require.Equal(t, http.StatusOK, recorder.Code)
+	assert.JSONEq(t, `{"queries":[]}`, recorder.Body.String())
+}
+
+func TestKillQuery(t *testing.T) {
+	logging.InitWithCores(nil)
+	tracker := executor.NewTracker()
+	engine := executor.NewEngineWithTracker(mock.NewMockStorage(), tracker)
+	router := newQueriesRouter(engine)
+
+	_, err := tracker.Track("foo", "", nil)
+	require.NoError(t, err)
+	require.Len(t, engine.ActiveQueries(), 1)
+	id := engine.ActiveQueries()[0].ID
+
+	req := httptest.NewRequest(KillQueryHTTPMethod, ActiveQueriesURL+"/bad", nil)
+	recorder := httptest.NewRecorder()
+	router.ServeHTTP(recorder, req)
+	assert.Equal(t, http.StatusBadRequest, recorder.Code)
+
+	req = httptest.NewRequest(KillQueryHTTPMethod, ActiveQueriesURL+"/12345", nil)
+	recorder = httptest.NewRecorder()
+	router.ServeHTTP(recorder, req)
+	assert.Equal(t, http.StatusNotFound, recorder.Code)
+
+	req = httptest.NewRequest(KillQueryHTTPMethod, ActiveQueriesURL+"/"+strconv.FormatUint(id, 10), nil)
+	recorder = httptest.NewRecorder()
+	router.ServeHTTP(recorder, req)
+	assert.Equal(t, http.StatusOK, recorder.Code)
+	assert.Equal(t, "killed", engine.ActiveQueries()[0].Status)
+}
@@ -0,0 +1,86 @@
+// Copyright (c) 2018 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package handler
+
+import (
+	"net/http"
+
+	opentracing "github.com/opentracing/opentracing-go"
+	"github.com/opentracing/opentracing-go/ext"
+)
+
+// WithTracing wraps the handler, starting a span for each request with the
+// global tracer which continues any trace propagated in the request headers.
+// The span is set on the request context so it is the parent of the spans
+// started while handling the request.
+func WithTracing(operationName string, next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		tracer := opentracing.GlobalTracer()
+		var opts []opentracing.StartSpanOption
+		parent, err := tracer.Extract(opentracing.HTTPHeaders,
+			opentracing.HTTPHeadersCarrier(r.Header))
+		if err == nil {
+			opts = append(opts, ext.RPCServerOption(parent))
+		}
+
+		span := tracer.StartSpan(operationName, opts...)
+		ext.HTTPMethod.Set(span, r.Method)
+		ext.HTTPUrl.Set(span, r.URL.RequestURI())
+
+		sw := &statusResponseWriter{ResponseWriter: w, status: http.StatusOK}
+		ctx := opentracing.ContextWithSpan(r.Context(), span)
+		next.ServeHTTP(sw, r.WithContext(ctx))
+
+		ext.HTTPStatusCode.Set(span, uint16(sw.status))
+		if sw.status >= http.StatusInternalServerError {
+			ext.Error.Set(span, true)
+		}
+		span.Finish()
+	})
+}
+
+// statusResponseWriter records the status code written to the response.
+type statusResponseWriter struct {
+	http.ResponseWriter
+	status int
+}
+
+func (w *statusResponseWriter) WriteHeader(status int) {
+	w.status = status
+	w.ResponseWriter.WriteHeader(status)
+}
+
+// Flush flushes the underlying writer if it supports flushing, which
+// streaming responses rely on.
+func (w *statusResponseWriter) Flush() {
+	if f, ok := w.ResponseWriter.(http.Flusher); ok {
+		f.Flush()
+	}
+}
+
+// CloseNotify returns the close notifications of the underlying writer which
+// CloseWatcher relies on, the returned channel never fires if unsupported.
+func (w *statusResponseWriter) CloseNotify() <-chan bool {
+	if n, ok := w.ResponseWriter.(http.CloseNotifier); ok {
+		return n.CloseNotify()
+	}
+	return nil
+}
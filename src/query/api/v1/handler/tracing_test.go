@@ -0,0 +1,68 @@
+// Copyright (c) 2018 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package handler
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	opentracing "github.com/opentracing/opentracing-go"
+	"github.com/opentracing/opentracing-go/mocktracer"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWithTracingContinuesTrace(t *testing.T) {
+	tracer := mocktracer.New()
+	prev := opentracing.GlobalTracer()
+	opentracing.SetGlobalTracer(tracer)
+	defer opentracing.SetGlobalTracer(prev)
+
+	parent := tracer.StartSpan("client")
+	req := httptest.NewRequest("GET", "/api/v1/query_range", nil)
+	require.NoError(t, tracer.Inject(parent.Context(), opentracing.HTTPHeaders,
+		opentracing.HTTPHeadersCarrier(req.Header)))
+
+	var inner opentracing.Span
+	h := WithTracing("PromRead", http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		inner = opentracing.SpanFromContext(r.Context())
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+
+	recorder := httptest.NewRecorder()
+	h.ServeHTTP(recorder, req)
+	parent.Finish()
+
+	require.NotNil(t, inner)
+	spans := tracer.FinishedSpans()
+	require.Len(t, spans, 2)
+
+	span := spans[0]
+	assert.Equal(t, "PromRead", span.OperationName)
+	assert.Equal(t, parent.Context().(mocktracer.MockSpanContext).TraceID,
+		span.SpanContext.TraceID)
+	assert.Equal(t, parent.Context().(mocktracer.MockSpanContext).SpanID,
+		span.ParentID)
+	assert.Equal(t, uint16(http.StatusInternalServerError), span.Tag("http.status_code"))
+	assert.Equal(t, true, span.Tag("error"))
+	assert.Equal(t, http.StatusInternalServerError, recorder.Code)
+}
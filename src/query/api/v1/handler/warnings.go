@@ -0,0 +1,79 @@
+// Copyright (c) 2018 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package handler
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/m3db/m3/src/query/block"
+)
+
+// ErrPartialResults is returned when partial results are not allowed and a
+// query returned results that are not exhaustive or had warnings.
+type ErrPartialResults struct {
+	meta block.ResultMetadata
+}
+
+// NewErrPartialResults returns a new partial results error.
+func NewErrPartialResults(meta block.ResultMetadata) error {
+	return ErrPartialResults{meta: meta}
+}
+
+func (e ErrPartialResults) Error() string {
+	if len(e.meta.Warnings) == 0 {
+		return "query results are not exhaustive"
+	}
+
+	return fmt.Sprintf("query results are partial: %s",
+		strings.Join(e.meta.Warnings.Strings(), ", "))
+}
+
+// AddWarningHeaders adds the limit and warnings headers to the response if
+// the result metadata indicates a partial result.
+func AddWarningHeaders(w http.ResponseWriter, meta block.ResultMetadata) {
+	if meta.NonExhaustive {
+		w.Header().Set(LimitHeader, "true")
+	}
+
+	if len(meta.Warnings) > 0 {
+		w.Header().Set(WarningsHeader, strings.Join(meta.Warnings.Strings(), ","))
+	}
+}
+
+// RequireExhaustive returns whether partial results should be returned as an
+// error, the request header takes precedence over the given default.
+func RequireExhaustive(r *http.Request, defaultValue bool) (bool, error) {
+	str := r.Header.Get(RequireExhaustiveHeader)
+	if str == "" {
+		return defaultValue, nil
+	}
+
+	value, err := strconv.ParseBool(str)
+	if err != nil {
+		return false, fmt.Errorf("unable to parse %s header: %v",
+			RequireExhaustiveHeader, err)
+	}
+
+	return value, nil
+}
@@ -29,6 +29,7 @@ import (
 	"github.com/m3db/m3/src/cmd/services/m3coordinator/downsample"
 	dbconfig "github.com/m3db/m3/src/cmd/services/m3dbnode/config"
 	"github.com/m3db/m3/src/cmd/services/m3query/config"
+	dbclient "github.com/m3db/m3/src/dbnode/client"
 	"github.com/m3db/m3/src/query/api/v1/handler"
 	"github.com/m3db/m3/src/query/api/v1/handler/database"
 	m3json "github.com/m3db/m3/src/query/api/v1/handler/json"
@@ -38,9 +39,14 @@ import (
 	"github.com/m3db/m3/src/query/api/v1/handler/prometheus/native"
 	"github.com/m3db/m3/src/query/api/v1/handler/prometheus/remote"
 	"github.com/m3db/m3/src/query/executor"
+	"github.com/m3db/m3/src/query/ha"
+	"github.com/m3db/m3/src/query/relabel"
+	"github.com/m3db/m3/src/query/rules"
 	"github.com/m3db/m3/src/query/storage"
+	"github.com/m3db/m3/src/query/storage/local"
 	"github.com/m3db/m3/src/query/util/logging"
 	clusterclient "github.com/m3db/m3cluster/client"
+	"github.com/m3db/m3cluster/kv"
 
 	"github.com/gorilla/mux"
 	"github.com/uber-go/tally"
@@ -62,6 +68,8 @@ type Handler struct {
 	storage       storage.Storage
 	downsampler   downsample.Downsampler
 	engine        *executor.Engine
+	ruleManager   *rules.Manager
+	clusters      local.Clusters
 	clusterClient clusterclient.Client
 	config        config.Configuration
 	embeddedDbCfg *dbconfig.DBConfiguration
@@ -74,6 +82,8 @@ func NewHandler(
 	storage storage.Storage,
 	downsampler downsample.Downsampler,
 	engine *executor.Engine,
+	ruleManager *rules.Manager,
+	clusters local.Clusters,
 	clusterClient clusterclient.Client,
 	cfg config.Configuration,
 	embeddedDbCfg *dbconfig.DBConfiguration,
@@ -85,6 +95,8 @@ func NewHandler(
 		storage:       storage,
 		downsampler:   downsampler,
 		engine:        engine,
+		ruleManager:   ruleManager,
+		clusters:      clusters,
 		clusterClient: clusterClient,
 		config:        cfg,
 		embeddedDbCfg: embeddedDbCfg,
@@ -97,27 +109,51 @@ func NewHandler(
 // RegisterRoutes registers all http routes.
 func (h *Handler) RegisterRoutes() error {
 	logged := logging.WithResponseTimeLogging
+	traced := func(operationName string, next http.Handler) http.Handler {
+		return logged(handler.WithTracing(operationName, next))
+	}
 
 	h.Router.HandleFunc(openapi.URL, logged(&openapi.DocHandler{}).ServeHTTP).Methods(openapi.HTTPMethod)
 	h.Router.PathPrefix(openapi.StaticURLPrefix).Handler(logged(openapi.StaticHandler()))
 
 	// Prometheus remote read/write endpoints
-	promRemoteReadHandler := remote.NewPromReadHandler(h.engine, h.scope.Tagged(remoteSource))
-	promRemoteWriteHandler, err := remote.NewPromWriteHandler(h.storage, nil, h.scope.Tagged(remoteSource))
+	requireExhaustive := h.config.ResultOptions.RequireExhaustive
+	promRemoteReadHandler := remote.NewPromReadHandler(h.engine, h.scope.Tagged(remoteSource), requireExhaustive)
+	deduper, err := h.newDeduper()
+	if err != nil {
+		return err
+	}
+
+	relabeler, err := h.newRelabeler()
+	if err != nil {
+		return err
+	}
+
+	promRemoteWriteHandler, err := remote.NewPromWriteHandler(h.storage, nil, deduper, relabeler, h.scope.Tagged(remoteSource))
 	if err != nil {
 		return err
 	}
 
-	h.Router.HandleFunc(remote.PromReadURL, logged(promRemoteReadHandler).ServeHTTP).Methods(remote.PromReadHTTPMethod)
-	h.Router.HandleFunc(remote.PromWriteURL, logged(promRemoteWriteHandler).ServeHTTP).Methods(remote.PromWriteHTTPMethod)
-	h.Router.HandleFunc(native.PromReadURL, logged(native.NewPromReadHandler(h.engine)).ServeHTTP).Methods(native.PromReadHTTPMethod)
+	h.Router.HandleFunc(remote.PromReadURL, traced("PromRemoteRead", promRemoteReadHandler).ServeHTTP).Methods(remote.PromReadHTTPMethod)
+	h.Router.HandleFunc(remote.PromWriteURL, traced("PromRemoteWrite", promRemoteWriteHandler).ServeHTTP).Methods(remote.PromWriteHTTPMethod)
+	h.Router.HandleFunc(native.PromReadURL, traced("PromRead", native.NewPromReadHandler(h.engine, requireExhaustive)).ServeHTTP).Methods(native.PromReadHTTPMethod)
+
+	// Recording and alerting rule status endpoints
+	if h.ruleManager != nil {
+		h.Router.HandleFunc(native.PromRulesURL, logged(native.NewPromRulesHandler(h.ruleManager)).ServeHTTP).Methods(native.PromRulesHTTPMethod)
+		h.Router.HandleFunc(native.PromAlertsURL, logged(native.NewPromAlertsHandler(h.ruleManager)).ServeHTTP).Methods(native.PromAlertsHTTPMethod)
+	}
+
+	// Active query listing and kill endpoints
+	h.Router.HandleFunc(handler.ActiveQueriesURL, logged(handler.NewActiveQueriesHandler(h.engine)).ServeHTTP).Methods(handler.ActiveQueriesHTTPMethod)
+	h.Router.HandleFunc(handler.KillQueryURL, logged(handler.NewKillQueryHandler(h.engine)).ServeHTTP).Methods(handler.KillQueryHTTPMethod)
 
 	// Native M3 search and write endpoints
-	h.Router.HandleFunc(handler.SearchURL, logged(handler.NewSearchHandler(h.storage)).ServeHTTP).Methods(handler.SearchHTTPMethod)
-	h.Router.HandleFunc(m3json.WriteJSONURL, logged(m3json.NewWriteJSONHandler(h.storage)).ServeHTTP).Methods(m3json.JSONWriteHTTPMethod)
+	h.Router.HandleFunc(handler.SearchURL, traced("Search", handler.NewSearchHandler(h.storage)).ServeHTTP).Methods(handler.SearchHTTPMethod)
+	h.Router.HandleFunc(m3json.WriteJSONURL, traced("WriteJSON", m3json.NewWriteJSONHandler(h.storage, relabeler)).ServeHTTP).Methods(m3json.JSONWriteHTTPMethod)
 
 	if h.clusterClient != nil {
-		placement.RegisterRoutes(h.Router, h.clusterClient, h.config)
+		placement.RegisterRoutes(h.Router, h.clusterClient, h.config, h.nodeHealthSession())
 		namespace.RegisterRoutes(h.Router, h.clusterClient)
 		database.RegisterRoutes(h.Router, h.clusterClient, h.config, h.embeddedDbCfg)
 	}
@@ -129,6 +165,50 @@ func (h *Handler) RegisterRoutes() error {
 	return nil
 }
 
+// nodeHealthSession returns the session used to check the health of the
+// database nodes, nil if the coordinator is not connected to a cluster.
+func (h *Handler) nodeHealthSession() dbclient.NodeHealthSession {
+	if h.clusters == nil {
+		return nil
+	}
+
+	session := h.clusters.UnaggregatedClusterNamespace().Session()
+	if checker, ok := session.(dbclient.NodeHealthSession); ok {
+		return checker
+	}
+	return nil
+}
+
+// newDeduper returns the deduper for writes from highly available Prometheus
+// pairs, nil if not configured.
+func (h *Handler) newDeduper() (*ha.Deduper, error) {
+	if h.config.HA == nil {
+		return nil, nil
+	}
+
+	var kvStoreFn ha.KVStoreFn
+	if h.clusterClient != nil {
+		kvStoreFn = h.clusterClient.KV
+	}
+
+	return h.config.HA.NewDeduper(kvStoreFn, h.scope.SubScope("ha"))
+}
+
+// newRelabeler returns the relabeler for ingested series, nil if not
+// configured.
+func (h *Handler) newRelabeler() (*relabel.Relabeler, error) {
+	if h.config.Relabel == nil {
+		return nil, nil
+	}
+
+	var kvStoreFn func() (kv.Store, error)
+	if h.clusterClient != nil {
+		kvStoreFn = h.clusterClient.KV
+	}
+
+	return h.config.Relabel.NewRelabeler(kvStoreFn, h.scope.SubScope("relabel"))
+}
+
 // Endpoints useful for profiling the service
 func (h *Handler) registerHealthEndpoints() {
 	h.Router.HandleFunc(healthURL, func(w http.ResponseWriter, r *http.Request) {
@@ -49,7 +49,7 @@ func TestPromRemoteReadGet(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	storage, _ := local.NewStorageAndSession(t, ctrl)
 
-	h, err := NewHandler(storage, nil, executor.NewEngine(storage), nil,
+	h, err := NewHandler(storage, nil, executor.NewEngine(storage), nil, nil, nil,
 		config.Configuration{}, nil, tally.NewTestScope("", nil))
 	require.NoError(t, err, "unable to setup handler")
 	err = h.RegisterRoutes()
@@ -66,7 +66,7 @@ func TestPromRemoteReadPost(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	storage, _ := local.NewStorageAndSession(t, ctrl)
 
-	h, err := NewHandler(storage, nil, executor.NewEngine(storage), nil,
+	h, err := NewHandler(storage, nil, executor.NewEngine(storage), nil, nil, nil,
 		config.Configuration{}, nil, tally.NewTestScope("", nil))
 	require.NoError(t, err, "unable to setup handler")
 	err = h.RegisterRoutes()
@@ -83,7 +83,7 @@ func TestPromNativeReadGet(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	storage, _ := local.NewStorageAndSession(t, ctrl)
 
-	h, err := NewHandler(storage, nil, executor.NewEngine(storage), nil,
+	h, err := NewHandler(storage, nil, executor.NewEngine(storage), nil, nil, nil,
 		config.Configuration{}, nil, tally.NewTestScope("", nil))
 	require.NoError(t, err, "unable to setup handler")
 	h.RegisterRoutes()
@@ -99,7 +99,7 @@ func TestPromNativeReadPost(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	storage, _ := local.NewStorageAndSession(t, ctrl)
 
-	h, err := NewHandler(storage, nil, executor.NewEngine(storage), nil,
+	h, err := NewHandler(storage, nil, executor.NewEngine(storage), nil, nil, nil,
 		config.Configuration{}, nil, tally.NewTestScope("", nil))
 	require.NoError(t, err, "unable to setup handler")
 	h.RegisterRoutes()
@@ -115,7 +115,7 @@ func TestJSONWritePost(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	storage, _ := local.NewStorageAndSession(t, ctrl)
 
-	h, err := NewHandler(storage, nil, executor.NewEngine(storage), nil,
+	h, err := NewHandler(storage, nil, executor.NewEngine(storage), nil, nil, nil,
 		config.Configuration{}, nil, tally.NewTestScope("", nil))
 	require.NoError(t, err, "unable to setup handler")
 	h.RegisterRoutes()
@@ -131,7 +131,7 @@ func TestRoutesGet(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	storage, _ := local.NewStorageAndSession(t, ctrl)
 
-	h, err := NewHandler(storage, nil, executor.NewEngine(storage), nil,
+	h, err := NewHandler(storage, nil, executor.NewEngine(storage), nil, nil, nil,
 		config.Configuration{}, nil, tally.NewTestScope("", nil))
 	require.NoError(t, err, "unable to setup handler")
 	h.RegisterRoutes()
@@ -164,7 +164,7 @@ func TestHealthGet(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	storage, _ := local.NewStorageAndSession(t, ctrl)
 
-	h, err := NewHandler(storage, nil, executor.NewEngine(storage), nil,
+	h, err := NewHandler(storage, nil, executor.NewEngine(storage), nil, nil, nil,
 		config.Configuration{}, nil, tally.NewTestScope("", nil))
 	require.NoError(t, err, "unable to setup handler")
 	h.RegisterRoutes()
@@ -0,0 +1,95 @@
+// Copyright (c) 2018 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package block
+
+import "fmt"
+
+// Warning is a message that indicates potential partial or incomplete results.
+type Warning struct {
+	// Name is the name of the store originating the warning.
+	Name string
+	// Message is the content of the warning message.
+	Message string
+}
+
+// String returns the string representation of the warning.
+func (w Warning) String() string {
+	return fmt.Sprintf("%s_%s", w.Name, w.Message)
+}
+
+// Warnings is a slice of warnings.
+type Warnings []Warning
+
+// Strings returns the string representations of the warnings.
+func (w Warnings) Strings() []string {
+	strs := make([]string, 0, len(w))
+	for _, warning := range w {
+		strs = append(strs, warning.String())
+	}
+
+	return strs
+}
+
+// ResultMetadata describes metadata common to each type of query result,
+// indicating any additional information about the result.
+type ResultMetadata struct {
+	// NonExhaustive indicates the underlying data set does not present a full
+	// collection of retrieved data as a limit was hit, the zero value is an
+	// exhaustive result.
+	NonExhaustive bool
+	// Warnings is a list of warnings that indicate potentially partial or
+	// incomplete results, such as a store that failed to respond.
+	Warnings Warnings
+}
+
+// NewResultMetadata creates a new result metadata.
+func NewResultMetadata() ResultMetadata {
+	return ResultMetadata{}
+}
+
+// CombineMetadata combines two result metadatas, the result is only
+// exhaustive if both are exhaustive and contains the warnings of both.
+func (m ResultMetadata) CombineMetadata(other ResultMetadata) ResultMetadata {
+	combined := ResultMetadata{
+		NonExhaustive: m.NonExhaustive || other.NonExhaustive,
+	}
+
+	if len(m.Warnings)+len(other.Warnings) > 0 {
+		combined.Warnings = make(Warnings, 0, len(m.Warnings)+len(other.Warnings))
+		combined.Warnings = append(combined.Warnings, m.Warnings...)
+		combined.Warnings = append(combined.Warnings, other.Warnings...)
+	}
+
+	return combined
+}
+
+// AddWarning adds a warning to the result metadata.
+func (m *ResultMetadata) AddWarning(name string, message string) {
+	m.Warnings = append(m.Warnings, Warning{
+		Name:    name,
+		Message: message,
+	})
+}
+
+// IsPartial returns true if the result is not exhaustive or has any warnings.
+func (m ResultMetadata) IsPartial() bool {
+	return m.NonExhaustive || len(m.Warnings) > 0
+}
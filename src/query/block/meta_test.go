@@ -0,0 +1,59 @@
+// Copyright (c) 2018 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package block
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCombineMetadata(t *testing.T) {
+	a := NewResultMetadata()
+	assert.False(t, a.IsPartial())
+
+	b := NewResultMetadata()
+	b.NonExhaustive = true
+	b.AddWarning("foo", "bar")
+
+	combined := a.CombineMetadata(b)
+	assert.True(t, combined.NonExhaustive)
+	assert.True(t, combined.IsPartial())
+	assert.Equal(t, []string{"foo_bar"}, combined.Warnings.Strings())
+
+	// Combining must not modify either of the inputs
+	assert.False(t, a.NonExhaustive)
+	assert.Len(t, a.Warnings, 0)
+	assert.Len(t, b.Warnings, 1)
+}
+
+func TestWarningsOnlyIsPartial(t *testing.T) {
+	meta := NewResultMetadata()
+	meta.AddWarning("remote", "unavailable")
+	assert.False(t, meta.NonExhaustive)
+	assert.True(t, meta.IsPartial())
+}
+
+func TestZeroValueIsExhaustive(t *testing.T) {
+	var meta ResultMetadata
+	assert.False(t, meta.IsPartial())
+	assert.Equal(t, NewResultMetadata(), meta)
+}
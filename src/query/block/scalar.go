@@ -32,14 +32,24 @@ import (
 // allowing them to treat this as a regular block, while at the same time
 // having an option to optimize by accessing the scalar value directly instead
 type Scalar struct {
-	val  float64
+	s    ScalarFunc
 	meta Metadata
 }
 
+// ScalarFunc is a function returning the value of a scalar block at the
+// given time, allowing scalars such as time() to vary across steps
+type ScalarFunc func(t time.Time) float64
+
 // NewScalar creates a scalar block containing val over the bounds
 func NewScalar(val float64, bounds Bounds) Block {
+	return NewScalarFunc(func(_ time.Time) float64 { return val }, bounds)
+}
+
+// NewScalarFunc creates a scalar block over the bounds, the value at each step
+// is determined by calling s with the time of the step
+func NewScalarFunc(s ScalarFunc, bounds Bounds) Block {
 	return &Scalar{
-		val: val,
+		s: s,
 		meta: Metadata{
 			Bounds: bounds,
 			Tags:   models.EmptyTags(),
@@ -52,10 +62,8 @@ func (b *Scalar) StepIter() (StepIter, error) {
 	bounds := b.meta.Bounds
 	steps := bounds.Steps()
 	return &scalarStepIter{
-		meta: b.meta,
-		step: scalarStep{
-			vals: []float64{b.val},
-		},
+		meta:    b.meta,
+		s:       b.s,
 		numVals: steps,
 		idx:     -1,
 	}, nil
@@ -67,7 +75,7 @@ func (b *Scalar) SeriesIter() (SeriesIter, error) {
 	steps := bounds.Steps()
 	vals := make([]float64, steps)
 	for i := range vals {
-		vals[i] = b.val
+		vals[i] = b.s(bounds.Start.Add(time.Duration(i) * bounds.StepSize))
 	}
 	return &scalarSeriesIter{
 		meta: b.meta,
@@ -79,12 +87,14 @@ func (b *Scalar) SeriesIter() (SeriesIter, error) {
 // Close closes the scalar block
 func (b *Scalar) Close() error { return nil }
 
-// Value returns the value for the scalar block
-func (b *Scalar) Value() float64 { return b.val }
+// Value returns the value for the scalar block at the given time, blocks
+// created with NewScalar have the same value at every time
+func (b *Scalar) Value(t time.Time) float64 { return b.s(t) }
 
 type scalarStepIter struct {
 	meta         Metadata
-	step         scalarStep
+	s            ScalarFunc
+	step         *scalarStep
 	numVals, idx int
 }
 
@@ -102,16 +112,24 @@ func (it *scalarStepIter) Meta() Metadata           { return it.meta }
 
 func (it *scalarStepIter) Next() bool {
 	it.idx++
+	if it.idx >= it.numVals {
+		return false
+	}
+
 	bounds := it.meta.Bounds
-	it.step.time = bounds.Start.Add(time.Duration(it.idx) * bounds.StepSize)
-	return it.idx < it.numVals
+	t := bounds.Start.Add(time.Duration(it.idx) * bounds.StepSize)
+	it.step = &scalarStep{
+		vals: []float64{it.s(t)},
+		time: t,
+	}
+	return true
 }
 
 func (it *scalarStepIter) Current() (Step, error) {
 	if it.idx >= it.numVals || it.idx < 0 {
 		return nil, fmt.Errorf("invalid scalar index: %d, numVals: %d", it.idx, it.numVals)
 	}
-	return &it.step, nil
+	return it.step, nil
 }
 
 type scalarStep struct {
@@ -44,7 +44,7 @@ func TestScalarBlock(t *testing.T) {
 	if scalar, ok := block.(*Scalar); !ok {
 		require.FailNow(t, "unexpected type for new scalar blocks")
 	} else {
-		assert.Equal(t, val, scalar.Value())
+		assert.Equal(t, val, scalar.Value(start))
 	}
 
 	stepIter, err := block.StepIter()
@@ -121,3 +121,32 @@ func verifyMetas(t *testing.T, meta Metadata, seriesMeta []SeriesMeta) {
 	assert.Len(t, sMeta.Tags, 0)
 	assert.Equal(t, "", sMeta.Name)
 }
+
+func TestScalarFuncBlock(t *testing.T) {
+	block := NewScalarFunc(func(t time.Time) float64 {
+		return float64(t.Sub(start) / time.Second)
+	}, bounds)
+
+	stepIter, err := block.StepIter()
+	require.NoError(t, err)
+	verifyMetas(t, stepIter.Meta(), stepIter.SeriesMeta())
+
+	var steps []Step
+	for stepIter.Next() {
+		step, err := stepIter.Current()
+		require.NoError(t, err)
+		steps = append(steps, step)
+	}
+
+	require.Len(t, steps, 6)
+	for i, step := range steps {
+		assert.Equal(t, []float64{float64(i * 10)}, step.Values())
+	}
+
+	seriesIter, err := block.SeriesIter()
+	require.NoError(t, err)
+	require.True(t, seriesIter.Next())
+	series, err := seriesIter.Current()
+	require.NoError(t, err)
+	assert.Equal(t, []float64{0, 10, 20, 30, 40, 50}, series.Values())
+}
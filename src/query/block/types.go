@@ -213,5 +213,6 @@ type Builder interface {
 
 // Result is the result from a block query
 type Result struct {
-	Blocks []Block
+	Blocks   []Block
+	Metadata ResultMetadata
 }
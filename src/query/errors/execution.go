@@ -55,3 +55,8 @@ var (
 func ErrMaxConcurrentQueriesLimitExceeded(n, limit int) error {
 	return fmt.Errorf("max concurrent queries limit exceeded(%d, %d)", n, limit)
 }
+
+// ErrQueryNotFound is returned when a query is not being tracked.
+func ErrQueryNotFound(id uint64) error {
+	return fmt.Errorf("no such query id: %d", id)
+}
@@ -28,6 +28,10 @@ var (
 	// ErrNilWriteQuery is returned when trying to write a nil query
 	ErrNilWriteQuery = errors.New("nil write query")
 
+	// ErrWriteQueueFull is returned when a write cannot be queued since the
+	// write queue is full, the write should be retried later
+	ErrWriteQueueFull = errors.New("write queue full")
+
 	// ErrNotImplemented is returned when the storage endpoint is not implemented
 	ErrNotImplemented = errors.New("not implemented")
 
@@ -23,15 +23,25 @@ package executor
 import (
 	"context"
 
+	"github.com/m3db/m3/src/query/executor/transform"
 	"github.com/m3db/m3/src/query/models"
 	"github.com/m3db/m3/src/query/parser"
 	"github.com/m3db/m3/src/query/plan"
 	"github.com/m3db/m3/src/query/storage"
 	"github.com/m3db/m3/src/query/util/logging"
+	"github.com/m3db/m3/src/x/tracing"
 
+	opentracing "github.com/opentracing/opentracing-go"
 	"go.uber.org/zap"
 )
 
+const (
+	executeSpanName     = "executor.engine.Execute"
+	executeExprSpanName = "executor.engine.ExecuteExpr"
+	planSpanName        = "executor.engine.plan"
+	executionSpanName   = "executor.engine.execution"
+)
+
 // Engine executes a Query.
 type Engine struct {
 	// Used for tracking running queries.
@@ -44,6 +54,12 @@ type Engine struct {
 type EngineOptions struct {
 	// AbortCh is a channel that signals when results are no longer desired by the caller.
 	AbortCh <-chan bool
+	// Profile, if set, collects per node execution statistics and storage fetch times.
+	Profile *transform.Profile
+	// ResultMetadata, if set, collects the exhaustiveness and warnings of each storage fetch.
+	ResultMetadata *ResultMetadataCollector
+	// Caller describes the origin of the query, used when listing and logging queries.
+	Caller string
 }
 
 // Query is the result after execution
@@ -54,13 +70,30 @@ type Query struct {
 
 // NewEngine returns a new instance of QueryExecutor.
 func NewEngine(store storage.Storage) *Engine {
+	return NewEngineWithTracker(store, NewTracker())
+}
+
+// NewEngineWithTracker returns a new instance of QueryExecutor that tracks
+// queries with the given tracker.
+func NewEngineWithTracker(store storage.Storage, tracker *Tracker) *Engine {
 	return &Engine{
-		tracker: NewTracker(),
+		tracker: tracker,
 		Stats:   &QueryStatistics{},
 		store:   store,
 	}
 }
 
+// ActiveQueries returns a description of each query currently executing.
+func (e *Engine) ActiveQueries() []QueryInfo {
+	return e.tracker.Queries()
+}
+
+// KillQuery stops a running query, fetches in progress are interrupted and
+// the caller receives an error.
+func (e *Engine) KillQuery(id uint64) error {
+	return e.tracker.KillQuery(id)
+}
+
 // QueryStatistics keeps statistics related to the QueryExecutor.
 type QueryStatistics struct {
 	ActiveQueries          int64
@@ -72,7 +105,7 @@ type QueryStatistics struct {
 // Execute runs the query and closes the results channel once done
 func (e *Engine) Execute(ctx context.Context, query *storage.FetchQuery, opts *EngineOptions, closing <-chan bool, results chan *storage.QueryResult) {
 	defer close(results)
-	task, err := e.tracker.Track(query, closing)
+	task, err := e.tracker.Track(query.String(), opts.Caller, closing)
 	if err != nil {
 		select {
 		case results <- &storage.QueryResult{Err: err}:
@@ -82,10 +115,15 @@ func (e *Engine) Execute(ctx context.Context, query *storage.FetchQuery, opts *E
 	}
 
 	defer e.tracker.DetachQuery(task.qid)
+	defer e.tracker.logIfSlow(ctx, task)
 
-	result, err := e.store.Fetch(ctx, query, &storage.FetchOptions{
+	span, ctx := opentracing.StartSpanFromContext(ctx, executeSpanName)
+	span.SetTag("query", query.String())
+	store := &trackedStorage{Storage: e.store, task: task}
+	result, err := store.Fetch(ctx, query, &storage.FetchOptions{
 		KillChan: task.closing,
 	})
+	tracing.FinishSpan(span, err)
 	if err != nil {
 		results <- &storage.QueryResult{Err: err}
 		return
@@ -94,38 +132,94 @@ func (e *Engine) Execute(ctx context.Context, query *storage.FetchQuery, opts *E
 	results <- &storage.QueryResult{FetchResult: result}
 }
 
-// ExecuteExpr runs the query DAG and closes the results channel once done
-// nolint: unparam
-func (e *Engine) ExecuteExpr(ctx context.Context, parser parser.Parser, opts *EngineOptions, params models.RequestParams, results chan Query) {
-	defer close(results)
+// Plan generates the logical and physical plans for a query without executing it
+func (e *Engine) Plan(
+	ctx context.Context,
+	parser parser.Parser,
+	params models.RequestParams,
+) (plan.LogicalPlan, plan.PhysicalPlan, error) {
+	return e.plan(ctx, parser, e.store, params)
+}
+
+func (e *Engine) plan(
+	ctx context.Context,
+	parser parser.Parser,
+	store storage.Storage,
+	params models.RequestParams,
+) (_ plan.LogicalPlan, _ plan.PhysicalPlan, err error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, planSpanName)
+	defer func() { tracing.FinishSpan(span, err) }()
 
 	nodes, edges, err := parser.DAG()
 	if err != nil {
-		results <- Query{Err: err}
-		return
+		return plan.LogicalPlan{}, plan.PhysicalPlan{}, err
 	}
 
 	lp, err := plan.NewLogicalPlan(nodes, edges)
 	if err != nil {
-		results <- Query{Err: err}
-		return
+		return plan.LogicalPlan{}, plan.PhysicalPlan{}, err
 	}
 
 	if params.Debug {
 		logging.WithContext(ctx).Info("logical plan", zap.String("plan", lp.String()))
 	}
 
-	pp, err := plan.NewPhysicalPlan(lp, e.store, params)
+	pp, err := plan.NewPhysicalPlan(lp, store, params)
 	if err != nil {
-		results <- Query{Err: err}
-		return
+		return plan.LogicalPlan{}, plan.PhysicalPlan{}, err
 	}
 
 	if params.Debug {
 		logging.WithContext(ctx).Info("physical plan", zap.String("plan", pp.String()))
 	}
 
-	state, err := GenerateExecutionState(pp, e.store)
+	return lp, pp, nil
+}
+
+// ExecuteExpr runs the query DAG and closes the results channel once done
+// nolint: unparam
+func (e *Engine) ExecuteExpr(ctx context.Context, parser parser.Parser, opts *EngineOptions, params models.RequestParams, results chan Query) {
+	defer close(results)
+
+	task, err := e.tracker.Track(params.Query, opts.Caller, opts.AbortCh)
+	if err != nil {
+		results <- Query{Err: err}
+		return
+	}
+
+	defer e.tracker.DetachQuery(task.qid)
+	defer e.tracker.logIfSlow(ctx, task)
+
+	span, ctx := opentracing.StartSpanFromContext(ctx, executeExprSpanName)
+	span.SetTag("query", params.Query)
+	defer span.Finish()
+
+	// Killing the query cancels execution
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	go func() {
+		select {
+		case <-task.closing:
+			cancel()
+		case <-ctx.Done():
+		}
+	}()
+
+	var store storage.Storage = &trackedStorage{Storage: e.store, task: task}
+	if opts.Profile != nil {
+		store = &profiledStorage{Storage: store, profile: opts.Profile}
+	}
+	if opts.ResultMetadata != nil {
+		store = &metadataStorage{Storage: store, collector: opts.ResultMetadata}
+	}
+
+	_, pp, err := e.plan(ctx, parser, store, params)
+	if err != nil {
+		results <- Query{Err: err}
+		return
+	}
+
+	state, err := GenerateExecutionState(pp, store, opts.Profile)
 	// free up resources
 	if err != nil {
 		results <- Query{Err: err}
@@ -136,9 +230,14 @@ func (e *Engine) ExecuteExpr(ctx context.Context, parser parser.Parser, opts *En
 		logging.WithContext(ctx).Info("execution state", zap.String("state", state.String()))
 	}
 
+	task.setPlan(pp.String())
+
 	result := state.resultNode
 	results <- Query{Result: result}
-	if err := state.Execute(ctx); err != nil {
+	execSpan, ctx := opentracing.StartSpanFromContext(ctx, executionSpanName)
+	err = state.Execute(ctx)
+	tracing.FinishSpan(execSpan, err)
+	if err != nil {
 		result.abort(err)
 	} else {
 		result.done()
@@ -45,6 +45,11 @@ func TestExecute(t *testing.T) {
 
 	engine := NewEngine(store)
 	go engine.Execute(context.TODO(), &storage.FetchQuery{}, &EngineOptions{}, closing, results)
-	<-results
-	assert.Equal(t, len(engine.tracker.queries), 1)
+	result := <-results
+	assert.Error(t, result.Err)
+
+	// Query is detached once execution has completed
+	for range results {
+	}
+	assert.Equal(t, len(engine.tracker.queries), 0)
 }
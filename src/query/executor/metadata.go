@@ -0,0 +1,86 @@
+// Copyright (c) 2018 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package executor
+
+import (
+	"context"
+	"sync"
+
+	"github.com/m3db/m3/src/query/block"
+	"github.com/m3db/m3/src/query/storage"
+)
+
+// ResultMetadataCollector combines the result metadata of each fetch made
+// from storage while executing a query.
+type ResultMetadataCollector struct {
+	mu   sync.Mutex
+	meta block.ResultMetadata
+}
+
+// NewResultMetadataCollector creates a new ResultMetadataCollector.
+func NewResultMetadataCollector() *ResultMetadataCollector {
+	return &ResultMetadataCollector{meta: block.NewResultMetadata()}
+}
+
+// Add combines the metadata of a single fetch into the collected metadata.
+func (c *ResultMetadataCollector) Add(meta block.ResultMetadata) {
+	c.mu.Lock()
+	c.meta = c.meta.CombineMetadata(meta)
+	c.mu.Unlock()
+}
+
+// Metadata returns the combined metadata of all fetches so far.
+func (c *ResultMetadataCollector) Metadata() block.ResultMetadata {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.meta
+}
+
+// metadataStorage records the result metadata of each fetch from the
+// underlying storage.
+type metadataStorage struct {
+	storage.Storage
+	collector *ResultMetadataCollector
+}
+
+func (s *metadataStorage) Fetch(
+	ctx context.Context,
+	query *storage.FetchQuery,
+	options *storage.FetchOptions,
+) (*storage.FetchResult, error) {
+	result, err := s.Storage.Fetch(ctx, query, options)
+	if err == nil && result != nil {
+		s.collector.Add(result.Metadata)
+	}
+	return result, err
+}
+
+func (s *metadataStorage) FetchBlocks(
+	ctx context.Context,
+	query *storage.FetchQuery,
+	options *storage.FetchOptions,
+) (block.Result, error) {
+	result, err := s.Storage.FetchBlocks(ctx, query, options)
+	if err == nil {
+		s.collector.Add(result.Metadata)
+	}
+	return result, err
+}
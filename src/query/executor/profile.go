@@ -0,0 +1,117 @@
+// Copyright (c) 2018 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package executor
+
+import (
+	"context"
+	"time"
+
+	"github.com/m3db/m3/src/query/block"
+	"github.com/m3db/m3/src/query/executor/transform"
+	"github.com/m3db/m3/src/query/parser"
+	"github.com/m3db/m3/src/query/plan"
+	"github.com/m3db/m3/src/query/storage"
+)
+
+// profileSource wraps a source so that its execution time is recorded when
+// the query is being profiled.
+func profileSource(
+	step plan.LogicalStep,
+	source parser.Source,
+	controller *transform.Controller,
+	options transform.Options,
+) parser.Source {
+	if options.Profile == nil {
+		return source
+	}
+
+	stats := options.Profile.Node(step.ID(), step.Transform.Op.OpType())
+	controller.Stats = stats
+	return &profiledSource{source: source, stats: stats}
+}
+
+// profileTransform wraps a transform so that the blocks it processes and the
+// time taken to process them are recorded when the query is being profiled.
+func profileTransform(
+	step plan.LogicalStep,
+	node transform.OpNode,
+	controller *transform.Controller,
+	options transform.Options,
+) transform.OpNode {
+	if options.Profile == nil {
+		return node
+	}
+
+	stats := options.Profile.Node(step.ID(), step.Transform.Op.OpType())
+	controller.Stats = stats
+	return &profiledNode{node: node, stats: stats}
+}
+
+type profiledSource struct {
+	source parser.Source
+	stats  *transform.NodeStats
+}
+
+func (s *profiledSource) Execute(ctx context.Context) error {
+	start := time.Now()
+	err := s.source.Execute(ctx)
+	s.stats.RecordExecution(time.Since(start))
+	return err
+}
+
+type profiledNode struct {
+	node  transform.OpNode
+	stats *transform.NodeStats
+}
+
+func (n *profiledNode) Process(ID parser.NodeID, b block.Block) error {
+	defer n.stats.TrackInput(b)()
+	return n.node.Process(ID, b)
+}
+
+// profiledStorage records the time taken by each fetch from the underlying
+// storage.
+type profiledStorage struct {
+	storage.Storage
+	profile *transform.Profile
+}
+
+func (s *profiledStorage) Fetch(
+	ctx context.Context,
+	query *storage.FetchQuery,
+	options *storage.FetchOptions,
+) (*storage.FetchResult, error) {
+	start := time.Now()
+	result, err := s.Storage.Fetch(ctx, query, options)
+	s.profile.RecordFetch(time.Since(start))
+	return result, err
+}
+
+func (s *profiledStorage) FetchBlocks(
+	ctx context.Context,
+	query *storage.FetchQuery,
+	options *storage.FetchOptions,
+) (block.Result, error) {
+	start := time.Now()
+	result, err := s.Storage.FetchBlocks(ctx, query, options)
+	s.profile.RecordFetch(time.Since(start))
+	return result, err
+}
@@ -101,10 +101,12 @@ type ScalarParams interface {
 	) parser.Source
 }
 
-// GenerateExecutionState creates an execution state from the physical plan
+// GenerateExecutionState creates an execution state from the physical plan,
+// if a profile is provided then execution statistics are collected for each node
 func GenerateExecutionState(
 	pplan plan.PhysicalPlan,
 	storage storage.Storage,
+	profile *transform.Profile,
 ) (*ExecutionState, error) {
 	result := pplan.ResultStep
 	state := &ExecutionState{
@@ -120,6 +122,7 @@ func GenerateExecutionState(
 	options := transform.Options{
 		TimeSpec: pplan.TimeSpec,
 		Debug:    pplan.Debug,
+		Profile:  profile,
 	}
 	controller, err := state.createNode(step, options)
 	if err != nil {
@@ -147,14 +150,14 @@ func (s *ExecutionState) createNode(
 	sourceParams, ok := step.Transform.Op.(SourceParams)
 	if ok {
 		source, controller := CreateSource(step.ID(), sourceParams, s.storage, options)
-		s.sources = append(s.sources, source)
+		s.sources = append(s.sources, profileSource(step, source, controller, options))
 		return controller, nil
 	}
 
 	scalarParams, ok := step.Transform.Op.(ScalarParams)
 	if ok {
 		source, controller := CreateScalarSource(step.ID(), scalarParams, options)
-		s.sources = append(s.sources, source)
+		s.sources = append(s.sources, profileSource(step, source, controller, options))
 		return controller, nil
 	}
 
@@ -164,6 +167,7 @@ func (s *ExecutionState) createNode(
 	}
 
 	transformNode, controller := CreateTransform(step.ID(), transformParams, options)
+	transformNode = profileTransform(step, transformNode, controller, options)
 	for _, parentID := range step.Parents {
 		parentStep, ok := s.plan.Step(parentID)
 		if !ok {
@@ -54,7 +54,7 @@ func TestValidState(t *testing.T) {
 	store := mock.NewMockStorage()
 	p, err := plan.NewPhysicalPlan(lp, store, models.RequestParams{Now: time.Now()})
 	require.NoError(t, err)
-	state, err := GenerateExecutionState(p, store)
+	state, err := GenerateExecutionState(p, store, nil)
 	require.NoError(t, err)
 	require.Len(t, state.sources, 1)
 	err = state.Execute(context.Background())
@@ -71,7 +71,7 @@ func TestWithoutSources(t *testing.T) {
 	require.NoError(t, err)
 	p, err := plan.NewPhysicalPlan(lp, nil, models.RequestParams{Now: time.Now()})
 	require.NoError(t, err)
-	_, err = GenerateExecutionState(p, nil)
+	_, err = GenerateExecutionState(p, nil, nil)
 	assert.Error(t, err)
 }
 
@@ -83,7 +83,7 @@ func TestOnlySources(t *testing.T) {
 	require.NoError(t, err)
 	p, err := plan.NewPhysicalPlan(lp, nil, models.RequestParams{Now: time.Now()})
 	require.NoError(t, err)
-	state, err := GenerateExecutionState(p, nil)
+	state, err := GenerateExecutionState(p, nil, nil)
 	assert.NoError(t, err)
 	require.Len(t, state.sources, 1)
 }
@@ -110,7 +110,7 @@ func TestMultipleSources(t *testing.T) {
 	require.NoError(t, err)
 	p, err := plan.NewPhysicalPlan(lp, nil, models.RequestParams{Now: time.Now()})
 	require.NoError(t, err)
-	state, err := GenerateExecutionState(p, nil)
+	state, err := GenerateExecutionState(p, nil, nil)
 	assert.NoError(t, err)
 	require.Len(t, state.sources, 2)
 	assert.Contains(t, state.String(), "sources")
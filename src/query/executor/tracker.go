@@ -21,12 +21,19 @@
 package executor
 
 import (
+	"context"
 	"fmt"
+	"sort"
 	"sync"
+	"sync/atomic"
 	"time"
 
+	"github.com/m3db/m3/src/query/block"
 	"github.com/m3db/m3/src/query/errors"
 	"github.com/m3db/m3/src/query/storage"
+	"github.com/m3db/m3/src/query/util/logging"
+
+	"go.uber.org/zap"
 )
 
 const (
@@ -74,16 +81,20 @@ type Tracker struct {
 }
 
 // QueryTask is the internal data structure for managing queries.
-// For the public use data structure that gets returned, see QueryTask.
+// For the public use data structure that gets returned, see QueryInfo.
 type QueryTask struct {
-	qid       uint64
-	query     string
-	status    TaskStatus
-	startTime time.Time
-	closing   chan struct{}
-	monitorCh chan error
-	err       error
-	mu        sync.Mutex
+	// NB: accessed atomically, keep first for alignment on 32 bit platforms.
+	seriesFetched int64
+	qid           uint64
+	query         string
+	caller        string
+	plan          string
+	status        TaskStatus
+	startTime     time.Time
+	closing       chan struct{}
+	monitorCh     chan error
+	err           error
+	mu            sync.Mutex
 }
 
 func (q *QueryTask) setError(err error) {
@@ -92,6 +103,27 @@ func (q *QueryTask) setError(err error) {
 	q.mu.Unlock()
 }
 
+func (q *QueryTask) setPlan(plan string) {
+	q.mu.Lock()
+	q.plan = plan
+	q.mu.Unlock()
+}
+
+func (q *QueryTask) addSeriesFetched(n int) {
+	atomic.AddInt64(&q.seriesFetched, int64(n))
+}
+
+// QueryInfo describes a running query.
+type QueryInfo struct {
+	ID            uint64        `json:"id"`
+	Query         string        `json:"query"`
+	Caller        string        `json:"caller"`
+	Status        string        `json:"status"`
+	StartTime     time.Time     `json:"startTime"`
+	Elapsed       time.Duration `json:"elapsedNanos"`
+	SeriesFetched int64         `json:"seriesFetched"`
+}
+
 // NewTracker creates a new tracker.
 func NewTracker() *Tracker {
 	return &Tracker{
@@ -100,8 +132,9 @@ func NewTracker() *Tracker {
 	}
 }
 
-// Track is used to add a new query to tracker
-func (t *Tracker) Track(query storage.Query, connClosed <-chan bool) (*QueryTask, error) {
+// Track is used to add a new query to tracker, caller describes the origin
+// of the query and is only used for reporting.
+func (t *Tracker) Track(query string, caller string, connClosed <-chan bool) (*QueryTask, error) {
 	t.mu.Lock()
 	defer t.mu.Unlock()
 	qid := t.nextID
@@ -116,7 +149,9 @@ func (t *Tracker) Track(query storage.Query, connClosed <-chan bool) (*QueryTask
 	}
 
 	queryTask := &QueryTask{
-		query:     query.String(),
+		qid:       qid,
+		query:     query,
+		caller:    caller,
 		status:    RunningTask,
 		startTime: time.Now(),
 		closing:   make(chan struct{}),
@@ -156,7 +191,9 @@ func (t *Tracker) Close() error {
 	t.shutdown = true
 	for _, query := range t.queries {
 		query.setError(errors.ErrQueryEngineShutdown)
-		close(query.closing)
+		if query.status != KilledTask {
+			close(query.closing)
+		}
 	}
 	t.queries = nil
 	return nil
@@ -171,6 +208,31 @@ func (t *Tracker) queryError(qid uint64, err error) {
 	}
 }
 
+// Queries returns a description of each running query ordered by ID.
+func (t *Tracker) Queries() []QueryInfo {
+	now := time.Now()
+	t.mu.RLock()
+	infos := make([]QueryInfo, 0, len(t.queries))
+	for _, query := range t.queries {
+		infos = append(infos, QueryInfo{
+			ID:            query.qid,
+			Query:         query.query,
+			Caller:        query.caller,
+			Status:        query.status.String(),
+			StartTime:     query.startTime,
+			Elapsed:       now.Sub(query.startTime),
+			SeriesFetched: atomic.LoadInt64(&query.seriesFetched),
+		})
+	}
+	t.mu.RUnlock()
+
+	sort.Slice(infos, func(i, j int) bool {
+		return infos[i].ID < infos[j].ID
+	})
+
+	return infos
+}
+
 // KillQuery enters a query into the killed state and closes the channel
 // from the TaskManager. This method can be used to forcefully terminate a
 // running query.
@@ -180,7 +242,11 @@ func (t *Tracker) KillQuery(qid uint64) error {
 
 	query := t.queries[qid]
 	if query == nil {
-		return fmt.Errorf("no such query id: %d", qid)
+		return errors.ErrQueryNotFound(qid)
+	}
+
+	if query.status == KilledTask {
+		return nil
 	}
 
 	close(query.closing)
@@ -196,7 +262,7 @@ func (t *Tracker) DetachQuery(qid uint64) error {
 
 	query := t.queries[qid]
 	if query == nil {
-		return fmt.Errorf("no such query id: %d", qid)
+		return errors.ErrQueryNotFound(qid)
 	}
 
 	if query.status != KilledTask {
@@ -205,3 +271,86 @@ func (t *Tracker) DetachQuery(qid uint64) error {
 	delete(t.queries, qid)
 	return nil
 }
+
+// logIfSlow logs the query, including its plan if one was recorded, when it
+// took longer than LogQueriesAfter to execute.
+func (t *Tracker) logIfSlow(ctx context.Context, query *QueryTask) {
+	if t.LogQueriesAfter <= 0 {
+		return
+	}
+
+	elapsed := time.Since(query.startTime)
+	if elapsed < t.LogQueriesAfter {
+		return
+	}
+
+	query.mu.Lock()
+	plan, err := query.plan, query.err
+	query.mu.Unlock()
+
+	fields := []zap.Field{
+		zap.Uint64("id", query.qid),
+		zap.String("query", query.query),
+		zap.String("caller", query.caller),
+		zap.Duration("elapsed", elapsed),
+		zap.Int64("seriesFetched", atomic.LoadInt64(&query.seriesFetched)),
+		zap.String("plan", plan),
+	}
+	if err != nil {
+		fields = append(fields, zap.Error(err))
+	}
+
+	logging.WithContext(ctx).Warn("slow query", fields...)
+}
+
+// trackedStorage records the number of series fetched by a query and makes
+// fetches interruptible by killing the query.
+type trackedStorage struct {
+	storage.Storage
+	task *QueryTask
+}
+
+func (s *trackedStorage) fetchOptions(options *storage.FetchOptions) *storage.FetchOptions {
+	if options != nil && options.KillChan != nil {
+		return options
+	}
+
+	var opts storage.FetchOptions
+	if options != nil {
+		opts = *options
+	}
+	opts.KillChan = s.task.closing
+	return &opts
+}
+
+func (s *trackedStorage) Fetch(
+	ctx context.Context,
+	query *storage.FetchQuery,
+	options *storage.FetchOptions,
+) (*storage.FetchResult, error) {
+	result, err := s.Storage.Fetch(ctx, query, s.fetchOptions(options))
+	if err == nil && result != nil {
+		s.task.addSeriesFetched(len(result.SeriesList))
+	}
+	return result, err
+}
+
+func (s *trackedStorage) FetchBlocks(
+	ctx context.Context,
+	query *storage.FetchQuery,
+	options *storage.FetchOptions,
+) (block.Result, error) {
+	result, err := s.Storage.FetchBlocks(ctx, query, s.fetchOptions(options))
+	if err != nil {
+		return result, err
+	}
+
+	for _, b := range result.Blocks {
+		// NB: errors are ignored since tracking should never fail a query.
+		if iter, err := b.SeriesIter(); err == nil {
+			s.task.addSeriesFetched(iter.SeriesCount())
+			iter.Close()
+		}
+	}
+	return result, err
+}
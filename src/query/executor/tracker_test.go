@@ -0,0 +1,106 @@
+// Copyright (c) 2018 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package executor
+
+import (
+	"context"
+	"testing"
+
+	"github.com/m3db/m3/src/query/block"
+	"github.com/m3db/m3/src/query/storage"
+	"github.com/m3db/m3/src/query/storage/mock"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTrackerListAndKillQuery(t *testing.T) {
+	tracker := NewTracker()
+	first, err := tracker.Track("foo", "caller", nil)
+	require.NoError(t, err)
+	second, err := tracker.Track("bar", "", nil)
+	require.NoError(t, err)
+
+	first.addSeriesFetched(3)
+	queries := tracker.Queries()
+	require.Len(t, queries, 2)
+	assert.Equal(t, first.qid, queries[0].ID)
+	assert.Equal(t, "foo", queries[0].Query)
+	assert.Equal(t, "caller", queries[0].Caller)
+	assert.Equal(t, "running", queries[0].Status)
+	assert.Equal(t, int64(3), queries[0].SeriesFetched)
+	assert.Equal(t, second.qid, queries[1].ID)
+
+	require.NoError(t, tracker.KillQuery(first.qid))
+	select {
+	case <-first.closing:
+	default:
+		require.FailNow(t, "expected query to be closed")
+	}
+	assert.Equal(t, "killed", tracker.Queries()[0].Status)
+
+	// Killing twice and detaching a killed query must not close twice
+	require.NoError(t, tracker.KillQuery(first.qid))
+	require.NoError(t, tracker.DetachQuery(first.qid))
+	assert.Error(t, tracker.KillQuery(first.qid))
+
+	require.NoError(t, tracker.Close())
+	_, err = tracker.Track("baz", "", nil)
+	assert.Error(t, err)
+}
+
+func TestTrackerMaxConcurrentQueries(t *testing.T) {
+	tracker := NewTracker()
+	tracker.MaxConcurrentQueries = 1
+	task, err := tracker.Track("foo", "", nil)
+	require.NoError(t, err)
+
+	_, err = tracker.Track("bar", "", nil)
+	assert.Error(t, err)
+
+	require.NoError(t, tracker.DetachQuery(task.qid))
+	_, err = tracker.Track("bar", "", nil)
+	assert.NoError(t, err)
+}
+
+func TestTrackedStorageFetchBlocksClosesSeriesIter(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	iter := block.NewMockSeriesIter(ctrl)
+	iter.EXPECT().SeriesCount().Return(2)
+	iter.EXPECT().Close()
+	b := block.NewMockBlock(ctrl)
+	b.EXPECT().SeriesIter().Return(iter, nil)
+
+	store := mock.NewMockStorage()
+	store.SetFetchBlocksResult(block.Result{Blocks: []block.Block{b}}, nil)
+
+	tracker := NewTracker()
+	task, err := tracker.Track("foo", "", nil)
+	require.NoError(t, err)
+
+	tracked := &trackedStorage{Storage: store, task: task}
+	_, err = tracked.FetchBlocks(context.TODO(), &storage.FetchQuery{}, nil)
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), tracker.Queries()[0].SeriesFetched)
+}
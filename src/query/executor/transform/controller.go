@@ -29,6 +29,8 @@ import (
 type Controller struct {
 	ID         parser.NodeID
 	transforms []OpNode
+	// Stats, if set, records the blocks emitted by the controller.
+	Stats *NodeStats
 }
 
 // AddTransform adds a dependent transformation to the controller
@@ -38,6 +40,10 @@ func (t *Controller) AddTransform(node OpNode) {
 
 // Process performs processing on the underlying transforms
 func (t *Controller) Process(block block.Block) error {
+	if t.Stats != nil {
+		defer t.Stats.TrackOutput(block)()
+	}
+
 	for _, ts := range t.transforms {
 		err := ts.Process(t.ID, block)
 		if err != nil {
@@ -0,0 +1,212 @@
+// Copyright (c) 2018 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package transform
+
+import (
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/m3db/m3/src/query/block"
+	"github.com/m3db/m3/src/query/parser"
+)
+
+// Profile collects execution statistics for each node of a query, it is
+// only populated when a query is analyzed.
+type Profile struct {
+	mu            sync.Mutex
+	nodes         map[parser.NodeID]*NodeStats
+	fetches       int
+	fetchDuration time.Duration
+}
+
+// NewProfile creates a new Profile.
+func NewProfile() *Profile {
+	return &Profile{
+		nodes: make(map[parser.NodeID]*NodeStats),
+	}
+}
+
+// Node returns the statistics for a node, creating them if required.
+func (p *Profile) Node(ID parser.NodeID, opType string) *NodeStats {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	stats, ok := p.nodes[ID]
+	if !ok {
+		stats = &NodeStats{id: ID, opType: opType}
+		p.nodes[ID] = stats
+	}
+
+	return stats
+}
+
+// RecordFetch records a single fetch from storage.
+func (p *Profile) RecordFetch(took time.Duration) {
+	p.mu.Lock()
+	p.fetches++
+	p.fetchDuration += took
+	p.mu.Unlock()
+}
+
+// ProfileSummary is a point in time summary of a Profile.
+type ProfileSummary struct {
+	Fetches       int
+	FetchDuration time.Duration
+	Nodes         []NodeSummary
+}
+
+// Summary returns a summary of the profile with nodes sorted by ID.
+func (p *Profile) Summary() ProfileSummary {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	summary := ProfileSummary{
+		Fetches:       p.fetches,
+		FetchDuration: p.fetchDuration,
+		Nodes:         make([]NodeSummary, 0, len(p.nodes)),
+	}
+
+	for _, stats := range p.nodes {
+		summary.Nodes = append(summary.Nodes, stats.Summary())
+	}
+
+	sort.Slice(summary.Nodes, func(i, j int) bool {
+		return summary.Nodes[i].ID < summary.Nodes[j].ID
+	})
+
+	return summary
+}
+
+// NodeStats are the execution statistics of a single node.
+type NodeStats struct {
+	mu            sync.Mutex
+	id            parser.NodeID
+	opType        string
+	total         time.Duration
+	downstream    time.Duration
+	blocksIn      int
+	seriesIn      int
+	datapointsIn  int
+	blocksOut     int
+	seriesOut     int
+	datapointsOut int
+}
+
+// TrackInput records a block about to be processed by the node, the
+// returned function must be called once processing has completed so that
+// the time taken, including any time spent in downstream nodes, is recorded.
+func (s *NodeStats) TrackInput(b block.Block) func() {
+	series, datapoints := blockSize(b)
+	start := time.Now()
+	return func() {
+		took := time.Since(start)
+		s.mu.Lock()
+		s.blocksIn++
+		s.seriesIn += series
+		s.datapointsIn += datapoints
+		s.total += took
+		s.mu.Unlock()
+	}
+}
+
+// TrackOutput records a block emitted by the node, the returned function
+// must be called once downstream nodes have processed the block.
+func (s *NodeStats) TrackOutput(b block.Block) func() {
+	series, datapoints := blockSize(b)
+	start := time.Now()
+	return func() {
+		took := time.Since(start)
+		s.mu.Lock()
+		s.blocksOut++
+		s.seriesOut += series
+		s.datapointsOut += datapoints
+		s.downstream += took
+		s.mu.Unlock()
+	}
+}
+
+// RecordExecution records time spent executing a source node, including any
+// time spent in downstream nodes.
+func (s *NodeStats) RecordExecution(took time.Duration) {
+	s.mu.Lock()
+	s.total += took
+	s.mu.Unlock()
+}
+
+// NodeSummary is a point in time summary of NodeStats.
+type NodeSummary struct {
+	ID     parser.NodeID
+	OpType string
+	// Total is the wall time spent in the node including downstream nodes.
+	Total time.Duration
+	// Self is the wall time spent in the node excluding downstream nodes,
+	// for lazily evaluated nodes this time is attributed to the consumer.
+	Self          time.Duration
+	BlocksIn      int
+	SeriesIn      int
+	DatapointsIn  int
+	BlocksOut     int
+	SeriesOut     int
+	DatapointsOut int
+}
+
+// Summary returns a summary of the node statistics.
+func (s *NodeStats) Summary() NodeSummary {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	self := s.total - s.downstream
+	if self < 0 {
+		self = 0
+	}
+
+	return NodeSummary{
+		ID:            s.id,
+		OpType:        s.opType,
+		Total:         s.total,
+		Self:          self,
+		BlocksIn:      s.blocksIn,
+		SeriesIn:      s.seriesIn,
+		DatapointsIn:  s.datapointsIn,
+		BlocksOut:     s.blocksOut,
+		SeriesOut:     s.seriesOut,
+		DatapointsOut: s.datapointsOut,
+	}
+}
+
+// blockSize returns the number of series and datapoints in a block, errors
+// are ignored since profiling should never fail a query.
+func blockSize(b block.Block) (int, int) {
+	seriesIter, err := b.SeriesIter()
+	if err != nil {
+		return 0, 0
+	}
+
+	series := seriesIter.SeriesCount()
+	seriesIter.Close()
+
+	stepIter, err := b.StepIter()
+	if err != nil {
+		return series, 0
+	}
+
+	steps := stepIter.StepCount()
+	stepIter.Close()
+	return series, series * steps
+}
@@ -0,0 +1,89 @@
+// Copyright (c) 2018 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package transform
+
+import (
+	"testing"
+	"time"
+
+	"github.com/m3db/m3/src/query/block"
+	"github.com/m3db/m3/src/query/parser"
+	"github.com/m3db/m3/src/query/test"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type sleepNode struct {
+	controller *Controller
+	sleep      time.Duration
+}
+
+func (n *sleepNode) Process(ID parser.NodeID, b block.Block) error {
+	time.Sleep(n.sleep)
+	return n.controller.Process(b)
+}
+
+func TestProfileNodeStats(t *testing.T) {
+	profile := NewProfile()
+	upstream := profile.Node(parser.NodeID("0"), "fetch")
+	downstream := profile.Node(parser.NodeID("1"), "abs")
+	require.Equal(t, upstream, profile.Node(parser.NodeID("0"), "fetch"))
+
+	sink := &sinkNode{}
+	sinkController := &Controller{ID: parser.NodeID("1"), Stats: downstream}
+	sinkController.AddTransform(sink)
+	node := &sleepNode{controller: sinkController, sleep: time.Millisecond}
+
+	values, bounds := test.GenerateValuesAndBounds(nil, nil)
+	b := test.NewBlockFromValues(bounds, values)
+
+	controller := &Controller{ID: parser.NodeID("0"), Stats: upstream}
+	controller.AddTransform(node)
+	done := downstream.TrackInput(b)
+	require.NoError(t, controller.Process(b))
+	done()
+	upstream.RecordExecution(5 * time.Millisecond)
+	profile.RecordFetch(2 * time.Millisecond)
+
+	summary := profile.Summary()
+	assert.Equal(t, 1, summary.Fetches)
+	assert.Equal(t, 2*time.Millisecond, summary.FetchDuration)
+	require.Len(t, summary.Nodes, 2)
+
+	fetch := summary.Nodes[0]
+	assert.Equal(t, parser.NodeID("0"), fetch.ID)
+	assert.Equal(t, "fetch", fetch.OpType)
+	assert.Equal(t, 1, fetch.BlocksOut)
+	assert.Equal(t, 2, fetch.SeriesOut)
+	assert.Equal(t, 10, fetch.DatapointsOut)
+	assert.Equal(t, 0, fetch.BlocksIn)
+	assert.True(t, fetch.Total >= 5*time.Millisecond)
+
+	abs := summary.Nodes[1]
+	assert.Equal(t, "abs", abs.OpType)
+	assert.Equal(t, 1, abs.BlocksIn)
+	assert.Equal(t, 2, abs.SeriesIn)
+	assert.Equal(t, 10, abs.DatapointsIn)
+	assert.Equal(t, 1, abs.BlocksOut)
+	assert.True(t, abs.Total >= time.Millisecond)
+	assert.True(t, abs.Self <= abs.Total)
+}
@@ -31,6 +31,8 @@ import (
 type Options struct {
 	TimeSpec TimeSpec
 	Debug    bool
+	// Profile, if set, collects execution statistics for each node.
+	Profile *Profile
 }
 
 // OpNode represents the execution node
@@ -21,13 +21,16 @@
 package binary
 
 import (
+	"time"
+
 	"github.com/m3db/m3/src/query/block"
 	"github.com/m3db/m3/src/query/executor/transform"
 	"github.com/m3db/m3/src/query/functions/utils"
+	"github.com/m3db/m3/src/query/models"
 )
 
 type binaryFunc func(x, y float64) float64
-type singleScalarFunc func(x float64) float64
+type singleScalarFunc func(t time.Time, x float64) float64
 
 // processes two logical blocks, performing a logical operation on them
 func processBinary(
@@ -48,15 +51,13 @@ func processBinary(
 			return nil, errLeftScalar
 		}
 
-		lVal := scalarL.Value()
-
 		// rhs is a series; use rhs metadata and series meta
 		if !params.RIsScalar {
 			return processSingleBlock(
 				rhs,
 				controller,
-				func(x float64) float64 {
-					return fn(lVal, x)
+				func(t time.Time, x float64) float64 {
+					return fn(scalarL.Value(t), x)
 				},
 			)
 		}
@@ -75,8 +76,10 @@ func processBinary(
 			return nil, errNoModifierForComparison
 		}
 
-		return block.NewScalar(
-			fn(lVal, scalarR.Value()),
+		return block.NewScalarFunc(
+			func(t time.Time) float64 {
+				return fn(scalarL.Value(t), scalarR.Value(t))
+			},
 			lIter.Meta().Bounds,
 		), nil
 	}
@@ -87,13 +90,12 @@ func processBinary(
 			return nil, errRightScalar
 		}
 
-		rVal := scalarR.Value()
 		// lhs is a series; use lhs metadata and series meta
 		return processSingleBlock(
 			lhs,
 			controller,
-			func(x float64) float64 {
-				return fn(x, rVal)
+			func(t time.Time, x float64) float64 {
+				return fn(x, scalarR.Value(t))
 			},
 		)
 	}
@@ -111,7 +113,11 @@ func processBinary(
 		return nil, errNoMatching
 	}
 
-	return processBothSeries(lIter, rIter, controller, params.VectorMatching, fn)
+	// NB: as in Prometheus, the metric name is only kept for comparisons
+	// which filter series rather than return bool values.
+	dropMetricName := !isComparison || params.ReturnBool
+	return processBothSeries(lIter, rIter, controller,
+		params.VectorMatching, dropMetricName, fn)
 }
 
 func processSingleBlock(
@@ -139,9 +145,10 @@ func processSingleBlock(
 			return nil, err
 		}
 
+		t := step.Time()
 		values := step.Values()
 		for _, value := range values {
-			builder.AppendValue(index, fn(value))
+			builder.AppendValue(index, fn(t, value))
 		}
 	}
 
@@ -152,6 +159,7 @@ func processBothSeries(
 	lIter, rIter block.StepIter,
 	controller *transform.Controller,
 	matching *VectorMatching,
+	dropMetricName bool,
 	fn binaryFunc,
 ) (block.Block, error) {
 	if lIter.StepCount() != rIter.StepCount() {
@@ -163,7 +171,11 @@ func processBothSeries(
 	lSeriesMeta := utils.FlattenMetadata(lMeta, lIter.SeriesMeta())
 	rSeriesMeta := utils.FlattenMetadata(rMeta, rIter.SeriesMeta())
 
-	takeLeft, correspondingRight, lSeriesMeta := intersect(matching, lSeriesMeta, rSeriesMeta)
+	takeLeft, correspondingRight, lSeriesMeta, err := intersect(matching,
+		dropMetricName, lSeriesMeta, rSeriesMeta)
+	if err != nil {
+		return nil, err
+	}
 
 	lMeta.Tags, lSeriesMeta = utils.DedupeMetadata(lSeriesMeta)
 
@@ -203,32 +215,131 @@ func processBothSeries(
 	return builder.Build(), nil
 }
 
-// intersect returns the slice of lhs indices that are shared with rhs,
-// the indices of the corresponding rhs values, and the metas for taken indices
+// intersect returns the slice of lhs indices that are matched with rhs,
+// the indices of the corresponding rhs values, and the metas for the resulting
+// series. For many-to-one and one-to-many matching every series on the "many"
+// side is matched with a single series on the "one" side, with the labels
+// listed in Include copied from the "one" side.
 func intersect(
 	matching *VectorMatching,
+	dropMetricName bool,
 	lhs, rhs []block.SeriesMeta,
-) ([]int, []int, []block.SeriesMeta) {
+) ([]int, []int, []block.SeriesMeta, error) {
 	idFunction := HashFunc(matching.On, matching.MatchingLabels...)
-	// The set of signatures for the right-hand side.
-	rightSigs := make(map[uint64]int, len(rhs))
-	for idx, meta := range rhs {
-		rightSigs[idFunction(meta.Tags)] = idx
+
+	// For one-to-many matching the rhs is the "many" side; swap the sides
+	// here and swap the indices back when building the results.
+	manySide, oneSide := lhs, rhs
+	if matching.Card == CardOneToMany {
+		manySide, oneSide = rhs, lhs
 	}
 
-	takeLeft := make([]int, 0, initIndexSliceLength)
-	correspondingRight := make([]int, 0, initIndexSliceLength)
-	leftMetas := make([]block.SeriesMeta, 0, initIndexSliceLength)
+	// The set of signatures for the "one" side, which must be unique.
+	oneSigs := make(map[uint64]int, len(oneSide))
+	for idx, meta := range oneSide {
+		id := idFunction(meta.Tags)
+		if _, ok := oneSigs[id]; ok {
+			if matching.Card == CardOneToOne {
+				return nil, nil, nil, errDuplicateRightSeries
+			}
+			return nil, nil, nil, errManyToManyMatching
+		}
+		oneSigs[id] = idx
+	}
 
-	for lIdx, ls := range lhs {
-		// If there's a matching entry in the left-hand side Vector, add the sample.
-		id := idFunction(ls.Tags)
-		if rIdx, ok := rightSigs[id]; ok {
-			takeLeft = append(takeLeft, lIdx)
-			correspondingRight = append(correspondingRight, rIdx)
-			leftMetas = append(leftMetas, ls)
+	var (
+		takeLeft           = make([]int, 0, initIndexSliceLength)
+		correspondingRight = make([]int, 0, initIndexSliceLength)
+		resultMetas        = make([]block.SeriesMeta, 0, initIndexSliceLength)
+
+		// Tracks the matched signatures for one-to-one matching and the
+		// resulting series for grouped matching to detect duplicates.
+		matchedSigs  = make(map[uint64]struct{}, len(manySide))
+		insertedSigs = make(map[string]struct{}, len(manySide))
+	)
+
+	for manyIdx, manyMeta := range manySide {
+		id := idFunction(manyMeta.Tags)
+		oneIdx, ok := oneSigs[id]
+		if !ok {
+			continue
+		}
+
+		meta := resultMeta(matching, dropMetricName, manyMeta, oneSide[oneIdx])
+		if matching.Card == CardOneToOne {
+			if _, ok := matchedSigs[id]; ok {
+				return nil, nil, nil, errMultipleMatchesOneToOne
+			}
+			matchedSigs[id] = struct{}{}
+		} else {
+			resultID := meta.Tags.ID()
+			if _, ok := insertedSigs[resultID]; ok {
+				return nil, nil, nil, errMultipleMatchesGrouped
+			}
+			insertedSigs[resultID] = struct{}{}
+		}
+
+		lIdx, rIdx := manyIdx, oneIdx
+		if matching.Card == CardOneToMany {
+			lIdx, rIdx = oneIdx, manyIdx
 		}
+
+		takeLeft = append(takeLeft, lIdx)
+		correspondingRight = append(correspondingRight, rIdx)
+		resultMetas = append(resultMetas, meta)
 	}
 
-	return takeLeft, correspondingRight, leftMetas
+	return takeLeft, correspondingRight, resultMetas, nil
+}
+
+// resultMeta builds the series meta for a matched pair of series, based on
+// the series from the "many" side. As in Prometheus, the labels of one-to-one
+// results are reduced to the labels used for matching when on() or ignoring()
+// is given.
+func resultMeta(
+	matching *VectorMatching,
+	dropMetricName bool,
+	manyMeta, oneMeta block.SeriesMeta,
+) block.SeriesMeta {
+	reduceToMatching := matching.Card == CardOneToOne &&
+		(matching.On || len(matching.MatchingLabels) > 0)
+	if !dropMetricName && !reduceToMatching &&
+		(matching.Card == CardOneToOne || len(matching.Include) == 0) {
+		return manyMeta
+	}
+
+	// NB: WithoutName, TagsWithKeys and TagsWithoutKeys copy the tags so the
+	// series metas of the inputs are never mutated.
+	tags := manyMeta.Tags
+	if dropMetricName {
+		tags = tags.WithoutName()
+	}
+
+	if reduceToMatching {
+		if matching.On {
+			tags = tags.TagsWithKeys(matching.MatchingLabels)
+		} else {
+			tags = tags.TagsWithoutKeys(matching.MatchingLabels)
+		}
+	}
+
+	if matching.Card != CardOneToOne && len(matching.Include) > 0 {
+		tags = tags.TagsWithoutKeys(matching.Include)
+		for _, name := range matching.Include {
+			if value, ok := oneMeta.Tags.Get(name); ok && value != "" {
+				tags = append(tags, models.Tag{Name: name, Value: value})
+			}
+		}
+		tags = models.Normalize(tags)
+	}
+
+	name := manyMeta.Name
+	if dropMetricName || reduceToMatching {
+		name = tags.ID()
+	}
+
+	return block.SeriesMeta{
+		Tags: tags,
+		Name: name,
+	}
 }
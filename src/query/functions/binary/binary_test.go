@@ -28,6 +28,7 @@ import (
 	"github.com/m3db/m3/src/query/block"
 	"github.com/m3db/m3/src/query/executor/transform"
 	"github.com/m3db/m3/src/query/functions/utils"
+	"github.com/m3db/m3/src/query/models"
 	"github.com/m3db/m3/src/query/parser"
 	"github.com/m3db/m3/src/query/test"
 	"github.com/m3db/m3/src/query/test/executor"
@@ -587,6 +588,16 @@ func TestSingleSeriesReturnValues(t *testing.T) {
 	}
 }
 
+// withoutNames drops the metric name from the given metas, as is expected
+// for the results of arithmetic and bool comparisons between series.
+func withoutNames(metas []block.SeriesMeta) []block.SeriesMeta {
+	for i, meta := range metas {
+		metas[i].Tags = meta.Tags.WithoutName()
+		metas[i].Name = metas[i].Tags.ID()
+	}
+	return metas
+}
+
 var bothSeriesTests = []struct {
 	name          string
 	opType        string
@@ -607,7 +618,7 @@ var bothSeriesTests = []struct {
 		test.NewSeriesMeta("a", 3)[1:],
 		[][]float64{{10, 20, 30}, {40, 50, 60}},
 		true,
-		test.NewSeriesMeta("a", 2)[1:],
+		withoutNames(test.NewSeriesMeta("a", 2)[1:]),
 		[][]float64{{14, 25, 36}},
 	},
 	{
@@ -618,7 +629,7 @@ var bothSeriesTests = []struct {
 		test.NewSeriesMeta("a", 3),
 		[][]float64{{10, 20, 30}, {40, 50, 60}, {700, 800, 900}},
 		true,
-		test.NewSeriesMeta("a", 2),
+		withoutNames(test.NewSeriesMeta("a", 2)),
 		[][]float64{{-9, -18, -27}, {-36, -45, -54}},
 	},
 	{
@@ -629,7 +640,7 @@ var bothSeriesTests = []struct {
 		test.NewSeriesMeta("a", 3)[1:],
 		[][]float64{{10, 20, 30}, {40, 50, 60}},
 		true,
-		test.NewSeriesMeta("a", 3)[1:],
+		withoutNames(test.NewSeriesMeta("a", 3)[1:]),
 		[][]float64{{40, 100, 180}, {280, 400, 540}},
 	},
 	{
@@ -640,7 +651,7 @@ var bothSeriesTests = []struct {
 		test.NewSeriesMeta("a", 2),
 		[][]float64{{10, 20, 30}, {4, 5, 6}},
 		true,
-		test.NewSeriesMeta("a", 2),
+		withoutNames(test.NewSeriesMeta("a", 2)),
 		[][]float64{{0.1, 0.1, 0.1}, {10, 10, 10}},
 	},
 	{
@@ -651,7 +662,7 @@ var bothSeriesTests = []struct {
 		test.NewSeriesMeta("a", 1),
 		[][]float64{{2, 2, 0.5, 0.5, -1, -0.5}},
 		true,
-		test.NewSeriesMeta("a", 1),
+		withoutNames(test.NewSeriesMeta("a", 1)),
 		[][]float64{{100, math.NaN(), math.NaN(), 3, 0.1, 0.5}},
 	},
 	{
@@ -662,7 +673,7 @@ var bothSeriesTests = []struct {
 		test.NewSeriesMeta("a", 1),
 		[][]float64{{2, 3, -5, 1.5, 1.5, -1.5}},
 		true,
-		test.NewSeriesMeta("a", 1),
+		withoutNames(test.NewSeriesMeta("a", 1)),
 		[][]float64{{0, 2, 2, 1, 0.5, 0}},
 	},
 	/* Comparison */
@@ -685,7 +696,7 @@ var bothSeriesTests = []struct {
 		test.NewSeriesMeta("a", 3)[1:],
 		[][]float64{{10, 6, 30}, {40, 50, 60}},
 		true,
-		test.NewSeriesMeta("a", 2)[1:],
+		withoutNames(test.NewSeriesMeta("a", 2)[1:]),
 		[][]float64{{0, 1, 0}},
 	},
 	{
@@ -696,7 +707,7 @@ var bothSeriesTests = []struct {
 		test.NewSeriesMeta("a", 2),
 		[][]float64{{1, 20, 3}, {40, 6, 60}},
 		true,
-		test.NewSeriesMeta("a", 2),
+		withoutNames(test.NewSeriesMeta("a", 2)),
 		[][]float64{{0, 1, 0}, {1, 0, 1}},
 	},
 	{
@@ -718,7 +729,7 @@ var bothSeriesTests = []struct {
 		test.NewSeriesMeta("a", 3),
 		[][]float64{{10, 10, 10}, {1, 20, -100}, {2, 4, 10}},
 		true,
-		test.NewSeriesMeta("a", 3)[1:],
+		withoutNames(test.NewSeriesMeta("a", 3)[1:]),
 		[][]float64{{0, 0, 1}, {1, 1, 0}},
 	},
 	{
@@ -740,7 +751,7 @@ var bothSeriesTests = []struct {
 		test.NewSeriesMeta("a", 1),
 		[][]float64{{-1, 2, 5}},
 		true,
-		test.NewSeriesMeta("a", 1),
+		withoutNames(test.NewSeriesMeta("a", 1)),
 		[][]float64{{0, 0, 1}},
 	},
 	{
@@ -762,7 +773,7 @@ var bothSeriesTests = []struct {
 		test.NewSeriesMeta("a", 1),
 		[][]float64{{-1, 2, 5}},
 		true,
-		test.NewSeriesMeta("a", 1),
+		withoutNames(test.NewSeriesMeta("a", 1)),
 		[][]float64{{1, 1, 0}},
 	},
 	{
@@ -784,7 +795,7 @@ var bothSeriesTests = []struct {
 		test.NewSeriesMeta("a", 1),
 		[][]float64{{-1, 2, 5}},
 		true,
-		test.NewSeriesMeta("a", 1),
+		withoutNames(test.NewSeriesMeta("a", 1)),
 		[][]float64{{0, 1, 1}},
 	},
 	{
@@ -842,3 +853,315 @@ func TestBothSeries(t *testing.T) {
 		})
 	}
 }
+
+func newGroupedTestMetas(tags ...map[string]string) []block.SeriesMeta {
+	metas := make([]block.SeriesMeta, 0, len(tags))
+	for _, t := range tags {
+		metas = append(metas, block.SeriesMeta{
+			Name: t[models.MetricName],
+			Tags: models.FromMap(t),
+		})
+	}
+	return metas
+}
+
+var (
+	groupedManyMetas = func() []block.SeriesMeta {
+		return newGroupedTestMetas(
+			map[string]string{models.MetricName: "rate", "instance": "i1", "job": "j1"},
+			map[string]string{models.MetricName: "rate", "instance": "i1", "job": "j2"},
+			map[string]string{models.MetricName: "rate", "instance": "i2", "job": "j1"},
+		)
+	}
+	groupedOneMetas = func() []block.SeriesMeta {
+		return newGroupedTestMetas(
+			map[string]string{models.MetricName: "build_info", "instance": "i1", "version": "v1"},
+			map[string]string{models.MetricName: "build_info", "instance": "i2", "version": "v2"},
+		)
+	}
+	groupedExpectedMetas = func() []block.SeriesMeta {
+		return withoutNames(newGroupedTestMetas(
+			map[string]string{"instance": "i1", "job": "j1", "version": "v1"},
+			map[string]string{"instance": "i1", "job": "j2", "version": "v1"},
+			map[string]string{"instance": "i2", "job": "j1", "version": "v2"},
+		))
+	}
+)
+
+type matchingSeriesTest struct {
+	name          string
+	opType        string
+	matching      *VectorMatching
+	lhsMeta       []block.SeriesMeta
+	lhs           [][]float64
+	rhsMeta       []block.SeriesMeta
+	rhs           [][]float64
+	expectedMetas []block.SeriesMeta
+	expected      [][]float64
+}
+
+var groupedSeriesTests = []matchingSeriesTest{
+	{
+		"* on(instance) group_left(version)",
+		MultiplyType,
+		&VectorMatching{
+			Card:           CardManyToOne,
+			MatchingLabels: []string{"instance"},
+			On:             true,
+			Include:        []string{"version"},
+		},
+		groupedManyMetas(),
+		[][]float64{{1, 2}, {3, 4}, {5, 6}},
+		groupedOneMetas(),
+		[][]float64{{10, 10}, {100, 100}},
+		groupedExpectedMetas(),
+		[][]float64{{10, 20}, {30, 40}, {500, 600}},
+	},
+	{
+		"- on(instance) group_right(version)",
+		MinusType,
+		&VectorMatching{
+			Card:           CardOneToMany,
+			MatchingLabels: []string{"instance"},
+			On:             true,
+			Include:        []string{"version"},
+		},
+		groupedOneMetas(),
+		[][]float64{{10, 10}, {100, 100}},
+		groupedManyMetas(),
+		[][]float64{{1, 2}, {3, 4}, {5, 6}},
+		groupedExpectedMetas(),
+		[][]float64{{9, 8}, {7, 6}, {95, 94}},
+	},
+	{
+		"* ignoring(job, version) group_left",
+		MultiplyType,
+		&VectorMatching{
+			Card:           CardManyToOne,
+			MatchingLabels: []string{"job", "version"},
+		},
+		groupedManyMetas(),
+		[][]float64{{1, 2}, {3, 4}, {5, 6}},
+		groupedOneMetas(),
+		[][]float64{{10, 10}, {100, 100}},
+		withoutNames(newGroupedTestMetas(
+			map[string]string{"instance": "i1", "job": "j1"},
+			map[string]string{"instance": "i1", "job": "j2"},
+			map[string]string{"instance": "i2", "job": "j1"},
+		)),
+		[][]float64{{10, 20}, {30, 40}, {500, 600}},
+	},
+}
+
+func TestGroupedSeries(t *testing.T) {
+	testMatchingSeries(t, groupedSeriesTests)
+}
+
+var oneToOneMatchingTests = []matchingSeriesTest{
+	{
+		"+ on(instance)",
+		PlusType,
+		&VectorMatching{
+			Card:           CardOneToOne,
+			MatchingLabels: []string{"instance"},
+			On:             true,
+		},
+		newGroupedTestMetas(
+			map[string]string{models.MetricName: "foo", "instance": "i1", "job": "j1"},
+			map[string]string{models.MetricName: "foo", "instance": "i2", "job": "j1"},
+		),
+		[][]float64{{1, 2}, {3, 4}},
+		newGroupedTestMetas(
+			map[string]string{models.MetricName: "bar", "instance": "i1", "job": "j2"},
+			map[string]string{models.MetricName: "bar", "instance": "i2", "job": "j2"},
+		),
+		[][]float64{{10, 20}, {30, 40}},
+		withoutNames(newGroupedTestMetas(
+			map[string]string{"instance": "i1"},
+			map[string]string{"instance": "i2"},
+		)),
+		[][]float64{{11, 22}, {33, 44}},
+	},
+	{
+		"- ignoring(job)",
+		MinusType,
+		&VectorMatching{
+			Card:           CardOneToOne,
+			MatchingLabels: []string{"job"},
+		},
+		newGroupedTestMetas(
+			map[string]string{models.MetricName: "foo", "instance": "i1", "job": "j1"},
+		),
+		[][]float64{{10, 20}},
+		newGroupedTestMetas(
+			map[string]string{models.MetricName: "bar", "instance": "i1", "job": "j2"},
+		),
+		[][]float64{{1, 2}},
+		withoutNames(newGroupedTestMetas(
+			map[string]string{"instance": "i1"},
+		)),
+		[][]float64{{9, 18}},
+	},
+	{
+		"> on(instance)",
+		GreaterType,
+		&VectorMatching{
+			Card:           CardOneToOne,
+			MatchingLabels: []string{"instance"},
+			On:             true,
+		},
+		newGroupedTestMetas(
+			map[string]string{models.MetricName: "foo", "instance": "i1", "job": "j1"},
+		),
+		[][]float64{{1, 20}},
+		newGroupedTestMetas(
+			map[string]string{models.MetricName: "bar", "instance": "i1", "job": "j2"},
+		),
+		[][]float64{{10, 10}},
+		withoutNames(newGroupedTestMetas(
+			map[string]string{"instance": "i1"},
+		)),
+		[][]float64{{math.NaN(), 20}},
+	},
+}
+
+// As in Prometheus, the labels of one-to-one results are reduced to the
+// labels used for matching when on() or ignoring() is given.
+func TestOneToOneMatchingLabels(t *testing.T) {
+	testMatchingSeries(t, oneToOneMatchingTests)
+}
+
+func testMatchingSeries(t *testing.T, tests []matchingSeriesTest) {
+	now := time.Now()
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			op, err := NewOp(
+				tt.opType,
+				NodeParams{
+					LNode:          parser.NodeID(0),
+					RNode:          parser.NodeID(1),
+					VectorMatching: tt.matching,
+				},
+			)
+			require.NoError(t, err)
+
+			c, sink := executor.NewControllerWithSink(parser.NodeID(2))
+			node := op.(baseOp).Node(c, transform.Options{})
+			bounds := block.Bounds{
+				Start:    now,
+				Duration: time.Minute * time.Duration(len(tt.lhs[0])),
+				StepSize: time.Minute,
+			}
+
+			err = node.Process(parser.NodeID(0), test.NewBlockFromValuesWithSeriesMeta(bounds, tt.lhsMeta, tt.lhs))
+			require.NoError(t, err)
+
+			err = node.Process(parser.NodeID(1), test.NewBlockFromValuesWithSeriesMeta(bounds, tt.rhsMeta, tt.rhs))
+			require.NoError(t, err)
+
+			test.EqualsWithNans(t, tt.expected, sink.Values)
+
+			expectedMeta := block.Metadata{Bounds: bounds}
+			expectedMeta.Tags, tt.expectedMetas = utils.DedupeMetadata(tt.expectedMetas)
+			assert.Equal(t, expectedMeta, sink.Meta)
+			assert.Equal(t, tt.expectedMetas, sink.Metas)
+		})
+	}
+}
+
+func TestMatchingErrors(t *testing.T) {
+	now := time.Now()
+	tests := []struct {
+		name     string
+		matching *VectorMatching
+		lhsMeta  []block.SeriesMeta
+		rhsMeta  []block.SeriesMeta
+		expected error
+	}{
+		{
+			name: "duplicate series on rhs for one-to-one",
+			matching: &VectorMatching{
+				Card:           CardOneToOne,
+				MatchingLabels: []string{"instance"},
+				On:             true,
+			},
+			lhsMeta:  groupedOneMetas(),
+			rhsMeta:  groupedManyMetas(),
+			expected: errDuplicateRightSeries,
+		},
+		{
+			name: "multiple matches on lhs for one-to-one",
+			matching: &VectorMatching{
+				Card:           CardOneToOne,
+				MatchingLabels: []string{"instance"},
+				On:             true,
+			},
+			lhsMeta:  groupedManyMetas(),
+			rhsMeta:  groupedOneMetas(),
+			expected: errMultipleMatchesOneToOne,
+		},
+		{
+			name: "duplicate series on the one side of group_left",
+			matching: &VectorMatching{
+				Card:           CardManyToOne,
+				MatchingLabels: []string{"instance"},
+				On:             true,
+			},
+			lhsMeta:  groupedOneMetas(),
+			rhsMeta:  groupedManyMetas(),
+			expected: errManyToManyMatching,
+		},
+		{
+			name: "grouped results are not unique",
+			matching: &VectorMatching{
+				Card:           CardManyToOne,
+				MatchingLabels: []string{"instance"},
+				On:             true,
+			},
+			lhsMeta: newGroupedTestMetas(
+				map[string]string{models.MetricName: "foo", "instance": "i1"},
+				map[string]string{models.MetricName: "bar", "instance": "i1"},
+			),
+			rhsMeta:  groupedOneMetas(),
+			expected: errMultipleMatchesGrouped,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			op, err := NewOp(
+				PlusType,
+				NodeParams{
+					LNode:          parser.NodeID(0),
+					RNode:          parser.NodeID(1),
+					VectorMatching: tt.matching,
+				},
+			)
+			require.NoError(t, err)
+
+			c, _ := executor.NewControllerWithSink(parser.NodeID(2))
+			node := op.(baseOp).Node(c, transform.Options{})
+			bounds := block.Bounds{
+				Start:    now,
+				Duration: time.Minute,
+				StepSize: time.Minute,
+			}
+
+			lhs := make([][]float64, len(tt.lhsMeta))
+			for i := range lhs {
+				lhs[i] = []float64{1}
+			}
+			rhs := make([][]float64, len(tt.rhsMeta))
+			for i := range rhs {
+				rhs[i] = []float64{1}
+			}
+
+			err = node.Process(parser.NodeID(0), test.NewBlockFromValuesWithSeriesMeta(bounds, tt.lhsMeta, lhs))
+			require.NoError(t, err)
+
+			err = node.Process(parser.NodeID(1), test.NewBlockFromValuesWithSeriesMeta(bounds, tt.rhsMeta, rhs))
+			assert.Equal(t, tt.expected, err)
+		})
+	}
+}
@@ -76,6 +76,10 @@ var (
 	errRightScalar             = errors.New("expected right scalar but node type incorrect")
 	errNoModifierForComparison = errors.New("comparisons between scalars must use BOOL modifier")
 	errNoMatching              = errors.New("vector matching parameters must be provided for binary operations between series")
+	errDuplicateRightSeries    = errors.New("found duplicate series for the match group on the right hand-side of the operation")
+	errManyToManyMatching      = errors.New("many-to-many matching not allowed: matching labels must be unique on one side")
+	errMultipleMatchesOneToOne = errors.New("multiple matches for labels: many-to-one matching must be explicit (group_left/group_right)")
+	errMultipleMatchesGrouped  = errors.New("multiple matches for labels: grouping labels must ensure unique matches")
 )
 
 func combineMetaAndSeriesMeta(
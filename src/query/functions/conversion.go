@@ -0,0 +1,210 @@
+// Copyright (c) 2018 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package functions
+
+import (
+	"fmt"
+	"math"
+	"time"
+
+	"github.com/m3db/m3/src/query/block"
+	"github.com/m3db/m3/src/query/executor/transform"
+	"github.com/m3db/m3/src/query/models"
+	"github.com/m3db/m3/src/query/parser"
+)
+
+const (
+	// VectorType returns the scalar as a vector with no labels
+	VectorType = "vector"
+)
+
+// NewConversionOp creates a new op converting between scalars and vectors.
+// scalar() converts a single element vector to a scalar, returning NaN at any
+// step without exactly one value. vector() converts a scalar to a vector with
+// no labels; if the scalar is a number literal the op is a source for it.
+func NewConversionOp(args []interface{}, opType string) (parser.Params, error) {
+	switch opType {
+	case ScalarType:
+		if len(args) != 0 {
+			return nil, fmt.Errorf("invalid number of args for %s: %d", opType, len(args))
+		}
+
+		return conversionOp{operatorType: opType}, nil
+
+	case VectorType:
+		if len(args) == 0 {
+			return conversionOp{operatorType: opType}, nil
+		}
+
+		if len(args) != 1 {
+			return nil, fmt.Errorf("invalid number of args for %s: %d", opType, len(args))
+		}
+
+		val, ok := args[0].(float64)
+		if !ok {
+			return nil, fmt.Errorf("unable to cast to scalar argument: %v", args[0])
+		}
+
+		return newConstantOp(opType, val), nil
+
+	default:
+		return nil, fmt.Errorf("unknown conversion type: %s", opType)
+	}
+}
+
+type conversionOp struct {
+	operatorType string
+}
+
+// OpType for the operator
+func (o conversionOp) OpType() string {
+	return o.operatorType
+}
+
+// String representation
+func (o conversionOp) String() string {
+	return fmt.Sprintf("type: %s", o.OpType())
+}
+
+// Node creates an execution node
+func (o conversionOp) Node(
+	controller *transform.Controller,
+	_ transform.Options,
+) transform.OpNode {
+	return &conversionNode{
+		op:         o,
+		controller: controller,
+	}
+}
+
+type conversionNode struct {
+	op         conversionOp
+	controller *transform.Controller
+}
+
+// Process the block
+func (n *conversionNode) Process(ID parser.NodeID, b block.Block) error {
+	var (
+		nextBlock block.Block
+		err       error
+	)
+
+	if n.op.operatorType == ScalarType {
+		nextBlock, err = toScalar(b)
+	} else {
+		nextBlock, err = toVector(b, n.controller)
+	}
+
+	if err != nil {
+		return err
+	}
+
+	defer nextBlock.Close()
+	return n.controller.Process(nextBlock)
+}
+
+func toScalar(b block.Block) (block.Block, error) {
+	stepIter, err := b.StepIter()
+	if err != nil {
+		return nil, err
+	}
+
+	bounds := stepIter.Meta().Bounds
+	vals := make([]float64, 0, stepIter.StepCount())
+	for stepIter.Next() {
+		step, err := stepIter.Current()
+		if err != nil {
+			return nil, err
+		}
+
+		vals = append(vals, singleValue(step.Values()))
+	}
+
+	return block.NewScalarFunc(func(t time.Time) float64 {
+		if bounds.StepSize <= 0 {
+			return math.NaN()
+		}
+
+		idx := int(t.Sub(bounds.Start) / bounds.StepSize)
+		if idx < 0 || idx >= len(vals) {
+			return math.NaN()
+		}
+
+		return vals[idx]
+	}, bounds), nil
+}
+
+// singleValue returns the only non NaN value, or NaN if there is not exactly one.
+func singleValue(values []float64) float64 {
+	val := math.NaN()
+	found := false
+	for _, v := range values {
+		if math.IsNaN(v) {
+			continue
+		}
+
+		if found {
+			return math.NaN()
+		}
+
+		val = v
+		found = true
+	}
+
+	return val
+}
+
+func toVector(b block.Block, controller *transform.Controller) (block.Block, error) {
+	stepIter, err := b.StepIter()
+	if err != nil {
+		return nil, err
+	}
+
+	if len(stepIter.SeriesMeta()) != 1 {
+		return nil, fmt.Errorf("expected a scalar for %s, found %d series",
+			VectorType, len(stepIter.SeriesMeta()))
+	}
+
+	meta := stepIter.Meta()
+	meta.Tags = models.EmptyTags()
+	seriesMeta := []block.SeriesMeta{{Tags: models.EmptyTags()}}
+	builder, err := controller.BlockBuilder(meta, seriesMeta)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := builder.AddCols(stepIter.StepCount()); err != nil {
+		return nil, err
+	}
+
+	for index := 0; stepIter.Next(); index++ {
+		step, err := stepIter.Current()
+		if err != nil {
+			return nil, err
+		}
+
+		if err := builder.AppendValues(index, step.Values()); err != nil {
+			return nil, err
+		}
+	}
+
+	return builder.Build(), nil
+}
@@ -0,0 +1,135 @@
+// Copyright (c) 2018 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package functions
+
+import (
+	"context"
+	"math"
+	"testing"
+	"time"
+
+	"github.com/m3db/m3/src/query/block"
+	"github.com/m3db/m3/src/query/executor/transform"
+	"github.com/m3db/m3/src/query/parser"
+	"github.com/m3db/m3/src/query/test"
+	"github.com/m3db/m3/src/query/test/executor"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestScalarConversion(t *testing.T) {
+	v := [][]float64{
+		{0, math.NaN(), 2, math.NaN(), 4},
+		{math.NaN(), math.NaN(), 7, 8, math.NaN()},
+	}
+	values, bounds := test.GenerateValuesAndBounds(v, nil)
+	b := test.NewBlockFromValues(bounds, values)
+
+	op, err := NewConversionOp(nil, ScalarType)
+	require.NoError(t, err)
+	c, sink := executor.NewControllerWithSink(parser.NodeID(1))
+	node := op.(transform.Params).Node(c, transform.Options{})
+
+	var scalar *block.Scalar
+	c.AddTransform(scalarCapture(func(b block.Block) {
+		scalar, _ = b.(*block.Scalar)
+	}))
+	err = node.Process(parser.NodeID(0), b)
+	require.NoError(t, err)
+
+	expected := [][]float64{{0, math.NaN(), math.NaN(), 8, 4}}
+	test.EqualsWithNans(t, expected, sink.Values)
+	require.NotNil(t, scalar, "expected a scalar block")
+	assert.Equal(t, 8.0, scalar.Value(bounds.Start.Add(3*bounds.StepSize)))
+}
+
+func TestVectorConversion(t *testing.T) {
+	bounds := block.Bounds{
+		Start:    time.Unix(100, 0),
+		Duration: 3 * time.Minute,
+		StepSize: time.Minute,
+	}
+
+	op, err := NewConversionOp(nil, VectorType)
+	require.NoError(t, err)
+	c, sink := executor.NewControllerWithSink(parser.NodeID(1))
+	node := op.(transform.Params).Node(c, transform.Options{})
+
+	b := block.NewScalarFunc(func(t time.Time) float64 {
+		return float64(t.Unix())
+	}, bounds)
+	err = node.Process(parser.NodeID(0), b)
+	require.NoError(t, err)
+
+	assert.Equal(t, [][]float64{{100, 160, 220}}, sink.Values)
+	require.Len(t, sink.Metas, 1)
+	assert.Len(t, sink.Metas[0].Tags, 0)
+}
+
+func TestVectorLiteral(t *testing.T) {
+	op, err := NewConversionOp([]interface{}{5.0}, VectorType)
+	require.NoError(t, err)
+	assert.Equal(t, VectorType, op.OpType())
+
+	c, sink := executor.NewControllerWithSink(parser.NodeID(0))
+	source := op.(*scalarOp).Node(c, transform.Options{
+		TimeSpec: transform.TimeSpec{
+			Start: time.Unix(0, 0),
+			End:   time.Unix(180, 0),
+			Step:  time.Minute,
+		},
+	})
+	require.NoError(t, source.Execute(context.TODO()))
+	assert.Equal(t, [][]float64{{5, 5, 5}}, sink.Values)
+}
+
+func TestTimeOp(t *testing.T) {
+	op := NewTimeOp()
+	assert.Equal(t, TimeType, op.OpType())
+
+	c, sink := executor.NewControllerWithSink(parser.NodeID(0))
+	source := op.(*scalarOp).Node(c, transform.Options{
+		TimeSpec: transform.TimeSpec{
+			Start: time.Unix(60, 0),
+			End:   time.Unix(240, 0),
+			Step:  time.Minute,
+		},
+	})
+	require.NoError(t, source.Execute(context.TODO()))
+	assert.Equal(t, [][]float64{{60, 120, 180}}, sink.Values)
+}
+
+func TestInvalidConversionArgs(t *testing.T) {
+	_, err := NewConversionOp([]interface{}{1.0}, ScalarType)
+	assert.Error(t, err)
+	_, err = NewConversionOp([]interface{}{"foo"}, VectorType)
+	assert.Error(t, err)
+	_, err = NewConversionOp(nil, "foo")
+	assert.Error(t, err)
+}
+
+type scalarCapture func(b block.Block)
+
+func (f scalarCapture) Process(_ parser.NodeID, b block.Block) error {
+	f(b)
+	return nil
+}
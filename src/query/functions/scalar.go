@@ -23,6 +23,7 @@ package functions
 import (
 	"context"
 	"fmt"
+	"time"
 
 	"github.com/m3db/m3/src/query/block"
 	"github.com/m3db/m3/src/query/executor/transform"
@@ -32,18 +33,29 @@ import (
 	"go.uber.org/zap"
 )
 
-// ScalarType is a scalar series
-const ScalarType = "scalar"
+const (
+	// ScalarType is a scalar series
+	ScalarType = "scalar"
+
+	// TimeType returns the number of seconds since January 1, 1970 UTC at each step
+	TimeType = "time"
+)
 
 type scalarOp struct {
-	val float64
+	operatorType string
+	val          float64
+	fn           block.ScalarFunc
 }
 
 func (o scalarOp) OpType() string {
-	return ScalarType
+	return o.operatorType
 }
 
 func (o scalarOp) String() string {
+	if o.operatorType == TimeType {
+		return fmt.Sprintf("type: %s", o.OpType())
+	}
+
 	return fmt.Sprintf("type: %s. val: %f", o.OpType(), o.val)
 }
 
@@ -60,7 +72,27 @@ func (o scalarOp) Node(
 
 // NewScalarOp creates a new scalar op
 func NewScalarOp(val float64) parser.Params {
-	return &scalarOp{val}
+	return newConstantOp(ScalarType, val)
+}
+
+// NewTimeOp creates a new scalar op which returns the time of each step
+func NewTimeOp() parser.Params {
+	return &scalarOp{
+		operatorType: TimeType,
+		fn: func(t time.Time) float64 {
+			return float64(t.Unix())
+		},
+	}
+}
+
+func newConstantOp(opType string, val float64) *scalarOp {
+	return &scalarOp{
+		operatorType: opType,
+		val:          val,
+		fn: func(_ time.Time) float64 {
+			return val
+		},
+	}
 }
 
 // scalarNode is the execution node
@@ -75,7 +107,7 @@ type scalarNode struct {
 func (n *scalarNode) Execute(ctx context.Context) error {
 	bounds := n.timespec.Bounds()
 
-	block := block.NewScalar(n.op.val, bounds)
+	block := block.NewScalarFunc(n.op.fn, bounds)
 	if n.debug {
 		// Ignore any errors
 		iter, _ := block.StepIter()
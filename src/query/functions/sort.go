@@ -0,0 +1,150 @@
+// Copyright (c) 2018 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package functions
+
+import (
+	"fmt"
+	"math"
+	"sort"
+
+	"github.com/m3db/m3/src/query/block"
+	"github.com/m3db/m3/src/query/executor/transform"
+	"github.com/m3db/m3/src/query/parser"
+)
+
+const (
+	// SortType returns the series sorted by value in ascending order
+	SortType = "sort"
+
+	// SortDescType returns the series sorted by value in descending order
+	SortDescType = "sort_desc"
+)
+
+// NewSortOp creates a new sort op. As in Prometheus, sorting only affects
+// instant queries; blocks spanning multiple steps are passed through as is
+// since a single series order cannot hold across every step.
+func NewSortOp(opType string) (parser.Params, error) {
+	if opType != SortType && opType != SortDescType {
+		return nil, fmt.Errorf("unknown sort type: %s", opType)
+	}
+
+	return sortOp{operatorType: opType}, nil
+}
+
+type sortOp struct {
+	operatorType string
+}
+
+// OpType for the operator
+func (o sortOp) OpType() string {
+	return o.operatorType
+}
+
+// String representation
+func (o sortOp) String() string {
+	return fmt.Sprintf("type: %s", o.OpType())
+}
+
+// Node creates an execution node
+func (o sortOp) Node(
+	controller *transform.Controller,
+	_ transform.Options,
+) transform.OpNode {
+	return &sortNode{
+		op:         o,
+		controller: controller,
+	}
+}
+
+type sortNode struct {
+	op         sortOp
+	controller *transform.Controller
+}
+
+// Process the block
+func (n *sortNode) Process(ID parser.NodeID, b block.Block) error {
+	stepIter, err := b.StepIter()
+	if err != nil {
+		return err
+	}
+
+	if stepIter.StepCount() != 1 || !stepIter.Next() {
+		return n.controller.Process(b)
+	}
+
+	step, err := stepIter.Current()
+	if err != nil {
+		return err
+	}
+
+	values := step.Values()
+	order := make([]int, len(values))
+	for i := range order {
+		order[i] = i
+	}
+
+	descending := n.op.operatorType == SortDescType
+	sort.SliceStable(order, func(i, j int) bool {
+		return valueLess(values[order[i]], values[order[j]], descending)
+	})
+
+	seriesMetas := stepIter.SeriesMeta()
+	sortedMetas := make([]block.SeriesMeta, len(order))
+	sortedValues := make([]float64, len(order))
+	for i, idx := range order {
+		sortedMetas[i] = seriesMetas[idx]
+		sortedValues[i] = values[idx]
+	}
+
+	builder, err := n.controller.BlockBuilder(stepIter.Meta(), sortedMetas)
+	if err != nil {
+		return err
+	}
+
+	if err := builder.AddCols(1); err != nil {
+		return err
+	}
+
+	if err := builder.AppendValues(0, sortedValues); err != nil {
+		return err
+	}
+
+	nextBlock := builder.Build()
+	defer nextBlock.Close()
+	return n.controller.Process(nextBlock)
+}
+
+// valueLess orders values in the given direction, with NaNs always last.
+func valueLess(a, b float64, descending bool) bool {
+	if math.IsNaN(a) {
+		return false
+	}
+
+	if math.IsNaN(b) {
+		return true
+	}
+
+	if descending {
+		return a > b
+	}
+
+	return a < b
+}
@@ -0,0 +1,95 @@
+// Copyright (c) 2018 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package functions
+
+import (
+	"math"
+	"testing"
+
+	"github.com/m3db/m3/src/query/block"
+	"github.com/m3db/m3/src/query/executor/transform"
+	"github.com/m3db/m3/src/query/parser"
+	"github.com/m3db/m3/src/query/test"
+	"github.com/m3db/m3/src/query/test/executor"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSort(t *testing.T) {
+	tests := []struct {
+		opType        string
+		expected      [][]float64
+		expectedNames []string
+	}{
+		{SortType, [][]float64{{1}, {3}, {5}, {math.NaN()}}, []string{"dummy2", "dummy0", "dummy3", "dummy1"}},
+		{SortDescType, [][]float64{{5}, {3}, {1}, {math.NaN()}}, []string{"dummy3", "dummy0", "dummy2", "dummy1"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.opType, func(t *testing.T) {
+			v := [][]float64{{3}, {math.NaN()}, {1}, {5}}
+			values, bounds := test.GenerateValuesAndBounds(v, nil)
+			bounds.Duration = bounds.StepSize
+			b := test.NewBlockFromValues(bounds, values)
+
+			op, err := NewSortOp(tt.opType)
+			require.NoError(t, err)
+			c, sink := executor.NewControllerWithSink(parser.NodeID(1))
+			node := op.(transform.Params).Node(c, transform.Options{})
+			require.NoError(t, node.Process(parser.NodeID(0), b))
+
+			test.EqualsWithNans(t, tt.expected, sink.Values)
+			assert.Equal(t, tt.expectedNames, seriesNames(sink.Metas))
+		})
+	}
+}
+
+func TestSortRangeIsUnchanged(t *testing.T) {
+	values, bounds := test.GenerateValuesAndBounds([][]float64{
+		{5, 6, 7, 8, 9},
+		{0, 1, 2, 3, 4},
+	}, nil)
+	b := test.NewBlockFromValues(bounds, values)
+
+	op, err := NewSortOp(SortType)
+	require.NoError(t, err)
+	c, sink := executor.NewControllerWithSink(parser.NodeID(1))
+	node := op.(transform.Params).Node(c, transform.Options{})
+	require.NoError(t, node.Process(parser.NodeID(0), b))
+
+	assert.Equal(t, values, sink.Values)
+	assert.Equal(t, []string{"dummy0", "dummy1"}, seriesNames(sink.Metas))
+}
+
+func TestInvalidSortType(t *testing.T) {
+	_, err := NewSortOp("foo")
+	assert.Error(t, err)
+}
+
+func seriesNames(metas []block.SeriesMeta) []string {
+	names := make([]string, len(metas))
+	for i, meta := range metas {
+		names[i] = meta.Name
+	}
+
+	return names
+}
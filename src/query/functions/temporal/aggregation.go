@@ -23,6 +23,7 @@ package temporal
 import (
 	"fmt"
 	"math"
+	"sort"
 
 	"github.com/m3db/m3/src/query/executor/transform"
 )
@@ -48,6 +49,16 @@ const (
 
 	// StdVarTemporalType calculates the standard variance of all values in the specified interval
 	StdVarTemporalType = "stdvar_over_time"
+
+	// QuantileTemporalType calculates the φ-quantile (0 ≤ φ ≤ 1) of all values in the specified interval
+	QuantileTemporalType = "quantile_over_time"
+
+	// ChangesType calculates the number of times the value changed in the specified interval
+	ChangesType = "changes"
+
+	// ResetsType calculates the number of counter resets in the specified interval,
+	// any decrease in value is treated as a counter reset
+	ResetsType = "resets"
 )
 
 type aggFunc func([]float64) float64
@@ -61,6 +72,8 @@ var (
 		SumTemporalType:    sumOverTime,
 		StdDevTemporalType: stddevOverTime,
 		StdVarTemporalType: stdvarOverTime,
+		ChangesType:        changes,
+		ResetsType:         resets,
 	}
 )
 
@@ -73,6 +86,27 @@ func NewAggOp(args []interface{}, optype string) (transform.Params, error) {
 	return nil, fmt.Errorf("unknown aggregation type: %s", optype)
 }
 
+// NewQuantileOp creates a new base temporal transform for quantile_over_time,
+// with the quantile taken from the first argument.
+func NewQuantileOp(args []interface{}, optype string) (transform.Params, error) {
+	if optype != QuantileTemporalType {
+		return nil, fmt.Errorf("unknown quantile type: %s", optype)
+	}
+
+	if len(args) != 2 {
+		return emptyOp, fmt.Errorf("invalid number of args for %s: %d", optype, len(args))
+	}
+
+	q, ok := args[0].(float64)
+	if !ok {
+		return emptyOp, fmt.Errorf("unable to cast to scalar argument: %v for %s", args[0], optype)
+	}
+
+	return newBaseOp(args[1:], optype, newAggNode, func(values []float64) float64 {
+		return quantileOverTime(q, values)
+	})
+}
+
 func newAggNode(op baseOp, controller *transform.Controller, _ transform.Options) Processor {
 	return &aggNode{
 		op:         op,
@@ -182,3 +216,79 @@ func sumAndCount(values []float64) (float64, float64) {
 
 	return sum, count
 }
+
+func quantileOverTime(q float64, values []float64) float64 {
+	// NB: values are copied as the underlying slice is reused across steps.
+	nonNaNValues := make([]float64, 0, len(values))
+	for _, v := range values {
+		if !math.IsNaN(v) {
+			nonNaNValues = append(nonNaNValues, v)
+		}
+	}
+
+	l := float64(len(nonNaNValues))
+	if l == 0 {
+		return math.NaN()
+	}
+
+	if q < 0 {
+		return math.Inf(-1)
+	}
+
+	if q > 1 {
+		return math.Inf(1)
+	}
+
+	sort.Float64s(nonNaNValues)
+	// When the quantile lies between two samples,
+	// use a weighted average of the two samples.
+	rank := q * (l - 1)
+	leftIndex := math.Max(0, math.Floor(rank))
+	rightIndex := math.Min(l-1, leftIndex+1)
+
+	weight := rank - math.Floor(rank)
+	weightedLeft := nonNaNValues[int(leftIndex)] * (1 - weight)
+	weightedRight := nonNaNValues[int(rightIndex)] * weight
+	return weightedLeft + weightedRight
+}
+
+func changes(values []float64) float64 {
+	return countTransitions(values, func(prev, curr float64) bool {
+		return curr != prev
+	})
+}
+
+func resets(values []float64) float64 {
+	return countTransitions(values, func(prev, curr float64) bool {
+		return curr < prev
+	})
+}
+
+// countTransitions counts the consecutive pairs of non-NaN values which
+// satisfy the given predicate, returning NaN if there are no values.
+func countTransitions(values []float64, fn func(prev, curr float64) bool) float64 {
+	var (
+		prev  float64
+		seen  bool
+		count float64
+	)
+
+	for _, v := range values {
+		if math.IsNaN(v) {
+			continue
+		}
+
+		if seen && fn(prev, v) {
+			count++
+		}
+
+		prev = v
+		seen = true
+	}
+
+	if !seen {
+		return math.NaN()
+	}
+
+	return count
+}
@@ -127,6 +127,30 @@ var testCases = []testCase{
 			{2, 2, 2, 2, 2},
 		},
 	},
+	{
+		name:   "changes",
+		opType: ChangesType,
+		afterBlockOne: [][]float64{
+			{math.NaN(), math.NaN(), math.NaN(), math.NaN(), 3},
+			{math.NaN(), math.NaN(), math.NaN(), math.NaN(), 4},
+		},
+		afterAllBlocks: [][]float64{
+			{4, 4, 4, 4, 4},
+			{4, 4, 4, 4, 4},
+		},
+	},
+	{
+		name:   "resets",
+		opType: ResetsType,
+		afterBlockOne: [][]float64{
+			{math.NaN(), math.NaN(), math.NaN(), math.NaN(), 0},
+			{math.NaN(), math.NaN(), math.NaN(), math.NaN(), 0},
+		},
+		afterAllBlocks: [][]float64{
+			{1, 1, 1, 1, 0},
+			{1, 1, 1, 1, 0},
+		},
+	},
 }
 
 func TestAggregation(t *testing.T) {
@@ -222,6 +246,30 @@ var testCasesNaNs = []testCase{
 			{math.NaN(), math.NaN(), math.NaN(), math.NaN(), math.NaN()},
 		},
 	},
+	{
+		name:   "changes",
+		opType: ChangesType,
+		afterBlockOne: [][]float64{
+			{math.NaN(), math.NaN(), math.NaN(), math.NaN(), math.NaN()},
+			{math.NaN(), math.NaN(), math.NaN(), math.NaN(), math.NaN()},
+		},
+		afterAllBlocks: [][]float64{
+			{math.NaN(), math.NaN(), math.NaN(), math.NaN(), math.NaN()},
+			{math.NaN(), math.NaN(), math.NaN(), math.NaN(), math.NaN()},
+		},
+	},
+	{
+		name:   "resets",
+		opType: ResetsType,
+		afterBlockOne: [][]float64{
+			{math.NaN(), math.NaN(), math.NaN(), math.NaN(), math.NaN()},
+			{math.NaN(), math.NaN(), math.NaN(), math.NaN(), math.NaN()},
+		},
+		afterAllBlocks: [][]float64{
+			{math.NaN(), math.NaN(), math.NaN(), math.NaN(), math.NaN()},
+			{math.NaN(), math.NaN(), math.NaN(), math.NaN(), math.NaN()},
+		},
+	},
 }
 
 func TestAggregationAllNaNs(t *testing.T) {
@@ -310,3 +358,50 @@ func TestUnknownAggregation(t *testing.T) {
 	_, err := NewAggOp([]interface{}{5 * time.Minute}, "unknown_agg_func")
 	require.Error(t, err)
 }
+
+var quantileTests = []struct {
+	name     string
+	q        float64
+	values   []float64
+	expected float64
+}{
+	{"median", 0.5, []float64{4, 1, 3, 2, 5}, 3},
+	{"interpolated", 0.9, []float64{4, 1, 3, 2, 5}, 4.6},
+	{"with NaNs", 0.5, []float64{math.NaN(), 1, 2, math.NaN(), 4}, 2},
+	{"below range", -1, []float64{1, 2, 3}, math.Inf(-1)},
+	{"above range", 2, []float64{1, 2, 3}, math.Inf(1)},
+	{"all NaNs", 0.5, []float64{math.NaN(), math.NaN()}, math.NaN()},
+}
+
+func TestQuantileOverTime(t *testing.T) {
+	for _, tt := range quantileTests {
+		t.Run(tt.name, func(t *testing.T) {
+			op, err := NewQuantileOp([]interface{}{tt.q, 5 * time.Minute}, QuantileTemporalType)
+			require.NoError(t, err)
+			assert.Equal(t, QuantileTemporalType, op.OpType())
+
+			c, _ := executor.NewControllerWithSink(parser.NodeID(1))
+			node := op.Node(c, transform.Options{}).(*baseNode)
+
+			values := append([]float64(nil), tt.values...)
+			actual := node.processor.Process(values)
+			if math.IsNaN(tt.expected) {
+				assert.True(t, math.IsNaN(actual))
+				return
+			}
+			assert.InDelta(t, tt.expected, actual, 0.0001)
+			test.EqualsWithNans(t, tt.values, values)
+		})
+	}
+}
+
+func TestQuantileOverTimeInvalidArgs(t *testing.T) {
+	for _, args := range [][]interface{}{
+		{5 * time.Minute},
+		{"foo", 5 * time.Minute},
+		{0.5, 5 * time.Minute, 1.0},
+	} {
+		_, err := NewQuantileOp(args, QuantileTemporalType)
+		require.Error(t, err)
+	}
+}
@@ -0,0 +1,115 @@
+// Copyright (c) 2018 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package temporal
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/m3db/m3/src/query/executor/transform"
+)
+
+const (
+	// HoltWintersType produces a smoothed value for the time series based on
+	// the given smoothing factor and trend factor.
+	HoltWintersType = "holt_winters"
+)
+
+// NewHoltWintersOp creates a new base temporal transform for the holt_winters
+// function, with the smoothing and trend factors taken from the arguments.
+func NewHoltWintersOp(args []interface{}) (transform.Params, error) {
+	if len(args) != 3 {
+		return emptyOp, fmt.Errorf("invalid number of args for %s: %d", HoltWintersType, len(args))
+	}
+
+	sf, ok := args[1].(float64)
+	if !ok {
+		return emptyOp, fmt.Errorf("unable to cast to scalar argument: %v for %s", args[1], HoltWintersType)
+	}
+
+	tf, ok := args[2].(float64)
+	if !ok {
+		return emptyOp, fmt.Errorf("unable to cast to scalar argument: %v for %s", args[2], HoltWintersType)
+	}
+
+	// Sanity check the input.
+	if sf <= 0 || sf >= 1 {
+		return emptyOp, fmt.Errorf("invalid smoothing factor. Expected: 0 < sf < 1, got: %f", sf)
+	}
+
+	if tf <= 0 || tf >= 1 {
+		return emptyOp, fmt.Errorf("invalid trend factor. Expected: 0 < tf < 1, got: %f", tf)
+	}
+
+	return newBaseOp(args[:1], HoltWintersType, newHoltWintersNode(sf, tf), nil)
+}
+
+func newHoltWintersNode(sf, tf float64) MakeProcessor {
+	return func(op baseOp, controller *transform.Controller, _ transform.Options) Processor {
+		return &holtWintersNode{
+			op:         op,
+			controller: controller,
+			sf:         sf,
+			tf:         tf,
+		}
+	}
+}
+
+type holtWintersNode struct {
+	op         baseOp
+	controller *transform.Controller
+	sf, tf     float64
+}
+
+func (h *holtWintersNode) Process(values []float64) float64 {
+	var (
+		// Smoothed values, s0 is the previous and s1 the current value.
+		s0, s1 float64
+		b      float64
+		seen   int
+	)
+
+	for _, v := range values {
+		if math.IsNaN(v) {
+			continue
+		}
+
+		switch seen {
+		case 0:
+			s1 = v
+		case 1:
+			// The initial trend is the difference of the first two values.
+			b = v - s1
+			s0, s1 = s1, h.sf*v+(1-h.sf)*(s1+b)
+		default:
+			b = h.tf*(s1-s0) + (1-h.tf)*b
+			s0, s1 = s1, h.sf*v+(1-h.sf)*(s1+b)
+		}
+		seen++
+	}
+
+	// At least two values are required to calculate a trend.
+	if seen < 2 {
+		return math.NaN()
+	}
+
+	return s1
+}
@@ -0,0 +1,79 @@
+// Copyright (c) 2018 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package temporal
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/m3db/m3/src/query/executor/transform"
+	"github.com/m3db/m3/src/query/parser"
+	"github.com/m3db/m3/src/query/test/executor"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var holtWintersTests = []struct {
+	name     string
+	sf, tf   float64
+	values   []float64
+	expected float64
+}{
+	{"linear", 0.5, 0.5, []float64{1, 2, 3, 4, 5}, 5},
+	{"smoothed", 0.2, 0.7, []float64{10, 8, 9, 3, 1}, 2.4025},
+	{"smoothed with NaNs", 0.2, 0.7, []float64{10, 8, math.NaN(), 3, 1}, 2.584},
+	{"single value", 0.2, 0.7, []float64{math.NaN(), 8, math.NaN()}, math.NaN()},
+}
+
+func TestHoltWinters(t *testing.T) {
+	for _, tt := range holtWintersTests {
+		t.Run(tt.name, func(t *testing.T) {
+			op, err := NewHoltWintersOp([]interface{}{5 * time.Minute, tt.sf, tt.tf})
+			require.NoError(t, err)
+			assert.Equal(t, HoltWintersType, op.OpType())
+
+			c, _ := executor.NewControllerWithSink(parser.NodeID(1))
+			node := op.Node(c, transform.Options{}).(*baseNode)
+
+			actual := node.processor.Process(tt.values)
+			if math.IsNaN(tt.expected) {
+				assert.True(t, math.IsNaN(actual))
+				return
+			}
+			assert.InDelta(t, tt.expected, actual, 0.0001)
+		})
+	}
+}
+
+func TestHoltWintersInvalidArgs(t *testing.T) {
+	for _, args := range [][]interface{}{
+		{5 * time.Minute},
+		{5 * time.Minute, 0.5},
+		{5 * time.Minute, "foo", 0.5},
+		{5 * time.Minute, 0.5, 0.0},
+		{5 * time.Minute, 1.0, 0.5},
+	} {
+		_, err := NewHoltWintersOp(args)
+		require.Error(t, err)
+	}
+}
@@ -0,0 +1,128 @@
+// Copyright (c) 2018 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package temporal
+
+import (
+	"fmt"
+	"math"
+	"time"
+
+	"github.com/m3db/m3/src/query/executor/transform"
+)
+
+const (
+	// DerivType calculates the per-second derivative of the time series,
+	// using a simple linear regression.
+	DerivType = "deriv"
+
+	// PredictLinearType predicts the value of the time series the given
+	// number of seconds from now, using a simple linear regression.
+	PredictLinearType = "predict_linear"
+)
+
+// NewLinearRegressionOp creates a new base temporal transform for linear
+// regression functions.
+func NewLinearRegressionOp(args []interface{}, optype string) (transform.Params, error) {
+	switch optype {
+	case DerivType:
+		return newBaseOp(args, optype, newLinearRegressionNode(false, 0), nil)
+
+	case PredictLinearType:
+		if len(args) != 2 {
+			return emptyOp, fmt.Errorf("invalid number of args for %s: %d", optype, len(args))
+		}
+
+		duration, ok := args[1].(float64)
+		if !ok {
+			return emptyOp, fmt.Errorf("unable to cast to scalar argument: %v for %s", args[1], optype)
+		}
+
+		return newBaseOp(args[:1], optype, newLinearRegressionNode(true, duration), nil)
+	}
+
+	return nil, fmt.Errorf("unknown linear regression type: %s", optype)
+}
+
+func newLinearRegressionNode(isPredict bool, duration float64) MakeProcessor {
+	return func(op baseOp, controller *transform.Controller, opts transform.Options) Processor {
+		return &linearRegressionNode{
+			op:         op,
+			controller: controller,
+			timeSpec:   opts.TimeSpec,
+			isPredict:  isPredict,
+			duration:   duration,
+		}
+	}
+}
+
+type linearRegressionNode struct {
+	op         baseOp
+	controller *transform.Controller
+	timeSpec   transform.TimeSpec
+	isPredict  bool
+	duration   float64
+}
+
+func (l *linearRegressionNode) Process(values []float64) float64 {
+	slope, intercept := linearRegression(values, l.timeSpec.Step)
+	if l.isPredict {
+		return slope*l.duration + intercept
+	}
+
+	return slope
+}
+
+// linearRegression performs a least-squares linear regression analysis on the
+// provided values, returning the slope in units per second and the intercept
+// at the time of the last value, i.e. the evaluation time of the step. Values
+// are taken to be step apart and NaNs are skipped.
+func linearRegression(values []float64, step time.Duration) (float64, float64) {
+	var (
+		n                        float64
+		sumX, sumY, sumXY, sumX2 float64
+		last                     = len(values) - 1
+		stepSeconds              = step.Seconds()
+	)
+
+	for i, v := range values {
+		if math.IsNaN(v) {
+			continue
+		}
+
+		// NB: x is relative to the evaluation time so it is always <= 0.
+		x := -float64(last-i) * stepSeconds
+		n++
+		sumX += x
+		sumY += v
+		sumXY += x * v
+		sumX2 += x * x
+	}
+
+	if n < 2 {
+		return math.NaN(), math.NaN()
+	}
+
+	covXY := sumXY - sumX*sumY/n
+	varX := sumX2 - sumX*sumX/n
+	slope := covXY / varX
+	intercept := sumY/n - slope*sumX/n
+	return slope, intercept
+}
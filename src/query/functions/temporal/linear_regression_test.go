@@ -0,0 +1,86 @@
+// Copyright (c) 2018 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package temporal
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/m3db/m3/src/query/executor/transform"
+	"github.com/m3db/m3/src/query/parser"
+	"github.com/m3db/m3/src/query/test/executor"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var linearRegressionTests = []struct {
+	name     string
+	opType   string
+	args     []interface{}
+	values   []float64
+	expected float64
+}{
+	{"deriv", DerivType, []interface{}{5 * time.Minute}, []float64{1, 2, 3, 4, 5}, 1.0 / 60},
+	{"deriv with NaNs", DerivType, []interface{}{5 * time.Minute}, []float64{math.NaN(), 2, math.NaN(), 4, 5}, 1.0 / 60},
+	{"deriv decreasing", DerivType, []interface{}{5 * time.Minute}, []float64{10, 8, 9, 3, 1}, -0.0383},
+	{"deriv single value", DerivType, []interface{}{5 * time.Minute}, []float64{math.NaN(), 2, math.NaN()}, math.NaN()},
+	{"predict_linear", PredictLinearType, []interface{}{5 * time.Minute, 60.0}, []float64{1, 2, 3, 4, 5}, 6},
+	{"predict_linear now", PredictLinearType, []interface{}{5 * time.Minute, 0.0}, []float64{10, 8, 9, 3, 1}, 1.6},
+	{"predict_linear past", PredictLinearType, []interface{}{5 * time.Minute, -240.0}, []float64{math.NaN(), 2, math.NaN(), 4, 5}, 1},
+}
+
+func TestLinearRegression(t *testing.T) {
+	for _, tt := range linearRegressionTests {
+		t.Run(tt.name, func(t *testing.T) {
+			op, err := NewLinearRegressionOp(tt.args, tt.opType)
+			require.NoError(t, err)
+			assert.Equal(t, tt.opType, op.OpType())
+
+			c, _ := executor.NewControllerWithSink(parser.NodeID(1))
+			node := op.Node(c, transform.Options{
+				TimeSpec: transform.TimeSpec{Step: time.Minute},
+			}).(*baseNode)
+
+			actual := node.processor.Process(tt.values)
+			if math.IsNaN(tt.expected) {
+				assert.True(t, math.IsNaN(actual))
+				return
+			}
+			assert.InDelta(t, tt.expected, actual, 0.0001)
+		})
+	}
+}
+
+func TestLinearRegressionInvalidArgs(t *testing.T) {
+	_, err := NewLinearRegressionOp([]interface{}{5 * time.Minute}, PredictLinearType)
+	require.Error(t, err)
+
+	_, err = NewLinearRegressionOp([]interface{}{5 * time.Minute, "foo"}, PredictLinearType)
+	require.Error(t, err)
+
+	_, err = NewLinearRegressionOp([]interface{}{5 * time.Minute, 10.0}, DerivType)
+	require.Error(t, err)
+
+	_, err = NewLinearRegressionOp([]interface{}{5 * time.Minute}, "unknown_regression_func")
+	require.Error(t, err)
+}
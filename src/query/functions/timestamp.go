@@ -0,0 +1,106 @@
+// Copyright (c) 2018 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package functions
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/m3db/m3/src/query/block"
+	"github.com/m3db/m3/src/query/executor/transform"
+	"github.com/m3db/m3/src/query/parser"
+)
+
+const (
+	// TimestampType returns the timestamp of each sample as the number of
+	// seconds since January 1, 1970 UTC
+	TimestampType = "timestamp"
+)
+
+// NewTimestampOp creates a new timestamp op, which replaces the value of
+// each sample with the timestamp of its step in seconds
+func NewTimestampOp() parser.Params {
+	return timestampOp{}
+}
+
+type timestampOp struct{}
+
+// OpType for the operator
+func (o timestampOp) OpType() string {
+	return TimestampType
+}
+
+// String representation
+func (o timestampOp) String() string {
+	return fmt.Sprintf("type: %s", o.OpType())
+}
+
+// Node creates an execution node
+func (o timestampOp) Node(
+	controller *transform.Controller,
+	_ transform.Options,
+) transform.OpNode {
+	return &timestampNode{controller: controller}
+}
+
+type timestampNode struct {
+	controller *transform.Controller
+}
+
+// Process the block
+func (n *timestampNode) Process(ID parser.NodeID, b block.Block) error {
+	stepIter, err := b.StepIter()
+	if err != nil {
+		return err
+	}
+
+	builder, err := n.controller.BlockBuilder(stepIter.Meta(), stepIter.SeriesMeta())
+	if err != nil {
+		return err
+	}
+
+	if err := builder.AddCols(stepIter.StepCount()); err != nil {
+		return err
+	}
+
+	for index := 0; stepIter.Next(); index++ {
+		step, err := stepIter.Current()
+		if err != nil {
+			return err
+		}
+
+		// NB: samples are aligned to steps, so a present sample has the
+		// timestamp of the step itself.
+		ts := float64(step.Time().Unix())
+		for _, value := range step.Values() {
+			if math.IsNaN(value) {
+				builder.AppendValue(index, math.NaN())
+				continue
+			}
+
+			builder.AppendValue(index, ts)
+		}
+	}
+
+	nextBlock := builder.Build()
+	defer nextBlock.Close()
+	return n.controller.Process(nextBlock)
+}
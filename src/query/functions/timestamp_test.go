@@ -0,0 +1,61 @@
+// Copyright (c) 2018 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package functions
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/m3db/m3/src/query/block"
+	"github.com/m3db/m3/src/query/executor/transform"
+	"github.com/m3db/m3/src/query/parser"
+	"github.com/m3db/m3/src/query/test"
+	"github.com/m3db/m3/src/query/test/executor"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTimestamp(t *testing.T) {
+	v := [][]float64{
+		{1, math.NaN(), 3},
+		{math.NaN(), 5, 6},
+	}
+	values, bounds := test.GenerateValuesAndBounds(v, &block.Bounds{
+		Start:    time.Unix(600, 0),
+		Duration: 3 * time.Minute,
+		StepSize: time.Minute,
+	})
+	b := test.NewBlockFromValues(bounds, values)
+
+	op := NewTimestampOp()
+	assert.Equal(t, TimestampType, op.OpType())
+	c, sink := executor.NewControllerWithSink(parser.NodeID(1))
+	node := op.(transform.Params).Node(c, transform.Options{})
+	require.NoError(t, node.Process(parser.NodeID(0), b))
+
+	expected := [][]float64{
+		{600, math.NaN(), 720},
+		{math.NaN(), 660, 720},
+	}
+	test.EqualsWithNans(t, expected, sink.Values)
+}
@@ -239,37 +239,41 @@ d8dUBmQZxiF/+uI1I7E8TMF6pCyWxDpY7WPA8pmKZsl6ouawOaOS+Sj+BQAA//8by2IcfAIAAA==
 
 	"/spec.yml": {
 		local:   "openapi/spec.yml",
-		size:    11361,
+		size:    14918,
 		modtime: 12345,
 		compressed: `
-H4sIAAAAAAAC/+xaX1PbSBJ/96foVe7h9gFEgNur8psBL7iKGAqorbrduqqMNS15EmlGmekJkNR996vR
-H1u2FEnGBBLf+QXQ9PTfn37dM/gNTK/uxkO4sRLeJ+wjAjMGaS9CuffJon58DyKER2UhX5SPEMyZjNAA
-KaC5MBCKGH8ZmHsWRaiH4B3uH3gDIUM1HACQoBiH4L07OjvxBgAcTaBFSkLJIXgj4MKQFjNLyIFEgmBQ
-CzTAGbEZMwjWCBnBu6O72z8hjBWj344hUEmq0Rih5D78S1kImIRQSA7KEiRKI7CZ+9VZBUbw15woHfp+
-csRn+5GguZ3tC+UnR/6///7NpV9BaVAS/joXdGFnuaQZ+n4hFagk2+UnR7/uu9g+ozZ5XG/3D1wSAAIl
-iQXkMgEgWZKn4uQMzpWKYoRzrWzqZatWx0PwFjbcgtmPMrHMVKi0Tfw3v+Q/nWG3LxYBSoMrBkYpC+YI
-l/kSHOau1CzUovBnsZr5CTOE2r+cnI6nt2NvMFeG3DZlKNP/z8ODt97A1eaa0XwIns9S4X9+6w2IRWY4
-2CvdcD9MygKs1/1UyVBEVuelPTuBhazxlgrSmAWYoKQeCiqyi/0lhurbz4qVvXvBEUIrA7dgvIEJ5phg
-FkWWKG+QMpobl15/4WOe7AiLsgLkgUPx2VsL3X2MTRKmH4fgnSOtRJuvqxQ1cz5MeDVz50ilRKCksZlr
-CyssTWMRZNv8D0bJUjTVitugl6hGkyppsOL+4cHB8o/1xHmVlSxXrCoL8DeN4RC8Nz7HUEiRZdWfVsK5
-KQwuFR0fHD+zvXOUqEUw1lrppYJ/PHtcdTupe1caQNEOiRHnwKAm8A1MjDj/vphImWYJEuqKcPFGzRR/
-XKZKyNqjeu7aETHi/AY/WTT0QyHyYEcQueQs/+vi18nZf3JVHGMk3ByvZ9m+DSCbb3g11FYiXwOvI/fl
-I42frNDIh0Da4uIxPaZOi5tTZPSiMM3zlvU6nWQhvzxId4ee/cWM0NrA99YGj3r7pjlCTWQV+ovl3ejg
-15Vw6nz5yp21pVpZZ5UgpCEmA8yPLP2Ltwut9roSzGu02nbo7E6r7WinLSAt2ukmwMy3jOJ4B7ilrcm9
-ZFPwncxwC7KZOBssFl82q6Xbtjss46L5P828CF6/lm2t30DfzUDVThlqlTyBkl4NyMtc/Fwj/v8MbsvL
-OD/QyEqQthBt9fJuFa3l3R+a/OrvXtAcEmUIVOayAY4hszEhb8Zsqfo08+Sn596zlXBeg3zXPdhVGFdW
-3N6GW6xcZUEnavYBg6ISqXYYJLEsRIaCdgYq8LyUar/MukqLW+yKa1dVFb38milFhjRLx5LNYuQ1H2dK
-xcgWCA9ja+Y9Ze+1IDR36lQliaBLFXVtCNwftq8rGlMmdG9hQumSc9Uryzdr4gtekiw1c0U9rQrJ8aGf
-xUlF1G2/aXS4V00XsV6jFopPmVSm5qmQhBFWXqdQuYE8X/ntuHw+i1Xw8VZ8we202DBE/bslq59D0TUz
-tH1UjsfGD6nQj11lXBMfhYR6qmgUBGjMlkme1CDSq8bYD4Dbl6/p8nwjLEbCUDXF7ax2U8ivmL5ZUdKb
-b/N/utWCrm50H8Z55gWLr2tqNqLh+tl2A4fzab61oE3T4wYW1i5Eu4fUMkXlf7o3Qs/R4YrLG/hZDvff
-qXKTQn2ljbhZ7ncWkNJdMUqb3M6Z5p2vkjCZXPcbGlhSn1HfiYb5oCebCfNOuJGl21jCHjK3bpEmvM1c
-maRNysY75hthVJy9FdnXIDqEvyjZNS/do4jm1JU0lDxVQlKHMtNcVaY1q47thEk3wrIUryjuzLf7LL5z
-0e5pqnRr0Jn17eq25pghRthBGjmsqHLCMsrqACddqCjwv1U7dzrC8Mkqlr6vpK3qJ0qb5It74E2mk7vJ
-6HLy52R67pUPR3+MJpejk8vx4snlePRHIdFwLf8shPgkeFYJsOkm78fwbCO2bWo9FW532vvxe5Oi6qlw
-kwltKd8IqsaD/FMmnGk3Z2QP20WqGC8AHKuAxV71SRBbQ0/oht+1PitHnsY+uhrpYibuoLSTUq5K0M+E
-9AuVX9qcrPrSq/DUHSI+pBgQ8tvs65UOaVkPdGeVC2X1U0jyQm3GDF28zzjXaMyWze4V54rnT3HzxdpT
-KKHvaavhGnrjU8KKjv8GAAD//1caENhhLAAA
+H4sIAAAAAAACA+1bWW/bOBB+z69g3X3YPiRKk2wXyJtzNBGQOoETFNgWC5SWKJutRGpJKkeL/e87pA5T
+lqLDdpPWWz/Etjic8+PMkHReotHlzekhGicMfYrwF4KwlERtTwnb/ich4uETogF64AlKB9kD8maYTYlE
+iiM1oxIFNCQvtuQdnk6JOESDvZ3dwRZlAT/cQkhRFRJ4+G7/5GgA330iPUFjRTmDp0PkU6kEnSSK+EAb
+ESSJoMDcxwpPsCQokZRN0bv9m+sPKAg5Vm8OkMejWBApgckO+gt08zADNZiPeKJQxAUoOtEftVSEFfo4
+Uyo+dJxo35/sTKmaJZMdyuGr8/fvjw69QlwgztDHM6rOk0lKKYE0owItzCz482pH23ZLhEzter2zq52A
+QFOmsKe0JxBiOEpdcXSCzjifhgSdCZ7EAzOaiBAGCxl6QO5MDZkRFXCRRM7LF+m7FqznhdQjTJKSgGGM
+vRlBF+kQ2ktVqUioWOFMQg7vWCoinAv3+HR0fTrYmnGp9DR4M/z/3Nt9PdjSsbnCagYjDo6pcwvPFJ7K
+w63tXA39JkEVUo37MWcBnSYiDS3EqKCVgzmDOIQHEWGqAwOLtpifY6g6/SQb2b6jPkFBwjw9ALIlOA40
+0VYYRw22YjBSavc6hY6ps6ckCyuA3BiOstf2gun6JZMowuIBRJ8RVbI2HecxEVjr4Pq254A4pwAkARNS
+yAEpOI4h/Gaa81lylpPGgvuJ14kUVlEMjIml/t7u7vzLouMG1ojxFbZpEfpNkADIXjo+gfVIjVedkWXO
+OBM4Z3Swe7BmeWeEQRLxToXgYs7gj7XbVZUT67VSA4pmSAx9H2FUIXgEE0D9fTERYwGyIAdYxNmKmnD/
+Ye4qyiqPqr5rRgQYMyZQZ6T6oRC5uyGInOcs51vx0T35N2XlkxDC3B+vJ2ZeD8imE54NtZblC+DVyX3+
+SAASqSCguhIJKR6rh1hz0X0Kmz4pTFO/mVonImPy04N0c9KzU/QIjQV8e6HxqJZvBc1VhaQM/WJ4Myr4
+lWVONV8+c2VtiJaprAzWulSYeSTdsnQP3iaU2ivLmOcotc3Q2ZxS21JOG0CaldM+wEynDMNwA3JLU5F7
+yqLgaJrDFZKNq2XgkH7tF0s9bXOyjLbmV5p5Erx+y8tat4a+PQPZlTIQPFoiJT0bkOe++Lla/P8hbgUx
+n1dJteOURQFXCZ96oTVjsDl5NzPoV+p9EgjjW0xDPAlXAvE7LL4gOcPCl4gHpeyLJSpEtCBZcxku0v78
+eC6Z9QvVT4JqAJ9K5BoORwyokWYHmRkzH004V1BccYxSGRrvBHuzOeJ75u9rw2aTTlZSizb5esTJ7+Mc
+TxCs2nOnfX9XBlp+/QfwMrd/d1TNUAScAC9GOOgf4CRUxK8HUs762Gjy06fNk5I5z5EuFzXY1IRpjei5
+NRdZKctsR8Enn4mXRQIgAxhUdB4Ig4LmTUiG5zlV833WZZxdZFuqXdosOulVpOtTpsuvX9ERCEKCC4QH
+YSJnHWnvBIWqcMOPeRRRdcGnbRM8/SXpqgpsLjAVnYkVZF5wzmUnL48XyIu8xHAsZ1x1lEqZT+67SXQt
+Uj19XKtwp5gWtl4Bmrk/wozLiqaUKTIl1nIKuD6TS0feHOTPJyH3vlzTr2Q1LkkQEPE2UZDH18DoCku1
+ulU6j53exxTKTEsYF8iHAWT4EVdDD6qCXNHJbgUinWJMugFw9fDV3Z/3wuJU/+zroWtWG2f0JdHjEpPO
++Tb93U3FaHuifmHfN1rg8KrCplcarh5v91A4PdBrDGjdPqWHhIU70fY+NXdR/mO3XujZ3yup3EPP4qTn
++0TOzdhbZUT3cm+xp7hos5El0bXZxrcRUmno2leolygODr6hNf1Bx2xG5TuqW5Z2YRG+N2pdE+X6TeJy
+J/UJm9/S31DJQ7MqzC8hW4i/ctbWL90ROp2pNqcR5secMtXCTNZHFQuB7bZdkagdYcbFJcat/tav4meX
+zZrGXDQabaSvFrcFxczuviVppLBS1g5L8kR4xG1DRYb/lcq55hEES7OY615ym60ngYWfDsLuzx25N+7w
+wv3gjs4G+cPh+6F7MTy6OC2eXJwO32cUNTfza0mIS8HTToB1l3k/hma9sm1d6bFyu+beLb831rDywXsP
+N0EKvgUo5ea5SySa+rWDmU99ffT2HZFRdzy7BERaE4FJlCu4xorkIydtPbReoeXp1ybkPyQAR3SbUVwW
+dCPPoNeNWJAJDnWwYMce2/fLj+9v17r+rWyU8p2f/T4ytM7+pGOn0CFk/RHbJbLLcW0GwHI8ZwSHamZH
+4FFvFedLcYfNqkW7Vn2J7o4bFLXP/frswVvY1h7VLrOHHbV3heZhM4ndxWQtSsg9HA7sJ16Y6P8J+rEq
+cOlQq3antAC6/NSjpWk9yunsFnxNueycp8fyR2VdOgVetZtI7mNgAOvE/A+dRprJXfo06hwa72Xa4HPe
+r7C35VPYnev/3FtxO/OMO8f1u7j+6mSZlND1PK3mSrv3OVCJx3+hBeDRRjoAAA==
 `,
 	},
 
@@ -107,10 +107,220 @@ func (m *PlacementAddRequest) GetInstances() []*placementpb.Instance {
 	return nil
 }
 
+type PlacementReplaceRequest struct {
+	LeavingInstanceIds []string                `protobuf:"bytes,1,rep,name=leaving_instance_ids,json=leavingInstanceIds" json:"leaving_instance_ids,omitempty"`
+	Candidates         []*placementpb.Instance `protobuf:"bytes,2,rep,name=candidates" json:"candidates,omitempty"`
+}
+
+func (m *PlacementReplaceRequest) Reset()         { *m = PlacementReplaceRequest{} }
+func (m *PlacementReplaceRequest) String() string { return proto.CompactTextString(m) }
+func (*PlacementReplaceRequest) ProtoMessage()    {}
+func (*PlacementReplaceRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptorPlacement, []int{3}
+}
+
+func (m *PlacementReplaceRequest) GetLeavingInstanceIds() []string {
+	if m != nil {
+		return m.LeavingInstanceIds
+	}
+	return nil
+}
+
+func (m *PlacementReplaceRequest) GetCandidates() []*placementpb.Instance {
+	if m != nil {
+		return m.Candidates
+	}
+	return nil
+}
+
+type PlacementMarkAvailableRequest struct {
+	InstanceId string `protobuf:"bytes,1,opt,name=instance_id,json=instanceId,proto3" json:"instance_id,omitempty"`
+	// (Optional) Shards to mark as available, if empty then all shards
+	// owned by the instance are marked as available.
+	ShardIds []uint32 `protobuf:"varint,2,rep,packed,name=shard_ids,json=shardIds" json:"shard_ids,omitempty"`
+}
+
+func (m *PlacementMarkAvailableRequest) Reset()         { *m = PlacementMarkAvailableRequest{} }
+func (m *PlacementMarkAvailableRequest) String() string { return proto.CompactTextString(m) }
+func (*PlacementMarkAvailableRequest) ProtoMessage()    {}
+func (*PlacementMarkAvailableRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptorPlacement, []int{4}
+}
+
+func (m *PlacementMarkAvailableRequest) GetInstanceId() string {
+	if m != nil {
+		return m.InstanceId
+	}
+	return ""
+}
+
+func (m *PlacementMarkAvailableRequest) GetShardIds() []uint32 {
+	if m != nil {
+		return m.ShardIds
+	}
+	return nil
+}
+
+type PlacementStatusResponse struct {
+	Version            int32  `protobuf:"varint,1,opt,name=version,proto3" json:"version,omitempty"`
+	NumShards          uint32 `protobuf:"varint,2,opt,name=num_shards,json=numShards,proto3" json:"num_shards,omitempty"`
+	InitializingShards uint32 `protobuf:"varint,3,opt,name=initializing_shards,json=initializingShards,proto3" json:"initializing_shards,omitempty"`
+	AvailableShards    uint32 `protobuf:"varint,4,opt,name=available_shards,json=availableShards,proto3" json:"available_shards,omitempty"`
+	LeavingShards      uint32 `protobuf:"varint,5,opt,name=leaving_shards,json=leavingShards,proto3" json:"leaving_shards,omitempty"`
+	// Set once every shard is available and every node has bootstrapped.
+	RebalanceComplete bool                       `protobuf:"varint,6,opt,name=rebalance_complete,json=rebalanceComplete,proto3" json:"rebalance_complete,omitempty"`
+	Instances         []*PlacementInstanceStatus `protobuf:"bytes,7,rep,name=instances" json:"instances,omitempty"`
+}
+
+func (m *PlacementStatusResponse) Reset()         { *m = PlacementStatusResponse{} }
+func (m *PlacementStatusResponse) String() string { return proto.CompactTextString(m) }
+func (*PlacementStatusResponse) ProtoMessage()    {}
+func (*PlacementStatusResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptorPlacement, []int{5}
+}
+
+func (m *PlacementStatusResponse) GetVersion() int32 {
+	if m != nil {
+		return m.Version
+	}
+	return 0
+}
+
+func (m *PlacementStatusResponse) GetNumShards() uint32 {
+	if m != nil {
+		return m.NumShards
+	}
+	return 0
+}
+
+func (m *PlacementStatusResponse) GetInitializingShards() uint32 {
+	if m != nil {
+		return m.InitializingShards
+	}
+	return 0
+}
+
+func (m *PlacementStatusResponse) GetAvailableShards() uint32 {
+	if m != nil {
+		return m.AvailableShards
+	}
+	return 0
+}
+
+func (m *PlacementStatusResponse) GetLeavingShards() uint32 {
+	if m != nil {
+		return m.LeavingShards
+	}
+	return 0
+}
+
+func (m *PlacementStatusResponse) GetRebalanceComplete() bool {
+	if m != nil {
+		return m.RebalanceComplete
+	}
+	return false
+}
+
+func (m *PlacementStatusResponse) GetInstances() []*PlacementInstanceStatus {
+	if m != nil {
+		return m.Instances
+	}
+	return nil
+}
+
+type PlacementInstanceStatus struct {
+	Id                 string   `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Endpoint           string   `protobuf:"bytes,2,opt,name=endpoint,proto3" json:"endpoint,omitempty"`
+	InitializingShards []uint32 `protobuf:"varint,3,rep,packed,name=initializing_shards,json=initializingShards" json:"initializing_shards,omitempty"`
+	AvailableShards    []uint32 `protobuf:"varint,4,rep,packed,name=available_shards,json=availableShards" json:"available_shards,omitempty"`
+	LeavingShards      []uint32 `protobuf:"varint,5,rep,packed,name=leaving_shards,json=leavingShards" json:"leaving_shards,omitempty"`
+	// Health reported by the node, empty if the node could not be reached.
+	HealthStatus string `protobuf:"bytes,6,opt,name=health_status,json=healthStatus,proto3" json:"health_status,omitempty"`
+	Bootstrapped bool   `protobuf:"varint,7,opt,name=bootstrapped,proto3" json:"bootstrapped,omitempty"`
+	// Error encountered while checking the node health, if any.
+	Error string `protobuf:"bytes,8,opt,name=error,proto3" json:"error,omitempty"`
+	// Shards the node reports as bootstrapped for every namespace.
+	BootstrappedShards []uint32 `protobuf:"varint,9,rep,packed,name=bootstrapped_shards,json=bootstrappedShards" json:"bootstrapped_shards,omitempty"`
+}
+
+func (m *PlacementInstanceStatus) Reset()         { *m = PlacementInstanceStatus{} }
+func (m *PlacementInstanceStatus) String() string { return proto.CompactTextString(m) }
+func (*PlacementInstanceStatus) ProtoMessage()    {}
+func (*PlacementInstanceStatus) Descriptor() ([]byte, []int) {
+	return fileDescriptorPlacement, []int{6}
+}
+
+func (m *PlacementInstanceStatus) GetId() string {
+	if m != nil {
+		return m.Id
+	}
+	return ""
+}
+
+func (m *PlacementInstanceStatus) GetEndpoint() string {
+	if m != nil {
+		return m.Endpoint
+	}
+	return ""
+}
+
+func (m *PlacementInstanceStatus) GetInitializingShards() []uint32 {
+	if m != nil {
+		return m.InitializingShards
+	}
+	return nil
+}
+
+func (m *PlacementInstanceStatus) GetAvailableShards() []uint32 {
+	if m != nil {
+		return m.AvailableShards
+	}
+	return nil
+}
+
+func (m *PlacementInstanceStatus) GetLeavingShards() []uint32 {
+	if m != nil {
+		return m.LeavingShards
+	}
+	return nil
+}
+
+func (m *PlacementInstanceStatus) GetHealthStatus() string {
+	if m != nil {
+		return m.HealthStatus
+	}
+	return ""
+}
+
+func (m *PlacementInstanceStatus) GetBootstrapped() bool {
+	if m != nil {
+		return m.Bootstrapped
+	}
+	return false
+}
+
+func (m *PlacementInstanceStatus) GetError() string {
+	if m != nil {
+		return m.Error
+	}
+	return ""
+}
+
+func (m *PlacementInstanceStatus) GetBootstrappedShards() []uint32 {
+	if m != nil {
+		return m.BootstrappedShards
+	}
+	return nil
+}
+
 func init() {
 	proto.RegisterType((*PlacementInitRequest)(nil), "admin.PlacementInitRequest")
 	proto.RegisterType((*PlacementGetResponse)(nil), "admin.PlacementGetResponse")
 	proto.RegisterType((*PlacementAddRequest)(nil), "admin.PlacementAddRequest")
+	proto.RegisterType((*PlacementReplaceRequest)(nil), "admin.PlacementReplaceRequest")
+	proto.RegisterType((*PlacementMarkAvailableRequest)(nil), "admin.PlacementMarkAvailableRequest")
+	proto.RegisterType((*PlacementStatusResponse)(nil), "admin.PlacementStatusResponse")
+	proto.RegisterType((*PlacementInstanceStatus)(nil), "admin.PlacementInstanceStatus")
 }
 func (m *PlacementInitRequest) Marshal() (dAtA []byte, err error) {
 	size := m.Size()
@@ -215,82 +425,471 @@ func (m *PlacementAddRequest) MarshalTo(dAtA []byte) (int, error) {
 	return i, nil
 }
 
-func encodeVarintPlacement(dAtA []byte, offset int, v uint64) int {
-	for v >= 1<<7 {
-		dAtA[offset] = uint8(v&0x7f | 0x80)
-		v >>= 7
-		offset++
+func (m *PlacementReplaceRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
 	}
-	dAtA[offset] = uint8(v)
-	return offset + 1
+	return dAtA[:n], nil
 }
-func (m *PlacementInitRequest) Size() (n int) {
+
+func (m *PlacementReplaceRequest) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
 	var l int
 	_ = l
-	if len(m.Instances) > 0 {
-		for _, e := range m.Instances {
-			l = e.Size()
-			n += 1 + l + sovPlacement(uint64(l))
+	if len(m.LeavingInstanceIds) > 0 {
+		for _, s := range m.LeavingInstanceIds {
+			dAtA[i] = 0xa
+			i++
+			l = len(s)
+			for l >= 1<<7 {
+				dAtA[i] = uint8(uint64(l)&0x7f | 0x80)
+				l >>= 7
+				i++
+			}
+			dAtA[i] = uint8(l)
+			i++
+			i += copy(dAtA[i:], s)
 		}
 	}
-	if m.NumShards != 0 {
-		n += 1 + sovPlacement(uint64(m.NumShards))
+	if len(m.Candidates) > 0 {
+		for _, msg := range m.Candidates {
+			dAtA[i] = 0x12
+			i++
+			i = encodeVarintPlacement(dAtA, i, uint64(msg.Size()))
+			n, err := msg.MarshalTo(dAtA[i:])
+			if err != nil {
+				return 0, err
+			}
+			i += n
+		}
 	}
-	if m.ReplicationFactor != 0 {
-		n += 1 + sovPlacement(uint64(m.ReplicationFactor))
+	return i, nil
+}
+
+func (m *PlacementMarkAvailableRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
 	}
-	return n
+	return dAtA[:n], nil
 }
 
-func (m *PlacementGetResponse) Size() (n int) {
+func (m *PlacementMarkAvailableRequest) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
 	var l int
 	_ = l
-	if m.Placement != nil {
-		l = m.Placement.Size()
-		n += 1 + l + sovPlacement(uint64(l))
+	if len(m.InstanceId) > 0 {
+		dAtA[i] = 0xa
+		i++
+		i = encodeVarintPlacement(dAtA, i, uint64(len(m.InstanceId)))
+		i += copy(dAtA[i:], m.InstanceId)
 	}
-	if m.Version != 0 {
-		n += 1 + sovPlacement(uint64(m.Version))
+	if len(m.ShardIds) > 0 {
+		dAtA3 := make([]byte, len(m.ShardIds)*10)
+		var j2 int
+		for _, num := range m.ShardIds {
+			for num >= 1<<7 {
+				dAtA3[j2] = uint8(uint64(num)&0x7f | 0x80)
+				num >>= 7
+				j2++
+			}
+			dAtA3[j2] = uint8(num)
+			j2++
+		}
+		dAtA[i] = 0x12
+		i++
+		i = encodeVarintPlacement(dAtA, i, uint64(j2))
+		i += copy(dAtA[i:], dAtA3[:j2])
 	}
-	return n
+	return i, nil
 }
 
-func (m *PlacementAddRequest) Size() (n int) {
+func (m *PlacementStatusResponse) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *PlacementStatusResponse) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
 	var l int
 	_ = l
+	if m.Version != 0 {
+		dAtA[i] = 0x8
+		i++
+		i = encodeVarintPlacement(dAtA, i, uint64(m.Version))
+	}
+	if m.NumShards != 0 {
+		dAtA[i] = 0x10
+		i++
+		i = encodeVarintPlacement(dAtA, i, uint64(m.NumShards))
+	}
+	if m.InitializingShards != 0 {
+		dAtA[i] = 0x18
+		i++
+		i = encodeVarintPlacement(dAtA, i, uint64(m.InitializingShards))
+	}
+	if m.AvailableShards != 0 {
+		dAtA[i] = 0x20
+		i++
+		i = encodeVarintPlacement(dAtA, i, uint64(m.AvailableShards))
+	}
+	if m.LeavingShards != 0 {
+		dAtA[i] = 0x28
+		i++
+		i = encodeVarintPlacement(dAtA, i, uint64(m.LeavingShards))
+	}
+	if m.RebalanceComplete {
+		dAtA[i] = 0x30
+		i++
+		if m.RebalanceComplete {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i++
+	}
 	if len(m.Instances) > 0 {
-		for _, e := range m.Instances {
-			l = e.Size()
-			n += 1 + l + sovPlacement(uint64(l))
+		for _, msg := range m.Instances {
+			dAtA[i] = 0x3a
+			i++
+			i = encodeVarintPlacement(dAtA, i, uint64(msg.Size()))
+			n, err := msg.MarshalTo(dAtA[i:])
+			if err != nil {
+				return 0, err
+			}
+			i += n
 		}
 	}
-	return n
+	return i, nil
 }
 
-func sovPlacement(x uint64) (n int) {
-	for {
-		n++
-		x >>= 7
-		if x == 0 {
-			break
-		}
+func (m *PlacementInstanceStatus) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
 	}
-	return n
-}
-func sozPlacement(x uint64) (n int) {
-	return sovPlacement(uint64((x << 1) ^ uint64((int64(x) >> 63))))
+	return dAtA[:n], nil
 }
-func (m *PlacementInitRequest) Unmarshal(dAtA []byte) error {
-	l := len(dAtA)
-	iNdEx := 0
-	for iNdEx < l {
-		preIndex := iNdEx
-		var wire uint64
-		for shift := uint(0); ; shift += 7 {
-			if shift >= 64 {
-				return ErrIntOverflowPlacement
+
+func (m *PlacementInstanceStatus) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if len(m.Id) > 0 {
+		dAtA[i] = 0xa
+		i++
+		i = encodeVarintPlacement(dAtA, i, uint64(len(m.Id)))
+		i += copy(dAtA[i:], m.Id)
+	}
+	if len(m.Endpoint) > 0 {
+		dAtA[i] = 0x12
+		i++
+		i = encodeVarintPlacement(dAtA, i, uint64(len(m.Endpoint)))
+		i += copy(dAtA[i:], m.Endpoint)
+	}
+	if len(m.InitializingShards) > 0 {
+		dAtA5 := make([]byte, len(m.InitializingShards)*10)
+		var j4 int
+		for _, num := range m.InitializingShards {
+			for num >= 1<<7 {
+				dAtA5[j4] = uint8(uint64(num)&0x7f | 0x80)
+				num >>= 7
+				j4++
 			}
-			if iNdEx >= l {
+			dAtA5[j4] = uint8(num)
+			j4++
+		}
+		dAtA[i] = 0x1a
+		i++
+		i = encodeVarintPlacement(dAtA, i, uint64(j4))
+		i += copy(dAtA[i:], dAtA5[:j4])
+	}
+	if len(m.AvailableShards) > 0 {
+		dAtA7 := make([]byte, len(m.AvailableShards)*10)
+		var j6 int
+		for _, num := range m.AvailableShards {
+			for num >= 1<<7 {
+				dAtA7[j6] = uint8(uint64(num)&0x7f | 0x80)
+				num >>= 7
+				j6++
+			}
+			dAtA7[j6] = uint8(num)
+			j6++
+		}
+		dAtA[i] = 0x22
+		i++
+		i = encodeVarintPlacement(dAtA, i, uint64(j6))
+		i += copy(dAtA[i:], dAtA7[:j6])
+	}
+	if len(m.LeavingShards) > 0 {
+		dAtA9 := make([]byte, len(m.LeavingShards)*10)
+		var j8 int
+		for _, num := range m.LeavingShards {
+			for num >= 1<<7 {
+				dAtA9[j8] = uint8(uint64(num)&0x7f | 0x80)
+				num >>= 7
+				j8++
+			}
+			dAtA9[j8] = uint8(num)
+			j8++
+		}
+		dAtA[i] = 0x2a
+		i++
+		i = encodeVarintPlacement(dAtA, i, uint64(j8))
+		i += copy(dAtA[i:], dAtA9[:j8])
+	}
+	if len(m.HealthStatus) > 0 {
+		dAtA[i] = 0x32
+		i++
+		i = encodeVarintPlacement(dAtA, i, uint64(len(m.HealthStatus)))
+		i += copy(dAtA[i:], m.HealthStatus)
+	}
+	if m.Bootstrapped {
+		dAtA[i] = 0x38
+		i++
+		if m.Bootstrapped {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i++
+	}
+	if len(m.Error) > 0 {
+		dAtA[i] = 0x42
+		i++
+		i = encodeVarintPlacement(dAtA, i, uint64(len(m.Error)))
+		i += copy(dAtA[i:], m.Error)
+	}
+	if len(m.BootstrappedShards) > 0 {
+		dAtA11 := make([]byte, len(m.BootstrappedShards)*10)
+		var j10 int
+		for _, num := range m.BootstrappedShards {
+			for num >= 1<<7 {
+				dAtA11[j10] = uint8(uint64(num)&0x7f | 0x80)
+				num >>= 7
+				j10++
+			}
+			dAtA11[j10] = uint8(num)
+			j10++
+		}
+		dAtA[i] = 0x4a
+		i++
+		i = encodeVarintPlacement(dAtA, i, uint64(j10))
+		i += copy(dAtA[i:], dAtA11[:j10])
+	}
+	return i, nil
+}
+
+func encodeVarintPlacement(dAtA []byte, offset int, v uint64) int {
+	for v >= 1<<7 {
+		dAtA[offset] = uint8(v&0x7f | 0x80)
+		v >>= 7
+		offset++
+	}
+	dAtA[offset] = uint8(v)
+	return offset + 1
+}
+func (m *PlacementInitRequest) Size() (n int) {
+	var l int
+	_ = l
+	if len(m.Instances) > 0 {
+		for _, e := range m.Instances {
+			l = e.Size()
+			n += 1 + l + sovPlacement(uint64(l))
+		}
+	}
+	if m.NumShards != 0 {
+		n += 1 + sovPlacement(uint64(m.NumShards))
+	}
+	if m.ReplicationFactor != 0 {
+		n += 1 + sovPlacement(uint64(m.ReplicationFactor))
+	}
+	return n
+}
+
+func (m *PlacementGetResponse) Size() (n int) {
+	var l int
+	_ = l
+	if m.Placement != nil {
+		l = m.Placement.Size()
+		n += 1 + l + sovPlacement(uint64(l))
+	}
+	if m.Version != 0 {
+		n += 1 + sovPlacement(uint64(m.Version))
+	}
+	return n
+}
+
+func (m *PlacementAddRequest) Size() (n int) {
+	var l int
+	_ = l
+	if len(m.Instances) > 0 {
+		for _, e := range m.Instances {
+			l = e.Size()
+			n += 1 + l + sovPlacement(uint64(l))
+		}
+	}
+	return n
+}
+
+func (m *PlacementReplaceRequest) Size() (n int) {
+	var l int
+	_ = l
+	if len(m.LeavingInstanceIds) > 0 {
+		for _, s := range m.LeavingInstanceIds {
+			l = len(s)
+			n += 1 + l + sovPlacement(uint64(l))
+		}
+	}
+	if len(m.Candidates) > 0 {
+		for _, e := range m.Candidates {
+			l = e.Size()
+			n += 1 + l + sovPlacement(uint64(l))
+		}
+	}
+	return n
+}
+
+func (m *PlacementMarkAvailableRequest) Size() (n int) {
+	var l int
+	_ = l
+	l = len(m.InstanceId)
+	if l > 0 {
+		n += 1 + l + sovPlacement(uint64(l))
+	}
+	if len(m.ShardIds) > 0 {
+		l = 0
+		for _, e := range m.ShardIds {
+			l += sovPlacement(uint64(e))
+		}
+		n += 1 + sovPlacement(uint64(l)) + l
+	}
+	return n
+}
+
+func (m *PlacementStatusResponse) Size() (n int) {
+	var l int
+	_ = l
+	if m.Version != 0 {
+		n += 1 + sovPlacement(uint64(m.Version))
+	}
+	if m.NumShards != 0 {
+		n += 1 + sovPlacement(uint64(m.NumShards))
+	}
+	if m.InitializingShards != 0 {
+		n += 1 + sovPlacement(uint64(m.InitializingShards))
+	}
+	if m.AvailableShards != 0 {
+		n += 1 + sovPlacement(uint64(m.AvailableShards))
+	}
+	if m.LeavingShards != 0 {
+		n += 1 + sovPlacement(uint64(m.LeavingShards))
+	}
+	if m.RebalanceComplete {
+		n += 2
+	}
+	if len(m.Instances) > 0 {
+		for _, e := range m.Instances {
+			l = e.Size()
+			n += 1 + l + sovPlacement(uint64(l))
+		}
+	}
+	return n
+}
+
+func (m *PlacementInstanceStatus) Size() (n int) {
+	var l int
+	_ = l
+	l = len(m.Id)
+	if l > 0 {
+		n += 1 + l + sovPlacement(uint64(l))
+	}
+	l = len(m.Endpoint)
+	if l > 0 {
+		n += 1 + l + sovPlacement(uint64(l))
+	}
+	if len(m.InitializingShards) > 0 {
+		l = 0
+		for _, e := range m.InitializingShards {
+			l += sovPlacement(uint64(e))
+		}
+		n += 1 + sovPlacement(uint64(l)) + l
+	}
+	if len(m.AvailableShards) > 0 {
+		l = 0
+		for _, e := range m.AvailableShards {
+			l += sovPlacement(uint64(e))
+		}
+		n += 1 + sovPlacement(uint64(l)) + l
+	}
+	if len(m.LeavingShards) > 0 {
+		l = 0
+		for _, e := range m.LeavingShards {
+			l += sovPlacement(uint64(e))
+		}
+		n += 1 + sovPlacement(uint64(l)) + l
+	}
+	l = len(m.HealthStatus)
+	if l > 0 {
+		n += 1 + l + sovPlacement(uint64(l))
+	}
+	if m.Bootstrapped {
+		n += 2
+	}
+	l = len(m.Error)
+	if l > 0 {
+		n += 1 + l + sovPlacement(uint64(l))
+	}
+	if len(m.BootstrappedShards) > 0 {
+		l = 0
+		for _, e := range m.BootstrappedShards {
+			l += sovPlacement(uint64(e))
+		}
+		n += 1 + sovPlacement(uint64(l)) + l
+	}
+	return n
+}
+
+func sovPlacement(x uint64) (n int) {
+	for {
+		n++
+		x >>= 7
+		if x == 0 {
+			break
+		}
+	}
+	return n
+}
+func sozPlacement(x uint64) (n int) {
+	return sovPlacement(uint64((x << 1) ^ uint64((int64(x) >> 63))))
+}
+func (m *PlacementInitRequest) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowPlacement
+			}
+			if iNdEx >= l {
 				return io.ErrUnexpectedEOF
 			}
 			b := dAtA[iNdEx]
@@ -328,23 +927,640 @@ func (m *PlacementInitRequest) Unmarshal(dAtA []byte) error {
 					break
 				}
 			}
-			if msglen < 0 {
-				return ErrInvalidLengthPlacement
-			}
-			postIndex := iNdEx + msglen
-			if postIndex > l {
-				return io.ErrUnexpectedEOF
-			}
-			m.Instances = append(m.Instances, &placementpb.Instance{})
-			if err := m.Instances[len(m.Instances)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
-			}
-			iNdEx = postIndex
-		case 2:
+			if msglen < 0 {
+				return ErrInvalidLengthPlacement
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Instances = append(m.Instances, &placementpb.Instance{})
+			if err := m.Instances[len(m.Instances)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field NumShards", wireType)
+			}
+			m.NumShards = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPlacement
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.NumShards |= (int32(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 3:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ReplicationFactor", wireType)
+			}
+			m.ReplicationFactor = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPlacement
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.ReplicationFactor |= (int32(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		default:
+			iNdEx = preIndex
+			skippy, err := skipPlacement(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthPlacement
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *PlacementGetResponse) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowPlacement
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: PlacementGetResponse: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: PlacementGetResponse: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Placement", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPlacement
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthPlacement
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Placement == nil {
+				m.Placement = &placementpb.Placement{}
+			}
+			if err := m.Placement.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Version", wireType)
+			}
+			m.Version = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPlacement
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Version |= (int32(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		default:
+			iNdEx = preIndex
+			skippy, err := skipPlacement(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthPlacement
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *PlacementAddRequest) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowPlacement
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: PlacementAddRequest: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: PlacementAddRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Instances", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPlacement
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthPlacement
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Instances = append(m.Instances, &placementpb.Instance{})
+			if err := m.Instances[len(m.Instances)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipPlacement(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthPlacement
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *PlacementReplaceRequest) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowPlacement
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: PlacementReplaceRequest: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: PlacementReplaceRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field LeavingInstanceIds", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPlacement
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthPlacement
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.LeavingInstanceIds = append(m.LeavingInstanceIds, string(dAtA[iNdEx:postIndex]))
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Candidates", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPlacement
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthPlacement
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Candidates = append(m.Candidates, &placementpb.Instance{})
+			if err := m.Candidates[len(m.Candidates)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipPlacement(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthPlacement
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *PlacementMarkAvailableRequest) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowPlacement
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: PlacementMarkAvailableRequest: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: PlacementMarkAvailableRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field InstanceId", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPlacement
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthPlacement
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.InstanceId = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 2:
+			if wireType == 0 {
+				var v uint32
+				for shift := uint(0); ; shift += 7 {
+					if shift >= 64 {
+						return ErrIntOverflowPlacement
+					}
+					if iNdEx >= l {
+						return io.ErrUnexpectedEOF
+					}
+					b := dAtA[iNdEx]
+					iNdEx++
+					v |= (uint32(b) & 0x7F) << shift
+					if b < 0x80 {
+						break
+					}
+				}
+				m.ShardIds = append(m.ShardIds, v)
+			} else if wireType == 2 {
+				var packedLen int
+				for shift := uint(0); ; shift += 7 {
+					if shift >= 64 {
+						return ErrIntOverflowPlacement
+					}
+					if iNdEx >= l {
+						return io.ErrUnexpectedEOF
+					}
+					b := dAtA[iNdEx]
+					iNdEx++
+					packedLen |= (int(b) & 0x7F) << shift
+					if b < 0x80 {
+						break
+					}
+				}
+				if packedLen < 0 {
+					return ErrInvalidLengthPlacement
+				}
+				postIndex := iNdEx + packedLen
+				if postIndex > l {
+					return io.ErrUnexpectedEOF
+				}
+				for iNdEx < postIndex {
+					var v uint32
+					for shift := uint(0); ; shift += 7 {
+						if shift >= 64 {
+							return ErrIntOverflowPlacement
+						}
+						if iNdEx >= l {
+							return io.ErrUnexpectedEOF
+						}
+						b := dAtA[iNdEx]
+						iNdEx++
+						v |= (uint32(b) & 0x7F) << shift
+						if b < 0x80 {
+							break
+						}
+					}
+					m.ShardIds = append(m.ShardIds, v)
+				}
+			} else {
+				return fmt.Errorf("proto: wrong wireType = %d for field ShardIds", wireType)
+			}
+		default:
+			iNdEx = preIndex
+			skippy, err := skipPlacement(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthPlacement
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *PlacementStatusResponse) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowPlacement
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: PlacementStatusResponse: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: PlacementStatusResponse: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Version", wireType)
+			}
+			m.Version = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPlacement
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Version |= (int32(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field NumShards", wireType)
+			}
+			m.NumShards = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPlacement
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.NumShards |= (uint32(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 3:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field InitializingShards", wireType)
+			}
+			m.InitializingShards = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPlacement
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.InitializingShards |= (uint32(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 4:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field AvailableShards", wireType)
+			}
+			m.AvailableShards = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPlacement
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.AvailableShards |= (uint32(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 5:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field LeavingShards", wireType)
+			}
+			m.LeavingShards = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPlacement
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.LeavingShards |= (uint32(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 6:
 			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field NumShards", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field RebalanceComplete", wireType)
 			}
-			m.NumShards = 0
+			var v int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowPlacement
@@ -354,16 +1570,17 @@ func (m *PlacementInitRequest) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				m.NumShards |= (int32(b) & 0x7F) << shift
+				v |= (int(b) & 0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-		case 3:
-			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field ReplicationFactor", wireType)
+			m.RebalanceComplete = bool(v != 0)
+		case 7:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Instances", wireType)
 			}
-			m.ReplicationFactor = 0
+			var msglen int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowPlacement
@@ -373,11 +1590,23 @@ func (m *PlacementInitRequest) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				m.ReplicationFactor |= (int32(b) & 0x7F) << shift
+				msglen |= (int(b) & 0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
+			if msglen < 0 {
+				return ErrInvalidLengthPlacement
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Instances = append(m.Instances, &PlacementInstanceStatus{})
+			if err := m.Instances[len(m.Instances)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipPlacement(dAtA[iNdEx:])
@@ -399,7 +1628,7 @@ func (m *PlacementInitRequest) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *PlacementGetResponse) Unmarshal(dAtA []byte) error {
+func (m *PlacementInstanceStatus) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -422,17 +1651,17 @@ func (m *PlacementGetResponse) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: PlacementGetResponse: wiretype end group for non-group")
+			return fmt.Errorf("proto: PlacementInstanceStatus: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: PlacementGetResponse: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: PlacementInstanceStatus: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Placement", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Id", wireType)
 			}
-			var msglen int
+			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowPlacement
@@ -442,30 +1671,26 @@ func (m *PlacementGetResponse) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				msglen |= (int(b) & 0x7F) << shift
+				stringLen |= (uint64(b) & 0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if msglen < 0 {
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
 				return ErrInvalidLengthPlacement
 			}
-			postIndex := iNdEx + msglen
+			postIndex := iNdEx + intStringLen
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if m.Placement == nil {
-				m.Placement = &placementpb.Placement{}
-			}
-			if err := m.Placement.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
-			}
+			m.Id = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
 		case 2:
-			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Version", wireType)
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Endpoint", wireType)
 			}
-			m.Version = 0
+			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowPlacement
@@ -475,66 +1700,212 @@ func (m *PlacementGetResponse) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				m.Version |= (int32(b) & 0x7F) << shift
+				stringLen |= (uint64(b) & 0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-		default:
-			iNdEx = preIndex
-			skippy, err := skipPlacement(dAtA[iNdEx:])
-			if err != nil {
-				return err
-			}
-			if skippy < 0 {
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
 				return ErrInvalidLengthPlacement
 			}
-			if (iNdEx + skippy) > l {
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			iNdEx += skippy
-		}
-	}
-
-	if iNdEx > l {
-		return io.ErrUnexpectedEOF
-	}
-	return nil
-}
-func (m *PlacementAddRequest) Unmarshal(dAtA []byte) error {
-	l := len(dAtA)
-	iNdEx := 0
-	for iNdEx < l {
-		preIndex := iNdEx
-		var wire uint64
-		for shift := uint(0); ; shift += 7 {
-			if shift >= 64 {
-				return ErrIntOverflowPlacement
+			m.Endpoint = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 3:
+			if wireType == 0 {
+				var v uint32
+				for shift := uint(0); ; shift += 7 {
+					if shift >= 64 {
+						return ErrIntOverflowPlacement
+					}
+					if iNdEx >= l {
+						return io.ErrUnexpectedEOF
+					}
+					b := dAtA[iNdEx]
+					iNdEx++
+					v |= (uint32(b) & 0x7F) << shift
+					if b < 0x80 {
+						break
+					}
+				}
+				m.InitializingShards = append(m.InitializingShards, v)
+			} else if wireType == 2 {
+				var packedLen int
+				for shift := uint(0); ; shift += 7 {
+					if shift >= 64 {
+						return ErrIntOverflowPlacement
+					}
+					if iNdEx >= l {
+						return io.ErrUnexpectedEOF
+					}
+					b := dAtA[iNdEx]
+					iNdEx++
+					packedLen |= (int(b) & 0x7F) << shift
+					if b < 0x80 {
+						break
+					}
+				}
+				if packedLen < 0 {
+					return ErrInvalidLengthPlacement
+				}
+				postIndex := iNdEx + packedLen
+				if postIndex > l {
+					return io.ErrUnexpectedEOF
+				}
+				for iNdEx < postIndex {
+					var v uint32
+					for shift := uint(0); ; shift += 7 {
+						if shift >= 64 {
+							return ErrIntOverflowPlacement
+						}
+						if iNdEx >= l {
+							return io.ErrUnexpectedEOF
+						}
+						b := dAtA[iNdEx]
+						iNdEx++
+						v |= (uint32(b) & 0x7F) << shift
+						if b < 0x80 {
+							break
+						}
+					}
+					m.InitializingShards = append(m.InitializingShards, v)
+				}
+			} else {
+				return fmt.Errorf("proto: wrong wireType = %d for field InitializingShards", wireType)
 			}
-			if iNdEx >= l {
-				return io.ErrUnexpectedEOF
+		case 4:
+			if wireType == 0 {
+				var v uint32
+				for shift := uint(0); ; shift += 7 {
+					if shift >= 64 {
+						return ErrIntOverflowPlacement
+					}
+					if iNdEx >= l {
+						return io.ErrUnexpectedEOF
+					}
+					b := dAtA[iNdEx]
+					iNdEx++
+					v |= (uint32(b) & 0x7F) << shift
+					if b < 0x80 {
+						break
+					}
+				}
+				m.AvailableShards = append(m.AvailableShards, v)
+			} else if wireType == 2 {
+				var packedLen int
+				for shift := uint(0); ; shift += 7 {
+					if shift >= 64 {
+						return ErrIntOverflowPlacement
+					}
+					if iNdEx >= l {
+						return io.ErrUnexpectedEOF
+					}
+					b := dAtA[iNdEx]
+					iNdEx++
+					packedLen |= (int(b) & 0x7F) << shift
+					if b < 0x80 {
+						break
+					}
+				}
+				if packedLen < 0 {
+					return ErrInvalidLengthPlacement
+				}
+				postIndex := iNdEx + packedLen
+				if postIndex > l {
+					return io.ErrUnexpectedEOF
+				}
+				for iNdEx < postIndex {
+					var v uint32
+					for shift := uint(0); ; shift += 7 {
+						if shift >= 64 {
+							return ErrIntOverflowPlacement
+						}
+						if iNdEx >= l {
+							return io.ErrUnexpectedEOF
+						}
+						b := dAtA[iNdEx]
+						iNdEx++
+						v |= (uint32(b) & 0x7F) << shift
+						if b < 0x80 {
+							break
+						}
+					}
+					m.AvailableShards = append(m.AvailableShards, v)
+				}
+			} else {
+				return fmt.Errorf("proto: wrong wireType = %d for field AvailableShards", wireType)
 			}
-			b := dAtA[iNdEx]
-			iNdEx++
-			wire |= (uint64(b) & 0x7F) << shift
-			if b < 0x80 {
-				break
+		case 5:
+			if wireType == 0 {
+				var v uint32
+				for shift := uint(0); ; shift += 7 {
+					if shift >= 64 {
+						return ErrIntOverflowPlacement
+					}
+					if iNdEx >= l {
+						return io.ErrUnexpectedEOF
+					}
+					b := dAtA[iNdEx]
+					iNdEx++
+					v |= (uint32(b) & 0x7F) << shift
+					if b < 0x80 {
+						break
+					}
+				}
+				m.LeavingShards = append(m.LeavingShards, v)
+			} else if wireType == 2 {
+				var packedLen int
+				for shift := uint(0); ; shift += 7 {
+					if shift >= 64 {
+						return ErrIntOverflowPlacement
+					}
+					if iNdEx >= l {
+						return io.ErrUnexpectedEOF
+					}
+					b := dAtA[iNdEx]
+					iNdEx++
+					packedLen |= (int(b) & 0x7F) << shift
+					if b < 0x80 {
+						break
+					}
+				}
+				if packedLen < 0 {
+					return ErrInvalidLengthPlacement
+				}
+				postIndex := iNdEx + packedLen
+				if postIndex > l {
+					return io.ErrUnexpectedEOF
+				}
+				for iNdEx < postIndex {
+					var v uint32
+					for shift := uint(0); ; shift += 7 {
+						if shift >= 64 {
+							return ErrIntOverflowPlacement
+						}
+						if iNdEx >= l {
+							return io.ErrUnexpectedEOF
+						}
+						b := dAtA[iNdEx]
+						iNdEx++
+						v |= (uint32(b) & 0x7F) << shift
+						if b < 0x80 {
+							break
+						}
+					}
+					m.LeavingShards = append(m.LeavingShards, v)
+				}
+			} else {
+				return fmt.Errorf("proto: wrong wireType = %d for field LeavingShards", wireType)
 			}
-		}
-		fieldNum := int32(wire >> 3)
-		wireType := int(wire & 0x7)
-		if wireType == 4 {
-			return fmt.Errorf("proto: PlacementAddRequest: wiretype end group for non-group")
-		}
-		if fieldNum <= 0 {
-			return fmt.Errorf("proto: PlacementAddRequest: illegal tag %d (wire type %d)", fieldNum, wire)
-		}
-		switch fieldNum {
-		case 1:
+		case 6:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Instances", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field HealthStatus", wireType)
 			}
-			var msglen int
+			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowPlacement
@@ -544,23 +1915,132 @@ func (m *PlacementAddRequest) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				msglen |= (int(b) & 0x7F) << shift
+				stringLen |= (uint64(b) & 0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if msglen < 0 {
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
 				return ErrInvalidLengthPlacement
 			}
-			postIndex := iNdEx + msglen
+			postIndex := iNdEx + intStringLen
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Instances = append(m.Instances, &placementpb.Instance{})
-			if err := m.Instances[len(m.Instances)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
+			m.HealthStatus = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 7:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Bootstrapped", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPlacement
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.Bootstrapped = bool(v != 0)
+		case 8:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Error", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPlacement
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthPlacement
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
 			}
+			m.Error = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
+		case 9:
+			if wireType == 0 {
+				var v uint32
+				for shift := uint(0); ; shift += 7 {
+					if shift >= 64 {
+						return ErrIntOverflowPlacement
+					}
+					if iNdEx >= l {
+						return io.ErrUnexpectedEOF
+					}
+					b := dAtA[iNdEx]
+					iNdEx++
+					v |= (uint32(b) & 0x7F) << shift
+					if b < 0x80 {
+						break
+					}
+				}
+				m.BootstrappedShards = append(m.BootstrappedShards, v)
+			} else if wireType == 2 {
+				var packedLen int
+				for shift := uint(0); ; shift += 7 {
+					if shift >= 64 {
+						return ErrIntOverflowPlacement
+					}
+					if iNdEx >= l {
+						return io.ErrUnexpectedEOF
+					}
+					b := dAtA[iNdEx]
+					iNdEx++
+					packedLen |= (int(b) & 0x7F) << shift
+					if b < 0x80 {
+						break
+					}
+				}
+				if packedLen < 0 {
+					return ErrInvalidLengthPlacement
+				}
+				postIndex := iNdEx + packedLen
+				if postIndex > l {
+					return io.ErrUnexpectedEOF
+				}
+				for iNdEx < postIndex {
+					var v uint32
+					for shift := uint(0); ; shift += 7 {
+						if shift >= 64 {
+							return ErrIntOverflowPlacement
+						}
+						if iNdEx >= l {
+							return io.ErrUnexpectedEOF
+						}
+						b := dAtA[iNdEx]
+						iNdEx++
+						v |= (uint32(b) & 0x7F) << shift
+						if b < 0x80 {
+							break
+						}
+					}
+					m.BootstrappedShards = append(m.BootstrappedShards, v)
+				}
+			} else {
+				return fmt.Errorf("proto: wrong wireType = %d for field BootstrappedShards", wireType)
+			}
 		default:
 			iNdEx = preIndex
 			skippy, err := skipPlacement(dAtA[iNdEx:])
@@ -692,24 +2172,43 @@ func init() {
 }
 
 var fileDescriptorPlacement = []byte{
-	// 300 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xa4, 0x90, 0xcd, 0x4a, 0x3b, 0x31,
-	0x14, 0xc5, 0xff, 0xf9, 0x97, 0x2a, 0x4d, 0x37, 0x1a, 0x3f, 0x18, 0x04, 0x87, 0xd2, 0x55, 0x37,
-	0x4e, 0xa0, 0xe3, 0x0b, 0x58, 0x50, 0xa9, 0x2b, 0x19, 0x1f, 0xa0, 0x64, 0x92, 0xdb, 0x36, 0xd0,
-	0x24, 0xd3, 0x7c, 0x08, 0xbe, 0x85, 0x5b, 0xdf, 0xc8, 0xa5, 0x8f, 0x20, 0xe3, 0x8b, 0x08, 0xa1,
-	0x9d, 0x8e, 0x76, 0xe9, 0xf2, 0x9e, 0x73, 0xee, 0x8f, 0xc3, 0xc1, 0x93, 0x85, 0xf4, 0xcb, 0x50,
-	0x66, 0xdc, 0x28, 0xaa, 0x72, 0x51, 0x52, 0x95, 0x53, 0x67, 0x39, 0x5d, 0x07, 0xb0, 0x2f, 0x74,
-	0x01, 0x1a, 0x2c, 0xf3, 0x20, 0x68, 0x65, 0x8d, 0x37, 0x94, 0x09, 0x25, 0x35, 0xad, 0x56, 0x8c,
-	0x83, 0x02, 0xed, 0xb3, 0xa8, 0x92, 0x6e, 0x94, 0x2f, 0x6e, 0xf7, 0x51, 0x7c, 0x15, 0x9c, 0x07,
-	0xbb, 0xc7, 0x69, 0x08, 0x55, 0xf9, 0x9b, 0x36, 0x7c, 0x43, 0xf8, 0xf4, 0x71, 0xab, 0x4d, 0xb5,
-	0xf4, 0x05, 0xac, 0x03, 0x38, 0x4f, 0x72, 0xdc, 0x93, 0xda, 0x79, 0xa6, 0x39, 0xb8, 0x04, 0x0d,
-	0x3a, 0xa3, 0xfe, 0xf8, 0x2c, 0x6b, 0x91, 0xb2, 0xe9, 0xc6, 0x2d, 0x76, 0x39, 0x72, 0x89, 0xb1,
-	0x0e, 0x6a, 0xe6, 0x96, 0xcc, 0x0a, 0x97, 0xfc, 0x1f, 0xa0, 0x51, 0xb7, 0xe8, 0xe9, 0xa0, 0x9e,
-	0xa2, 0x40, 0xae, 0x30, 0xb1, 0x50, 0xad, 0x24, 0x67, 0x5e, 0x1a, 0x3d, 0x9b, 0x33, 0xee, 0x8d,
-	0x4d, 0x3a, 0x31, 0x76, 0xdc, 0x72, 0xee, 0xa2, 0x31, 0x9c, 0xb7, 0xaa, 0xdd, 0x83, 0x2f, 0xc0,
-	0x55, 0x46, 0x3b, 0x20, 0xd7, 0xb8, 0xd7, 0x14, 0x49, 0xd0, 0x00, 0x8d, 0xfa, 0xe3, 0xf3, 0x1f,
-	0xd5, 0x9a, 0xaf, 0x62, 0x17, 0x24, 0x09, 0x3e, 0x7c, 0x06, 0xeb, 0xa4, 0xd1, 0x9b, 0x62, 0xdb,
-	0x73, 0xf8, 0x80, 0x4f, 0x9a, 0x8f, 0x1b, 0x21, 0xfe, 0xb2, 0xc0, 0xe4, 0xe8, 0xbd, 0x4e, 0xd1,
-	0x47, 0x9d, 0xa2, 0xcf, 0x3a, 0x45, 0xaf, 0x5f, 0xe9, 0xbf, 0xf2, 0x20, 0x0e, 0x9d, 0x7f, 0x07,
-	0x00, 0x00, 0xff, 0xff, 0x8b, 0x10, 0xf7, 0x35, 0xfc, 0x01, 0x00, 0x00,
+	// 606 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0x03, 0xa5, 0x54, 0x41, 0x6f, 0xd3, 0x30,
+	0x14, 0x26, 0x0d, 0xdd, 0x9a, 0xb7, 0x75, 0x0c, 0xaf, 0x40, 0x35, 0xb4, 0x32, 0x05, 0x21, 0x6d,
+	0x07, 0x12, 0x44, 0xe1, 0xc6, 0x65, 0x43, 0x80, 0x8a, 0x84, 0x84, 0xb2, 0x33, 0xaa, 0x9c, 0xc4,
+	0x6b, 0x2d, 0x52, 0x27, 0xb3, 0xdd, 0x4a, 0x70, 0xe4, 0x17, 0x70, 0xe5, 0xef, 0x70, 0xe2, 0xc8,
+	0x91, 0x23, 0x82, 0x3f, 0x82, 0xe3, 0x38, 0xa9, 0x4b, 0x01, 0x81, 0x38, 0x24, 0x8a, 0xbf, 0xf7,
+	0xf9, 0xf9, 0x7b, 0xdf, 0x7b, 0x0e, 0x9c, 0x4e, 0xa8, 0x9c, 0xce, 0xe3, 0x20, 0xc9, 0x67, 0xe1,
+	0x6c, 0x98, 0xc6, 0xea, 0x15, 0x0a, 0x9e, 0x84, 0x17, 0x73, 0xc2, 0xdf, 0x84, 0x13, 0xc2, 0x08,
+	0xc7, 0x92, 0xa4, 0x61, 0xc1, 0x73, 0x99, 0x87, 0x38, 0x9d, 0x51, 0x16, 0x16, 0x19, 0x4e, 0xc8,
+	0x8c, 0x30, 0x19, 0x68, 0x14, 0xb5, 0x35, 0xbc, 0xff, 0x64, 0x3d, 0x55, 0x92, 0xcd, 0x85, 0x24,
+	0x7c, 0x2d, 0x4f, 0x93, 0xa1, 0x88, 0x7f, 0xce, 0xe6, 0x7f, 0x70, 0xa0, 0xf7, 0xb2, 0xc6, 0x46,
+	0x8c, 0xca, 0x88, 0x28, 0x31, 0x42, 0xa2, 0x21, 0x78, 0x94, 0x09, 0x89, 0x59, 0x42, 0x44, 0xdf,
+	0x39, 0x74, 0x8f, 0xb6, 0xee, 0x5f, 0x0b, 0xac, 0x4c, 0xc1, 0xc8, 0x44, 0xa3, 0x25, 0x0f, 0x1d,
+	0x00, 0xb0, 0xf9, 0x6c, 0x2c, 0xa6, 0x98, 0xa7, 0xa2, 0xdf, 0x3a, 0x74, 0x8e, 0xda, 0x91, 0xa7,
+	0x90, 0x33, 0x0d, 0xa0, 0xbb, 0x80, 0x38, 0x29, 0x32, 0x9a, 0x60, 0x49, 0x73, 0x36, 0x3e, 0xc7,
+	0x89, 0xcc, 0x79, 0xdf, 0xd5, 0xb4, 0xab, 0x56, 0xe4, 0xa9, 0x0e, 0xf8, 0xe7, 0x96, 0xb4, 0x67,
+	0x44, 0x29, 0x13, 0x45, 0xce, 0x04, 0x41, 0x0f, 0xc0, 0x6b, 0x84, 0x28, 0x69, 0x8e, 0x92, 0x76,
+	0x7d, 0x45, 0x5a, 0xb3, 0x2b, 0x5a, 0x12, 0x51, 0x1f, 0x36, 0x17, 0x84, 0x0b, 0x95, 0xde, 0x08,
+	0xab, 0x97, 0xfe, 0x73, 0xd8, 0x6b, 0x76, 0x9c, 0xa4, 0xe9, 0xff, 0x38, 0xe0, 0xbf, 0x73, 0xe0,
+	0xc6, 0xf2, 0x78, 0xa2, 0xe9, 0x75, 0xc2, 0x7b, 0xd0, 0xcb, 0x08, 0x5e, 0x50, 0x36, 0x19, 0xd7,
+	0x1b, 0xc6, 0x34, 0xad, 0x72, 0x7b, 0x11, 0x32, 0xb1, 0x3a, 0xeb, 0x48, 0x19, 0xf6, 0x10, 0x20,
+	0xc1, 0x2c, 0xa5, 0xa9, 0xea, 0x66, 0xe9, 0xe7, 0x1f, 0x34, 0x58, 0x44, 0xff, 0x15, 0x1c, 0x34,
+	0x1a, 0x5e, 0x60, 0xfe, 0xfa, 0x64, 0x81, 0x69, 0x86, 0xe3, 0xac, 0x51, 0x72, 0x0b, 0xb6, 0x2c,
+	0x05, 0xda, 0x43, 0x2f, 0x02, 0xda, 0x9c, 0x8c, 0x6e, 0x82, 0xa7, 0x9b, 0xa8, 0xf5, 0x95, 0xe7,
+	0x76, 0xa3, 0x8e, 0x06, 0x94, 0x2a, 0xff, 0x63, 0xcb, 0xaa, 0xf1, 0x4c, 0x62, 0x39, 0x17, 0x4d,
+	0x6f, 0x2c, 0x97, 0x9d, 0x15, 0x97, 0x7f, 0x31, 0x1b, 0x5d, 0x7b, 0x36, 0x42, 0xd8, 0xa3, 0x6a,
+	0xfc, 0x28, 0xce, 0xe8, 0xdb, 0xd2, 0x21, 0xc3, 0x73, 0x35, 0x0f, 0xd9, 0x21, 0xb3, 0xe1, 0x18,
+	0x76, 0x71, 0x5d, 0x57, 0xcd, 0xbe, 0xac, 0xd9, 0x57, 0x1a, 0xdc, 0x50, 0xef, 0xc0, 0x4e, 0x6d,
+	0xbc, 0x21, 0xb6, 0x35, 0xb1, 0x6b, 0x50, 0x7b, 0x3c, 0x63, 0x9c, 0x69, 0x5b, 0xd4, 0xbd, 0x2a,
+	0x32, 0x22, 0x49, 0x7f, 0x43, 0x51, 0x3b, 0xe5, 0x78, 0x9a, 0xc8, 0x63, 0x13, 0x40, 0x8f, 0xec,
+	0xf9, 0xd8, 0xd4, 0xbd, 0x19, 0x04, 0xfa, 0x72, 0x06, 0xd6, 0x8d, 0xaa, 0x08, 0xc6, 0x25, 0x6b,
+	0x50, 0xbe, 0xd8, 0x26, 0xae, 0xd2, 0xd0, 0x0e, 0xb4, 0x9a, 0xae, 0xa8, 0x2f, 0xb4, 0x0f, 0x1d,
+	0xc2, 0xd2, 0x22, 0xa7, 0x6a, 0xde, 0x5b, 0x1a, 0x6d, 0xd6, 0xbf, 0xf7, 0xcd, 0xfd, 0x27, 0xdf,
+	0xdc, 0xbf, 0xf5, 0xcd, 0x5d, 0xf7, 0xed, 0x36, 0x74, 0xa7, 0x04, 0x67, 0x72, 0x3a, 0x16, 0x5a,
+	0xbf, 0xb6, 0xcc, 0x8b, 0xb6, 0x2b, 0xd0, 0xd4, 0xe4, 0xc3, 0x76, 0x9c, 0xe7, 0x52, 0x48, 0x8e,
+	0x8b, 0x82, 0xa4, 0xca, 0xb0, 0xd2, 0xd6, 0x15, 0x0c, 0xf5, 0xa0, 0x4d, 0x38, 0x57, 0xbf, 0x84,
+	0x8e, 0x4e, 0x50, 0x2d, 0xca, 0x0a, 0x6d, 0x56, 0x2d, 0xc5, 0xab, 0x2a, 0xb4, 0x43, 0x95, 0x9e,
+	0xd3, 0xdd, 0x4f, 0xdf, 0x06, 0xce, 0x67, 0xf5, 0x7c, 0x55, 0xcf, 0xfb, 0xef, 0x83, 0x4b, 0xf1,
+	0x86, 0xfe, 0xd9, 0x0d, 0x7f, 0x00, 0x3c, 0x8b, 0xd0, 0x44, 0x80, 0x05, 0x00, 0x00,
 }
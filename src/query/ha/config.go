@@ -0,0 +1,92 @@
+// Copyright (c) 2018 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package ha
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/uber-go/tally"
+)
+
+// StoreType is the type of store used for elections.
+type StoreType string
+
+const (
+	// MemStoreType stores elections in memory, only suitable when a single
+	// coordinator receives writes from both replicas.
+	MemStoreType StoreType = "memory"
+	// KVStoreType stores elections in the cluster KV store so that they are
+	// shared by all coordinators.
+	KVStoreType StoreType = "kv"
+)
+
+// Configuration is the configuration for deduplicating writes from highly
+// available Prometheus pairs.
+type Configuration struct {
+	// ReplicaLabel is the label identifying the replica.
+	ReplicaLabel string `yaml:"replicaLabel"`
+
+	// ClusterLabel is the label identifying the cluster.
+	ClusterLabel string `yaml:"clusterLabel"`
+
+	// FailoverTimeout is the time since the last sample from the elected
+	// replica after which another replica is elected.
+	FailoverTimeout time.Duration `yaml:"failoverTimeout"`
+
+	// UpdateInterval is the interval at which elections are refreshed.
+	UpdateInterval time.Duration `yaml:"updateInterval"`
+
+	// Store is the type of store used for elections, defaults to memory.
+	Store StoreType `yaml:"store"`
+
+	// KeyPrefix is the prefix of the keys of elections in the KV store.
+	KeyPrefix string `yaml:"keyPrefix"`
+}
+
+// NewDeduper returns a new deduper from the configuration, the KV store
+// function is required when using the KV store.
+func (c Configuration) NewDeduper(
+	kvStoreFn KVStoreFn,
+	scope tally.Scope,
+) (*Deduper, error) {
+	var store Store
+	switch c.Store {
+	case "", MemStoreType:
+		store = NewMemStore()
+	case KVStoreType:
+		if kvStoreFn == nil {
+			return nil, fmt.Errorf("ha store %s requires a cluster client", c.Store)
+		}
+		store = NewKVStore(kvStoreFn, c.KeyPrefix)
+	default:
+		return nil, fmt.Errorf("unknown ha store type: %s", c.Store)
+	}
+
+	return NewDeduper(Options{
+		ReplicaLabel:    c.ReplicaLabel,
+		ClusterLabel:    c.ClusterLabel,
+		FailoverTimeout: c.FailoverTimeout,
+		UpdateInterval:  c.UpdateInterval,
+		Store:           store,
+		Scope:           scope,
+	}), nil
+}
@@ -0,0 +1,230 @@
+// Copyright (c) 2018 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package ha
+
+import (
+	"sync"
+	"time"
+
+	"github.com/m3db/m3/src/query/generated/proto/prompb"
+
+	"github.com/uber-go/tally"
+)
+
+const (
+	// DefaultReplicaLabel is the default label identifying the replica of
+	// a highly available Prometheus pair.
+	DefaultReplicaLabel = "__replica__"
+	// DefaultClusterLabel is the default label identifying the cluster of
+	// a highly available Prometheus pair.
+	DefaultClusterLabel = "cluster"
+	// DefaultFailoverTimeout is the default time since the last sample from
+	// the elected replica after which another replica is elected.
+	DefaultFailoverTimeout = 30 * time.Second
+	// DefaultUpdateInterval is the default interval at which the election is
+	// refreshed from and written to the store.
+	DefaultUpdateInterval = 15 * time.Second
+)
+
+// Options are the options for a deduper.
+type Options struct {
+	// ReplicaLabel is the label identifying the replica.
+	ReplicaLabel string
+	// ClusterLabel is the label identifying the cluster.
+	ClusterLabel string
+	// FailoverTimeout is the time since the last sample from the elected
+	// replica after which another replica is elected.
+	FailoverTimeout time.Duration
+	// UpdateInterval is the interval at which the election is refreshed
+	// from and written to the store, it should be less than the failover
+	// timeout.
+	UpdateInterval time.Duration
+	// Store is the store of elections.
+	Store Store
+	// Scope is the metrics scope.
+	Scope tally.Scope
+	// NowFn returns the current time.
+	NowFn func() time.Time
+}
+
+func (o Options) withDefaults() Options {
+	if o.ReplicaLabel == "" {
+		o.ReplicaLabel = DefaultReplicaLabel
+	}
+	if o.ClusterLabel == "" {
+		o.ClusterLabel = DefaultClusterLabel
+	}
+	if o.FailoverTimeout <= 0 {
+		o.FailoverTimeout = DefaultFailoverTimeout
+	}
+	if o.UpdateInterval <= 0 {
+		o.UpdateInterval = DefaultUpdateInterval
+	}
+	if o.Store == nil {
+		o.Store = NewMemStore()
+	}
+	if o.Scope == nil {
+		o.Scope = tally.NoopScope
+	}
+	if o.NowFn == nil {
+		o.NowFn = time.Now
+	}
+	return o
+}
+
+type dedupMetrics struct {
+	accepted  tally.Counter
+	dropped   tally.Counter
+	failovers tally.Counter
+	errors    tally.Counter
+}
+
+func newDedupMetrics(scope tally.Scope) dedupMetrics {
+	return dedupMetrics{
+		accepted:  scope.Counter("accepted"),
+		dropped:   scope.Counter("dropped"),
+		failovers: scope.Counter("failovers"),
+		errors:    scope.Counter("errors"),
+	}
+}
+
+type cachedElection struct {
+	election  Election
+	checkedAt time.Time
+}
+
+// Deduper accepts samples from the elected replica of each cluster and drops
+// samples from all other replicas.
+type Deduper struct {
+	mu      sync.RWMutex
+	opts    Options
+	cache   map[string]cachedElection
+	metrics dedupMetrics
+}
+
+// NewDeduper returns a new deduper.
+func NewDeduper(opts Options) *Deduper {
+	opts = opts.withDefaults()
+	return &Deduper{
+		opts:    opts,
+		cache:   make(map[string]cachedElection),
+		metrics: newDedupMetrics(opts.Scope),
+	}
+}
+
+// Accept returns true if samples from the replica of the cluster should be
+// written, electing the replica if no replica is elected or the elected
+// replica has not sent samples within the failover timeout.
+func (d *Deduper) Accept(cluster, replica string) (bool, error) {
+	now := d.opts.NowFn()
+
+	// Use the cached election until it needs to be refreshed, this avoids
+	// going to the store for every write
+	d.mu.RLock()
+	cached, ok := d.cache[cluster]
+	d.mu.RUnlock()
+	if ok && now.Sub(cached.checkedAt) < d.opts.UpdateInterval {
+		if cached.election.Replica == replica {
+			return true, nil
+		}
+		if now.Sub(cached.election.LastSampleAt) <= d.opts.FailoverTimeout {
+			return false, nil
+		}
+	}
+
+	var failover bool
+	election, err := d.opts.Store.Update(cluster, func(
+		current Election,
+		exists bool,
+	) (Election, bool) {
+		failover = false
+		next := Election{Replica: replica, LastSampleAt: now}
+		switch {
+		case !exists:
+			return next, true
+		case current.Replica == replica:
+			return next, true
+		case now.Sub(current.LastSampleAt) > d.opts.FailoverTimeout:
+			failover = true
+			return next, true
+		default:
+			return current, false
+		}
+	})
+	if err != nil {
+		d.metrics.errors.Inc(1)
+		return false, err
+	}
+
+	if failover {
+		d.metrics.failovers.Inc(1)
+	}
+
+	d.mu.Lock()
+	d.cache[cluster] = cachedElection{election: election, checkedAt: now}
+	d.mu.Unlock()
+
+	return election.Replica == replica, nil
+}
+
+// Filter removes the series sent by replicas that are not elected and
+// strips the replica label from the series sent by elected replicas. Series
+// without both a cluster and replica label are always kept. The series are
+// filtered in place.
+func (d *Deduper) Filter(series []*prompb.TimeSeries) ([]*prompb.TimeSeries, error) {
+	filtered := series[:0]
+	for _, ts := range series {
+		var (
+			cluster, replica string
+			hasCluster       bool
+			replicaIdx       = -1
+		)
+		for i, label := range ts.Labels {
+			switch label.Name {
+			case d.opts.ClusterLabel:
+				cluster, hasCluster = label.Value, true
+			case d.opts.ReplicaLabel:
+				replica, replicaIdx = label.Value, i
+			}
+		}
+
+		if !hasCluster || replicaIdx < 0 {
+			filtered = append(filtered, ts)
+			continue
+		}
+
+		accept, err := d.Accept(cluster, replica)
+		if err != nil {
+			return nil, err
+		}
+
+		if !accept {
+			d.metrics.dropped.Inc(int64(len(ts.Samples)))
+			continue
+		}
+
+		d.metrics.accepted.Inc(int64(len(ts.Samples)))
+		ts.Labels = append(ts.Labels[:replicaIdx], ts.Labels[replicaIdx+1:]...)
+		filtered = append(filtered, ts)
+	}
+
+	return filtered, nil
+}
@@ -0,0 +1,178 @@
+// Copyright (c) 2018 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package ha
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/m3db/m3/src/query/generated/proto/prompb"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type testClock struct {
+	now time.Time
+}
+
+func (c *testClock) Now() time.Time {
+	return c.now
+}
+
+func (c *testClock) Add(d time.Duration) {
+	c.now = c.now.Add(d)
+}
+
+func newTestDeduper(store Store) (*Deduper, *testClock) {
+	clock := &testClock{now: time.Unix(1000, 0)}
+	return NewDeduper(Options{
+		FailoverTimeout: 30 * time.Second,
+		UpdateInterval:  10 * time.Second,
+		Store:           store,
+		NowFn:           clock.Now,
+	}), clock
+}
+
+func TestDeduperElectsFirstReplica(t *testing.T) {
+	d, clock := newTestDeduper(NewMemStore())
+
+	accept, err := d.Accept("c1", "a")
+	require.NoError(t, err)
+	assert.True(t, accept)
+
+	accept, err = d.Accept("c1", "b")
+	require.NoError(t, err)
+	assert.False(t, accept)
+
+	// Other clusters elect independently
+	accept, err = d.Accept("c2", "b")
+	require.NoError(t, err)
+	assert.True(t, accept)
+
+	// Elected replica keeps the election while sending samples
+	for i := 0; i < 10; i++ {
+		clock.Add(10 * time.Second)
+		accept, err = d.Accept("c1", "a")
+		require.NoError(t, err)
+		assert.True(t, accept)
+
+		accept, err = d.Accept("c1", "b")
+		require.NoError(t, err)
+		assert.False(t, accept)
+	}
+}
+
+func TestDeduperFailover(t *testing.T) {
+	d, clock := newTestDeduper(NewMemStore())
+
+	accept, err := d.Accept("c1", "a")
+	require.NoError(t, err)
+	assert.True(t, accept)
+
+	clock.Add(20 * time.Second)
+	accept, err = d.Accept("c1", "b")
+	require.NoError(t, err)
+	assert.False(t, accept)
+
+	// Replica a stops sending samples, b is elected after the timeout
+	clock.Add(15 * time.Second)
+	accept, err = d.Accept("c1", "b")
+	require.NoError(t, err)
+	assert.True(t, accept)
+
+	accept, err = d.Accept("c1", "a")
+	require.NoError(t, err)
+	assert.False(t, accept)
+}
+
+func TestDeduperSharedStore(t *testing.T) {
+	store := NewMemStore()
+	d1, _ := newTestDeduper(store)
+	d2, _ := newTestDeduper(store)
+
+	accept, err := d1.Accept("c1", "a")
+	require.NoError(t, err)
+	assert.True(t, accept)
+
+	// A second coordinator sees the same election
+	accept, err = d2.Accept("c1", "b")
+	require.NoError(t, err)
+	assert.False(t, accept)
+
+	accept, err = d2.Accept("c1", "a")
+	require.NoError(t, err)
+	assert.True(t, accept)
+}
+
+type errStore struct{}
+
+func (errStore) Update(string, UpdateFn) (Election, error) {
+	return Election{}, errors.New("store unavailable")
+}
+
+func TestDeduperStoreError(t *testing.T) {
+	d, _ := newTestDeduper(errStore{})
+	_, err := d.Accept("c1", "a")
+	assert.Error(t, err)
+}
+
+func newTestSeries(labels ...string) *prompb.TimeSeries {
+	ts := &prompb.TimeSeries{
+		Samples: []*prompb.Sample{{Value: 1, Timestamp: 1}},
+	}
+	for i := 0; i < len(labels); i += 2 {
+		ts.Labels = append(ts.Labels, &prompb.Label{
+			Name:  labels[i],
+			Value: labels[i+1],
+		})
+	}
+	return ts
+}
+
+func TestDeduperFilter(t *testing.T) {
+	d, _ := newTestDeduper(NewMemStore())
+
+	series := []*prompb.TimeSeries{
+		newTestSeries("__name__", "up", "cluster", "c1", "__replica__", "a"),
+		newTestSeries("__name__", "up", "cluster", "c1", "__replica__", "b"),
+		newTestSeries("__name__", "up", "cluster", "c1"),
+		newTestSeries("__name__", "up", "__replica__", "b"),
+	}
+
+	filtered, err := d.Filter(series)
+	require.NoError(t, err)
+	require.Len(t, filtered, 3)
+
+	assert.Equal(t, []*prompb.Label{
+		{Name: "__name__", Value: "up"},
+		{Name: "cluster", Value: "c1"},
+	}, filtered[0].Labels)
+	assert.Equal(t, []*prompb.Label{
+		{Name: "__name__", Value: "up"},
+		{Name: "cluster", Value: "c1"},
+	}, filtered[1].Labels)
+	assert.Equal(t, []*prompb.Label{
+		{Name: "__name__", Value: "up"},
+		{Name: "__replica__", Value: "b"},
+	}, filtered[2].Labels)
+}
@@ -0,0 +1,139 @@
+// Copyright (c) 2018 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package ha
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/m3db/m3cluster/generated/proto/commonpb"
+	"github.com/m3db/m3cluster/kv"
+)
+
+const (
+	defaultKVKeyPrefix = "_ha.elections"
+	maxKVUpdateRetries = 5
+)
+
+var (
+	errKVUpdateConflict = errors.New("unable to update election, too many conflicting updates")
+)
+
+// KVStoreFn returns the KV store to use, it is resolved lazily so that the
+// cluster client does not need to be connected at construction.
+type KVStoreFn func() (kv.Store, error)
+
+type kvStore struct {
+	storeFn   KVStoreFn
+	keyPrefix string
+}
+
+// NewKVStore returns a new Store backed by a KV store, elections are shared
+// by all coordinators using the same KV store and key prefix.
+func NewKVStore(storeFn KVStoreFn, keyPrefix string) Store {
+	if keyPrefix == "" {
+		keyPrefix = defaultKVKeyPrefix
+	}
+	return &kvStore{storeFn: storeFn, keyPrefix: keyPrefix}
+}
+
+func (s *kvStore) key(cluster string) string {
+	return fmt.Sprintf("%s/%s", s.keyPrefix, cluster)
+}
+
+func (s *kvStore) Update(cluster string, fn UpdateFn) (Election, error) {
+	store, err := s.storeFn()
+	if err != nil {
+		return Election{}, err
+	}
+
+	key := s.key(cluster)
+	for i := 0; i < maxKVUpdateRetries; i++ {
+		var (
+			current Election
+			version int
+			exists  bool
+		)
+		value, err := store.Get(key)
+		switch {
+		case err == kv.ErrNotFound:
+		case err != nil:
+			return Election{}, err
+		default:
+			exists = true
+			version = value.Version()
+			current, err = unmarshalElection(value)
+			if err != nil {
+				return Election{}, err
+			}
+		}
+
+		next, update := fn(current, exists)
+		if !update {
+			return current, nil
+		}
+
+		proto, err := marshalElection(next)
+		if err != nil {
+			return Election{}, err
+		}
+
+		if exists {
+			_, err = store.CheckAndSet(key, version, proto)
+		} else {
+			_, err = store.SetIfNotExists(key, proto)
+		}
+		if err == kv.ErrVersionMismatch || err == kv.ErrAlreadyExists {
+			// Another coordinator updated the election, retry with the
+			// latest value
+			continue
+		}
+		if err != nil {
+			return Election{}, err
+		}
+
+		return next, nil
+	}
+
+	return Election{}, errKVUpdateConflict
+}
+
+func marshalElection(e Election) (*commonpb.StringProto, error) {
+	data, err := json.Marshal(e)
+	if err != nil {
+		return nil, err
+	}
+	return &commonpb.StringProto{Value: string(data)}, nil
+}
+
+func unmarshalElection(value kv.Value) (Election, error) {
+	var proto commonpb.StringProto
+	if err := value.Unmarshal(&proto); err != nil {
+		return Election{}, err
+	}
+
+	var e Election
+	if err := json.Unmarshal([]byte(proto.Value), &e); err != nil {
+		return Election{}, fmt.Errorf("unable to parse election: %v", err)
+	}
+	return e, nil
+}
@@ -0,0 +1,48 @@
+// Copyright (c) 2018 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package ha
+
+import "sync"
+
+type memStore struct {
+	mu        sync.Mutex
+	elections map[string]Election
+}
+
+// NewMemStore returns a new in-memory Store, it is only suitable for a
+// single coordinator receiving writes from both replicas.
+func NewMemStore() Store {
+	return &memStore{elections: make(map[string]Election)}
+}
+
+func (s *memStore) Update(cluster string, fn UpdateFn) (Election, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	current, exists := s.elections[cluster]
+	next, update := fn(current, exists)
+	if !update {
+		return current, nil
+	}
+
+	s.elections[cluster] = next
+	return next, nil
+}
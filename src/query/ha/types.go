@@ -0,0 +1,48 @@
+// Copyright (c) 2018 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+// Package ha provides deduplication of writes from highly available pairs of
+// Prometheus replicas by electing a single replica per cluster.
+package ha
+
+import "time"
+
+// Election is the elected replica of a cluster.
+type Election struct {
+	// Replica is the elected replica.
+	Replica string `json:"replica"`
+	// LastSampleAt is the last time a sample from the elected replica was
+	// recorded, used to determine when to fail over to another replica.
+	LastSampleAt time.Time `json:"lastSampleAt"`
+}
+
+// UpdateFn returns the next election given the current election of a
+// cluster, exists is false if no replica has been elected yet. The returned
+// bool indicates whether the store should be updated with the next election.
+type UpdateFn func(current Election, exists bool) (next Election, update bool)
+
+// Store stores the elected replica of each cluster, implementations must
+// apply updates atomically so that only a single replica is elected per
+// cluster even when shared by many coordinators.
+type Store interface {
+	// Update atomically reads and conditionally updates the election of a
+	// cluster, returning the resulting election.
+	Update(cluster string, fn UpdateFn) (Election, error)
+}
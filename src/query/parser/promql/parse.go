@@ -123,6 +123,7 @@ func (p *parseState) walk(node pql.Node) error {
 	case *pql.Call:
 		expressions := n.Args
 		argValues := make([]interface{}, 0, len(expressions))
+		hasParent := false
 		for _, expr := range expressions {
 			switch e := expr.(type) {
 			case *pql.NumberLiteral:
@@ -136,6 +137,8 @@ func (p *parseState) walk(node pql.Node) error {
 			if err != nil {
 				return err
 			}
+
+			hasParent = true
 		}
 
 		op, err := NewFunctionExpr(n.Func.Name, argValues)
@@ -144,10 +147,13 @@ func (p *parseState) walk(node pql.Node) error {
 		}
 
 		opTransform := parser.NewTransformFromOperation(op, p.transformLen())
-		p.edges = append(p.edges, parser.Edge{
-			ParentID: p.lastTransformID(),
-			ChildID:  opTransform.ID,
-		})
+		// NB: functions without any series arguments, such as time(), are sources.
+		if hasParent {
+			p.edges = append(p.edges, parser.Edge{
+				ParentID: p.lastTransformID(),
+				ChildID:  opTransform.ID,
+			})
+		}
 		p.transforms = append(p.transforms, opTransform)
 		return nil
 
@@ -149,6 +149,49 @@ func TestLinearParses(t *testing.T) {
 	}
 }
 
+var sortParseTests = []struct {
+	q            string
+	expectedType string
+}{
+	{"sort(up)", functions.SortType},
+	{"sort_desc(up)", functions.SortDescType},
+	{"timestamp(up)", functions.TimestampType},
+	{"vector(scalar(up))", functions.VectorType},
+}
+
+func TestSortAndConversionParses(t *testing.T) {
+	for _, tt := range sortParseTests {
+		t.Run(tt.q, func(t *testing.T) {
+			p, err := Parse(tt.q)
+			require.NoError(t, err)
+			transforms, edges, err := p.DAG()
+			require.NoError(t, err)
+			require.NotEmpty(t, transforms)
+			assert.Equal(t, transforms[0].Op.OpType(), functions.FetchType)
+			last := transforms[len(transforms)-1]
+			assert.Equal(t, last.Op.OpType(), tt.expectedType)
+			require.Len(t, edges, len(transforms)-1)
+			for i, edge := range edges {
+				assert.Equal(t, edge.ParentID, transforms[i].ID)
+				assert.Equal(t, edge.ChildID, transforms[i+1].ID)
+			}
+		})
+	}
+}
+
+func TestScalarSourceParses(t *testing.T) {
+	for _, q := range []string{"time()", "vector(1)"} {
+		t.Run(q, func(t *testing.T) {
+			p, err := Parse(q)
+			require.NoError(t, err)
+			transforms, edges, err := p.DAG()
+			require.NoError(t, err)
+			assert.Len(t, transforms, 1)
+			assert.Len(t, edges, 0)
+		})
+	}
+}
+
 var binaryParseTests = []struct {
 	q                string
 	LHSType, RHSType string
@@ -174,6 +217,10 @@ var binaryParseTests = []struct {
 	{"up and up", functions.FetchType, functions.FetchType, binary.AndType},
 	{"up or up", functions.FetchType, functions.FetchType, binary.OrType},
 	{"up unless up", functions.FetchType, functions.FetchType, binary.UnlessType},
+
+	// Sources
+	{"time() - up", functions.TimeType, functions.FetchType, binary.MinusType},
+	{"up + vector(1)", functions.FetchType, functions.VectorType, binary.PlusType},
 }
 
 func TestBinaryParses(t *testing.T) {
@@ -247,8 +294,14 @@ var temporalParseTests = []struct {
 	{"sum_over_time(up[5m])", temporal.SumTemporalType},
 	{"stddev_over_time(up[5m])", temporal.StdDevTemporalType},
 	{"stdvar_over_time(up[5m])", temporal.StdVarTemporalType},
+	{"quantile_over_time(0.5, up[5m])", temporal.QuantileTemporalType},
+	{"changes(up[5m])", temporal.ChangesType},
+	{"resets(up[5m])", temporal.ResetsType},
 	{"irate(up[5m])", temporal.IRateType},
 	{"idelta(up[5m])", temporal.IDeltaType},
+	{"deriv(up[5m])", temporal.DerivType},
+	{"predict_linear(up[5m], 100)", temporal.PredictLinearType},
+	{"holt_winters(up[5m], 0.2, 0.5)", temporal.HoltWintersType},
 }
 
 func TestTemporalParses(t *testing.T) {
@@ -276,3 +329,13 @@ func TestFailedTemporalParse(t *testing.T) {
 	_, err := Parse(q)
 	require.Error(t, err)
 }
+
+func TestFailedHoltWintersParse(t *testing.T) {
+	for _, q := range []string{
+		"holt_winters(up[5m], 1, 0.5)",
+		"holt_winters(up[5m], 0.5, 0)",
+	} {
+		_, err := Parse(q)
+		require.Error(t, err, q)
+	}
+}
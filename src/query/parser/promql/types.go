@@ -169,12 +169,33 @@ func NewFunctionExpr(name string, argValues []interface{}) (parser.Params, error
 
 	case temporal.AvgTemporalType, temporal.CountTemporalType, temporal.MinTemporalType,
 		temporal.MaxTemporalType, temporal.SumTemporalType, temporal.StdDevTemporalType,
-		temporal.StdVarTemporalType:
+		temporal.StdVarTemporalType, temporal.ChangesType, temporal.ResetsType:
 		return temporal.NewAggOp(argValues, name)
 
+	case temporal.QuantileTemporalType:
+		return temporal.NewQuantileOp(argValues, name)
+
 	case temporal.IRateType, temporal.IDeltaType:
 		return temporal.NewRateOp(argValues, name)
 
+	case temporal.DerivType, temporal.PredictLinearType:
+		return temporal.NewLinearRegressionOp(argValues, name)
+
+	case temporal.HoltWintersType:
+		return temporal.NewHoltWintersOp(argValues)
+
+	case functions.ScalarType, functions.VectorType:
+		return functions.NewConversionOp(argValues, name)
+
+	case functions.TimeType:
+		return functions.NewTimeOp(), nil
+
+	case functions.TimestampType:
+		return functions.NewTimestampOp(), nil
+
+	case functions.SortType, functions.SortDescType:
+		return functions.NewSortOp(name)
+
 	default:
 		// TODO: handle other types
 		return nil, fmt.Errorf("function not supported: %s", name)
@@ -0,0 +1,81 @@
+// Copyright (c) 2018 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package plan
+
+import (
+	"time"
+
+	"github.com/m3db/m3/src/query/parser"
+)
+
+// StepDescription is a serializable description of a step in a plan
+type StepDescription struct {
+	ID       parser.NodeID   `json:"id"`
+	Type     string          `json:"type"`
+	Op       string          `json:"op"`
+	Parents  []parser.NodeID `json:"parents,omitempty"`
+	Children []parser.NodeID `json:"children,omitempty"`
+}
+
+// PhysicalDescription is a serializable description of a physical plan
+type PhysicalDescription struct {
+	Steps        []StepDescription `json:"steps"`
+	ResultParent parser.NodeID     `json:"resultParent"`
+	Start        time.Time         `json:"start"`
+	End          time.Time         `json:"end"`
+	Step         string            `json:"step"`
+}
+
+// Describe returns a description of each step in the logical plan in pipeline order
+func (l LogicalPlan) Describe() []StepDescription {
+	return describeSteps(l.Steps, l.Pipeline)
+}
+
+// Describe returns a description of the physical plan
+func (p PhysicalPlan) Describe() PhysicalDescription {
+	return PhysicalDescription{
+		Steps:        describeSteps(p.steps, p.pipeline),
+		ResultParent: p.ResultStep.Parent,
+		Start:        p.TimeSpec.Start,
+		End:          p.TimeSpec.End,
+		Step:         p.TimeSpec.Step.String(),
+	}
+}
+
+func describeSteps(steps map[parser.NodeID]LogicalStep, pipeline []parser.NodeID) []StepDescription {
+	descriptions := make([]StepDescription, 0, len(pipeline))
+	for _, id := range pipeline {
+		step, ok := steps[id]
+		if !ok {
+			continue
+		}
+
+		descriptions = append(descriptions, StepDescription{
+			ID:       step.ID(),
+			Type:     step.Transform.Op.OpType(),
+			Op:       step.Transform.Op.String(),
+			Parents:  step.Parents,
+			Children: step.Children,
+		})
+	}
+
+	return descriptions
+}
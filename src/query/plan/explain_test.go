@@ -0,0 +1,69 @@
+// Copyright (c) 2018 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package plan
+
+import (
+	"testing"
+	"time"
+
+	"github.com/m3db/m3/src/query/functions"
+	"github.com/m3db/m3/src/query/functions/aggregation"
+	"github.com/m3db/m3/src/query/models"
+	"github.com/m3db/m3/src/query/parser"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDescribe(t *testing.T) {
+	fetchTransform := parser.NewTransformFromOperation(functions.FetchOp{}, 1)
+	agg, err := aggregation.NewAggregationOp(aggregation.CountType, aggregation.NodeParams{})
+	require.NoError(t, err)
+	countTransform := parser.NewTransformFromOperation(agg, 2)
+	transforms := parser.Nodes{fetchTransform, countTransform}
+	edges := parser.Edges{
+		parser.Edge{
+			ParentID: fetchTransform.ID,
+			ChildID:  countTransform.ID,
+		},
+	}
+
+	lp, err := NewLogicalPlan(transforms, edges)
+	require.NoError(t, err)
+	steps := lp.Describe()
+	require.Len(t, steps, 2)
+	assert.Equal(t, fetchTransform.ID, steps[0].ID)
+	assert.Equal(t, functions.FetchType, steps[0].Type)
+	assert.Equal(t, []parser.NodeID{countTransform.ID}, steps[0].Children)
+	assert.Equal(t, countTransform.ID, steps[1].ID)
+	assert.Equal(t, aggregation.CountType, steps[1].Type)
+	assert.Equal(t, []parser.NodeID{fetchTransform.ID}, steps[1].Parents)
+
+	now := time.Now()
+	start := now.Add(-time.Hour)
+	p, err := NewPhysicalPlan(lp, nil, models.RequestParams{Now: now, Start: start, End: now, Step: time.Minute})
+	require.NoError(t, err)
+	desc := p.Describe()
+	assert.Len(t, desc.Steps, 2)
+	assert.Equal(t, countTransform.ID, desc.ResultParent)
+	assert.Equal(t, start, desc.Start)
+	assert.Equal(t, time.Minute.String(), desc.Step)
+}
@@ -0,0 +1,167 @@
+// Copyright (c) 2018 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package relabel
+
+import (
+	"fmt"
+	"regexp"
+)
+
+// Action is the action of a relabel rule.
+type Action string
+
+const (
+	// Replace sets the target label to the replacement, expanded with the
+	// regex capture groups, if the regex matches the source labels.
+	Replace Action = "replace"
+	// Keep drops series whose source labels do not match the regex.
+	Keep Action = "keep"
+	// Drop drops series whose source labels match the regex.
+	Drop Action = "drop"
+	// HashMod sets the target label to the modulus of a hash of the source
+	// labels.
+	HashMod Action = "hashmod"
+	// LabelMap copies the values of labels whose names match the regex to
+	// labels named by the replacement.
+	LabelMap Action = "labelmap"
+	// LabelDrop removes labels whose names match the regex.
+	LabelDrop Action = "labeldrop"
+	// LabelKeep removes labels whose names do not match the regex.
+	LabelKeep Action = "labelkeep"
+)
+
+const (
+	defaultSeparator   = ";"
+	defaultRegex       = "(.*)"
+	defaultReplacement = "$1"
+)
+
+// RuleConfiguration is the configuration of a relabel rule, it follows the
+// semantics of Prometheus relabel_configs.
+type RuleConfiguration struct {
+	// Name is the name of the rule used to tag its metrics, defaults to the
+	// index of the rule.
+	Name string `yaml:"name" json:"name"`
+
+	// SourceLabels are the labels whose values are concatenated and matched
+	// against the regex.
+	SourceLabels []string `yaml:"sourceLabels" json:"sourceLabels"`
+
+	// Separator is placed between the concatenated source label values,
+	// defaults to ";".
+	Separator *string `yaml:"separator" json:"separator"`
+
+	// Regex is matched against the concatenated source label values, or the
+	// label names for labelmap, labeldrop and labelkeep. Defaults to "(.*)".
+	Regex *string `yaml:"regex" json:"regex"`
+
+	// Modulus is the modulus to take of the hash of the source label values
+	// for hashmod.
+	Modulus uint64 `yaml:"modulus" json:"modulus"`
+
+	// TargetLabel is the label set by replace and hashmod.
+	TargetLabel string `yaml:"targetLabel" json:"targetLabel"`
+
+	// Replacement is the value the target label is set to by replace and the
+	// label name used by labelmap, defaults to "$1".
+	Replacement *string `yaml:"replacement" json:"replacement"`
+
+	// Action is the action to perform, defaults to replace.
+	Action Action `yaml:"action" json:"action"`
+}
+
+// NewRule returns a new rule from the configuration.
+func (c RuleConfiguration) NewRule() (*Rule, error) {
+	r := &Rule{
+		name:         c.Name,
+		sourceLabels: c.SourceLabels,
+		separator:    defaultSeparator,
+		modulus:      c.Modulus,
+		targetLabel:  c.TargetLabel,
+		replacement:  defaultReplacement,
+		action:       c.Action,
+	}
+	if c.Separator != nil {
+		r.separator = *c.Separator
+	}
+	if c.Replacement != nil {
+		r.replacement = *c.Replacement
+	}
+	if r.action == "" {
+		r.action = Replace
+	}
+
+	regex := defaultRegex
+	if c.Regex != nil {
+		regex = *c.Regex
+	}
+	re, err := regexp.Compile("^(?:" + regex + ")$")
+	if err != nil {
+		return nil, fmt.Errorf("invalid relabel regex %q: %v", regex, err)
+	}
+	r.regex = re
+
+	switch r.action {
+	case Replace:
+		if r.targetLabel == "" {
+			return nil, fmt.Errorf("relabel action %s requires a target label", r.action)
+		}
+	case HashMod:
+		if r.targetLabel == "" {
+			return nil, fmt.Errorf("relabel action %s requires a target label", r.action)
+		}
+		if r.modulus == 0 {
+			return nil, fmt.Errorf("relabel action %s requires a non-zero modulus", r.action)
+		}
+	case Keep, Drop, LabelMap, LabelDrop, LabelKeep:
+	default:
+		return nil, fmt.Errorf("unknown relabel action: %s", r.action)
+	}
+
+	return r, nil
+}
+
+// Configuration is the configuration for ingest time relabeling.
+type Configuration struct {
+	// Rules are the relabel rules applied in order to every written series.
+	Rules []RuleConfiguration `yaml:"rules"`
+
+	// KVKey is the key of the rules in the cluster KV store, if set the
+	// rules are reloaded whenever the key changes and take precedence over
+	// the static rules.
+	KVKey string `yaml:"kvKey"`
+}
+
+// NewRules returns the rules from a list of rule configurations.
+func NewRules(configs []RuleConfiguration) ([]*Rule, error) {
+	rules := make([]*Rule, 0, len(configs))
+	for i, config := range configs {
+		if config.Name == "" {
+			config.Name = fmt.Sprintf("%d", i)
+		}
+		rule, err := config.NewRule()
+		if err != nil {
+			return nil, fmt.Errorf("invalid relabel rule %s: %v", config.Name, err)
+		}
+		rules = append(rules, rule)
+	}
+	return rules, nil
+}
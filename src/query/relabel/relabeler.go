@@ -0,0 +1,204 @@
+// Copyright (c) 2018 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package relabel
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sync"
+
+	"github.com/m3db/m3/src/query/models"
+	"github.com/m3db/m3/src/query/util/logging"
+	"github.com/m3db/m3cluster/generated/proto/commonpb"
+	"github.com/m3db/m3cluster/kv"
+
+	"github.com/uber-go/tally"
+	"go.uber.org/zap"
+)
+
+type ruleMetrics struct {
+	seriesDropped tally.Counter
+	tagsDropped   tally.Counter
+}
+
+func newRuleMetrics(scope tally.Scope, rule *Rule) ruleMetrics {
+	scope = scope.Tagged(map[string]string{"rule": rule.Name()})
+	return ruleMetrics{
+		seriesDropped: scope.Counter("series-dropped"),
+		tagsDropped:   scope.Counter("tags-dropped"),
+	}
+}
+
+type activeRules struct {
+	rules   []*Rule
+	metrics []ruleMetrics
+}
+
+// Relabeler applies relabel rules to written series, the rules can be
+// replaced at any time.
+type Relabeler struct {
+	mu     sync.RWMutex
+	scope  tally.Scope
+	active activeRules
+}
+
+// NewRelabeler returns a new relabeler with the given rules.
+func NewRelabeler(rules []*Rule, scope tally.Scope) *Relabeler {
+	if scope == nil {
+		scope = tally.NoopScope
+	}
+	r := &Relabeler{scope: scope}
+	r.SetRules(rules)
+	return r
+}
+
+// SetRules replaces the rules of the relabeler.
+func (r *Relabeler) SetRules(rules []*Rule) {
+	active := activeRules{
+		rules:   rules,
+		metrics: make([]ruleMetrics, 0, len(rules)),
+	}
+	for _, rule := range rules {
+		active.metrics = append(active.metrics, newRuleMetrics(r.scope, rule))
+	}
+
+	r.mu.Lock()
+	r.active = active
+	r.mu.Unlock()
+}
+
+// Relabel applies the rules to the tags in order, returning the resulting
+// sorted tags and false if the series should be dropped. The input tags are
+// not modified.
+func (r *Relabeler) Relabel(tags models.Tags) (models.Tags, bool) {
+	r.mu.RLock()
+	active := r.active
+	r.mu.RUnlock()
+
+	if len(active.rules) == 0 {
+		return tags, true
+	}
+
+	tags = tags.Clone()
+	for i, rule := range active.rules {
+		numTags := len(tags)
+		relabeled, keep := rule.Apply(tags)
+		if !keep {
+			active.metrics[i].seriesDropped.Inc(1)
+			return nil, false
+		}
+		if dropped := numTags - len(relabeled); dropped > 0 &&
+			(rule.Action() == LabelDrop || rule.Action() == LabelKeep) {
+			active.metrics[i].tagsDropped.Inc(int64(dropped))
+		}
+		tags = relabeled
+	}
+
+	if len(tags) == 0 {
+		// Series without any tags cannot be written
+		return nil, false
+	}
+
+	return models.Normalize(tags), true
+}
+
+// WatchKV watches the key in the KV store and replaces the rules whenever
+// the key changes, the rules are stored as a JSON list of rule
+// configurations. The static rules are used if the key is deleted.
+func (r *Relabeler) WatchKV(store kv.Store, key string, static []*Rule) error {
+	watch, err := store.Watch(key)
+	if err != nil {
+		return err
+	}
+
+	logger := logging.WithContext(context.Background())
+	go func() {
+		for range watch.C() {
+			value := watch.Get()
+			if value == nil {
+				logger.Info("relabel rules removed from KV, using static rules",
+					zap.String("key", key))
+				r.SetRules(static)
+				continue
+			}
+
+			rules, err := unmarshalRules(value)
+			if err != nil {
+				logger.Error("unable to parse relabel rules from KV",
+					zap.String("key", key), zap.Any("err", err))
+				continue
+			}
+
+			logger.Info("relabel rules updated from KV",
+				zap.String("key", key), zap.Int("rules", len(rules)))
+			r.SetRules(rules)
+		}
+	}()
+
+	return nil
+}
+
+func unmarshalRules(value kv.Value) ([]*Rule, error) {
+	var proto commonpb.StringProto
+	if err := value.Unmarshal(&proto); err != nil {
+		return nil, err
+	}
+
+	var configs []RuleConfiguration
+	if err := json.Unmarshal([]byte(proto.Value), &configs); err != nil {
+		return nil, fmt.Errorf("unable to parse relabel rules: %v", err)
+	}
+
+	return NewRules(configs)
+}
+
+// NewRelabeler returns a new relabeler from the configuration, the KV store
+// function is required if a KV key is configured.
+func (c Configuration) NewRelabeler(
+	kvStoreFn func() (kv.Store, error),
+	scope tally.Scope,
+) (*Relabeler, error) {
+	rules, err := NewRules(c.Rules)
+	if err != nil {
+		return nil, err
+	}
+
+	relabeler := NewRelabeler(rules, scope)
+	if c.KVKey == "" {
+		return relabeler, nil
+	}
+
+	if kvStoreFn == nil {
+		return nil, fmt.Errorf("relabel KV key %s requires a cluster client", c.KVKey)
+	}
+
+	store, err := kvStoreFn()
+	if err != nil {
+		return nil, err
+	}
+
+	if err := relabeler.WatchKV(store, c.KVKey, rules); err != nil {
+		return nil, err
+	}
+
+	return relabeler, nil
+}
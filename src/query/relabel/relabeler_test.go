@@ -0,0 +1,196 @@
+// Copyright (c) 2018 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package relabel
+
+import (
+	"testing"
+
+	"github.com/m3db/m3/src/query/models"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/uber-go/tally"
+)
+
+func strPtr(s string) *string {
+	return &s
+}
+
+func newTestRelabeler(t *testing.T, configs ...RuleConfiguration) (*Relabeler, tally.TestScope) {
+	rules, err := NewRules(configs)
+	require.NoError(t, err)
+	scope := tally.NewTestScope("", nil)
+	return NewRelabeler(rules, scope), scope
+}
+
+func testTags(tags ...string) models.Tags {
+	m := make(map[string]string)
+	for i := 0; i < len(tags); i += 2 {
+		m[tags[i]] = tags[i+1]
+	}
+	return models.FromMap(m)
+}
+
+func TestRelabelDrop(t *testing.T) {
+	r, scope := newTestRelabeler(t, RuleConfiguration{
+		Name:         "drop-debug",
+		SourceLabels: []string{"__name__"},
+		Regex:        strPtr("debug_.*"),
+		Action:       Drop,
+	})
+
+	_, keep := r.Relabel(testTags("__name__", "debug_requests"))
+	assert.False(t, keep)
+
+	tags, keep := r.Relabel(testTags("__name__", "requests"))
+	assert.True(t, keep)
+	assert.Equal(t, testTags("__name__", "requests"), tags)
+
+	counters := scope.Snapshot().Counters()
+	counter, ok := counters["series-dropped+rule=drop-debug"]
+	require.True(t, ok)
+	assert.Equal(t, int64(1), counter.Value())
+}
+
+func TestRelabelKeep(t *testing.T) {
+	r, _ := newTestRelabeler(t, RuleConfiguration{
+		SourceLabels: []string{"__name__", "env"},
+		Regex:        strPtr("requests;prod"),
+		Action:       Keep,
+	})
+
+	_, keep := r.Relabel(testTags("__name__", "requests", "env", "prod"))
+	assert.True(t, keep)
+
+	_, keep = r.Relabel(testTags("__name__", "requests", "env", "dev"))
+	assert.False(t, keep)
+}
+
+func TestRelabelReplace(t *testing.T) {
+	r, _ := newTestRelabeler(t,
+		RuleConfiguration{
+			SourceLabels: []string{"host"},
+			Regex:        strPtr("(.*)\\.example\\.com"),
+			TargetLabel:  "instance",
+		},
+		RuleConfiguration{
+			SourceLabels: []string{"missing"},
+			TargetLabel:  "host",
+			Replacement:  strPtr(""),
+		},
+	)
+
+	input := testTags("__name__", "up", "host", "a.example.com")
+	tags, keep := r.Relabel(input)
+	assert.True(t, keep)
+	assert.Equal(t, testTags("__name__", "up", "instance", "a"), tags)
+
+	// The input tags are not modified
+	assert.Equal(t, testTags("__name__", "up", "host", "a.example.com"), input)
+}
+
+func TestRelabelHashMod(t *testing.T) {
+	r, _ := newTestRelabeler(t, RuleConfiguration{
+		SourceLabels: []string{"instance"},
+		Modulus:      4,
+		TargetLabel:  "shard",
+		Action:       HashMod,
+	})
+
+	tags, keep := r.Relabel(testTags("instance", "a"))
+	require.True(t, keep)
+	shard, ok := tags.Get("shard")
+	require.True(t, ok)
+
+	// The same source labels always hash to the same shard
+	tags, _ = r.Relabel(testTags("instance", "a", "other", "b"))
+	other, _ := tags.Get("shard")
+	assert.Equal(t, shard, other)
+}
+
+func TestRelabelLabelMap(t *testing.T) {
+	r, _ := newTestRelabeler(t, RuleConfiguration{
+		Regex:       strPtr("k8s_(.*)"),
+		Replacement: strPtr("$1"),
+		Action:      LabelMap,
+	})
+
+	tags, keep := r.Relabel(testTags("k8s_pod", "p1"))
+	assert.True(t, keep)
+	assert.Equal(t, testTags("k8s_pod", "p1", "pod", "p1"), tags)
+}
+
+func TestRelabelLabelDropAndKeep(t *testing.T) {
+	r, scope := newTestRelabeler(t,
+		RuleConfiguration{
+			Name:   "drop-request-id",
+			Regex:  strPtr("request_id|trace_id"),
+			Action: LabelDrop,
+		},
+		RuleConfiguration{
+			Regex:  strPtr("__name__|env|request_id"),
+			Action: LabelKeep,
+		},
+	)
+
+	tags, keep := r.Relabel(testTags("__name__", "up", "env", "prod",
+		"request_id", "1", "trace_id", "2", "host", "a"))
+	assert.True(t, keep)
+	assert.Equal(t, testTags("__name__", "up", "env", "prod"), tags)
+
+	counters := scope.Snapshot().Counters()
+	assert.Equal(t, int64(2), counters["tags-dropped+rule=drop-request-id"].Value())
+	assert.Equal(t, int64(1), counters["tags-dropped+rule=1"].Value())
+
+	// Series without any remaining tags are dropped
+	_, keep = r.Relabel(testTags("host", "a"))
+	assert.False(t, keep)
+}
+
+func TestRelabelSetRules(t *testing.T) {
+	r, _ := newTestRelabeler(t)
+
+	_, keep := r.Relabel(testTags("__name__", "up"))
+	assert.True(t, keep)
+
+	rules, err := NewRules([]RuleConfiguration{{
+		SourceLabels: []string{"__name__"},
+		Regex:        strPtr("up"),
+		Action:       Drop,
+	}})
+	require.NoError(t, err)
+	r.SetRules(rules)
+
+	_, keep = r.Relabel(testTags("__name__", "up"))
+	assert.False(t, keep)
+}
+
+func TestNewRulesInvalid(t *testing.T) {
+	for _, config := range []RuleConfiguration{
+		{Regex: strPtr("(")},
+		{Action: Replace},
+		{Action: HashMod, TargetLabel: "shard"},
+		{Action: "unknown"},
+	} {
+		_, err := NewRules([]RuleConfiguration{config})
+		assert.Error(t, err)
+	}
+}
@@ -0,0 +1,138 @@
+// Copyright (c) 2018 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package relabel
+
+import (
+	"crypto/md5"
+	"encoding/binary"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/m3db/m3/src/query/models"
+)
+
+// Rule is a compiled relabel rule.
+type Rule struct {
+	name         string
+	sourceLabels []string
+	separator    string
+	regex        *regexp.Regexp
+	modulus      uint64
+	targetLabel  string
+	replacement  string
+	action       Action
+}
+
+// Name returns the name of the rule.
+func (r *Rule) Name() string {
+	return r.name
+}
+
+// Action returns the action of the rule.
+func (r *Rule) Action() Action {
+	return r.action
+}
+
+// Apply applies the rule to the tags, returning the resulting tags and
+// false if the series should be dropped. The returned tags are not sorted
+// and the input tags may be modified.
+func (r *Rule) Apply(tags models.Tags) (models.Tags, bool) {
+	values := make([]string, 0, len(r.sourceLabels))
+	for _, name := range r.sourceLabels {
+		value, _ := tags.Get(name)
+		values = append(values, value)
+	}
+	value := strings.Join(values, r.separator)
+
+	switch r.action {
+	case Drop:
+		if r.regex.MatchString(value) {
+			return nil, false
+		}
+
+	case Keep:
+		if !r.regex.MatchString(value) {
+			return nil, false
+		}
+
+	case Replace:
+		indexes := r.regex.FindStringSubmatchIndex(value)
+		if indexes == nil {
+			break
+		}
+		target := string(r.regex.ExpandString(nil, r.targetLabel, value, indexes))
+		if target == "" {
+			break
+		}
+		replaced := string(r.regex.ExpandString(nil, r.replacement, value, indexes))
+		if replaced == "" {
+			tags = deleteTag(tags, target)
+			break
+		}
+		tags = setTag(tags, target, replaced)
+
+	case HashMod:
+		sum := md5.Sum([]byte(value))
+		mod := binary.BigEndian.Uint64(sum[8:]) % r.modulus
+		tags = setTag(tags, r.targetLabel, fmt.Sprintf("%d", mod))
+
+	case LabelMap:
+		mapped := tags.Clone()
+		for _, tag := range tags {
+			if r.regex.MatchString(tag.Name) {
+				name := r.regex.ReplaceAllString(tag.Name, r.replacement)
+				mapped = setTag(mapped, name, tag.Value)
+			}
+		}
+		tags = mapped
+
+	case LabelDrop, LabelKeep:
+		kept := tags[:0]
+		for _, tag := range tags {
+			if r.regex.MatchString(tag.Name) == (r.action == LabelKeep) {
+				kept = append(kept, tag)
+			}
+		}
+		tags = kept
+	}
+
+	return tags, true
+}
+
+func setTag(tags models.Tags, name, value string) models.Tags {
+	for i, tag := range tags {
+		if tag.Name == name {
+			tags[i].Value = value
+			return tags
+		}
+	}
+	return append(tags, models.Tag{Name: name, Value: value})
+}
+
+func deleteTag(tags models.Tags, name string) models.Tags {
+	for i, tag := range tags {
+		if tag.Name == name {
+			return append(tags[:i], tags[i+1:]...)
+		}
+	}
+	return tags
+}
@@ -0,0 +1,348 @@
+// Copyright (c) 2018 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package rules
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+	"text/template"
+	"time"
+
+	"github.com/m3db/m3/src/query/models"
+)
+
+const (
+	alertingRuleType = "alerting"
+
+	// AlertNameTag is the tag holding the name of the alert.
+	AlertNameTag = "alertname"
+	// AlertStateTag is the tag holding the state of the alert in the
+	// alerts series.
+	AlertStateTag = "alertstate"
+	// AlertMetricName is the name of the series recording active alerts.
+	AlertMetricName = "ALERTS"
+
+	// resolvedRetention is how long resolved alerts are kept around so
+	// that the resolution keeps being sent to the alertmanager.
+	resolvedRetention = 15 * time.Minute
+
+	// templateDefs are the variables made available to annotation templates.
+	templateDefs = "{{$labels := .Labels}}{{$value := .Value}}"
+)
+
+// AlertState is the state of an alert.
+type AlertState int
+
+const (
+	// StateInactive is the state of a resolved alert.
+	StateInactive AlertState = iota
+	// StatePending is the state of an active alert that has not been active
+	// for the hold duration of the rule yet.
+	StatePending
+	// StateFiring is the state of an alert that has been active for at least
+	// the hold duration of the rule.
+	StateFiring
+)
+
+func (s AlertState) String() string {
+	switch s {
+	case StateInactive:
+		return "inactive"
+	case StatePending:
+		return "pending"
+	case StateFiring:
+		return "firing"
+	default:
+		return "unknown"
+	}
+}
+
+// Alert is an alert generated by an alerting rule for a single series.
+type Alert struct {
+	State       AlertState
+	Tags        models.Tags
+	Annotations models.Tags
+	Value       float64
+	ActiveAt    time.Time
+	FiredAt     time.Time
+	ResolvedAt  time.Time
+	LastSentAt  time.Time
+	ValidUntil  time.Time
+}
+
+// AlertStatus describes an active alert.
+type AlertStatus struct {
+	Labels      map[string]string `json:"labels"`
+	Annotations map[string]string `json:"annotations"`
+	State       string            `json:"state"`
+	ActiveAt    time.Time         `json:"activeAt"`
+	Value       float64           `json:"value"`
+}
+
+func (a *Alert) status() AlertStatus {
+	return AlertStatus{
+		Labels:      a.Tags.StringMap(),
+		Annotations: a.Annotations.StringMap(),
+		State:       a.State.String(),
+		ActiveAt:    a.ActiveAt,
+		Value:       a.Value,
+	}
+}
+
+func (a *Alert) needsSending(t time.Time, resendDelay time.Duration) bool {
+	if a.State == StatePending {
+		return false
+	}
+
+	// Always send a resolution that has not been sent yet
+	if !a.ResolvedAt.IsZero() && a.LastSentAt.Before(a.ResolvedAt) {
+		return true
+	}
+
+	return !a.LastSentAt.Add(resendDelay).After(t)
+}
+
+// AlertingRule evaluates an expression and generates an alert for every
+// series in the result.
+type AlertingRule struct {
+	name         string
+	query        string
+	holdDuration time.Duration
+	tags         models.Tags
+	annotations  map[string]string
+	templates    map[string]*template.Template
+
+	mu     sync.Mutex
+	active map[string]*Alert
+
+	ruleHealth
+}
+
+// NewAlertingRule returns a new alerting rule which fires once the query
+// has returned a series for at least the hold duration. Annotations may
+// use the $labels and $value template variables.
+func NewAlertingRule(
+	name, query string,
+	holdDuration time.Duration,
+	labels, annotations map[string]string,
+) (*AlertingRule, error) {
+	templates := make(map[string]*template.Template, len(annotations))
+	for key, text := range annotations {
+		tmpl, err := template.New(key).Option("missingkey=zero").Parse(templateDefs + text)
+		if err != nil {
+			return nil, fmt.Errorf("invalid template for annotation %s of alert %s: %v", key, name, err)
+		}
+
+		templates[key] = tmpl
+	}
+
+	return &AlertingRule{
+		name:         name,
+		query:        query,
+		holdDuration: holdDuration,
+		tags:         models.FromMap(labels),
+		annotations:  annotations,
+		templates:    templates,
+		active:       make(map[string]*Alert),
+	}, nil
+}
+
+// Name returns the name of the alert.
+func (r *AlertingRule) Name() string {
+	return r.name
+}
+
+// Query returns the PromQL expression of the rule.
+func (r *AlertingRule) Query() string {
+	return r.query
+}
+
+// Eval evaluates the rule and updates the state of its alerts, the
+// returned vector is the alerts series for all pending and firing alerts.
+func (r *AlertingRule) Eval(ctx context.Context, t time.Time, query QueryFunc) (Vector, error) {
+	vector, err := query(ctx, r.query, t)
+	if err != nil {
+		return nil, err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	seen := make(map[string]struct{}, len(vector))
+	for _, sample := range vector {
+		tags := withTag(withTags(sample.Tags.WithoutName(), r.tags), AlertNameTag, r.name)
+		id := tags.ID()
+		if _, ok := seen[id]; ok {
+			return nil, fmt.Errorf("vector contains metrics with the same labelset after applying alert labels: %s", id)
+		}
+
+		seen[id] = struct{}{}
+		annotations, err := r.expandAnnotations(tags, sample.Value)
+		if err != nil {
+			return nil, err
+		}
+
+		if alert, ok := r.active[id]; ok && alert.State != StateInactive {
+			alert.Value = sample.Value
+			alert.Annotations = annotations
+			continue
+		}
+
+		r.active[id] = &Alert{
+			State:       StatePending,
+			Tags:        tags,
+			Annotations: annotations,
+			Value:       sample.Value,
+			ActiveAt:    t,
+		}
+	}
+
+	result := make(Vector, 0, len(r.active))
+	for id, alert := range r.active {
+		if _, ok := seen[id]; !ok {
+			// Pending alerts are dropped straight away, firing ones are
+			// resolved and kept around for a while to send the resolution
+			if alert.State == StatePending ||
+				(!alert.ResolvedAt.IsZero() && t.Sub(alert.ResolvedAt) > resolvedRetention) {
+				delete(r.active, id)
+				continue
+			}
+
+			if alert.State != StateInactive {
+				alert.State = StateInactive
+				alert.ResolvedAt = t
+			}
+
+			continue
+		}
+
+		if alert.State == StatePending && t.Sub(alert.ActiveAt) >= r.holdDuration {
+			alert.State = StateFiring
+			alert.FiredAt = t
+		}
+
+		result = append(result, Sample{
+			Tags: withTags(alert.Tags, models.Tags{
+				{Name: models.MetricName, Value: AlertMetricName},
+				{Name: AlertStateTag, Value: alert.State.String()},
+			}),
+			Value: 1,
+		})
+	}
+
+	return result, nil
+}
+
+func (r *AlertingRule) expandAnnotations(tags models.Tags, value float64) (models.Tags, error) {
+	if len(r.templates) == 0 {
+		return nil, nil
+	}
+
+	data := struct {
+		Labels map[string]string
+		Value  float64
+	}{
+		Labels: tags.StringMap(),
+		Value:  value,
+	}
+
+	annotations := make(models.Tags, 0, len(r.templates))
+	for key, tmpl := range r.templates {
+		var buf bytes.Buffer
+		if err := tmpl.Execute(&buf, data); err != nil {
+			return nil, fmt.Errorf("unable to expand annotation %s of alert %s: %v", key, r.name, err)
+		}
+
+		annotations = append(annotations, models.Tag{Name: key, Value: buf.String()})
+	}
+
+	return models.Normalize(annotations), nil
+}
+
+// ActiveAlerts returns copies of all pending and firing alerts.
+func (r *AlertingRule) ActiveAlerts() []Alert {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	alerts := make([]Alert, 0, len(r.active))
+	for _, alert := range r.active {
+		if alert.State != StateInactive {
+			alerts = append(alerts, *alert)
+		}
+	}
+
+	sortAlerts(alerts)
+	return alerts
+}
+
+// alertsToSend returns copies of the alerts that are due to be sent to the
+// alertmanager and marks them as sent.
+func (r *AlertingRule) alertsToSend(t time.Time, resendDelay, interval time.Duration) []Alert {
+	validFor := 3 * resendDelay
+	if interval > resendDelay {
+		validFor = 3 * interval
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	var alerts []Alert
+	for _, alert := range r.active {
+		if !alert.needsSending(t, resendDelay) {
+			continue
+		}
+
+		alert.LastSentAt = t
+		alert.ValidUntil = t.Add(validFor)
+		alerts = append(alerts, *alert)
+	}
+
+	sortAlerts(alerts)
+	return alerts
+}
+
+// Status returns the current status of the rule.
+func (r *AlertingRule) Status() RuleStatus {
+	alerts := r.ActiveAlerts()
+	statuses := make([]AlertStatus, 0, len(alerts))
+	for _, alert := range alerts {
+		statuses = append(statuses, alert.status())
+	}
+
+	return r.status(RuleStatus{
+		Name:        r.name,
+		Query:       r.query,
+		Type:        alertingRuleType,
+		Labels:      r.tags.StringMap(),
+		Annotations: r.annotations,
+		Duration:    r.holdDuration.Seconds(),
+		Alerts:      statuses,
+	})
+}
+
+func sortAlerts(alerts []Alert) {
+	sort.Slice(alerts, func(i, j int) bool {
+		return alerts[i].Tags.ID() < alerts[j].Tags.ID()
+	})
+}
@@ -0,0 +1,149 @@
+// Copyright (c) 2018 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package rules
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/m3db/m3/src/query/models"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func staticQuery(vector Vector) QueryFunc {
+	return func(ctx context.Context, query string, t time.Time) (Vector, error) {
+		result := make(Vector, len(vector))
+		copy(result, vector)
+		return result, nil
+	}
+}
+
+func TestAlertingRuleStateTransitions(t *testing.T) {
+	rule, err := NewAlertingRule("HighRate", "rate > 1", 2*time.Minute,
+		map[string]string{"severity": "page"},
+		map[string]string{"summary": "{{ $labels.job }} is at {{ $value }}"})
+	require.NoError(t, err)
+
+	active := staticQuery(Vector{{
+		Tags: models.Tags{
+			{Name: models.MetricName, Value: "rate"},
+			{Name: "job", Value: "api"},
+		},
+		Value: 5,
+	}})
+	ctx := context.Background()
+	start := time.Now().Truncate(time.Minute)
+
+	vector, err := rule.Eval(ctx, start, active)
+	require.NoError(t, err)
+	require.Len(t, vector, 1)
+	state, _ := vector[0].Tags.Get(AlertStateTag)
+	assert.Equal(t, "pending", state)
+	name, _ := vector[0].Tags.Get(models.MetricName)
+	assert.Equal(t, AlertMetricName, name)
+
+	alerts := rule.ActiveAlerts()
+	require.Len(t, alerts, 1)
+	assert.Equal(t, StatePending, alerts[0].State)
+	assert.Equal(t, models.Tags{
+		{Name: AlertNameTag, Value: "HighRate"},
+		{Name: "job", Value: "api"},
+		{Name: "severity", Value: "page"},
+	}, alerts[0].Tags)
+	assert.Equal(t, models.Tags{{Name: "summary", Value: "api is at 5"}}, alerts[0].Annotations)
+	assert.Empty(t, rule.alertsToSend(start, time.Minute, time.Minute))
+
+	_, err = rule.Eval(ctx, start.Add(2*time.Minute), active)
+	require.NoError(t, err)
+	alerts = rule.ActiveAlerts()
+	require.Len(t, alerts, 1)
+	assert.Equal(t, StateFiring, alerts[0].State)
+
+	sent := rule.alertsToSend(start.Add(2*time.Minute), time.Minute, time.Minute)
+	require.Len(t, sent, 1)
+	assert.Equal(t, start.Add(5*time.Minute), sent[0].ValidUntil)
+	// Not resent until the resend delay has passed
+	assert.Empty(t, rule.alertsToSend(start.Add(2*time.Minute+time.Second), time.Minute, time.Minute))
+
+	vector, err = rule.Eval(ctx, start.Add(3*time.Minute), staticQuery(nil))
+	require.NoError(t, err)
+	assert.Empty(t, vector)
+	assert.Empty(t, rule.ActiveAlerts())
+
+	resolved := rule.alertsToSend(start.Add(3*time.Minute), time.Minute, time.Minute)
+	require.Len(t, resolved, 1)
+	assert.Equal(t, StateInactive, resolved[0].State)
+	assert.Equal(t, start.Add(3*time.Minute), resolved[0].ResolvedAt)
+}
+
+func TestAlertingRulePendingDropped(t *testing.T) {
+	rule, err := NewAlertingRule("HighRate", "rate > 1", time.Minute, nil, nil)
+	require.NoError(t, err)
+
+	ctx := context.Background()
+	now := time.Now()
+	_, err = rule.Eval(ctx, now, staticQuery(Vector{{Tags: models.Tags{{Name: "job", Value: "api"}}, Value: 2}}))
+	require.NoError(t, err)
+	require.Len(t, rule.ActiveAlerts(), 1)
+
+	_, err = rule.Eval(ctx, now.Add(time.Second), staticQuery(nil))
+	require.NoError(t, err)
+	assert.Empty(t, rule.ActiveAlerts())
+	assert.Empty(t, rule.alertsToSend(now.Add(time.Second), time.Minute, time.Minute))
+}
+
+func TestAlertingRuleInvalidTemplate(t *testing.T) {
+	_, err := NewAlertingRule("HighRate", "rate > 1", time.Minute, nil,
+		map[string]string{"summary": "{{ $labels.job"})
+	require.Error(t, err)
+}
+
+func TestRecordingRuleEval(t *testing.T) {
+	rule := NewRecordingRule("job:rate:sum", "sum(rate)", map[string]string{"team": "infra"})
+	vector, err := rule.Eval(context.Background(), time.Now(), staticQuery(Vector{{
+		Tags: models.Tags{
+			{Name: models.MetricName, Value: "rate"},
+			{Name: "job", Value: "api"},
+			{Name: "team", Value: "other"},
+		},
+		Value: 3,
+	}}))
+	require.NoError(t, err)
+	require.Len(t, vector, 1)
+	assert.Equal(t, models.Tags{
+		{Name: models.MetricName, Value: "job:rate:sum"},
+		{Name: "job", Value: "api"},
+		{Name: "team", Value: "infra"},
+	}, vector[0].Tags)
+	assert.Equal(t, 3.0, vector[0].Value)
+}
+
+func TestRecordingRuleDuplicateLabelsets(t *testing.T) {
+	rule := NewRecordingRule("job:rate:sum", "rate", map[string]string{"job": "all"})
+	_, err := rule.Eval(context.Background(), time.Now(), staticQuery(Vector{
+		{Tags: models.Tags{{Name: "job", Value: "api"}}, Value: 1},
+		{Tags: models.Tags{{Name: "job", Value: "db"}}, Value: 2},
+	}))
+	require.Error(t, err)
+}
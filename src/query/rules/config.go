@@ -0,0 +1,103 @@
+// Copyright (c) 2018 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package rules
+
+import (
+	"time"
+
+	"github.com/m3db/m3/src/query/executor"
+	"github.com/m3db/m3/src/query/storage"
+	"github.com/m3db/m3x/instrument"
+)
+
+const (
+	defaultQueryTimeout = 30 * time.Second
+)
+
+// Configuration is the configuration for recording and alerting rule
+// evaluation.
+type Configuration struct {
+	// RuleFiles are the Prometheus format rule files to load.
+	RuleFiles []string `yaml:"ruleFiles"`
+
+	// EvaluationInterval is the interval of groups that do not set one.
+	EvaluationInterval time.Duration `yaml:"evaluationInterval"`
+
+	// QueryTimeout is the timeout for evaluating a single rule.
+	QueryTimeout time.Duration `yaml:"queryTimeout"`
+
+	// ResendDelay is the minimum delay before resending a firing alert.
+	ResendDelay time.Duration `yaml:"resendDelay"`
+
+	// Alertmanager configures where alerts are sent (optional).
+	Alertmanager *AlertmanagerConfiguration `yaml:"alertmanager"`
+}
+
+// AlertmanagerConfiguration is the configuration for sending alerts to an
+// alertmanager compatible webhook.
+type AlertmanagerConfiguration struct {
+	// URL is the webhook URL, e.g. http://alertmanager:9093/api/v1/alerts.
+	URL string `yaml:"url" validate:"nonzero"`
+
+	// Timeout is the timeout for sending alerts.
+	Timeout time.Duration `yaml:"timeout"`
+}
+
+// NewManager creates a rule manager evaluating rules with the engine and
+// writing results to the appender, with the rule files loaded.
+func (c Configuration) NewManager(
+	engine *executor.Engine,
+	appender storage.Appender,
+	instrumentOpts instrument.Options,
+) (*Manager, error) {
+	interval := c.EvaluationInterval
+	if interval <= 0 {
+		interval = defaultEvaluationInterval
+	}
+
+	timeout := c.QueryTimeout
+	if timeout <= 0 {
+		timeout = defaultQueryTimeout
+	}
+
+	var notifier Notifier
+	if c.Alertmanager != nil {
+		notifier = NewWebhookNotifier(c.Alertmanager.URL, c.Alertmanager.Timeout)
+	}
+
+	manager, err := NewManager(ManagerOptions{
+		Query:              NewEngineQueryFunc(engine, interval, timeout),
+		Appender:           appender,
+		Notifier:           notifier,
+		EvaluationInterval: interval,
+		ResendDelay:        c.ResendDelay,
+		InstrumentOptions:  instrumentOpts,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if err := manager.LoadFiles(c.RuleFiles); err != nil {
+		return nil, err
+	}
+
+	return manager, nil
+}
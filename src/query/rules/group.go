@@ -0,0 +1,246 @@
+// Copyright (c) 2018 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package rules
+
+import (
+	"context"
+	"hash/fnv"
+	"sync"
+	"time"
+
+	"github.com/m3db/m3/src/query/storage"
+	"github.com/m3db/m3/src/query/ts"
+	xtime "github.com/m3db/m3x/time"
+
+	"github.com/uber-go/tally"
+	"go.uber.org/zap"
+)
+
+// GroupStatus describes a rule group and the rules within it.
+type GroupStatus struct {
+	Name           string       `json:"name"`
+	File           string       `json:"file"`
+	Interval       float64      `json:"interval"`
+	Rules          []RuleStatus `json:"rules"`
+	LastEvaluation time.Time    `json:"lastEvaluation"`
+	EvaluationTime float64      `json:"evaluationTime"`
+}
+
+type groupMetrics struct {
+	iterations       tally.Counter
+	iterationsMissed tally.Counter
+	evaluationTime   tally.Timer
+	ruleEvaluations  tally.Counter
+	ruleFailures     tally.Counter
+	writeFailures    tally.Counter
+	notifyFailures   tally.Counter
+}
+
+func newGroupMetrics(scope tally.Scope) groupMetrics {
+	return groupMetrics{
+		iterations:       scope.Counter("iterations"),
+		iterationsMissed: scope.Counter("iterations-missed"),
+		evaluationTime:   scope.Timer("evaluation-time"),
+		ruleEvaluations:  scope.Counter("rule-evaluations"),
+		ruleFailures:     scope.Counter("rule-evaluation-failures"),
+		writeFailures:    scope.Counter("write-failures"),
+		notifyFailures:   scope.Counter("notify-failures"),
+	}
+}
+
+// Group is a set of rules evaluated sequentially on an interval.
+type Group struct {
+	name     string
+	file     string
+	interval time.Duration
+	rules    []Rule
+	opts     ManagerOptions
+	logger   *zap.Logger
+	metrics  groupMetrics
+
+	// source is the definition the group was loaded from, used to find the
+	// groups that are unchanged when reloading rule files.
+	source RuleGroup
+
+	mu             sync.RWMutex
+	lastEvaluation time.Time
+	evaluationTime time.Duration
+
+	done       chan struct{}
+	terminated chan struct{}
+}
+
+func newGroup(
+	name, file string,
+	interval time.Duration,
+	rules []Rule,
+	opts ManagerOptions,
+) *Group {
+	iOpts := opts.InstrumentOptions
+	scope := iOpts.MetricsScope().Tagged(map[string]string{"rule-group": name})
+	return &Group{
+		name:       name,
+		file:       file,
+		interval:   interval,
+		rules:      rules,
+		opts:       opts,
+		logger:     iOpts.ZapLogger().With(zap.String("group", name)),
+		metrics:    newGroupMetrics(scope),
+		done:       make(chan struct{}),
+		terminated: make(chan struct{}),
+	}
+}
+
+// Name returns the name of the group.
+func (g *Group) Name() string {
+	return g.name
+}
+
+// Rules returns the rules of the group.
+func (g *Group) Rules() []Rule {
+	return g.rules
+}
+
+// Status returns the current status of the group and its rules.
+func (g *Group) Status() GroupStatus {
+	rules := make([]RuleStatus, 0, len(g.rules))
+	for _, rule := range g.rules {
+		rules = append(rules, rule.Status())
+	}
+
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+
+	return GroupStatus{
+		Name:           g.name,
+		File:           g.file,
+		Interval:       g.interval.Seconds(),
+		Rules:          rules,
+		LastEvaluation: g.lastEvaluation,
+		EvaluationTime: g.evaluationTime.Seconds(),
+	}
+}
+
+func (g *Group) run(ctx context.Context) {
+	defer close(g.terminated)
+
+	// Spread the evaluation of groups across the interval
+	evalTimestamp := g.evalTimestamp(time.Now()).Add(g.interval)
+	select {
+	case <-time.After(time.Until(evalTimestamp)):
+	case <-g.done:
+		return
+	}
+
+	tick := time.NewTicker(g.interval)
+	defer tick.Stop()
+
+	g.Eval(ctx, evalTimestamp)
+	for {
+		select {
+		case <-g.done:
+			return
+		case <-tick.C:
+			missed := time.Since(evalTimestamp)/g.interval - 1
+			if missed > 0 {
+				g.metrics.iterationsMissed.Inc(int64(missed))
+			}
+
+			evalTimestamp = evalTimestamp.Add((missed + 1) * g.interval)
+			g.Eval(ctx, evalTimestamp)
+		}
+	}
+}
+
+func (g *Group) stop() {
+	close(g.done)
+	<-g.terminated
+}
+
+// evalTimestamp returns the latest evaluation timestamp of the group at or
+// before now, offset within the interval by a hash of the group.
+func (g *Group) evalTimestamp(now time.Time) time.Time {
+	h := fnv.New64a()
+	h.Write([]byte(g.file))
+	h.Write([]byte(g.name))
+	offset := time.Duration(h.Sum64() % uint64(g.interval))
+	return now.Add(-offset).Truncate(g.interval).Add(offset)
+}
+
+// Eval evaluates all rules of the group at the given time, writing the
+// results to storage and sending any due alerts.
+func (g *Group) Eval(ctx context.Context, t time.Time) {
+	start := time.Now()
+	for _, rule := range g.rules {
+		g.evalRule(ctx, rule, t)
+	}
+
+	took := time.Since(start)
+	g.metrics.iterations.Inc(1)
+	g.metrics.evaluationTime.Record(took)
+
+	g.mu.Lock()
+	g.lastEvaluation = t
+	g.evaluationTime = took
+	g.mu.Unlock()
+}
+
+func (g *Group) evalRule(ctx context.Context, rule Rule, t time.Time) {
+	start := time.Now()
+	g.metrics.ruleEvaluations.Inc(1)
+	vector, err := rule.Eval(ctx, t, g.opts.Query)
+	rule.recordEvaluation(t, time.Since(start), err)
+	if err != nil {
+		g.metrics.ruleFailures.Inc(1)
+		g.logger.Warn("rule evaluation failed",
+			zap.String("rule", rule.Name()), zap.Error(err))
+		return
+	}
+
+	for _, sample := range vector {
+		if err := g.opts.Appender.Write(ctx, &storage.WriteQuery{
+			Tags: sample.Tags,
+			Datapoints: ts.Datapoints{
+				{
+					Timestamp: t,
+					Value:     sample.Value,
+				},
+			},
+			Unit: xtime.Millisecond,
+		}); err != nil {
+			g.metrics.writeFailures.Inc(1)
+			g.logger.Warn("unable to write rule result",
+				zap.String("rule", rule.Name()), zap.Error(err))
+		}
+	}
+
+	alerting, ok := rule.(*AlertingRule)
+	if !ok || g.opts.Notifier == nil {
+		return
+	}
+
+	alerts := alerting.alertsToSend(t, g.opts.ResendDelay, g.interval)
+	if err := g.opts.Notifier.Send(ctx, alerts); err != nil {
+		g.metrics.notifyFailures.Inc(1)
+		g.logger.Warn("unable to send alerts",
+			zap.String("rule", rule.Name()), zap.Error(err))
+	}
+}
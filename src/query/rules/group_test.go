@@ -0,0 +1,199 @@
+// Copyright (c) 2018 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package rules
+
+import (
+	"context"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/m3db/m3/src/query/models"
+	"github.com/m3db/m3/src/query/storage/mock"
+	"github.com/m3db/m3x/instrument"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type recordingNotifier struct {
+	sync.Mutex
+	alerts []Alert
+}
+
+func (n *recordingNotifier) Send(ctx context.Context, alerts []Alert) error {
+	n.Lock()
+	defer n.Unlock()
+	n.alerts = append(n.alerts, alerts...)
+	return nil
+}
+
+func newTestManager(t *testing.T, query QueryFunc) (*Manager, mock.Storage, *recordingNotifier) {
+	dir, err := ioutil.TempDir("", "rules")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	file := filepath.Join(dir, "rules.yml")
+	require.NoError(t, ioutil.WriteFile(file, []byte(testRuleFile), 0644))
+
+	store := mock.NewMockStorage()
+	notifier := &recordingNotifier{}
+	manager, err := NewManager(ManagerOptions{
+		Query:             query,
+		Appender:          store,
+		Notifier:          notifier,
+		InstrumentOptions: instrument.NewOptions(),
+	})
+	require.NoError(t, err)
+	require.NoError(t, manager.LoadFiles([]string{file}))
+	return manager, store, notifier
+}
+
+func TestGroupEval(t *testing.T) {
+	query := staticQuery(Vector{{
+		Tags: models.Tags{
+			{Name: models.MetricName, Value: "http_requests_total"},
+			{Name: "job", Value: "api"},
+		},
+		Value: 150,
+	}})
+	manager, store, notifier := newTestManager(t, query)
+
+	groups := manager.Groups()
+	require.Len(t, groups, 1)
+	group := groups[0]
+	assert.Equal(t, "example", group.Name())
+	require.Len(t, manager.AlertingRules(), 1)
+
+	now := time.Now().Truncate(time.Second)
+	group.Eval(context.Background(), now)
+
+	writes := store.Writes()
+	require.Len(t, writes, 2)
+	name, _ := writes[0].Tags.Get(models.MetricName)
+	assert.Equal(t, "job:http_requests:sum", name)
+	team, _ := writes[0].Tags.Get("team")
+	assert.Equal(t, "infra", team)
+	require.Len(t, writes[0].Datapoints, 1)
+	assert.Equal(t, now, writes[0].Datapoints[0].Timestamp)
+	assert.Equal(t, 150.0, writes[0].Datapoints[0].Value)
+
+	name, _ = writes[1].Tags.Get(models.MetricName)
+	assert.Equal(t, AlertMetricName, name)
+	assert.Empty(t, notifier.alerts)
+
+	group.Eval(context.Background(), now.Add(5*time.Minute))
+	require.Len(t, notifier.alerts, 1)
+	assert.Equal(t, StateFiring, notifier.alerts[0].State)
+	summary, _ := notifier.alerts[0].Annotations.Get("summary")
+	assert.Equal(t, "api has a high request rate: 150", summary)
+
+	status := group.Status()
+	assert.Equal(t, 30.0, status.Interval)
+	assert.Equal(t, now.Add(5*time.Minute), status.LastEvaluation)
+	require.Len(t, status.Rules, 2)
+	for _, rule := range status.Rules {
+		assert.Equal(t, HealthGood, rule.Health)
+	}
+	require.Len(t, status.Rules[1].Alerts, 1)
+	assert.Equal(t, "firing", status.Rules[1].Alerts[0].State)
+}
+
+func TestGroupEvalFailure(t *testing.T) {
+	manager, store, _ := newTestManager(t, func(context.Context, string, time.Time) (Vector, error) {
+		return nil, assert.AnError
+	})
+
+	group := manager.Groups()[0]
+	group.Eval(context.Background(), time.Now())
+	assert.Empty(t, store.Writes())
+
+	for _, rule := range group.Status().Rules {
+		assert.Equal(t, HealthBad, rule.Health)
+		assert.Equal(t, assert.AnError.Error(), rule.LastError)
+	}
+}
+
+func TestGroupEvalTimestamp(t *testing.T) {
+	group := newGroup("example", "rules.yml", time.Minute, nil, ManagerOptions{
+		InstrumentOptions: instrument.NewOptions(),
+	})
+
+	now := time.Now()
+	ts := group.evalTimestamp(now)
+	assert.False(t, ts.After(now))
+	assert.True(t, now.Sub(ts) < time.Minute)
+	assert.Equal(t, ts, group.evalTimestamp(ts))
+	assert.Equal(t, ts.Add(time.Minute), group.evalTimestamp(ts.Add(time.Minute)))
+}
+
+func TestManagerRunClose(t *testing.T) {
+	manager, _, _ := newTestManager(t, staticQuery(nil))
+	require.NoError(t, manager.Run())
+	require.NoError(t, manager.Close())
+	assert.Error(t, manager.Close())
+	assert.Error(t, manager.Run())
+}
+
+func TestManagerLoadFilesKeepsUnchangedGroups(t *testing.T) {
+	dir, err := ioutil.TempDir("", "rules")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	file := filepath.Join(dir, "rules.yml")
+	require.NoError(t, ioutil.WriteFile(file, []byte(testRuleFile), 0644))
+
+	manager, err := NewManager(ManagerOptions{
+		Query: staticQuery(Vector{{
+			Tags:  models.Tags{{Name: "job", Value: "api"}},
+			Value: 150,
+		}}),
+		Appender:          mock.NewMockStorage(),
+		InstrumentOptions: instrument.NewOptions(),
+	})
+	require.NoError(t, err)
+	require.NoError(t, manager.LoadFiles([]string{file}))
+	require.NoError(t, manager.Run())
+	defer manager.Close()
+
+	group := manager.Groups()[0]
+	group.Eval(context.Background(), time.Now())
+	require.Len(t, manager.AlertingRules()[0].ActiveAlerts(), 1)
+
+	// Reloading an unchanged group keeps the group and its alerts
+	require.NoError(t, manager.LoadFiles([]string{file}))
+	require.Len(t, manager.Groups(), 1)
+	assert.True(t, group == manager.Groups()[0])
+	assert.Len(t, manager.AlertingRules()[0].ActiveAlerts(), 1)
+
+	// Changing the group replaces it
+	changed := strings.Replace(testRuleFile, "interval: 30s", "interval: 1m", 1)
+	require.NoError(t, ioutil.WriteFile(file, []byte(changed), 0644))
+	require.NoError(t, manager.LoadFiles([]string{file}))
+	require.Len(t, manager.Groups(), 1)
+	assert.False(t, group == manager.Groups()[0])
+	assert.Equal(t, time.Minute, manager.Groups()[0].interval)
+	assert.Len(t, manager.AlertingRules()[0].ActiveAlerts(), 0)
+}
@@ -0,0 +1,263 @@
+// Copyright (c) 2018 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package rules
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+	"sync"
+	"time"
+
+	"github.com/m3db/m3/src/query/storage"
+	"github.com/m3db/m3x/instrument"
+)
+
+const (
+	defaultEvaluationInterval = time.Minute
+	defaultResendDelay        = time.Minute
+)
+
+var (
+	errNoQuery             = errors.New("no query function set")
+	errNoAppender          = errors.New("no appender set")
+	errNoInstrumentOptions = errors.New("no instrument options set")
+	errManagerClosed       = errors.New("rule manager is closed")
+)
+
+// ManagerOptions are the options for the rule manager.
+type ManagerOptions struct {
+	// Query evaluates rule expressions.
+	Query QueryFunc
+	// Appender writes recording rule results and the alerts series.
+	Appender storage.Appender
+	// Notifier sends alerts, alerts are only tracked if not set.
+	Notifier Notifier
+	// EvaluationInterval is the interval of groups that do not set one.
+	EvaluationInterval time.Duration
+	// ResendDelay is the minimum delay before resending a firing alert.
+	ResendDelay time.Duration
+	// InstrumentOptions are the instrument options.
+	InstrumentOptions instrument.Options
+}
+
+func (o ManagerOptions) validate() error {
+	if o.Query == nil {
+		return errNoQuery
+	}
+	if o.Appender == nil {
+		return errNoAppender
+	}
+	if o.InstrumentOptions == nil {
+		return errNoInstrumentOptions
+	}
+	return nil
+}
+
+// Manager evaluates groups of recording and alerting rules.
+type Manager struct {
+	opts ManagerOptions
+
+	mu      sync.RWMutex
+	groups  []*Group
+	running bool
+	closed  bool
+}
+
+// NewManager returns a new rule manager.
+func NewManager(opts ManagerOptions) (*Manager, error) {
+	if err := opts.validate(); err != nil {
+		return nil, err
+	}
+
+	if opts.EvaluationInterval <= 0 {
+		opts.EvaluationInterval = defaultEvaluationInterval
+	}
+
+	if opts.ResendDelay <= 0 {
+		opts.ResendDelay = defaultResendDelay
+	}
+
+	return &Manager{opts: opts}, nil
+}
+
+// LoadFiles loads the rule groups in the given files, replacing any
+// currently loaded groups. Groups that are unchanged keep running along with
+// the state of their alerts, other groups are started if the manager is
+// running.
+func (m *Manager) LoadFiles(files []string) error {
+	var groups []*Group
+	for _, file := range files {
+		ruleGroups, err := ParseFile(file)
+		if err != nil {
+			return err
+		}
+
+		for _, ruleGroup := range ruleGroups.Groups {
+			group, err := m.newGroup(file, ruleGroup)
+			if err != nil {
+				return fmt.Errorf("invalid rule file %s: %v", file, err)
+			}
+
+			groups = append(groups, group)
+		}
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if m.closed {
+		return errManagerClosed
+	}
+
+	existing := make(map[groupKey]*Group, len(m.groups))
+	for _, group := range m.groups {
+		existing[newGroupKey(group)] = group
+	}
+
+	var added []*Group
+	for i, group := range groups {
+		key := newGroupKey(group)
+		if old, ok := existing[key]; ok && reflect.DeepEqual(old.source, group.source) {
+			groups[i] = old
+			delete(existing, key)
+			continue
+		}
+
+		added = append(added, group)
+	}
+
+	if m.running {
+		removed := make([]*Group, 0, len(existing))
+		for _, group := range existing {
+			removed = append(removed, group)
+		}
+
+		stopGroups(removed)
+		startGroups(added)
+	}
+
+	m.groups = groups
+	return nil
+}
+
+// groupKey identifies a rule group across reloads.
+type groupKey struct {
+	file string
+	name string
+}
+
+func newGroupKey(group *Group) groupKey {
+	return groupKey{file: group.file, name: group.name}
+}
+
+func (m *Manager) newGroup(file string, ruleGroup RuleGroup) (*Group, error) {
+	interval := ruleGroup.Interval
+	if interval == 0 {
+		interval = m.opts.EvaluationInterval
+	}
+
+	rules := make([]Rule, 0, len(ruleGroup.Rules))
+	for _, node := range ruleGroup.Rules {
+		rule, err := node.NewRule()
+		if err != nil {
+			return nil, err
+		}
+
+		rules = append(rules, rule)
+	}
+
+	group := newGroup(ruleGroup.Name, file, interval, rules, m.opts)
+	group.source = ruleGroup
+	return group, nil
+}
+
+// Run starts evaluating the loaded rule groups.
+func (m *Manager) Run() error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if m.closed {
+		return errManagerClosed
+	}
+
+	if !m.running {
+		m.running = true
+		startGroups(m.groups)
+	}
+
+	return nil
+}
+
+// Close stops evaluating all rule groups.
+func (m *Manager) Close() error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if m.closed {
+		return errManagerClosed
+	}
+
+	m.closed = true
+	if m.running {
+		m.running = false
+		stopGroups(m.groups)
+	}
+
+	return nil
+}
+
+// Groups returns the loaded rule groups.
+func (m *Manager) Groups() []*Group {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	groups := make([]*Group, len(m.groups))
+	copy(groups, m.groups)
+	return groups
+}
+
+// AlertingRules returns the alerting rules of all loaded rule groups.
+func (m *Manager) AlertingRules() []*AlertingRule {
+	var rules []*AlertingRule
+	for _, group := range m.Groups() {
+		for _, rule := range group.Rules() {
+			if alerting, ok := rule.(*AlertingRule); ok {
+				rules = append(rules, alerting)
+			}
+		}
+	}
+
+	return rules
+}
+
+func startGroups(groups []*Group) {
+	for _, group := range groups {
+		go group.run(context.Background())
+	}
+}
+
+func stopGroups(groups []*Group) {
+	for _, group := range groups {
+		group.stop()
+	}
+}
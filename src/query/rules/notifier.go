@@ -0,0 +1,115 @@
+// Copyright (c) 2018 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package rules
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"time"
+)
+
+const (
+	defaultNotifierTimeout = 10 * time.Second
+)
+
+// Notifier sends alerts to an alertmanager.
+type Notifier interface {
+	// Send sends the alerts, pending alerts must not be passed.
+	Send(ctx context.Context, alerts []Alert) error
+}
+
+// alertmanagerAlert is the alert format accepted by the alertmanager API.
+type alertmanagerAlert struct {
+	Labels      map[string]string `json:"labels"`
+	Annotations map[string]string `json:"annotations"`
+	StartsAt    time.Time         `json:"startsAt,omitempty"`
+	EndsAt      time.Time         `json:"endsAt,omitempty"`
+}
+
+type webhookNotifier struct {
+	url    string
+	client *http.Client
+}
+
+// NewWebhookNotifier returns a notifier that posts alerts to an
+// alertmanager compatible webhook, e.g. http://alertmanager:9093/api/v1/alerts.
+func NewWebhookNotifier(url string, timeout time.Duration) Notifier {
+	if timeout <= 0 {
+		timeout = defaultNotifierTimeout
+	}
+
+	return &webhookNotifier{
+		url:    url,
+		client: &http.Client{Timeout: timeout},
+	}
+}
+
+func (n *webhookNotifier) Send(ctx context.Context, alerts []Alert) error {
+	if len(alerts) == 0 {
+		return nil
+	}
+
+	payload := make([]alertmanagerAlert, 0, len(alerts))
+	for _, alert := range alerts {
+		amAlert := alertmanagerAlert{
+			Labels:      alert.Tags.StringMap(),
+			Annotations: alert.Annotations.StringMap(),
+			StartsAt:    alert.ActiveAt,
+			EndsAt:      alert.ValidUntil,
+		}
+
+		if !alert.ResolvedAt.IsZero() {
+			amAlert.EndsAt = alert.ResolvedAt
+		}
+
+		payload = append(payload, amAlert)
+	}
+
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequest(http.MethodPost, n.url, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := n.client.Do(req.WithContext(ctx))
+	if err != nil {
+		return err
+	}
+
+	defer resp.Body.Close()
+	// Drain the body so the connection can be reused
+	io.Copy(ioutil.Discard, resp.Body)
+	if resp.StatusCode/100 != 2 {
+		return fmt.Errorf("bad response from alertmanager %s: %s", n.url, resp.Status)
+	}
+
+	return nil
+}
@@ -0,0 +1,87 @@
+// Copyright (c) 2018 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package rules
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/m3db/m3/src/query/models"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWebhookNotifierSend(t *testing.T) {
+	var received []alertmanagerAlert
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodPost, r.Method)
+		assert.Equal(t, "application/json", r.Header.Get("Content-Type"))
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&received))
+	}))
+	defer server.Close()
+
+	now := time.Now().UTC().Truncate(time.Second)
+	notifier := NewWebhookNotifier(server.URL, time.Second)
+	err := notifier.Send(context.Background(), []Alert{
+		{
+			State:       StateFiring,
+			Tags:        models.Tags{{Name: AlertNameTag, Value: "firing"}},
+			Annotations: models.Tags{{Name: "summary", Value: "foo"}},
+			ActiveAt:    now,
+			FiredAt:     now.Add(time.Minute),
+			ValidUntil:  now.Add(time.Minute),
+		},
+		{
+			State:      StateInactive,
+			Tags:       models.Tags{{Name: AlertNameTag, Value: "resolved"}},
+			FiredAt:    now,
+			ResolvedAt: now.Add(time.Second),
+			ValidUntil: now.Add(time.Minute),
+		},
+	})
+	require.NoError(t, err)
+
+	require.Len(t, received, 2)
+	assert.Equal(t, map[string]string{AlertNameTag: "firing"}, received[0].Labels)
+	assert.Equal(t, map[string]string{"summary": "foo"}, received[0].Annotations)
+	assert.True(t, now.Equal(received[0].StartsAt))
+	assert.True(t, now.Add(time.Minute).Equal(received[0].EndsAt))
+	assert.True(t, now.Add(time.Second).Equal(received[1].EndsAt))
+}
+
+func TestWebhookNotifierSendError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer server.Close()
+
+	notifier := NewWebhookNotifier(server.URL, time.Second)
+	err := notifier.Send(context.Background(), []Alert{{State: StateFiring}})
+	require.Error(t, err)
+
+	// Nothing is sent without alerts
+	require.NoError(t, notifier.Send(context.Background(), nil))
+}
@@ -0,0 +1,147 @@
+// Copyright (c) 2018 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package rules
+
+import (
+	"context"
+	"math"
+	"time"
+
+	"github.com/m3db/m3/src/query/block"
+	"github.com/m3db/m3/src/query/executor"
+	"github.com/m3db/m3/src/query/models"
+	"github.com/m3db/m3/src/query/parser/promql"
+)
+
+// NewEngineQueryFunc returns a query function evaluating instant queries
+// with the engine, using the given step as the resolution of the fetch.
+func NewEngineQueryFunc(engine *executor.Engine, step, timeout time.Duration) QueryFunc {
+	return func(ctx context.Context, query string, t time.Time) (Vector, error) {
+		parser, err := promql.Parse(query)
+		if err != nil {
+			return nil, err
+		}
+
+		ctx, cancel := context.WithTimeout(ctx, timeout)
+		defer cancel()
+
+		params := models.RequestParams{
+			Start:      t,
+			End:        t,
+			Now:        t,
+			Timeout:    timeout,
+			Step:       step,
+			Query:      query,
+			IncludeEnd: true,
+		}
+
+		// Results is closed by execute
+		results := make(chan executor.Query)
+		go engine.ExecuteExpr(ctx, parser, &executor.EngineOptions{Caller: "rules"}, params, results)
+
+		var (
+			vector     Vector
+			latest     time.Time
+			processErr error
+		)
+		for result := range results {
+			if result.Err != nil {
+				processErr = result.Err
+				continue
+			}
+
+			for blkResult := range result.Result.ResultChan() {
+				// Keep draining after an error so execution can finish
+				if processErr == nil {
+					if blkResult.Err != nil {
+						processErr = blkResult.Err
+					} else {
+						vector, latest, processErr = appendLatestStep(vector, latest, blkResult.Block, t)
+					}
+				}
+
+				if blkResult.Block != nil {
+					blkResult.Block.Close()
+				}
+			}
+		}
+
+		if processErr != nil {
+			return nil, processErr
+		}
+
+		return vector, nil
+	}
+}
+
+// appendLatestStep appends the values of the latest step of the block at or
+// before t, replacing the current vector if that step is newer than latest.
+func appendLatestStep(
+	vector Vector,
+	latest time.Time,
+	b block.Block,
+	t time.Time,
+) (Vector, time.Time, error) {
+	iter, err := b.StepIter()
+	if err != nil {
+		return nil, latest, err
+	}
+
+	defer iter.Close()
+	var (
+		values   []float64
+		stepTime time.Time
+	)
+	for iter.Next() {
+		step, err := iter.Current()
+		if err != nil {
+			return nil, latest, err
+		}
+
+		if step.Time().After(t) {
+			break
+		}
+
+		values, stepTime = step.Values(), step.Time()
+	}
+
+	if values == nil || stepTime.Before(latest) {
+		return vector, latest, nil
+	}
+
+	if stepTime.After(latest) {
+		vector = vector[:0]
+	}
+
+	meta := iter.SeriesMeta()
+	for i, value := range values {
+		if math.IsNaN(value) {
+			continue
+		}
+
+		vector = append(vector, Sample{
+			Tags:  meta[i].Tags.Clone(),
+			Value: value,
+		})
+	}
+
+	return vector, stepTime, nil
+}
@@ -0,0 +1,75 @@
+// Copyright (c) 2018 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package rules
+
+import (
+	"context"
+	"math"
+	"testing"
+	"time"
+
+	"github.com/m3db/m3/src/query/block"
+	"github.com/m3db/m3/src/query/executor"
+	"github.com/m3db/m3/src/query/models"
+	"github.com/m3db/m3/src/query/storage/mock"
+	"github.com/m3db/m3/src/query/test"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEngineQueryFunc(t *testing.T) {
+	values, bounds := test.GenerateValuesAndBounds([][]float64{
+		{0, 1, 2, 3, 4},
+		{5, 6, math.NaN(), 8, 9},
+	}, nil)
+	b := test.NewBlockFromValues(bounds, values)
+
+	store := mock.NewMockStorage()
+	store.SetFetchBlocksResult(block.Result{Blocks: []block.Block{b}}, nil)
+	query := NewEngineQueryFunc(executor.NewEngine(store), time.Minute, time.Minute)
+
+	vector, err := query(context.Background(), "foo", bounds.Start.Add(time.Minute))
+	require.NoError(t, err)
+	require.Len(t, vector, 2)
+	assert.Equal(t, 1.0, vector[0].Value)
+	assert.Equal(t, 6.0, vector[1].Value)
+	name, _ := vector[1].Tags.Get(models.MetricName)
+	assert.Equal(t, "dummy1", name)
+
+	// NaN values are not part of the instant vector
+	vector, err = query(context.Background(), "foo", bounds.Start.Add(2*time.Minute))
+	require.NoError(t, err)
+	require.Len(t, vector, 1)
+	assert.Equal(t, 2.0, vector[0].Value)
+}
+
+func TestEngineQueryFuncError(t *testing.T) {
+	store := mock.NewMockStorage()
+	store.SetFetchBlocksResult(block.Result{}, assert.AnError)
+	query := NewEngineQueryFunc(executor.NewEngine(store), time.Minute, time.Minute)
+
+	_, err := query(context.Background(), "foo", time.Now())
+	require.Error(t, err)
+
+	_, err = query(context.Background(), "sum(", time.Now())
+	require.Error(t, err)
+}
@@ -0,0 +1,95 @@
+// Copyright (c) 2018 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package rules
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/m3db/m3/src/query/models"
+)
+
+const (
+	recordingRuleType = "recording"
+)
+
+// RecordingRule evaluates an expression and records the result as a new
+// series named after the rule.
+type RecordingRule struct {
+	name  string
+	query string
+	tags  models.Tags
+
+	ruleHealth
+}
+
+// NewRecordingRule returns a new recording rule that records the result of
+// the query as the given metric name with the extra labels set.
+func NewRecordingRule(name, query string, labels map[string]string) *RecordingRule {
+	return &RecordingRule{
+		name:  name,
+		query: query,
+		tags:  models.FromMap(labels),
+	}
+}
+
+// Name returns the name of the recorded metric.
+func (r *RecordingRule) Name() string {
+	return r.name
+}
+
+// Query returns the PromQL expression of the rule.
+func (r *RecordingRule) Query() string {
+	return r.query
+}
+
+// Eval evaluates the rule, renaming the resulting series to the rule name.
+func (r *RecordingRule) Eval(ctx context.Context, t time.Time, query QueryFunc) (Vector, error) {
+	vector, err := query(ctx, r.query, t)
+	if err != nil {
+		return nil, err
+	}
+
+	seen := make(map[string]struct{}, len(vector))
+	for i, sample := range vector {
+		tags := withTag(withTags(sample.Tags, r.tags), models.MetricName, r.name)
+		id := tags.ID()
+		if _, ok := seen[id]; ok {
+			return nil, fmt.Errorf("vector contains metrics with the same labelset after applying rule labels: %s", id)
+		}
+
+		seen[id] = struct{}{}
+		vector[i].Tags = tags
+	}
+
+	return vector, nil
+}
+
+// Status returns the current status of the rule.
+func (r *RecordingRule) Status() RuleStatus {
+	return r.status(RuleStatus{
+		Name:   r.name,
+		Query:  r.query,
+		Type:   recordingRuleType,
+		Labels: r.tags.StringMap(),
+	})
+}
@@ -0,0 +1,180 @@
+// Copyright (c) 2018 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package rules
+
+import (
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"regexp"
+	"time"
+
+	"github.com/m3db/m3/src/query/parser/promql"
+
+	yaml "gopkg.in/yaml.v2"
+)
+
+var (
+	metricNameRegexp = regexp.MustCompile(`^[a-zA-Z_:][a-zA-Z0-9_:]*$`)
+	tagNameRegexp    = regexp.MustCompile(`^[a-zA-Z_][a-zA-Z0-9_]*$`)
+
+	errNoGroupName     = errors.New("rule group name must not be empty")
+	errNoRuleName      = errors.New("one of record or alert must be set")
+	errBothRuleNames   = errors.New("only one of record and alert may be set")
+	errNoRuleExpr      = errors.New("expr must not be empty")
+	errNegativeFor     = errors.New("for must not be negative")
+	errRecordingFor    = errors.New("for is only valid for alerting rules")
+	errRecordingAnnots = errors.New("annotations are only valid for alerting rules")
+)
+
+// RuleGroups is the Prometheus rule file format.
+type RuleGroups struct {
+	Groups []RuleGroup `yaml:"groups"`
+}
+
+// RuleGroup is a named list of rules evaluated sequentially on an interval.
+type RuleGroup struct {
+	Name     string        `yaml:"name"`
+	Interval time.Duration `yaml:"interval"`
+	Rules    []RuleNode    `yaml:"rules"`
+}
+
+// RuleNode is a recording or alerting rule definition.
+type RuleNode struct {
+	Record      string            `yaml:"record"`
+	Alert       string            `yaml:"alert"`
+	Expr        string            `yaml:"expr"`
+	For         time.Duration     `yaml:"for"`
+	Labels      map[string]string `yaml:"labels"`
+	Annotations map[string]string `yaml:"annotations"`
+}
+
+// ParseFile reads and validates a rule file.
+func ParseFile(file string) (*RuleGroups, error) {
+	content, err := ioutil.ReadFile(file)
+	if err != nil {
+		return nil, err
+	}
+
+	groups, err := Parse(content)
+	if err != nil {
+		return nil, fmt.Errorf("invalid rule file %s: %v", file, err)
+	}
+
+	return groups, nil
+}
+
+// Parse parses and validates rule groups in the Prometheus rule file format.
+func Parse(content []byte) (*RuleGroups, error) {
+	var groups RuleGroups
+	if err := yaml.UnmarshalStrict(content, &groups); err != nil {
+		return nil, err
+	}
+
+	if err := groups.Validate(); err != nil {
+		return nil, err
+	}
+
+	return &groups, nil
+}
+
+// Validate validates the rule groups.
+func (g *RuleGroups) Validate() error {
+	names := make(map[string]struct{}, len(g.Groups))
+	for _, group := range g.Groups {
+		if group.Name == "" {
+			return errNoGroupName
+		}
+
+		if _, ok := names[group.Name]; ok {
+			return fmt.Errorf("duplicate rule group name: %s", group.Name)
+		}
+
+		names[group.Name] = struct{}{}
+		if group.Interval < 0 {
+			return fmt.Errorf("rule group %s: interval must not be negative", group.Name)
+		}
+
+		for i, rule := range group.Rules {
+			if err := rule.Validate(); err != nil {
+				return fmt.Errorf("rule group %s, rule %d: %v", group.Name, i, err)
+			}
+		}
+	}
+
+	return nil
+}
+
+// Validate validates the rule definition.
+func (r RuleNode) Validate() error {
+	switch {
+	case r.Record == "" && r.Alert == "":
+		return errNoRuleName
+	case r.Record != "" && r.Alert != "":
+		return errBothRuleNames
+	case r.Expr == "":
+		return errNoRuleExpr
+	}
+
+	if r.Record != "" {
+		if !metricNameRegexp.MatchString(r.Record) {
+			return fmt.Errorf("invalid recording rule name: %s", r.Record)
+		}
+
+		if r.For != 0 {
+			return errRecordingFor
+		}
+
+		if len(r.Annotations) > 0 {
+			return errRecordingAnnots
+		}
+	}
+
+	if r.For < 0 {
+		return errNegativeFor
+	}
+
+	for name := range r.Labels {
+		if !tagNameRegexp.MatchString(name) {
+			return fmt.Errorf("invalid label name: %s", name)
+		}
+	}
+
+	parser, err := promql.Parse(r.Expr)
+	if err != nil {
+		return fmt.Errorf("invalid expr: %v", err)
+	}
+
+	if _, _, err := parser.DAG(); err != nil {
+		return fmt.Errorf("unsupported expr: %v", err)
+	}
+
+	return nil
+}
+
+// NewRule creates the rule described by the definition.
+func (r RuleNode) NewRule() (Rule, error) {
+	if r.Record != "" {
+		return NewRecordingRule(r.Record, r.Expr, r.Labels), nil
+	}
+
+	return NewAlertingRule(r.Alert, r.Expr, r.For, r.Labels, r.Annotations)
+}
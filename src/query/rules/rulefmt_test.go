@@ -0,0 +1,118 @@
+// Copyright (c) 2018 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package rules
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testRuleFile = `
+groups:
+  - name: example
+    interval: 30s
+    rules:
+      - record: job:http_requests:sum
+        expr: sum(http_requests_total)
+        labels:
+          team: infra
+      - alert: HighRequestRate
+        expr: http_requests_total > 100
+        for: 5m
+        labels:
+          severity: page
+        annotations:
+          summary: "{{ $labels.job }} has a high request rate: {{ $value }}"
+`
+
+func TestParse(t *testing.T) {
+	groups, err := Parse([]byte(testRuleFile))
+	require.NoError(t, err)
+	require.Len(t, groups.Groups, 1)
+
+	group := groups.Groups[0]
+	assert.Equal(t, "example", group.Name)
+	assert.Equal(t, 30*time.Second, group.Interval)
+	require.Len(t, group.Rules, 2)
+
+	recording, err := group.Rules[0].NewRule()
+	require.NoError(t, err)
+	assert.IsType(t, &RecordingRule{}, recording)
+	assert.Equal(t, "job:http_requests:sum", recording.Name())
+
+	alerting, err := group.Rules[1].NewRule()
+	require.NoError(t, err)
+	assert.IsType(t, &AlertingRule{}, alerting)
+	assert.Equal(t, "HighRequestRate", alerting.Name())
+	assert.Equal(t, 300.0, alerting.Status().Duration)
+}
+
+func TestParseUnknownField(t *testing.T) {
+	_, err := Parse([]byte(`
+groups:
+  - name: example
+    rules:
+      - record: foo
+        expr: bar
+        unknown: baz
+`))
+	require.Error(t, err)
+}
+
+func TestRuleNodeValidate(t *testing.T) {
+	tests := []struct {
+		name string
+		rule RuleNode
+		err  bool
+	}{
+		{"valid recording", RuleNode{Record: "foo:bar", Expr: "bar"}, false},
+		{"valid alert", RuleNode{Alert: "Foo", Expr: "bar > 1", For: time.Minute}, false},
+		{"no name", RuleNode{Expr: "bar"}, true},
+		{"both names", RuleNode{Record: "foo", Alert: "Foo", Expr: "bar"}, true},
+		{"no expr", RuleNode{Record: "foo"}, true},
+		{"invalid expr", RuleNode{Record: "foo", Expr: "sum("}, true},
+		{"invalid record name", RuleNode{Record: "foo-bar", Expr: "bar"}, true},
+		{"recording with for", RuleNode{Record: "foo", Expr: "bar", For: time.Minute}, true},
+		{"recording with annotations", RuleNode{Record: "foo", Expr: "bar",
+			Annotations: map[string]string{"summary": "baz"}}, true},
+		{"invalid label", RuleNode{Record: "foo", Expr: "bar",
+			Labels: map[string]string{"a-b": "c"}}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.rule.Validate()
+			if tt.err {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestRuleGroupsValidateDuplicateNames(t *testing.T) {
+	groups := RuleGroups{Groups: []RuleGroup{{Name: "foo"}, {Name: "foo"}}}
+	assert.Error(t, groups.Validate())
+}
@@ -0,0 +1,144 @@
+// Copyright (c) 2018 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package rules
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/m3db/m3/src/query/models"
+)
+
+// Sample is a single element of an instant vector.
+type Sample struct {
+	Tags  models.Tags
+	Value float64
+}
+
+// Vector is the instant vector result of evaluating a rule expression.
+type Vector []Sample
+
+// QueryFunc evaluates an instant query at the given time.
+type QueryFunc func(ctx context.Context, query string, t time.Time) (Vector, error)
+
+// Health describes the outcome of the last evaluation of a rule.
+type Health string
+
+const (
+	// HealthUnknown is the health of a rule that has not been evaluated yet.
+	HealthUnknown Health = "unknown"
+	// HealthGood is the health of a rule whose last evaluation succeeded.
+	HealthGood Health = "ok"
+	// HealthBad is the health of a rule whose last evaluation failed.
+	HealthBad Health = "err"
+)
+
+// Rule is a recording or alerting rule.
+type Rule interface {
+	// Name returns the name of the rule, the recorded metric name for
+	// recording rules and the alert name for alerting rules.
+	Name() string
+	// Query returns the PromQL expression of the rule.
+	Query() string
+	// Eval evaluates the rule at the given time and returns the samples
+	// to be written back to storage.
+	Eval(ctx context.Context, t time.Time, query QueryFunc) (Vector, error)
+	// Status returns the current status of the rule.
+	Status() RuleStatus
+
+	recordEvaluation(t time.Time, took time.Duration, err error)
+}
+
+// RuleStatus describes a rule and the outcome of its last evaluation.
+type RuleStatus struct {
+	Name           string            `json:"name"`
+	Query          string            `json:"query"`
+	Type           string            `json:"type"`
+	Labels         map[string]string `json:"labels,omitempty"`
+	Annotations    map[string]string `json:"annotations,omitempty"`
+	Duration       float64           `json:"duration,omitempty"`
+	Alerts         []AlertStatus     `json:"alerts,omitempty"`
+	Health         Health            `json:"health"`
+	LastError      string            `json:"lastError,omitempty"`
+	LastEvaluation time.Time         `json:"lastEvaluation"`
+	EvaluationTime float64           `json:"evaluationTime"`
+}
+
+// ruleHealth tracks the outcome of the last evaluation of a rule.
+type ruleHealth struct {
+	mu             sync.RWMutex
+	health         Health
+	lastError      error
+	lastEvaluation time.Time
+	evaluationTime time.Duration
+}
+
+func (h *ruleHealth) recordEvaluation(t time.Time, took time.Duration, err error) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	h.lastEvaluation = t
+	h.evaluationTime = took
+	h.lastError = err
+	if err != nil {
+		h.health = HealthBad
+	} else {
+		h.health = HealthGood
+	}
+}
+
+func (h *ruleHealth) status(status RuleStatus) RuleStatus {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+
+	status.Health = h.health
+	if status.Health == "" {
+		status.Health = HealthUnknown
+	}
+	status.LastEvaluation = h.lastEvaluation
+	status.EvaluationTime = h.evaluationTime.Seconds()
+	if h.lastError != nil {
+		status.LastError = h.lastError.Error()
+	}
+
+	return status
+}
+
+// withTags returns a sorted copy of tags with the values of overrides set,
+// replacing any existing tags with the same name.
+func withTags(tags models.Tags, overrides models.Tags) models.Tags {
+	result := make(models.Tags, 0, len(tags)+len(overrides))
+	for _, tag := range tags {
+		if _, ok := overrides.Get(tag.Name); ok {
+			continue
+		}
+
+		result = append(result, tag)
+	}
+
+	return models.Normalize(append(result, overrides...))
+}
+
+// withTag returns a sorted copy of tags with the given tag set.
+func withTag(tags models.Tags, name, value string) models.Tags {
+	return withTags(tags, models.Tags{{Name: name, Value: value}})
+}
@@ -39,6 +39,7 @@ import (
 	m3dbcluster "github.com/m3db/m3/src/query/cluster/m3db"
 	"github.com/m3db/m3/src/query/executor"
 	"github.com/m3db/m3/src/query/policy/filter"
+	"github.com/m3db/m3/src/query/rules"
 	"github.com/m3db/m3/src/query/storage"
 	"github.com/m3db/m3/src/query/storage/fanout"
 	"github.com/m3db/m3/src/query/storage/local"
@@ -134,8 +135,13 @@ func Run(runOpts RunOptions) {
 		}
 	}()
 
+	if _, err := cfg.Tracing.InitGlobalTracer(); err != nil {
+		logger.Fatal("could not create tracer", zap.Any("error", err))
+	}
+
 	var (
 		backendStorage storage.Storage
+		clusters       local.Clusters
 		clusterClient  clusterclient.Client
 		downsampler    downsample.Downsampler
 		enabled        bool
@@ -155,17 +161,42 @@ func Run(runOpts RunOptions) {
 		logger.Info("setup grpc backend")
 	} else {
 		var cleanup cleanupFn
-		backendStorage, clusterClient, downsampler, cleanup, err = newM3DBStorage(runOpts, cfg, logger, scope)
+		backendStorage, clusters, clusterClient, downsampler, cleanup, err = newM3DBStorage(runOpts, cfg, logger, scope)
 		if err != nil {
 			logger.Fatal("unable to setup m3db backend", zap.Error(err))
 		}
 		defer cleanup()
 	}
 
-	engine := executor.NewEngine(backendStorage)
+	engine := executor.NewEngineWithTracker(backendStorage, cfg.Query.NewTracker())
+
+	var ruleManager *rules.Manager
+	if cfg.Rules != nil {
+		instrumentOpts := instrument.NewOptions().
+			SetZapLogger(logger).
+			SetMetricsScope(scope.SubScope("rules"))
+		ruleManager, err = cfg.Rules.NewManager(engine, backendStorage, instrumentOpts)
+		if err != nil {
+			logger.Fatal("unable to set up rule manager", zap.Error(err))
+		}
+
+		if err := ruleManager.Run(); err != nil {
+			logger.Fatal("unable to start rule manager", zap.Error(err))
+		}
+
+		defer func() {
+			logger.Info("closing rule manager")
+			if err := ruleManager.Close(); err != nil {
+				logger.Error("unable to close rule manager", zap.Error(err))
+			}
+		}()
+
+		logger.Info("started rule manager",
+			zap.Strings("ruleFiles", cfg.Rules.RuleFiles))
+	}
 
 	handler, err := httpd.NewHandler(backendStorage, downsampler, engine,
-		clusterClient, cfg, runOpts.DBConfig, scope)
+		ruleManager, clusters, clusterClient, cfg, runOpts.DBConfig, scope)
 	if err != nil {
 		logger.Fatal("unable to set up handlers", zap.Error(err))
 	}
@@ -216,7 +247,7 @@ func newM3DBStorage(
 	cfg config.Configuration,
 	logger *zap.Logger,
 	scope tally.Scope,
-) (storage.Storage, clusterclient.Client, downsample.Downsampler, cleanupFn, error) {
+) (storage.Storage, local.Clusters, clusterclient.Client, downsample.Downsampler, cleanupFn, error) {
 	var clusterClientCh <-chan clusterclient.Client
 	if runOpts.ClusterClient != nil {
 		clusterClientCh = runOpts.ClusterClient
@@ -242,7 +273,7 @@ func newM3DBStorage(
 			clusterSvcClientOpts := etcdCfg.NewOptions()
 			clusterManagementClient, err = etcdclient.NewConfigServiceClient(clusterSvcClientOpts)
 			if err != nil {
-				return nil, nil, nil, nil, errors.Wrap(err, "unable to create cluster management etcd client")
+				return nil, nil, nil, nil, nil, errors.Wrap(err, "unable to create cluster management etcd client")
 			}
 
 			clusterClientSendableCh := make(chan clusterclient.Client, 1)
@@ -253,7 +284,7 @@ func newM3DBStorage(
 
 	clusters, err := initClusters(cfg, runOpts.DBClient, logger)
 	if err != nil {
-		return nil, nil, nil, nil, err
+		return nil, nil, nil, nil, nil, err
 	}
 
 	workerPoolCount := cfg.DecompressWorkerPoolCount
@@ -281,9 +312,9 @@ func newM3DBStorage(
 		return workerPool
 	})
 
-	fanoutStorage, storageCleanup, err := newStorages(logger, clusters, cfg, objectPool)
+	fanoutStorage, storageCleanup, err := newStorages(logger, scope, clusters, cfg, objectPool)
 	if err != nil {
-		return nil, nil, nil, nil, errors.Wrap(err, "unable to set up storages")
+		return nil, nil, nil, nil, nil, errors.Wrap(err, "unable to set up storages")
 	}
 
 	var clusterClient clusterclient.Client
@@ -304,12 +335,12 @@ func newM3DBStorage(
 			zap.Int("numAggregatedClusterNamespaces", n))
 		autoMappingRules, err := newDownsamplerAutoMappingRules(namespaces)
 		if err != nil {
-			return nil, nil, nil, nil, err
+			return nil, nil, nil, nil, nil, err
 		}
 		downsampler, err = newDownsampler(clusterManagementClient,
-			fanoutStorage, autoMappingRules, instrumentOptions)
+			fanoutStorage, autoMappingRules, cfg.Downsample.Rules, instrumentOptions)
 		if err != nil {
-			return nil, nil, nil, nil, err
+			return nil, nil, nil, nil, nil, err
 		}
 	}
 
@@ -329,13 +360,14 @@ func newM3DBStorage(
 		return lastErr
 	}
 
-	return fanoutStorage, clusterClient, downsampler, cleanup, nil
+	return fanoutStorage, clusters, clusterClient, downsampler, cleanup, nil
 }
 
 func newDownsampler(
 	clusterManagementClient clusterclient.Client,
 	storage storage.Storage,
 	autoMappingRules []downsample.MappingRule,
+	staticRules downsample.RulesConfiguration,
 	instrumentOpts instrument.Options,
 ) (downsample.Downsampler, error) {
 	if clusterManagementClient == nil {
@@ -364,6 +396,8 @@ func newDownsampler(
 		Storage:               storage,
 		RulesKVStore:          kvStore,
 		AutoMappingRules:      autoMappingRules,
+		MappingRules:          staticRules.MappingRules,
+		RollupRules:           staticRules.RollupRules,
 		ClockOptions:          clock.NewOptions(),
 		InstrumentOptions:     instrumentOpts,
 		TagEncoderOptions:     tagEncoderOptions,
@@ -455,13 +489,19 @@ func initClusters(cfg config.Configuration, dbClientCh <-chan client.Client, log
 
 func newStorages(
 	logger *zap.Logger,
+	scope tally.Scope,
 	clusters local.Clusters,
 	cfg config.Configuration,
 	workerPool pool.ObjectPool,
 ) (storage.Storage, cleanupFn, error) {
 	cleanup := func() error { return nil }
 
-	localStorage := local.NewStorage(clusters, workerPool)
+	localStorage := local.NewStorage(clusters, workerPool, local.WriteBatchOptions{
+		Workers:          cfg.Writes.WorkerPoolSize,
+		QueueSize:        cfg.Writes.QueueSize,
+		WriteConcurrency: cfg.Writes.WriteConcurrency,
+		Scope:            scope.SubScope("write-batch"),
+	})
 	stores := []storage.Storage{localStorage}
 	remoteEnabled := false
 	if cfg.RPC != nil && cfg.RPC.Enabled {
@@ -41,7 +41,8 @@ func FetchResultToBlockResult(result *FetchResult, query *FetchQuery) (block.Res
 	}
 
 	return block.Result{
-		Blocks: []block.Block{multiBlock},
+		Blocks:   []block.Block{multiBlock},
+		Metadata: result.Metadata,
 	}, nil
 }
 
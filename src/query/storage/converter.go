@@ -26,6 +26,7 @@ import (
 	"time"
 
 	"github.com/m3db/m3/src/dbnode/encoding"
+	"github.com/m3db/m3/src/query/block"
 	"github.com/m3db/m3/src/query/generated/proto/prompb"
 	"github.com/m3db/m3/src/query/models"
 	"github.com/m3db/m3/src/query/ts"
@@ -225,6 +226,7 @@ func decompressSequentially(
 
 	return &FetchResult{
 		SeriesList: seriesList,
+		Metadata:   block.NewResultMetadata(),
 	}, nil
 }
 
@@ -279,6 +281,7 @@ func decompressConcurrently(
 
 	return &FetchResult{
 		SeriesList: seriesList,
+		Metadata:   block.NewResultMetadata(),
 	}, nil
 }
 
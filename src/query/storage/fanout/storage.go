@@ -22,6 +22,7 @@ package fanout
 
 import (
 	"context"
+	"fmt"
 
 	"github.com/m3db/m3/src/query/block"
 	"github.com/m3db/m3/src/query/errors"
@@ -63,13 +64,30 @@ func (s *fanoutStorage) Fetch(ctx context.Context, query *storage.FetchQuery, op
 
 func handleFetchResponses(requests []execution.Request) (*storage.FetchResult, error) {
 	seriesList := make([]*ts.Series, 0, len(requests))
-	result := &storage.FetchResult{SeriesList: seriesList, LocalOnly: true}
+	result := &storage.FetchResult{
+		SeriesList: seriesList,
+		LocalOnly:  true,
+		Metadata:   block.NewResultMetadata(),
+	}
+
+	var firstErr error
+	numSucceeded := 0
 	for _, req := range requests {
 		fetchreq, ok := req.(*fetchRequest)
 		if !ok {
 			return nil, errors.ErrFetchRequestType
 		}
 
+		if fetchreq.err != nil {
+			// NB: the store is allowed to fail, the result is returned as
+			// partial with a warning naming the store that failed.
+			if firstErr == nil {
+				firstErr = fetchreq.err
+			}
+			result.Metadata.AddWarning(fetchreq.store.Type().String(), fetchreq.err.Error())
+			continue
+		}
+
 		if fetchreq.result == nil {
 			return nil, errors.ErrInvalidFetchResult
 		}
@@ -78,7 +96,13 @@ func handleFetchResponses(requests []execution.Request) (*storage.FetchResult, e
 			result.LocalOnly = false
 		}
 
+		numSucceeded++
 		result.SeriesList = append(result.SeriesList, fetchreq.result.SeriesList...)
+		result.Metadata = result.Metadata.CombineMetadata(fetchreq.result.Metadata)
+	}
+
+	if numSucceeded == 0 && firstErr != nil {
+		return nil, firstErr
 	}
 
 	return result, nil
@@ -111,6 +135,31 @@ func (s *fanoutStorage) Write(ctx context.Context, query *storage.WriteQuery) er
 	return execution.ExecuteParallel(ctx, requests)
 }
 
+func (s *fanoutStorage) WriteBatch(ctx context.Context, queries []*storage.WriteQuery) error {
+	requests := make([]execution.Request, 0, len(s.stores))
+	for _, store := range s.stores {
+		filtered := make([]*storage.WriteQuery, 0, len(queries))
+		for _, query := range queries {
+			if s.writeFilter(query, store) {
+				filtered = append(filtered, query)
+			}
+		}
+
+		if len(filtered) > 0 {
+			requests = append(requests, newWriteBatchRequest(store, filtered))
+		}
+	}
+
+	err := execution.ExecuteParallel(ctx, requests)
+	if err == errors.ErrWriteQueueFull && len(requests) > 1 {
+		// Other stores may have written the queries already so the write
+		// must not be reported as rejected, otherwise it is retried and
+		// written twice to those stores
+		return fmt.Errorf("partial write, a store rejected the write: %v", err)
+	}
+	return err
+}
+
 func (s *fanoutStorage) Type() storage.Type {
 	return storage.TypeMultiDC
 }
@@ -118,14 +167,42 @@ func (s *fanoutStorage) Type() storage.Type {
 func (s *fanoutStorage) FetchBlocks(
 	ctx context.Context, query *storage.FetchQuery, options *storage.FetchOptions) (block.Result, error) {
 	stores := filterStores(s.stores, s.writeFilter, query)
-	blockResult := block.Result{}
+	blockResult := block.Result{
+		Metadata: block.NewResultMetadata(),
+	}
+
+	var firstErr error
+	numSucceeded := 0
 	for _, store := range stores {
 		result, err := store.FetchBlocks(ctx, query, options)
+		if err == errors.ErrNotImplemented {
+			// NB: stores that do not support fetching blocks, such as remote
+			// stores, are skipped rather than reported as having failed.
+			if firstErr == nil {
+				firstErr = err
+			}
+			continue
+		}
+
 		if err != nil {
-			return block.Result{}, err
+			if !allowPartialFailure(store) {
+				return block.Result{}, err
+			}
+
+			if firstErr == nil {
+				firstErr = err
+			}
+			blockResult.Metadata.AddWarning(store.Type().String(), err.Error())
+			continue
 		}
 
+		numSucceeded++
 		blockResult.Blocks = append(blockResult.Blocks, result.Blocks...)
+		blockResult.Metadata = blockResult.Metadata.CombineMetadata(result.Metadata)
+	}
+
+	if numSucceeded == 0 && firstErr != nil {
+		return block.Result{}, firstErr
 	}
 
 	return blockResult, nil
@@ -159,6 +236,7 @@ type fetchRequest struct {
 	query   *storage.FetchQuery
 	options *storage.FetchOptions
 	result  *storage.FetchResult
+	err     error
 }
 
 func newFetchRequest(store storage.Storage, query *storage.FetchQuery, options *storage.FetchOptions) execution.Request {
@@ -172,13 +250,25 @@ func newFetchRequest(store storage.Storage, query *storage.FetchQuery, options *
 func (f *fetchRequest) Process(ctx context.Context) error {
 	result, err := f.store.Fetch(ctx, f.query, f.options)
 	if err != nil {
-		return err
+		if !allowPartialFailure(f.store) {
+			return err
+		}
+
+		f.err = err
+		return nil
 	}
 
 	f.result = result
 	return nil
 }
 
+// allowPartialFailure returns true if a failure of the store should result
+// in a partial result rather than failing the whole fetch, only failures of
+// local storage are considered fatal.
+func allowPartialFailure(store storage.Storage) bool {
+	return store.Type() != storage.TypeLocalDC
+}
+
 type writeRequest struct {
 	store storage.Storage
 	query *storage.WriteQuery
@@ -194,3 +284,28 @@ func newWriteRequest(store storage.Storage, query *storage.WriteQuery) execution
 func (f *writeRequest) Process(ctx context.Context) error {
 	return f.store.Write(ctx, f.query)
 }
+
+type writeBatchRequest struct {
+	store   storage.Storage
+	queries []*storage.WriteQuery
+}
+
+func newWriteBatchRequest(store storage.Storage, queries []*storage.WriteQuery) execution.Request {
+	return &writeBatchRequest{
+		store:   store,
+		queries: queries,
+	}
+}
+
+func (f *writeBatchRequest) Process(ctx context.Context) error {
+	if appender, ok := f.store.(storage.BatchAppender); ok {
+		return appender.WriteBatch(ctx, f.queries)
+	}
+
+	// Fall back to writing each query for stores that do not support batches
+	requests := make([]execution.Request, len(f.queries))
+	for idx, query := range f.queries {
+		requests[idx] = newWriteRequest(f.store, query)
+	}
+	return execution.ExecuteParallel(ctx, requests)
+}
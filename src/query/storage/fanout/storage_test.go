@@ -27,9 +27,11 @@ import (
 	"time"
 
 	"github.com/m3db/m3/src/dbnode/encoding"
+	"github.com/m3db/m3/src/query/block"
 	"github.com/m3db/m3/src/query/errors"
 	"github.com/m3db/m3/src/query/policy/filter"
 	"github.com/m3db/m3/src/query/storage"
+	"github.com/m3db/m3/src/query/storage/mock"
 	"github.com/m3db/m3/src/query/test/local"
 	"github.com/m3db/m3/src/query/test/seriesiter"
 	"github.com/m3db/m3/src/query/ts"
@@ -130,6 +132,67 @@ func TestFanoutReadSuccess(t *testing.T) {
 	assert.NoError(t, store.Close())
 }
 
+func TestFanoutReadRemoteErrorReturnsPartialResult(t *testing.T) {
+	setup()
+	localStore := mock.NewMockStorage()
+	localStore.SetTypeResult(storage.TypeLocalDC)
+	localStore.SetFetchResult(&storage.FetchResult{
+		SeriesList: ts.SeriesList{
+			ts.NewSeries("foo", ts.NewFixedStepValues(time.Second, 1, 1, time.Now()), nil),
+		},
+		Metadata: block.NewResultMetadata(),
+	}, nil)
+
+	remoteStore := mock.NewMockStorage()
+	remoteStore.SetTypeResult(storage.TypeRemoteDC)
+	remoteStore.SetFetchResult(nil, fmt.Errorf("remote unavailable"))
+
+	store := NewStorage([]storage.Storage{localStore, remoteStore}, filterFunc(true), filterFunc(true))
+	res, err := store.Fetch(context.TODO(), &storage.FetchQuery{}, &storage.FetchOptions{})
+	require.NoError(t, err)
+	require.Len(t, res.SeriesList, 1)
+	assert.False(t, res.LocalOnly)
+	assert.False(t, res.Metadata.NonExhaustive)
+	assert.Equal(t, block.Warnings{
+		{Name: "remote", Message: "remote unavailable"},
+	}, res.Metadata.Warnings)
+}
+
+func TestFanoutReadAllRemoteErrors(t *testing.T) {
+	setup()
+	remoteStore := mock.NewMockStorage()
+	remoteStore.SetTypeResult(storage.TypeRemoteDC)
+	remoteStore.SetFetchResult(nil, fmt.Errorf("remote unavailable"))
+
+	store := NewStorage([]storage.Storage{remoteStore}, filterFunc(true), filterFunc(true))
+	_, err := store.Fetch(context.TODO(), &storage.FetchQuery{}, &storage.FetchOptions{})
+	assert.Error(t, err)
+}
+
+func TestFanoutFetchBlocksSkipsNotImplemented(t *testing.T) {
+	setup()
+	localStore := mock.NewMockStorage()
+	localStore.SetTypeResult(storage.TypeLocalDC)
+	localStore.SetFetchBlocksResult(block.Result{
+		Blocks:   []block.Block{block.NewScalar(1, block.Bounds{})},
+		Metadata: block.NewResultMetadata(),
+	}, nil)
+
+	remoteStore := mock.NewMockStorage()
+	remoteStore.SetTypeResult(storage.TypeRemoteDC)
+	remoteStore.SetFetchBlocksResult(block.Result{}, errors.ErrNotImplemented)
+
+	store := NewStorage([]storage.Storage{localStore, remoteStore}, filterFunc(true), filterFunc(true))
+	res, err := store.FetchBlocks(context.TODO(), &storage.FetchQuery{}, &storage.FetchOptions{})
+	require.NoError(t, err)
+	assert.Len(t, res.Blocks, 1)
+	assert.False(t, res.Metadata.IsPartial())
+
+	store = NewStorage([]storage.Storage{remoteStore}, filterFunc(true), filterFunc(true))
+	_, err = store.FetchBlocks(context.TODO(), &storage.FetchQuery{}, &storage.FetchOptions{})
+	assert.Equal(t, errors.ErrNotImplemented, err)
+}
+
 func TestFanoutSearchEmpty(t *testing.T) {
 	store := setupFanoutRead(t, false)
 	res, err := store.FetchTags(context.TODO(), nil, nil)
@@ -169,3 +232,29 @@ func TestFanoutWriteSuccess(t *testing.T) {
 	})
 	assert.NoError(t, err)
 }
+
+type batchStorage struct {
+	mock.Storage
+	err error
+}
+
+func (s *batchStorage) WriteBatch(context.Context, []*storage.WriteQuery) error {
+	return s.err
+}
+
+func TestFanoutWriteBatchQueueFull(t *testing.T) {
+	queries := []*storage.WriteQuery{{}}
+	full := &batchStorage{Storage: mock.NewMockStorage(), err: errors.ErrWriteQueueFull}
+
+	// A single store rejecting the write means nothing was written
+	store := NewStorage([]storage.Storage{full}, filterFunc(true), filterFunc(true))
+	err := store.(storage.BatchAppender).WriteBatch(context.TODO(), queries)
+	assert.Equal(t, errors.ErrWriteQueueFull, err)
+
+	// Other stores may have written so the write must not be retried
+	written := &batchStorage{Storage: mock.NewMockStorage()}
+	store = NewStorage([]storage.Storage{full, written}, filterFunc(true), filterFunc(true))
+	err = store.(storage.BatchAppender).WriteBatch(context.TODO(), queries)
+	require.Error(t, err)
+	assert.NotEqual(t, errors.ErrWriteQueueFull, err)
+}
@@ -22,6 +22,7 @@ package storage
 
 import (
 	"fmt"
+	"regexp/syntax"
 
 	"github.com/m3db/m3/src/dbnode/storage/index"
 	"github.com/m3db/m3/src/m3ninx/idx"
@@ -87,6 +88,11 @@ func FetchOptionsToM3Options(fetchOptions *FetchOptions, fetchQuery *FetchQuery)
 // FetchQueryToM3Query converts an m3coordinator fetch query to an M3 query
 func FetchQueryToM3Query(fetchQuery *FetchQuery) (index.Query, error) {
 	matchers := fetchQuery.TagMatchers
+	if len(matchers) == 0 {
+		// NB: a query without any matchers selects all series.
+		return index.Query{Query: idx.NewAllQuery()}, nil
+	}
+
 	idxQueries := make([]idx.Query, len(matchers))
 	var err error
 	for i, matcher := range matchers {
@@ -101,34 +107,96 @@ func FetchQueryToM3Query(fetchQuery *FetchQuery) (index.Query, error) {
 }
 
 func matcherToQuery(matcher *models.Matcher) (idx.Query, error) {
-	negate := false
+	var (
+		name   = []byte(matcher.Name)
+		value  = []byte(matcher.Value)
+		negate = false
+	)
 	switch matcher.Type {
 	// Support for Regexp types
 	case models.MatchNotRegexp:
 		negate = true
 		fallthrough
 	case models.MatchRegexp:
-		query, err := idx.NewRegexpQuery([]byte(matcher.Name), []byte(matcher.Value))
-		if err != nil {
-			return idx.Query{}, err
-		}
-		if negate {
-			query = idx.NewNegationQuery(query)
-		}
-		return query, nil
+		return regexpToQuery(name, value, negate)
 
 		// Support exact matches
 	case models.MatchNotEqual:
 		negate = true
 		fallthrough
 	case models.MatchEqual:
-		query := idx.NewTermQuery([]byte(matcher.Name), []byte(matcher.Value))
-		if negate {
-			query = idx.NewNegationQuery(query)
+		if len(value) == 0 {
+			return emptyValueToQuery(name, negate), nil
 		}
-		return query, nil
+		return negateIf(idx.NewTermQuery(name, value), negate), nil
 
 	default:
 		return idx.Query{}, fmt.Errorf("unsupported query type: %v", matcher)
 	}
 }
+
+// regexpToQuery converts a regexp matcher to a query, using the cheaper field,
+// prefix and match all queries for the common regexps they are equivalent to.
+// NB: these assume tag values do not contain newlines, which '.' does not match.
+func regexpToQuery(name, value []byte, negate bool) (idx.Query, error) {
+	parsed, err := syntax.Parse(string(value), syntax.Perl)
+	if err != nil {
+		return idx.Query{}, err
+	}
+
+	parsed = parsed.Simplify()
+	switch {
+	case parsed.Op == syntax.OpEmptyMatch:
+		// i.e. `=~""` is equivalent to `=""`.
+		return emptyValueToQuery(name, negate), nil
+
+	case isAnyString(parsed):
+		// i.e. `=~".*"` matches all series, including those without the tag.
+		return negateIf(idx.NewAllQuery(), negate), nil
+
+	case parsed.Op == syntax.OpPlus && isAnyChar(parsed.Sub[0]):
+		// i.e. `=~".+"` is equivalent to `!=""`.
+		return emptyValueToQuery(name, !negate), nil
+
+	case parsed.Op == syntax.OpConcat && len(parsed.Sub) == 2 &&
+		parsed.Sub[0].Op == syntax.OpLiteral &&
+		parsed.Sub[0].Flags&syntax.FoldCase == 0 &&
+		isAnyString(parsed.Sub[1]):
+		// i.e. `=~"foo.*"` matches values beginning with the literal prefix.
+		prefix := []byte(string(parsed.Sub[0].Rune))
+		return negateIf(idx.NewPrefixQuery(name, prefix), negate), nil
+	}
+
+	query, err := idx.NewRegexpQuery(name, value)
+	if err != nil {
+		return idx.Query{}, err
+	}
+
+	return negateIf(query, negate), nil
+}
+
+// emptyValueToQuery returns the query for matching an empty tag value, which
+// matches the series without the tag.
+func emptyValueToQuery(name []byte, negate bool) idx.Query {
+	if negate {
+		return idx.NewFieldQuery(name)
+	}
+
+	return idx.NewNegationQuery(idx.NewFieldQuery(name))
+}
+
+func negateIf(query idx.Query, negate bool) idx.Query {
+	if negate {
+		return idx.NewNegationQuery(query)
+	}
+
+	return query
+}
+
+func isAnyChar(re *syntax.Regexp) bool {
+	return re.Op == syntax.OpAnyChar || re.Op == syntax.OpAnyCharNotNL
+}
+
+func isAnyString(re *syntax.Regexp) bool {
+	return re.Op == syntax.OpStar && isAnyChar(re.Sub[0])
+}
@@ -118,6 +118,98 @@ func TestFetchQueryToM3Query(t *testing.T) {
 				},
 			},
 		},
+		{
+			name:     "empty value match",
+			expected: "conjunction(negation(field(t1)))",
+			matchers: models.Matchers{
+				{
+					Type:  models.MatchEqual,
+					Name:  "t1",
+					Value: "",
+				},
+			},
+		},
+		{
+			name:     "empty value match negated",
+			expected: "conjunction(field(t1))",
+			matchers: models.Matchers{
+				{
+					Type:  models.MatchNotEqual,
+					Name:  "t1",
+					Value: "",
+				},
+			},
+		},
+		{
+			name:     "regexp match any",
+			expected: "conjunction(all())",
+			matchers: models.Matchers{
+				{
+					Type:  models.MatchRegexp,
+					Name:  "t1",
+					Value: ".*",
+				},
+			},
+		},
+		{
+			name:     "regexp match non empty",
+			expected: "conjunction(field(t1))",
+			matchers: models.Matchers{
+				{
+					Type:  models.MatchRegexp,
+					Name:  "t1",
+					Value: ".+",
+				},
+			},
+		},
+		{
+			name:     "regexp match non empty negated",
+			expected: "conjunction(negation(field(t1)))",
+			matchers: models.Matchers{
+				{
+					Type:  models.MatchNotRegexp,
+					Name:  "t1",
+					Value: ".+",
+				},
+			},
+		},
+		{
+			name:     "regexp match prefix",
+			expected: "conjunction(prefix(t1, v1))",
+			matchers: models.Matchers{
+				{
+					Type:  models.MatchRegexp,
+					Name:  "t1",
+					Value: "v1.*",
+				},
+			},
+		},
+		{
+			name:     "regexp match prefix negated",
+			expected: "conjunction(negation(prefix(t1, v1)))",
+			matchers: models.Matchers{
+				{
+					Type:  models.MatchNotRegexp,
+					Name:  "t1",
+					Value: "v1.*",
+				},
+			},
+		},
+		{
+			name:     "regexp match case insensitive prefix",
+			expected: "conjunction(regexp(t1, (?i)v1.*))",
+			matchers: models.Matchers{
+				{
+					Type:  models.MatchRegexp,
+					Name:  "t1",
+					Value: "(?i)v1.*",
+				},
+			},
+		},
+		{
+			name:     "no matchers",
+			expected: "all()",
+		},
 	}
 
 	for _, test := range tests {
@@ -43,6 +43,20 @@ const (
 	TypeMultiDC
 )
 
+// String returns the string representation of the storage type.
+func (t Type) String() string {
+	switch t {
+	case TypeLocalDC:
+		return "local"
+	case TypeRemoteDC:
+		return "remote"
+	case TypeMultiDC:
+		return "multi"
+	default:
+		return "unknown"
+	}
+}
+
 // Storage provides an interface for reading and writing to the tsdb
 type Storage interface {
 	Querier
@@ -113,6 +127,15 @@ type Appender interface {
 	Write(ctx context.Context, query *WriteQuery) error
 }
 
+// BatchAppender provides batched writes against a storage, storages that
+// implement it can write many series more efficiently than one at a time.
+type BatchAppender interface {
+	// WriteBatch writes a batch of queries, errors.ErrWriteQueueFull is
+	// returned without any of the queries having been written if the storage
+	// is overloaded and the write should be retried.
+	WriteBatch(ctx context.Context, queries []*WriteQuery) error
+}
+
 // SearchResults is the result from a search
 type SearchResults struct {
 	Metrics models.Metrics
@@ -123,6 +146,7 @@ type FetchResult struct {
 	SeriesList ts.SeriesList // The aggregated list of results across all underlying storage calls
 	LocalOnly  bool
 	HasNext    bool
+	Metadata   block.ResultMetadata
 }
 
 // QueryResult is the result from a query
@@ -0,0 +1,160 @@
+// Copyright (c) 2018 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package local
+
+import (
+	"sort"
+	"time"
+
+	"github.com/m3db/m3/src/query/storage"
+)
+
+// fetchSegment is a time range of a query that is fetched from one or more
+// cluster namespaces, when there is more than one namespace the results are
+// deduplicated per series preferring the finest resolution namespace.
+type fetchSegment struct {
+	namespaces ClusterNamespaces
+	start      time.Time
+	end        time.Time
+	resolution time.Duration
+}
+
+// planFetch splits the query range across the cluster namespaces, preferring
+// the finest resolution namespace that retains all series for each part of the
+// range. Finer resolution namespaces that only retain a subset of series are
+// fetched alongside it for the part of the range they retain completely, and
+// a part of the range retained only by such namespaces is fetched from all of
+// them. The resulting segments are ordered by time and together cover the
+// whole range, false is returned if no combination of namespaces can cover
+// the range.
+func planFetch(
+	namespaces ClusterNamespaces,
+	start, end, now time.Time,
+) ([]fetchSegment, bool) {
+	candidates := make(ClusterNamespaces, len(namespaces))
+	copy(candidates, namespaces)
+	sort.SliceStable(candidates, func(i, j int) bool {
+		a, b := candidates[i].Options().Attributes(), candidates[j].Options().Attributes()
+		if a.Resolution != b.Resolution {
+			return a.Resolution < b.Resolution
+		}
+		return a.Retention > b.Retention
+	})
+
+	var complete, partial ClusterNamespaces
+	for _, namespace := range candidates {
+		if retainsAllSeries(namespace) {
+			complete = append(complete, namespace)
+		} else {
+			partial = append(partial, namespace)
+		}
+	}
+
+	// Walk backwards in time from the end of the query, each namespace
+	// retaining all series covers the span between where its retention
+	// begins and where the previously selected finer resolution namespace's
+	// coverage began
+	var (
+		segments []fetchSegment
+		cursor   = end
+	)
+	for _, namespace := range complete {
+		if !cursor.After(start) {
+			break
+		}
+
+		attrs := namespace.Options().Attributes()
+		clusterStart := now.Add(-1 * attrs.Retention)
+		if !clusterStart.Before(cursor) {
+			// Retention does not extend further back than finer namespaces
+			continue
+		}
+
+		segmentStart := clusterStart
+		if segmentStart.Before(start) {
+			segmentStart = start
+		}
+		segmentNamespaces := partialNamespaces(partial, segmentStart, now,
+			func(resolution time.Duration) bool {
+				return resolution < attrs.Resolution
+			})
+		segments = append(segments, fetchSegment{
+			namespaces: append(segmentNamespaces, namespace),
+			start:      segmentStart,
+			end:        cursor,
+			resolution: attrs.Resolution,
+		})
+		cursor = segmentStart
+	}
+
+	if cursor.After(start) {
+		// Only namespaces retaining a subset of series can cover the rest
+		// of the range, fan out to all of them
+		segmentNamespaces := partialNamespaces(partial, start, now,
+			func(time.Duration) bool { return true })
+		if len(segmentNamespaces) == 0 {
+			return nil, false
+		}
+		last := segmentNamespaces[len(segmentNamespaces)-1]
+		segments = append(segments, fetchSegment{
+			namespaces: segmentNamespaces,
+			start:      start,
+			end:        cursor,
+			resolution: last.Options().Attributes().Resolution,
+		})
+	}
+
+	// Order segments from oldest to newest
+	for i, j := 0, len(segments)-1; i < j; i, j = i+1, j-1 {
+		segments[i], segments[j] = segments[j], segments[i]
+	}
+	return segments, true
+}
+
+// partialNamespaces returns the namespaces that retain data back to start and
+// match the resolution filter.
+func partialNamespaces(
+	namespaces ClusterNamespaces,
+	start, now time.Time,
+	resolutionFilter func(resolution time.Duration) bool,
+) ClusterNamespaces {
+	var result ClusterNamespaces
+	for _, namespace := range namespaces {
+		attrs := namespace.Options().Attributes()
+		clusterStart := now.Add(-1 * attrs.Retention)
+		if clusterStart.After(start) || !resolutionFilter(attrs.Resolution) {
+			continue
+		}
+		result = append(result, namespace)
+	}
+	return result
+}
+
+// retainsAllSeries returns whether a namespace retains every series written,
+// aggregated namespaces only do so when all series are downsampled to them.
+func retainsAllSeries(namespace ClusterNamespace) bool {
+	opts := namespace.Options()
+	if opts.Attributes().MetricsType != storage.AggregatedMetricsType {
+		return true
+	}
+	downsampleOpts, err := opts.DownsampleOptions()
+	return err == nil && downsampleOpts.All
+}
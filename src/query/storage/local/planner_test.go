@@ -0,0 +1,189 @@
+// Copyright (c) 2018 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package local
+
+import (
+	"testing"
+	"time"
+
+	"github.com/m3db/m3/src/query/storage"
+	"github.com/m3db/m3x/ident"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestClusterNamespace(
+	id string,
+	metricsType storage.MetricsType,
+	retention, resolution time.Duration,
+) ClusterNamespace {
+	return &clusterNamespace{
+		namespaceID: ident.StringID(id),
+		options: ClusterNamespaceOptions{
+			attributes: storage.Attributes{
+				MetricsType: metricsType,
+				Retention:   retention,
+				Resolution:  resolution,
+			},
+		},
+	}
+}
+
+func newTestPartialClusterNamespace(
+	id string,
+	retention, resolution time.Duration,
+) ClusterNamespace {
+	namespace := newTestClusterNamespace(id, storage.AggregatedMetricsType,
+		retention, resolution).(*clusterNamespace)
+	namespace.options.downsample = &ClusterNamespaceDownsampleOptions{All: false}
+	return namespace
+}
+
+func segmentNamespaceIDs(segment fetchSegment) []string {
+	ids := make([]string, 0, len(segment.namespaces))
+	for _, namespace := range segment.namespaces {
+		ids = append(ids, namespace.NamespaceID().String())
+	}
+	return ids
+}
+
+func testPlannerNamespaces() ClusterNamespaces {
+	return ClusterNamespaces{
+		newTestClusterNamespace("aggregated_1y", storage.AggregatedMetricsType,
+			365*24*time.Hour, time.Hour),
+		newTestClusterNamespace("unaggregated", storage.UnaggregatedMetricsType,
+			48*time.Hour, 0),
+		newTestClusterNamespace("aggregated_30d", storage.AggregatedMetricsType,
+			30*24*time.Hour, 5*time.Minute),
+	}
+}
+
+func TestPlanFetchRecentUsesRaw(t *testing.T) {
+	now := time.Now()
+	start := now.Add(-time.Hour)
+	segments, ok := planFetch(testPlannerNamespaces(), start, now, now)
+	require.True(t, ok)
+	require.Equal(t, 1, len(segments))
+	assert.Equal(t, []string{"unaggregated"}, segmentNamespaceIDs(segments[0]))
+	assert.Equal(t, start, segments[0].start)
+	assert.Equal(t, now, segments[0].end)
+}
+
+func TestPlanFetchSplitsAcrossNamespaces(t *testing.T) {
+	now := time.Now()
+	start := now.Add(-90 * 24 * time.Hour)
+	segments, ok := planFetch(testPlannerNamespaces(), start, now, now)
+	require.True(t, ok)
+	require.Equal(t, 3, len(segments))
+
+	expected := []struct {
+		namespace  string
+		start      time.Time
+		end        time.Time
+		resolution time.Duration
+	}{
+		{"aggregated_1y", start, now.Add(-30 * 24 * time.Hour), time.Hour},
+		{"aggregated_30d", now.Add(-30 * 24 * time.Hour), now.Add(-48 * time.Hour), 5 * time.Minute},
+		{"unaggregated", now.Add(-48 * time.Hour), now, 0},
+	}
+	for i, e := range expected {
+		assert.Equal(t, []string{e.namespace}, segmentNamespaceIDs(segments[i]))
+		assert.Equal(t, e.start, segments[i].start)
+		assert.Equal(t, e.end, segments[i].end)
+		assert.Equal(t, e.resolution, segments[i].resolution)
+	}
+}
+
+func TestPlanFetchSkipsRedundantNamespaces(t *testing.T) {
+	now := time.Now()
+	start := now.Add(-10 * 24 * time.Hour)
+	segments, ok := planFetch(testPlannerNamespaces(), start, now, now)
+	require.True(t, ok)
+	require.Equal(t, 2, len(segments))
+	assert.Equal(t, []string{"aggregated_30d"}, segmentNamespaceIDs(segments[0]))
+	assert.Equal(t, []string{"unaggregated"}, segmentNamespaceIDs(segments[1]))
+}
+
+func TestPlanFetchBeyondRetention(t *testing.T) {
+	now := time.Now()
+	start := now.Add(-2 * 365 * 24 * time.Hour)
+	_, ok := planFetch(testPlannerNamespaces(), start, now, now)
+	assert.False(t, ok)
+}
+
+func TestPlanFetchPartialNamespaceFetchedWithCompleteNamespace(t *testing.T) {
+	namespaces := ClusterNamespaces{
+		newTestClusterNamespace("unaggregated", storage.UnaggregatedMetricsType,
+			48*time.Hour, 0),
+		newTestPartialClusterNamespace("aggregated_partial_1m",
+			30*24*time.Hour, time.Minute),
+		newTestClusterNamespace("aggregated_5m", storage.AggregatedMetricsType,
+			365*24*time.Hour, 5*time.Minute),
+	}
+
+	// The partial namespace does not retain all series so cannot cover the
+	// range by itself, it is fetched alongside the complete namespace
+	now := time.Now()
+	start := now.Add(-10 * 24 * time.Hour)
+	segments, ok := planFetch(namespaces, start, now, now)
+	require.True(t, ok)
+	require.Equal(t, 2, len(segments))
+
+	assert.Equal(t, []string{"aggregated_partial_1m", "aggregated_5m"},
+		segmentNamespaceIDs(segments[0]))
+	assert.Equal(t, start, segments[0].start)
+	assert.Equal(t, now.Add(-48*time.Hour), segments[0].end)
+	assert.Equal(t, 5*time.Minute, segments[0].resolution)
+	assert.Equal(t, []string{"unaggregated"}, segmentNamespaceIDs(segments[1]))
+
+	// Beyond the partial namespace retention only the complete namespace is used
+	start = now.Add(-90 * 24 * time.Hour)
+	segments, ok = planFetch(namespaces, start, now, now)
+	require.True(t, ok)
+	require.Equal(t, 2, len(segments))
+	assert.Equal(t, []string{"aggregated_5m"}, segmentNamespaceIDs(segments[0]))
+	assert.Equal(t, []string{"unaggregated"}, segmentNamespaceIDs(segments[1]))
+}
+
+func TestPlanFetchOnlyPartialNamespacesFanOut(t *testing.T) {
+	namespaces := ClusterNamespaces{
+		newTestClusterNamespace("unaggregated", storage.UnaggregatedMetricsType,
+			48*time.Hour, 0),
+		newTestPartialClusterNamespace("aggregated_partial_1m",
+			30*24*time.Hour, time.Minute),
+		newTestPartialClusterNamespace("aggregated_partial_5m",
+			30*24*time.Hour, 5*time.Minute),
+	}
+
+	now := time.Now()
+	start := now.Add(-10 * 24 * time.Hour)
+	segments, ok := planFetch(namespaces, start, now, now)
+	require.True(t, ok)
+	require.Equal(t, 2, len(segments))
+	assert.Equal(t, []string{"aggregated_partial_1m", "aggregated_partial_5m"},
+		segmentNamespaceIDs(segments[0]))
+	assert.Equal(t, 5*time.Minute, segments[0].resolution)
+	assert.Equal(t, []string{"unaggregated"}, segmentNamespaceIDs(segments[1]))
+
+	_, ok = planFetch(namespaces, now.Add(-90*24*time.Hour), now, now)
+	assert.False(t, ok)
+}
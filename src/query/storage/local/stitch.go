@@ -0,0 +1,99 @@
+// Copyright (c) 2018 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package local
+
+import (
+	"github.com/m3db/m3/src/query/block"
+	"github.com/m3db/m3/src/query/storage"
+	"github.com/m3db/m3/src/query/ts"
+)
+
+// stitchFetchResults joins the results of fetching each segment of a fetch
+// plan into a single result, concatenating the datapoints of each series in
+// time order and recording the resolution of each segment on the series.
+func stitchFetchResults(
+	segments []fetchSegment,
+	results []*storage.FetchResult,
+) *storage.FetchResult {
+	resolutions := make(ts.ResolutionSegments, 0, len(segments))
+	for _, segment := range segments {
+		resolutions = append(resolutions, ts.ResolutionSegment{
+			Start:      segment.start,
+			End:        segment.end,
+			Resolution: segment.resolution,
+		})
+	}
+
+	if len(results) == 1 {
+		result := results[0]
+		if segments[0].resolution > 0 {
+			for _, series := range result.SeriesList {
+				series.Resolutions = resolutions
+			}
+		}
+		return result
+	}
+
+	var (
+		stitched = &storage.FetchResult{
+			LocalOnly: true,
+			HasNext:   true,
+			Metadata:  block.NewResultMetadata(),
+		}
+		datapoints = make(map[string]ts.Datapoints)
+		firstSeen  = make(map[string]*ts.Series)
+		order      []string
+	)
+	for _, result := range results {
+		stitched.LocalOnly = stitched.LocalOnly && result.LocalOnly
+		stitched.HasNext = stitched.HasNext && result.HasNext
+		stitched.Metadata = stitched.Metadata.CombineMetadata(result.Metadata)
+
+		for _, series := range result.SeriesList {
+			id := series.Name()
+			existing, ok := datapoints[id]
+			if !ok {
+				order = append(order, id)
+				firstSeen[id] = series
+			}
+
+			values := series.Values()
+			for i := 0; i < values.Len(); i++ {
+				dp := values.DatapointAt(i)
+				// Segments are ordered oldest first, skip any datapoints
+				// that overlap with those of the previous segment
+				if n := len(existing); n > 0 && !dp.Timestamp.After(existing[n-1].Timestamp) {
+					continue
+				}
+				existing = append(existing, dp)
+			}
+			datapoints[id] = existing
+		}
+	}
+
+	stitched.SeriesList = make(ts.SeriesList, 0, len(order))
+	for _, id := range order {
+		series := ts.NewSeries(id, datapoints[id], firstSeen[id].Tags)
+		series.Resolutions = resolutions
+		stitched.SeriesList = append(stitched.SeriesList, series)
+	}
+	return stitched
+}
@@ -0,0 +1,90 @@
+// Copyright (c) 2018 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package local
+
+import (
+	"testing"
+	"time"
+
+	"github.com/m3db/m3/src/query/models"
+	"github.com/m3db/m3/src/query/storage"
+	"github.com/m3db/m3/src/query/ts"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStitchFetchResults(t *testing.T) {
+	now := time.Now().Truncate(time.Hour)
+	seam := now.Add(-time.Hour)
+	segments := []fetchSegment{
+		{start: now.Add(-3 * time.Hour), end: seam, resolution: time.Minute},
+		{start: seam, end: now, resolution: 0},
+	}
+
+	tags := models.Tags{{Name: "foo", Value: "bar"}}
+	results := []*storage.FetchResult{
+		{
+			SeriesList: ts.SeriesList{
+				ts.NewSeries("foo", ts.Datapoints{
+					{Timestamp: seam.Add(-2 * time.Minute), Value: 1},
+					{Timestamp: seam.Add(-time.Minute), Value: 2},
+				}, tags),
+				ts.NewSeries("old", ts.Datapoints{
+					{Timestamp: seam.Add(-time.Minute), Value: 3},
+				}, nil),
+			},
+			LocalOnly: true,
+		},
+		{
+			SeriesList: ts.SeriesList{
+				ts.NewSeries("foo", ts.Datapoints{
+					{Timestamp: seam.Add(-time.Minute), Value: 20},
+					{Timestamp: seam.Add(10 * time.Second), Value: 4},
+				}, tags),
+			},
+			LocalOnly: true,
+		},
+	}
+
+	result := stitchFetchResults(segments, results)
+	require.Equal(t, 2, len(result.SeriesList))
+	assert.True(t, result.LocalOnly)
+
+	foo := result.SeriesList[0]
+	assert.Equal(t, "foo", foo.Name())
+	assert.Equal(t, tags, foo.Tags)
+	assert.Equal(t, ts.Datapoints{
+		{Timestamp: seam.Add(-2 * time.Minute), Value: 1},
+		{Timestamp: seam.Add(-time.Minute), Value: 2},
+		{Timestamp: seam.Add(10 * time.Second), Value: 4},
+	}, foo.Values())
+
+	expectedResolutions := ts.ResolutionSegments{
+		{Start: now.Add(-3 * time.Hour), End: seam, Resolution: time.Minute},
+		{Start: seam, End: now, Resolution: 0},
+	}
+	assert.Equal(t, expectedResolutions, foo.Resolutions)
+
+	old := result.SeriesList[1]
+	assert.Equal(t, "old", old.Name())
+	assert.Equal(t, 1, old.Len())
+}
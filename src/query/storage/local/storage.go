@@ -33,10 +33,13 @@ import (
 	"github.com/m3db/m3/src/query/models"
 	"github.com/m3db/m3/src/query/storage"
 	"github.com/m3db/m3/src/query/util/execution"
+	"github.com/m3db/m3/src/x/tracing"
 	xerrors "github.com/m3db/m3x/errors"
 	"github.com/m3db/m3x/ident"
 	"github.com/m3db/m3x/pool"
 	xtime "github.com/m3db/m3x/time"
+
+	opentracing "github.com/opentracing/opentracing-go"
 )
 
 var (
@@ -46,11 +49,20 @@ var (
 type localStorage struct {
 	clusters   Clusters
 	workerPool pool.ObjectPool
+	writeQueue *writeQueue
 }
 
 // NewStorage creates a new local Storage instance.
-func NewStorage(clusters Clusters, workerPool pool.ObjectPool) storage.Storage {
-	return &localStorage{clusters: clusters, workerPool: workerPool}
+func NewStorage(
+	clusters Clusters,
+	workerPool pool.ObjectPool,
+	writeBatchOpts WriteBatchOptions,
+) storage.Storage {
+	return &localStorage{
+		clusters:   clusters,
+		workerPool: workerPool,
+		writeQueue: newWriteQueue(writeBatchOpts),
+	}
 }
 
 func (s *localStorage) Fetch(ctx context.Context, query *storage.FetchQuery, options *storage.FetchOptions) (*storage.FetchResult, error) {
@@ -63,47 +75,91 @@ func (s *localStorage) Fetch(ctx context.Context, query *storage.FetchQuery, opt
 	default:
 	}
 
+	span, ctx := opentracing.StartSpanFromContext(ctx, fetchSpanName)
+	result, err := s.fetchSegments(ctx, query, options)
+	tracing.FinishSpan(span, err)
+	return result, err
+}
+
+func (s *localStorage) fetchSegments(
+	ctx context.Context,
+	query *storage.FetchQuery,
+	options *storage.FetchOptions,
+) (*storage.FetchResult, error) {
 	m3query, err := storage.FetchQueryToM3Query(query)
 	if err != nil {
 		return nil, err
 	}
 
-	// NB(r): Since we don't use a single index we fan out to each
-	// cluster that can completely fulfill this range and then prefer the
-	// highest resolution (most fine grained) results.
-	// This needs to be optimized, however this is a start.
+	// NB(r): Since we don't use a single index we split the range across
+	// the cluster namespaces, using the finest resolution namespace that
+	// retains data for each part of the range, and stitch the results
+	// back together per series.
+	segments, ok := planFetch(s.clusters.ClusterNamespaces(),
+		query.Start, query.End, time.Now())
+	if !ok {
+		return nil, errNoLocalClustersFulfillsQuery
+	}
+
 	var (
-		opts       = storage.FetchOptionsToM3Options(options, query)
-		namespaces = s.clusters.ClusterNamespaces()
-		now        = time.Now()
-		fetches    = 0
-		result     multiFetchResult
-		wg         sync.WaitGroup
+		opts    = storage.FetchOptionsToM3Options(options, query)
+		results = make([]*storage.FetchResult, len(segments))
+		errs    syncMultiErrs
+		wg      sync.WaitGroup
 	)
-	for _, namespace := range namespaces {
-		namespace := namespace // Capture var
+	for i, segment := range segments {
+		i, segment := i, segment // Capture vars
 
-		clusterStart := now.Add(-1 * namespace.Options().Attributes().Retention)
+		segmentOpts := opts
+		segmentOpts.StartInclusive = segment.start
+		segmentOpts.EndExclusive = segment.end
 
-		// Only include if cluster can completely fulfill the range
-		if clusterStart.After(query.Start) {
-			continue
-		}
+		wg.Add(1)
+		go func() {
+			r, err := s.fetchNamespaces(ctx, segment.namespaces, m3query, segmentOpts)
+			if err != nil {
+				errs.add(err)
+			} else {
+				results[i] = r
+			}
+			wg.Done()
+		}()
+	}
 
-		fetches++
+	wg.Wait()
+	if err := errs.finalError(); err != nil {
+		return nil, err
+	}
+	return stitchFetchResults(segments, results), nil
+}
+
+// fetchNamespaces fetches from each of the namespaces and deduplicates the
+// results per series, preferring the finest resolution namespace.
+func (s *localStorage) fetchNamespaces(
+	ctx context.Context,
+	namespaces ClusterNamespaces,
+	query index.Query,
+	opts index.QueryOptions,
+) (*storage.FetchResult, error) {
+	if len(namespaces) == 1 {
+		return s.fetch(ctx, namespaces[0], query, opts)
+	}
+
+	var (
+		result multiFetchResult
+		wg     sync.WaitGroup
+	)
+	for _, namespace := range namespaces {
+		namespace := namespace // Capture var
 
 		wg.Add(1)
 		go func() {
-			r, err := s.fetch(namespace, m3query, opts)
+			r, err := s.fetch(ctx, namespace, query, opts)
 			result.add(namespace.Options().Attributes(), r, err)
 			wg.Done()
 		}()
 	}
 
-	if fetches == 0 {
-		return nil, errNoLocalClustersFulfillsQuery
-	}
-
 	wg.Wait()
 	if err := result.err.FinalError(); err != nil {
 		return nil, err
@@ -112,6 +168,7 @@ func (s *localStorage) Fetch(ctx context.Context, query *storage.FetchQuery, opt
 }
 
 func (s *localStorage) fetch(
+	ctx context.Context,
 	namespace ClusterNamespace,
 	query index.Query,
 	opts index.QueryOptions,
@@ -119,13 +176,27 @@ func (s *localStorage) fetch(
 	namespaceID := namespace.NamespaceID()
 	session := namespace.Session()
 
-	// TODO (nikunj): Handle second return param
-	iters, _, err := session.FetchTagged(namespaceID, query, opts)
+	iters, exhaustive, err := fetchTagged(ctx, session, namespaceID, query, opts)
 	if err != nil {
 		return nil, err
 	}
 
-	return storage.SeriesIteratorsToFetchResult(iters, namespaceID, s.workerPool)
+	span, _ := opentracing.StartSpanFromContext(ctx, decodeSpanName)
+	span.SetTag("series", iters.Len())
+	result, err := storage.SeriesIteratorsToFetchResult(iters, namespaceID, s.workerPool)
+	tracing.FinishSpan(span, err)
+	if err != nil {
+		return nil, err
+	}
+
+	if !exhaustive {
+		// NB: the index limit was hit for this namespace, mark the result as
+		// non-exhaustive so callers can surface that the data is truncated.
+		result.Metadata.NonExhaustive = true
+		result.Metadata.AddWarning(namespaceID.String(), "limit exceeded")
+	}
+
+	return result, nil
 }
 
 func (s *localStorage) FetchTags(ctx context.Context, query *storage.FetchQuery, options *storage.FetchOptions) (*storage.SearchResults, error) {
@@ -138,6 +209,9 @@ func (s *localStorage) FetchTags(ctx context.Context, query *storage.FetchQuery,
 	default:
 	}
 
+	span, _ := opentracing.StartSpanFromContext(ctx, fetchTagsSpanName)
+	defer span.Finish()
+
 	m3query, err := storage.FetchQueryToM3Query(query)
 	if err != nil {
 		return nil, err
@@ -236,11 +310,14 @@ func (s *localStorage) Write(ctx context.Context, query *storage.WriteQuery) err
 		attributes:  query.Attributes,
 	}
 
+	span, ctx := opentracing.StartSpanFromContext(ctx, writeSpanName)
 	requests := make([]execution.Request, len(query.Datapoints))
 	for idx, datapoint := range query.Datapoints {
 		requests[idx] = newWriteRequest(common, datapoint.Timestamp, datapoint.Value)
 	}
-	return execution.ExecuteParallel(ctx, requests)
+	err := execution.ExecuteParallel(ctx, requests)
+	tracing.FinishSpan(span, err)
+	return err
 }
 
 func (s *localStorage) Type() storage.Type {
@@ -265,6 +342,7 @@ func (s *localStorage) FetchBlocks(
 }
 
 func (s *localStorage) Close() error {
+	s.writeQueue.close()
 	return nil
 }
 
@@ -273,37 +351,41 @@ func (w *writeRequest) Process(ctx context.Context) error {
 	store := common.store
 	id := ident.StringID(common.id)
 
-	var (
-		namespace ClusterNamespace
-		err       error
-	)
-	switch common.attributes.MetricsType {
+	namespace, err := store.resolveClusterNamespace(common.attributes)
+	if err != nil {
+		return err
+	}
+
+	namespaceID := namespace.NamespaceID()
+	session := namespace.Session()
+	return writeTagged(ctx, session, namespaceID, id, common.tagIterator,
+		w.timestamp, w.value, common.unit, common.annotation)
+}
+
+// resolveClusterNamespace returns the cluster namespace to write to for the
+// given attributes.
+func (s *localStorage) resolveClusterNamespace(
+	attributes storage.Attributes,
+) (ClusterNamespace, error) {
+	switch attributes.MetricsType {
 	case storage.UnaggregatedMetricsType:
-		namespace = store.clusters.UnaggregatedClusterNamespace()
+		return s.clusters.UnaggregatedClusterNamespace(), nil
 	case storage.AggregatedMetricsType:
 		attrs := RetentionResolution{
-			Retention:  common.attributes.Retention,
-			Resolution: common.attributes.Resolution,
+			Retention:  attributes.Retention,
+			Resolution: attributes.Resolution,
 		}
-		var exists bool
-		namespace, exists = store.clusters.AggregatedClusterNamespace(attrs)
+		namespace, exists := s.clusters.AggregatedClusterNamespace(attrs)
 		if !exists {
-			err = fmt.Errorf("no configured cluster namespace for: retention=%s, resolution=%s",
+			return nil, fmt.Errorf("no configured cluster namespace for: retention=%s, resolution=%s",
 				attrs.Retention.String(), attrs.Resolution.String())
 		}
+		return namespace, nil
 	default:
-		metricsType := common.attributes.MetricsType
-		err = fmt.Errorf("invalid write request metrics type: %s (%d)",
+		metricsType := attributes.MetricsType
+		return nil, fmt.Errorf("invalid write request metrics type: %s (%d)",
 			metricsType.String(), uint(metricsType))
 	}
-	if err != nil {
-		return err
-	}
-
-	namespaceID := namespace.NamespaceID()
-	session := namespace.Session()
-	return session.WriteTagged(namespaceID, id, common.tagIterator,
-		w.timestamp, w.value, common.unit, common.annotation)
 }
 
 type writeRequestCommon struct {
@@ -363,6 +445,7 @@ func (r *multiFetchResult) add(
 
 	r.result.HasNext = r.result.HasNext && result.HasNext
 	r.result.LocalOnly = r.result.LocalOnly && result.LocalOnly
+	r.result.Metadata = r.result.Metadata.CombineMetadata(result.Metadata)
 
 	// Need to dedupe
 	if r.dedupeMap == nil {
@@ -78,7 +78,7 @@ func setup(
 		Resolution:  time.Minute,
 	})
 	require.NoError(t, err)
-	storage := NewStorage(clusters, nil)
+	storage := NewStorage(clusters, nil, WriteBatchOptions{})
 	return storage, testSessions{
 		unaggregated1MonthRetention:                unaggregated1MonthRetention,
 		aggregated1MonthRetention1MinuteResolution: aggregated1MonthRetention1MinuteResolution,
@@ -207,10 +207,11 @@ func TestLocalRead(t *testing.T) {
 	defer ctrl.Finish()
 	store, sessions := setup(t, ctrl)
 	testTags := seriesiter.GenerateTag()
-	sessions.forEach(func(session *client.MockSession) {
-		session.EXPECT().FetchTagged(gomock.Any(), gomock.Any(), gomock.Any()).
-			Return(seriesiter.NewMockSeriesIters(ctrl, testTags, 1, 2), true, nil)
-	})
+	// Both namespaces retain the whole range so only the unaggregated
+	// namespace with the finest resolution is fetched from
+	sessions.unaggregated1MonthRetention.EXPECT().
+		FetchTagged(gomock.Any(), gomock.Any(), gomock.Any()).
+		Return(seriesiter.NewMockSeriesIters(ctrl, testTags, 1, 2), true, nil)
 	searchReq := newFetchReq()
 	results, err := store.Fetch(context.TODO(), searchReq, &storage.FetchOptions{Limit: 100})
 	assert.NoError(t, err)
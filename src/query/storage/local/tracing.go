@@ -0,0 +1,74 @@
+// Copyright (c) 2018 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package local
+
+import (
+	"context"
+	"time"
+
+	"github.com/m3db/m3/src/dbnode/client"
+	"github.com/m3db/m3/src/dbnode/encoding"
+	"github.com/m3db/m3/src/dbnode/storage/index"
+	"github.com/m3db/m3x/ident"
+	xtime "github.com/m3db/m3x/time"
+)
+
+const (
+	fetchSpanName      = "local.storage.Fetch"
+	fetchTagsSpanName  = "local.storage.FetchTags"
+	decodeSpanName     = "local.storage.decode"
+	writeSpanName      = "local.storage.Write"
+	writeBatchSpanName = "local.storage.WriteBatch"
+)
+
+// fetchTagged fetches using the context aware session method if supported
+// so that the fetch is traced and the trace propagated to the nodes.
+func fetchTagged(
+	ctx context.Context,
+	session client.Session,
+	namespace ident.ID,
+	query index.Query,
+	opts index.QueryOptions,
+) (encoding.SeriesIterators, bool, error) {
+	if traced, ok := session.(client.ContextSession); ok {
+		return traced.FetchTaggedContext(ctx, namespace, query, opts)
+	}
+	return session.FetchTagged(namespace, query, opts)
+}
+
+// writeTagged writes using the context aware session method if supported
+// so that the write is traced.
+func writeTagged(
+	ctx context.Context,
+	session client.Session,
+	namespace, id ident.ID,
+	tags ident.TagIterator,
+	t time.Time,
+	value float64,
+	unit xtime.Unit,
+	annotation []byte,
+) error {
+	if traced, ok := session.(client.ContextSession); ok {
+		return traced.WriteTaggedContext(ctx, namespace, id, tags, t, value,
+			unit, annotation)
+	}
+	return session.WriteTagged(namespace, id, tags, t, value, unit, annotation)
+}
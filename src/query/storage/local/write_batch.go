@@ -0,0 +1,369 @@
+// Copyright (c) 2018 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package local
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/m3db/m3/src/dbnode/client"
+	"github.com/m3db/m3/src/query/errors"
+	"github.com/m3db/m3/src/query/storage"
+	"github.com/m3db/m3/src/x/tracing"
+	xerrors "github.com/m3db/m3x/errors"
+	"github.com/m3db/m3x/ident"
+	xsync "github.com/m3db/m3x/sync"
+
+	opentracing "github.com/opentracing/opentracing-go"
+	"github.com/uber-go/tally"
+)
+
+const (
+	defaultWriteWorkers     = 64
+	defaultWriteQueueSize   = 4096
+	defaultWriteConcurrency = 1024
+)
+
+// WriteBatchOptions are the options for batched writes.
+type WriteBatchOptions struct {
+	// Workers is the number of workers writing batches concurrently.
+	Workers int
+	// QueueSize is the number of batches that can be queued awaiting a
+	// worker before writes are rejected.
+	QueueSize int
+	// WriteConcurrency is the number of datapoints written concurrently
+	// across all batches to sessions that cannot write batches.
+	WriteConcurrency int
+	// Scope is the metrics scope for batched writes.
+	Scope tally.Scope
+}
+
+func (o WriteBatchOptions) withDefaults() WriteBatchOptions {
+	if o.Workers <= 0 {
+		o.Workers = defaultWriteWorkers
+	}
+	if o.QueueSize <= 0 {
+		o.QueueSize = defaultWriteQueueSize
+	}
+	if o.WriteConcurrency <= 0 {
+		o.WriteConcurrency = defaultWriteConcurrency
+	}
+	if o.Scope == nil {
+		o.Scope = tally.NoopScope
+	}
+	return o
+}
+
+type writeBatchMetrics struct {
+	batches     tally.Counter
+	batchErrors tally.Counter
+	queueFull   tally.Counter
+	batchSize   tally.Histogram
+	latency     tally.Timer
+}
+
+func newWriteBatchMetrics(scope tally.Scope) writeBatchMetrics {
+	return writeBatchMetrics{
+		batches:     scope.Counter("batches"),
+		batchErrors: scope.Counter("batch-errors"),
+		queueFull:   scope.Counter("queue-full"),
+		batchSize: scope.Histogram("batch-size",
+			tally.MustMakeExponentialValueBuckets(1, 2, 12)),
+		latency: scope.Timer("batch-latency"),
+	}
+}
+
+// writeBatch is a set of series written to the same namespace and shard.
+type writeBatch struct {
+	ctx       context.Context
+	namespace ClusterNamespace
+	writes    []*storage.WriteQuery
+	done      func(err error)
+}
+
+// writeQueue is a bounded queue of write batches processed by a fixed number
+// of workers, each batch is written with a single batch write of the session
+// or, if the session cannot write batches, datapoint by datapoint by a bounded
+// pool of writers shared by all workers.
+type writeQueue struct {
+	batches   chan *writeBatch
+	writePool xsync.WorkerPool
+	metrics   writeBatchMetrics
+	wg        sync.WaitGroup
+	closeMu   sync.RWMutex
+	closed    bool
+	closeCh   chan struct{}
+	closeOnce sync.Once
+}
+
+func newWriteQueue(opts WriteBatchOptions) *writeQueue {
+	opts = opts.withDefaults()
+	writePool := xsync.NewWorkerPool(opts.WriteConcurrency)
+	writePool.Init()
+	q := &writeQueue{
+		batches:   make(chan *writeBatch, opts.QueueSize),
+		writePool: writePool,
+		metrics:   newWriteBatchMetrics(opts.Scope),
+		closeCh:   make(chan struct{}),
+	}
+
+	q.wg.Add(opts.Workers)
+	for i := 0; i < opts.Workers; i++ {
+		go q.work()
+	}
+
+	return q
+}
+
+// enqueue queues either all or none of the batches so that a rejected write
+// has not been partially written, returning errors.ErrWriteQueueFull if the
+// queue does not have room for all of them. Sets of batches larger than the
+// queue itself can never fit and are instead queued one at a time as room
+// becomes available until the context is done, the number of batches queued
+// is returned.
+func (q *writeQueue) enqueue(ctx context.Context, batches []*writeBatch) (int, error) {
+	if len(batches) > cap(q.batches) {
+		return q.enqueueEach(ctx, batches)
+	}
+
+	// NB: taking the write lock serializes enqueues so that the room
+	// checked for is not taken by a concurrent enqueue, workers only ever
+	// drain the queue so the room can only grow while it is held.
+	q.closeMu.Lock()
+	defer q.closeMu.Unlock()
+	if q.closed {
+		return 0, errors.ErrWriteQueueFull
+	}
+
+	if len(batches) > cap(q.batches)-len(q.batches) {
+		q.metrics.queueFull.Inc(1)
+		return 0, errors.ErrWriteQueueFull
+	}
+
+	for _, batch := range batches {
+		q.batches <- batch
+	}
+	return len(batches), nil
+}
+
+func (q *writeQueue) enqueueEach(ctx context.Context, batches []*writeBatch) (int, error) {
+	for i, batch := range batches {
+		if err := q.enqueueOne(ctx, batch); err != nil {
+			return i, err
+		}
+	}
+	return len(batches), nil
+}
+
+func (q *writeQueue) enqueueOne(ctx context.Context, batch *writeBatch) error {
+	// NB: the read lock only guards against the queue being closed while
+	// waiting for room, closing the queue first signals the close channel
+	// so that waiting enqueues give up before the lock is taken.
+	q.closeMu.RLock()
+	defer q.closeMu.RUnlock()
+	if q.closed {
+		return errors.ErrWriteQueueFull
+	}
+
+	select {
+	case q.batches <- batch:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-q.closeCh:
+		return errors.ErrWriteQueueFull
+	}
+}
+
+func (q *writeQueue) work() {
+	defer q.wg.Done()
+	for batch := range q.batches {
+		start := time.Now()
+		err := q.write(batch)
+		q.metrics.latency.Record(time.Since(start))
+		q.metrics.batches.Inc(1)
+		q.metrics.batchSize.RecordValue(float64(len(batch.writes)))
+		if err != nil {
+			q.metrics.batchErrors.Inc(1)
+		}
+		batch.done(err)
+	}
+}
+
+func (q *writeQueue) write(batch *writeBatch) error {
+	var (
+		namespaceID = batch.namespace.NamespaceID()
+		session     = batch.namespace.Session()
+	)
+	if batched, ok := session.(client.BatchSession); ok {
+		var writes []client.TaggedWrite
+		for _, write := range batch.writes {
+			id := ident.StringID(write.Tags.ID())
+			for _, dp := range write.Datapoints {
+				writes = append(writes, client.TaggedWrite{
+					ID:         id,
+					Tags:       storage.TagsToIdentTagIterator(write.Tags),
+					Timestamp:  dp.Timestamp,
+					Value:      dp.Value,
+					Unit:       write.Unit,
+					Annotation: write.Annotation,
+				})
+			}
+		}
+		return batched.WriteTaggedBatch(batch.ctx, namespaceID, writes)
+	}
+
+	var (
+		wg       sync.WaitGroup
+		errLock  sync.Mutex
+		multiErr xerrors.MultiError
+	)
+	for _, write := range batch.writes {
+		write := write
+		id := ident.StringID(write.Tags.ID())
+		for _, dp := range write.Datapoints {
+			dp := dp
+			wg.Add(1)
+			q.writePool.Go(func() {
+				tags := storage.TagsToIdentTagIterator(write.Tags)
+				err := writeTagged(batch.ctx, session, namespaceID, id, tags,
+					dp.Timestamp, dp.Value, write.Unit, write.Annotation)
+				if err != nil {
+					errLock.Lock()
+					multiErr = multiErr.Add(err)
+					errLock.Unlock()
+				}
+				wg.Done()
+			})
+		}
+	}
+
+	wg.Wait()
+	return multiErr.FinalError()
+}
+
+func (q *writeQueue) close() {
+	q.closeOnce.Do(func() {
+		// Signal enqueues waiting for room before taking the write lock
+		close(q.closeCh)
+
+		q.closeMu.Lock()
+		q.closed = true
+		close(q.batches)
+		q.closeMu.Unlock()
+		q.wg.Wait()
+	})
+}
+
+type writeBatchKey struct {
+	namespace string
+	shard     uint32
+}
+
+// WriteBatch writes the queries grouping them into batches by namespace and
+// shard, the batches are written by a bounded pool of workers and
+// errors.ErrWriteQueueFull is returned without writing any of the queries if
+// the batches could not be queued. Writes with more batches than the queue can
+// hold are queued as room becomes available, so may be partially written if
+// the context is done before all batches were queued.
+func (s *localStorage) WriteBatch(ctx context.Context, queries []*storage.WriteQuery) error {
+	// Check if the query was interrupted.
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	default:
+	}
+
+	span, ctx := opentracing.StartSpanFromContext(ctx, writeBatchSpanName)
+	span.SetTag("series", len(queries))
+	err := s.writeBatch(ctx, queries)
+	tracing.FinishSpan(span, err)
+	return err
+}
+
+func (s *localStorage) writeBatch(ctx context.Context, queries []*storage.WriteQuery) error {
+	var (
+		batches  = make(map[writeBatchKey]*writeBatch)
+		ordered  []*writeBatch
+		multiErr xerrors.MultiError
+	)
+	for _, query := range queries {
+		if query == nil {
+			return errors.ErrNilWriteQuery
+		}
+
+		namespace, err := s.resolveClusterNamespace(query.Attributes)
+		if err != nil {
+			multiErr = multiErr.Add(err)
+			continue
+		}
+
+		// NB: if the shard cannot be determined, for instance if the session
+		// is not yet connected, fall back to batching by namespace only and
+		// let the write itself surface any error.
+		shard, _ := namespace.Session().ShardID(ident.StringID(query.Tags.ID()))
+		key := writeBatchKey{
+			namespace: namespace.NamespaceID().String(),
+			shard:     shard,
+		}
+		batch, ok := batches[key]
+		if !ok {
+			batch = &writeBatch{ctx: ctx, namespace: namespace}
+			batches[key] = batch
+			ordered = append(ordered, batch)
+		}
+		batch.writes = append(batch.writes, query)
+	}
+
+	if len(ordered) == 0 {
+		return multiErr.FinalError()
+	}
+
+	var (
+		wg      sync.WaitGroup
+		errLock sync.Mutex
+	)
+	done := func(err error) {
+		if err != nil {
+			errLock.Lock()
+			multiErr = multiErr.Add(err)
+			errLock.Unlock()
+		}
+		wg.Done()
+	}
+	for _, batch := range ordered {
+		batch.done = done
+	}
+
+	wg.Add(len(ordered))
+	enqueued, err := s.writeQueue.enqueue(ctx, ordered)
+	for i := enqueued; i < len(ordered); i++ {
+		wg.Done()
+	}
+	if enqueued == 0 {
+		return err
+	}
+
+	wg.Wait()
+	multiErr = multiErr.Add(err)
+	return multiErr.FinalError()
+}
@@ -0,0 +1,225 @@
+// Copyright (c) 2018 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package local
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/m3db/m3/src/dbnode/client"
+	"github.com/m3db/m3/src/query/errors"
+	"github.com/m3db/m3/src/query/models"
+	"github.com/m3db/m3/src/query/storage"
+	"github.com/m3db/m3/src/query/ts"
+	"github.com/m3db/m3x/ident"
+	xtime "github.com/m3db/m3x/time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/uber-go/tally"
+)
+
+func newBatchWriteQuery(name string) *storage.WriteQuery {
+	return &storage.WriteQuery{
+		Tags: models.FromMap(map[string]string{"__name__": name}),
+		Unit: xtime.Millisecond,
+		Datapoints: ts.Datapoints{
+			{Timestamp: time.Now(), Value: 1},
+			{Timestamp: time.Now().Add(-10 * time.Second), Value: 2},
+		},
+		Attributes: storage.Attributes{
+			MetricsType: storage.UnaggregatedMetricsType,
+		},
+	}
+}
+
+type testBatchSession struct {
+	*client.MockSession
+
+	sync.Mutex
+	batches [][]client.TaggedWrite
+}
+
+func (s *testBatchSession) WriteTaggedBatch(
+	_ context.Context,
+	_ ident.ID,
+	writes []client.TaggedWrite,
+) error {
+	s.Lock()
+	s.batches = append(s.batches, writes)
+	s.Unlock()
+	return nil
+}
+
+func TestLocalWriteBatchUsesSessionBatches(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	session := &testBatchSession{MockSession: client.NewMockSession(ctrl)}
+	clusters, err := NewClusters(UnaggregatedClusterNamespaceDefinition{
+		NamespaceID: ident.StringID("metrics_unaggregated"),
+		Session:     session,
+		Retention:   testRetention,
+	})
+	require.NoError(t, err)
+	store := NewStorage(clusters, nil, WriteBatchOptions{Workers: 2})
+
+	gomock.InOrder(
+		session.MockSession.EXPECT().ShardID(gomock.Any()).Return(uint32(1), nil).Times(2),
+		session.MockSession.EXPECT().ShardID(gomock.Any()).Return(uint32(2), nil).Times(1),
+	)
+
+	// Each batch is written with a single batch write of all its datapoints
+	err = store.(*localStorage).WriteBatch(context.TODO(), []*storage.WriteQuery{
+		newBatchWriteQuery("foo"),
+		newBatchWriteQuery("bar"),
+		newBatchWriteQuery("baz"),
+	})
+	require.NoError(t, err)
+	require.Len(t, session.batches, 2)
+
+	var sizes []int
+	for _, batch := range session.batches {
+		sizes = append(sizes, len(batch))
+	}
+	assert.ElementsMatch(t, []int{4, 2}, sizes)
+
+	require.NoError(t, store.Close())
+}
+
+func TestLocalWriteBatchGroupsByShard(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	store, sessions := setup(t, ctrl)
+	scope := tally.NewTestScope("", nil)
+	local := store.(*localStorage)
+	local.writeQueue.close()
+	local.writeQueue = newWriteQueue(WriteBatchOptions{Workers: 2, Scope: scope})
+
+	session := sessions.unaggregated1MonthRetention
+	gomock.InOrder(
+		session.EXPECT().ShardID(gomock.Any()).Return(uint32(1), nil).Times(2),
+		session.EXPECT().ShardID(gomock.Any()).Return(uint32(2), nil).Times(1),
+	)
+	session.EXPECT().WriteTagged(gomock.Any(), gomock.Any(), gomock.Any(),
+		gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(nil).Times(6)
+
+	err := local.WriteBatch(context.TODO(), []*storage.WriteQuery{
+		newBatchWriteQuery("foo"),
+		newBatchWriteQuery("bar"),
+		newBatchWriteQuery("baz"),
+	})
+	require.NoError(t, err)
+	require.NoError(t, store.Close())
+
+	counters := scope.Snapshot().Counters()
+	require.NotNil(t, counters["batches+"])
+	assert.Equal(t, int64(2), counters["batches+"].Value())
+}
+
+func TestLocalWriteBatchQueueFull(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	store, sessions := setup(t, ctrl)
+	local := store.(*localStorage)
+	local.writeQueue.close()
+	// No workers and a queue with room for one of the two batches so
+	// that the write is rejected without anything being written
+	local.writeQueue = &writeQueue{
+		batches: make(chan *writeBatch, 2),
+		metrics: newWriteBatchMetrics(tally.NoopScope),
+		closeCh: make(chan struct{}),
+	}
+	local.writeQueue.batches <- &writeBatch{}
+
+	session := sessions.unaggregated1MonthRetention
+	gomock.InOrder(
+		session.EXPECT().ShardID(gomock.Any()).Return(uint32(1), nil),
+		session.EXPECT().ShardID(gomock.Any()).Return(uint32(2), nil),
+	)
+
+	err := local.WriteBatch(context.TODO(), []*storage.WriteQuery{
+		newBatchWriteQuery("foo"),
+		newBatchWriteQuery("bar"),
+	})
+	assert.Equal(t, errors.ErrWriteQueueFull, err)
+	assert.Equal(t, 1, len(local.writeQueue.batches))
+}
+
+func TestLocalWriteBatchLargerThanQueue(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	store, sessions := setup(t, ctrl)
+	local := store.(*localStorage)
+	local.writeQueue.close()
+	// Batches that could never fit in the queue are queued as room
+	// becomes available rather than being rejected
+	local.writeQueue = newWriteQueue(WriteBatchOptions{Workers: 1, QueueSize: 1})
+
+	session := sessions.unaggregated1MonthRetention
+	gomock.InOrder(
+		session.EXPECT().ShardID(gomock.Any()).Return(uint32(1), nil),
+		session.EXPECT().ShardID(gomock.Any()).Return(uint32(2), nil),
+		session.EXPECT().ShardID(gomock.Any()).Return(uint32(3), nil),
+	)
+	session.EXPECT().WriteTagged(gomock.Any(), gomock.Any(), gomock.Any(),
+		gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(nil).Times(6)
+
+	err := local.WriteBatch(context.TODO(), []*storage.WriteQuery{
+		newBatchWriteQuery("foo"),
+		newBatchWriteQuery("bar"),
+		newBatchWriteQuery("baz"),
+	})
+	require.NoError(t, err)
+	require.NoError(t, store.Close())
+}
+
+func TestWriteQueueEnqueueLargerThanQueueGivesUp(t *testing.T) {
+	// No workers and a full queue so that batches larger than the queue
+	// wait for room until the context is done or the queue is closed
+	q := &writeQueue{
+		batches: make(chan *writeBatch, 1),
+		metrics: newWriteBatchMetrics(tally.NoopScope),
+		closeCh: make(chan struct{}),
+	}
+	q.batches <- &writeBatch{}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	enqueued, err := q.enqueue(ctx, []*writeBatch{{}, {}})
+	assert.Equal(t, 0, enqueued)
+	assert.Equal(t, context.Canceled, err)
+
+	errCh := make(chan error)
+	go func() {
+		_, err := q.enqueue(context.Background(), []*writeBatch{{}, {}})
+		errCh <- err
+	}()
+
+	q.close()
+	assert.Equal(t, errors.ErrWriteQueueFull, <-errCh)
+}
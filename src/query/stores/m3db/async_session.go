@@ -21,6 +21,7 @@
 package m3db
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"sync"
@@ -28,7 +29,9 @@ import (
 
 	"github.com/m3db/m3/src/dbnode/client"
 	"github.com/m3db/m3/src/dbnode/encoding"
+	"github.com/m3db/m3/src/dbnode/generated/thrift/rpc"
 	"github.com/m3db/m3/src/dbnode/storage/index"
+	xerrors "github.com/m3db/m3x/errors"
 	"github.com/m3db/m3x/ident"
 	xtime "github.com/m3db/m3x/time"
 )
@@ -38,7 +41,8 @@ const (
 )
 
 var (
-	errSessionUninitialized = errors.New("M3DB session not yet initialized")
+	errSessionUninitialized         = errors.New("M3DB session not yet initialized")
+	errSessionNodeHealthUnsupported = errors.New("M3DB session does not support node health checks")
 )
 
 // AsyncSession is a thin wrapper around an M3DB session that does not block on initialization.
@@ -114,6 +118,60 @@ func (s *AsyncSession) WriteTagged(namespace, id ident.ID, tags ident.TagIterato
 	return s.session.WriteTagged(namespace, id, tags, t, value, unit, annotation)
 }
 
+// WriteTaggedContext writes a value to the database for an ID and given tags,
+// tracing the write if the underlying session supports it.
+func (s *AsyncSession) WriteTaggedContext(ctx context.Context, namespace, id ident.ID, tags ident.TagIterator, t time.Time, value float64, unit xtime.Unit, annotation []byte) error {
+	s.RLock()
+	defer s.RUnlock()
+	if s.err != nil {
+		return s.err
+	}
+
+	if traced, ok := s.session.(client.ContextSession); ok {
+		return traced.WriteTaggedContext(ctx, namespace, id, tags, t, value, unit, annotation)
+	}
+	return s.session.WriteTagged(namespace, id, tags, t, value, unit, annotation)
+}
+
+// WriteTaggedBatch writes a batch of values to the database for IDs and given
+// tags, writing them one at a time if the underlying session does not
+// support batches.
+func (s *AsyncSession) WriteTaggedBatch(ctx context.Context, namespace ident.ID, writes []client.TaggedWrite) error {
+	s.RLock()
+	defer s.RUnlock()
+	if s.err != nil {
+		return s.err
+	}
+
+	if batched, ok := s.session.(client.BatchSession); ok {
+		return batched.WriteTaggedBatch(ctx, namespace, writes)
+	}
+
+	var multiErr xerrors.MultiError
+	for _, w := range writes {
+		err := s.session.WriteTagged(namespace, w.ID, w.Tags, w.Timestamp,
+			w.Value, w.Unit, w.Annotation)
+		multiErr = multiErr.Add(err)
+	}
+	return multiErr.FinalError()
+}
+
+// NodeHealth returns the health of the node of a host using the connections
+// of the underlying session.
+func (s *AsyncSession) NodeHealth(hostID string) (*rpc.NodeHealthResult_, error) {
+	s.RLock()
+	defer s.RUnlock()
+	if s.err != nil {
+		return nil, s.err
+	}
+
+	checker, ok := s.session.(client.NodeHealthSession)
+	if !ok {
+		return nil, errSessionNodeHealthUnsupported
+	}
+	return checker.NodeHealth(hostID)
+}
+
 // Fetch fetches values from the database for an ID
 func (s *AsyncSession) Fetch(namespace, id ident.ID, startInclusive, endExclusive time.Time) (encoding.SeriesIterator, error) {
 	s.RLock()
@@ -147,6 +205,21 @@ func (s *AsyncSession) FetchTagged(namespace ident.ID, q index.Query, opts index
 	return s.session.FetchTagged(namespace, q, opts)
 }
 
+// FetchTaggedContext resolves the provided query to known IDs, and fetches the
+// data for them, tracing the fetch if the underlying session supports it.
+func (s *AsyncSession) FetchTaggedContext(ctx context.Context, namespace ident.ID, q index.Query, opts index.QueryOptions) (results encoding.SeriesIterators, exhaustive bool, err error) {
+	s.RLock()
+	defer s.RUnlock()
+	if s.err != nil {
+		return nil, false, s.err
+	}
+
+	if traced, ok := s.session.(client.ContextSession); ok {
+		return traced.FetchTaggedContext(ctx, namespace, q, opts)
+	}
+	return s.session.FetchTagged(namespace, q, opts)
+}
+
 // FetchTaggedIDs resolves the provided query to known IDs.
 func (s *AsyncSession) FetchTaggedIDs(namespace ident.ID, q index.Query, opts index.QueryOptions) (client.TaggedIDsIterator, bool, error) {
 	s.RLock()
@@ -34,8 +34,23 @@ type Series struct {
 	name string
 	vals Values
 	Tags models.Tags
+	// Resolutions records the resolution of the underlying datapoints over
+	// time when the series was stitched together from multiple sources,
+	// it is empty for series of raw datapoints from a single source.
+	Resolutions ResolutionSegments
 }
 
+// ResolutionSegment describes the resolution of a series' datapoints within
+// a time range, a zero resolution denotes raw unaggregated datapoints.
+type ResolutionSegment struct {
+	Start      time.Time
+	End        time.Time
+	Resolution time.Duration
+}
+
+// ResolutionSegments is a list of resolution segments ordered by time.
+type ResolutionSegments []ResolutionSegment
+
 // NewSeries creates a new Series at a given start time, backed by the provided values
 func NewSeries(name string, vals Values, tags models.Tags) *Series {
 	return &Series{
@@ -56,17 +71,27 @@ func (s *Series) Values() Values { return s.vals }
 
 // Align adjusts the datapoints to start, end and a fixed interval
 func (s *Series) Align(start, end time.Time, interval time.Duration) (*Series, error) {
-	fixedVals, err := alignValues(s.Values(), start, end, interval)
+	fixedVals, err := alignValues(s.Values(), s.Resolutions, start, end, interval)
 	if err != nil {
 		return nil, err
 	}
 
-	return NewSeries(s.name, fixedVals, s.Tags), nil
+	aligned := NewSeries(s.name, fixedVals, s.Tags)
+	aligned.Resolutions = s.Resolutions
+	return aligned, nil
 }
 
-func alignValues(values Values, start, end time.Time, interval time.Duration) (FixedResolutionMutableValues, error) {
+func alignValues(
+	values Values,
+	resolutions ResolutionSegments,
+	start, end time.Time,
+	interval time.Duration,
+) (FixedResolutionMutableValues, error) {
 	switch vals := values.(type) {
 	case Datapoints:
+		if len(resolutions) > 0 {
+			return RawPointsToFixedStepWithResolutions(vals, resolutions, start, end, interval)
+		}
 		return RawPointsToFixedStep(vals, start, end, interval)
 	case FixedResolutionMutableValues:
 		// TODO: Align fixed resolution as well once storages can return those directly
@@ -186,3 +186,79 @@ func RawPointsToFixedStep(datapoints Datapoints, start time.Time, end time.Time,
 
 	return fixStepValues, nil
 }
+
+// RawPointsToFixedStepWithResolutions converts raw datapoints into the interval
+// required within the bounds specified, taking into account the resolution of
+// the datapoints at each time step. Where the resolution is no coarser than the
+// interval the closest point is used as with RawPointsToFixedStep. Where the
+// resolution is coarser than the interval each datapoint is only used for the
+// single step it falls into so that functions such as rate see each aggregated
+// datapoint once rather than a repeated value followed by a jump.
+func RawPointsToFixedStepWithResolutions(
+	datapoints Datapoints,
+	resolutions ResolutionSegments,
+	start time.Time,
+	end time.Time,
+	interval time.Duration,
+) (FixedResolutionMutableValues, error) {
+	if end.Before(start) {
+		return nil, fmt.Errorf("start cannot be after end, start: %v, end: %v", start, end)
+	}
+
+	if interval == 0 {
+		return nil, errors.ErrZeroInterval
+	}
+
+	var numSteps int
+	if end.Equal(start) {
+		numSteps = 1
+	} else {
+		numSteps = int(end.Sub(start) / interval)
+	}
+
+	fixStepValues := newFixedStepValues(interval, numSteps, math.NaN(), start)
+	dpIdx := 0
+	resIdx := 0
+	numPoints := len(datapoints)
+	for i := 0; i < numSteps; i++ {
+		t := start.Add(time.Duration(i) * interval)
+
+		// Find first datapoint not before time t
+		for ; dpIdx < numPoints; dpIdx++ {
+			if !datapoints[dpIdx].Timestamp.Before(t) {
+				break
+			}
+		}
+
+		// Find the resolution segment for time t, times outside of all
+		// segments use the resolution of the closest segment
+		for resIdx < len(resolutions)-1 && !t.Before(resolutions[resIdx].End) {
+			resIdx++
+		}
+
+		exact := dpIdx < numPoints && datapoints[dpIdx].Timestamp.Equal(t)
+		if len(resolutions) == 0 || resolutions[resIdx].Resolution <= interval {
+			if dpIdx >= numPoints {
+				// Values after the last datapoint are NaN
+				break
+			}
+
+			// If datapoint aligns to the time or its the first datapoint then take that
+			if exact || dpIdx == 0 {
+				fixStepValues.values[i] = datapoints[dpIdx].Value
+			} else {
+				fixStepValues.values[i] = datapoints[dpIdx-1].Value
+			}
+			continue
+		}
+
+		// Coarse resolution, only take a datapoint that falls within this step
+		if exact {
+			fixStepValues.values[i] = datapoints[dpIdx].Value
+		} else if dpIdx > 0 && datapoints[dpIdx-1].Timestamp.After(t.Add(-interval)) {
+			fixStepValues.values[i] = datapoints[dpIdx-1].Value
+		}
+	}
+
+	return fixStepValues, nil
+}
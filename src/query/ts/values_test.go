@@ -112,3 +112,57 @@ func TestRawPointsToFixedStep(t *testing.T) {
 		}
 	}
 }
+
+func TestRawPointsToFixedStepWithRawResolution(t *testing.T) {
+	samples := createExamples()
+	for idx, sample := range samples {
+		resolutions := ResolutionSegments{{Start: sample.start, End: sample.end}}
+		expected, err := RawPointsToFixedStep(sample.input, sample.start, sample.end, sample.interval)
+		require.NoError(t, err)
+		actual, err := RawPointsToFixedStepWithResolutions(sample.input, resolutions,
+			sample.start, sample.end, sample.interval)
+		require.NoError(t, err)
+
+		expectedValues := expected.(*fixedResolutionValues).values
+		actualValues := actual.(*fixedResolutionValues).values
+		debugMsg := fmt.Sprintf("index: %d, converted %v, expected %v, description: %s",
+			idx, actualValues, expectedValues, sample.description)
+		require.Len(t, actualValues, len(expectedValues), debugMsg)
+		for i, v := range expectedValues {
+			if math.IsNaN(v) {
+				require.True(t, math.IsNaN(actualValues[i]), debugMsg)
+			} else {
+				require.Equal(t, v, actualValues[i], debugMsg)
+			}
+		}
+	}
+}
+
+func TestRawPointsToFixedStepWithCoarseResolution(t *testing.T) {
+	start := time.Time{}
+	seam := start.Add(3 * time.Minute)
+	end := start.Add(6 * time.Minute)
+	resolutions := ResolutionSegments{
+		{Start: start, End: seam, Resolution: 2 * time.Minute},
+		{Start: seam, End: end},
+	}
+	datapoints := Datapoints{
+		{Timestamp: start, Value: 0},
+		{Timestamp: start.Add(2 * time.Minute), Value: 1},
+		{Timestamp: seam, Value: 2},
+		{Timestamp: seam.Add(30 * time.Second), Value: 3},
+		{Timestamp: seam.Add(2 * time.Minute), Value: 4},
+	}
+
+	fixedRes, err := RawPointsToFixedStepWithResolutions(datapoints, resolutions,
+		start, end, time.Minute)
+	require.NoError(t, err)
+
+	// Aggregated datapoints are only used for the step they fall into, while
+	// raw datapoints are carried forward as with RawPointsToFixedStep
+	values := fixedRes.(*fixedResolutionValues).values
+	require.Len(t, values, 6)
+	assert.Equal(t, float64(0), values[0])
+	assert.True(t, math.IsNaN(values[1]))
+	assert.Equal(t, []float64{1, 2, 3, 4}, values[2:])
+}
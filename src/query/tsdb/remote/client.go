@@ -102,7 +102,11 @@ func (c *grpcClient) Fetch(ctx context.Context, query *storage.FetchQuery, optio
 		tsSeries = append(tsSeries, fResult...)
 	}
 
-	return &storage.FetchResult{LocalOnly: false, SeriesList: tsSeries}, nil
+	return &storage.FetchResult{
+		LocalOnly:  false,
+		SeriesList: tsSeries,
+		Metadata:   block.NewResultMetadata(),
+	}, nil
 }
 
 func (c *grpcClient) FetchTags(ctx context.Context, query *storage.FetchQuery, options *storage.FetchOptions) (*storage.SearchResults, error) {
@@ -0,0 +1,144 @@
+// Copyright (c) 2018 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+// Package tracing provides helpers for tracing requests with OpenTracing
+// across the coordinator, the client and the database nodes.
+package tracing
+
+import (
+	"context"
+	"fmt"
+
+	xcontext "github.com/m3db/m3x/context"
+
+	opentracing "github.com/opentracing/opentracing-go"
+	"github.com/opentracing/opentracing-go/ext"
+)
+
+// Backend is a tracer backend.
+type Backend string
+
+const (
+	// NoopBackend discards all spans.
+	NoopBackend Backend = "noop"
+)
+
+// Configuration is the tracing configuration.
+type Configuration struct {
+	// Backend is the tracer backend, defaults to noop.
+	Backend Backend `yaml:"backend"`
+}
+
+// NewTracer returns a new tracer for the configuration.
+func (c Configuration) NewTracer() (opentracing.Tracer, error) {
+	switch c.Backend {
+	case "", NoopBackend:
+		return opentracing.NoopTracer{}, nil
+	default:
+		return nil, fmt.Errorf("unknown tracing backend: %s", c.Backend)
+	}
+}
+
+// InitGlobalTracer creates the tracer for the configuration and sets it as
+// the global tracer used to start spans.
+func (c Configuration) InitGlobalTracer() (opentracing.Tracer, error) {
+	tracer, err := c.NewTracer()
+	if err != nil {
+		return nil, err
+	}
+	opentracing.SetGlobalTracer(tracer)
+	return tracer, nil
+}
+
+// InjectHeaders returns headers carrying the trace context of the span in
+// the context, nil if there is no span or the context cannot be injected.
+func InjectHeaders(ctx context.Context) map[string]string {
+	if ctx == nil {
+		return nil
+	}
+
+	span := opentracing.SpanFromContext(ctx)
+	if span == nil {
+		return nil
+	}
+
+	headers := make(map[string]string)
+	err := span.Tracer().Inject(span.Context(), opentracing.TextMap,
+		opentracing.TextMapCarrier(headers))
+	if err != nil || len(headers) == 0 {
+		return nil
+	}
+
+	return headers
+}
+
+// StartSpanFromHeaders starts a span using the global tracer, as a child of
+// the trace context carried by the headers if any.
+func StartSpanFromHeaders(
+	headers map[string]string,
+	operationName string,
+) opentracing.Span {
+	tracer := opentracing.GlobalTracer()
+	var opts []opentracing.StartSpanOption
+	if len(headers) > 0 {
+		parent, err := tracer.Extract(opentracing.TextMap,
+			opentracing.TextMapCarrier(headers))
+		if err == nil {
+			opts = append(opts, opentracing.ChildOf(parent))
+		}
+	}
+
+	return tracer.StartSpan(operationName, opts...)
+}
+
+// spanContext is a context of a request that carries the span of the request.
+type spanContext struct {
+	xcontext.Context
+
+	span opentracing.Span
+}
+
+// ContextWithSpan returns a context carrying the span so that work done with
+// the context further down, such as retrieving blocks from disk, can be traced
+// as a child of the span. The context is returned as is if the span is not
+// recorded.
+func ContextWithSpan(ctx xcontext.Context, span opentracing.Span) xcontext.Context {
+	if _, noop := span.Tracer().(opentracing.NoopTracer); noop {
+		return ctx
+	}
+	return spanContext{Context: ctx, span: span}
+}
+
+// SpanFromContext returns the span carried by the context, nil if none.
+func SpanFromContext(ctx xcontext.Context) opentracing.Span {
+	if ctx, ok := ctx.(spanContext); ok {
+		return ctx.span
+	}
+	return nil
+}
+
+// FinishSpan finishes the span, marking it as failed if err is not nil.
+func FinishSpan(span opentracing.Span, err error) {
+	if err != nil {
+		ext.Error.Set(span, true)
+		span.LogKV("event", "error", "message", err.Error())
+	}
+	span.Finish()
+}
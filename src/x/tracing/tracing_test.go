@@ -0,0 +1,95 @@
+// Copyright (c) 2018 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package tracing
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	xcontext "github.com/m3db/m3x/context"
+
+	opentracing "github.com/opentracing/opentracing-go"
+	"github.com/opentracing/opentracing-go/mocktracer"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewTracer(t *testing.T) {
+	tracer, err := Configuration{}.NewTracer()
+	require.NoError(t, err)
+	assert.Equal(t, opentracing.NoopTracer{}, tracer)
+
+	tracer, err = Configuration{Backend: NoopBackend}.NewTracer()
+	require.NoError(t, err)
+	assert.Equal(t, opentracing.NoopTracer{}, tracer)
+
+	// Tracers recording spans in memory are only used by tests as they
+	// keep every finished span
+	_, err = Configuration{Backend: "inmemory"}.NewTracer()
+	assert.Error(t, err)
+
+	_, err = Configuration{Backend: "unknown"}.NewTracer()
+	assert.Error(t, err)
+}
+
+func TestPropagateHeaders(t *testing.T) {
+	tracer := mocktracer.New()
+	prev := opentracing.GlobalTracer()
+	opentracing.SetGlobalTracer(tracer)
+	defer opentracing.SetGlobalTracer(prev)
+
+	assert.Nil(t, InjectHeaders(context.Background()))
+
+	parent, ctx := opentracing.StartSpanFromContext(context.Background(), "parent")
+	headers := InjectHeaders(ctx)
+	require.NotEmpty(t, headers)
+
+	child := StartSpanFromHeaders(headers, "child")
+	FinishSpan(child, errors.New("boom"))
+	parent.Finish()
+
+	spans := tracer.FinishedSpans()
+	require.Len(t, spans, 2)
+	assert.Equal(t, "child", spans[0].OperationName)
+	assert.Equal(t, spans[1].SpanContext.TraceID, spans[0].SpanContext.TraceID)
+	assert.Equal(t, spans[1].SpanContext.SpanID, spans[0].ParentID)
+	assert.Equal(t, true, spans[0].Tag("error"))
+
+	// No parent is used without headers
+	orphan := StartSpanFromHeaders(nil, "orphan")
+	orphan.Finish()
+	assert.Equal(t, 0, tracer.FinishedSpans()[2].ParentID)
+}
+
+func TestContextWithSpan(t *testing.T) {
+	ctx := xcontext.NewContext()
+	defer ctx.Close()
+
+	// Spans that are not recorded are not carried
+	noop := opentracing.NoopTracer{}.StartSpan("noop")
+	assert.Equal(t, ctx, ContextWithSpan(ctx, noop))
+	assert.Nil(t, SpanFromContext(ctx))
+
+	span := mocktracer.New().StartSpan("span")
+	assert.Equal(t, span, SpanFromContext(ContextWithSpan(ctx, span)))
+}